@@ -25,12 +25,12 @@ translation stage. (ToDo)
 + The lines numbers in the un-minified generated JavaScript match up with the
 lines numbers in the original source file.
 
-+ Generates minimized JavaScript.
++ Generates minimized JavaScript, and indented JavaScript.
 
 Go sintaxis not supported:
 
 + Complex numbers, integers of 64 bits.
-+ Function type, interface type excepting the empty interface.
++ Interface type excepting the empty interface.
 + Channels, goroutines (could be translated to Web Workers (http://www.html5rocks.com/en/tutorials/workers/basics/).
 + Built-in function recover.
 + Defer statement.
@@ -47,12 +47,42 @@ By this reason, the integers of 64 bits are unsupported.
 
 ## Translation
 
-#### Reserved words
+#### Lowering
+
+Before of being translated, the syntax tree is lowered to "Kernel Go" by the
+package "lower": a subset of Go with less constructs, which is still Go. So the
+translator only handles those constructs:
+
+	new([3]int)        => &[3]int{}
+	a, b = b, a        => tmp1 := a; a = b; b = tmp1
+	func f() (n int)   => func f() int { var n int; ...; return n }
+	_ = f()            => f()
+	p == q             => (p.x == q.x && p.y == q.y) // struct
+	var s I = c        => var s I = I(c) // c has methods, and it is not a struct
+
+See files "lower/lower_test.go".
+
+#### Names
 
 The reserved words and keywords used in JavaScript are translated adding "_" at
-the end of the name.
+the end of the name, like the declarations named like a global object of
+JavaScript (Object, Array, Math, JSON, undefined, NaN, arguments, window,
+document...) or like the library. A name which already ends with "_" gets
+another one, so it can not collide with the name escaped:
+
+	class, class_ := 1, 2  => var class_ = 1, class__ = 2;
+	type Object struct{}   => function Object_() {}
+
+The fields and methods are only escaped when they are reserved words, since the
+properties do not hide the global objects. The characters which are not ASCII
+are written like escape sequences:
+
+	café := "crème"  => var caf\u00E9 = "crème";
+
+The temporary variables added by the translation start with "$", so they can not
+collide with the names of Go; i.e. "$r1" stores the results of a call.
 
-See files "testdata/decl_reserved.{go,js}".
+See files "testdata/decl_reserved.{go,js}" and "testdata/naming.{go,js}".
 
 #### Initialization
 
@@ -72,19 +102,72 @@ value from the stack. If the value happens to be a pointer, then it may appear
 as if you were passing by reference, but in reality you aren't.  
 ECMAScript is simply not able to pass by reference.
 
-The emulation is done using an object with a field named `p`. So:
+The structs and arrays are objects, so a pointer to them is the own object.
+The variables of other types whose address is taken are found from the type
+information, before of translating, and they are stored in an object with a
+field named `p`:
 
-`*x` and `x` is `x.p` in javascript while `&x` would simply be `x`.
+	x := false  => var x = {p:false};
+	x           => x.p
+	&x          => x
+	*p          => p.p
 
-Then, for any variable that is addressed:
+The pointers to fields and elements are references which get and set the
+value through `p` too:
 
-`var x *bool` to `var x = {p:false}`
+	&s.f   => g.Ref(s, "f")
+	&a[i]  => g.Ref(a.v, i)
+	&s[i]  => s.ref(i)
+
+The nil pointer is `undefined`. A struct or array which could be pointed is
+changed in place when a value is assigned to it:
+
+	*p = v  => g.Store(p, v, {x: 0, y: 0});
+
+Since a pointer to a struct is the own object, the selectors through a pointer
+and the calls to methods with pointer receiver on an addressable struct are
+written as they are, and "new(T)" and "&T{}" are the struct:
+
+	p := &T{1}  => var p = new T(1);
+	p.f         => p.f
+	v.m()       => v.m() // func (t *T) m()
+	new(T)      => new T(0)
+
+A pointer receiver which is assigned into the method is copied to a variable,
+instead of using "this". The elements of arrays and slices which are structs or
+arrays get each one its own zero value.
 
 Note: the printing of an address in Go (`&x`) results into an hexadecimal
-address. Instead, in JavaScript with this emulation, it prints the value.
+address, which is not the same in JavaScript.
+
+See files "testdata/pointer.{go,js}".
+
+#### Embedded fields
 
-Warning: due to JavaScript design, it cann't be guaranteed that the
-emulation of pointers can work in other scenes that are not in the test file.
+An embedded field is stored in a field named like its type, and the selectors
+of promoted fields and methods pass through it:
+
+	type User struct { Base; email string }
+	u.name => u.Base.name
+
+The methods promoted are also added to the prototype of the outer struct,
+calling to the embedded value, so the struct satisfies the interfaces like in Go.
+The interfaces are not translated since they are only checked at compiling.
+
+#### Named types
+
+The values of the named types which are neither structs nor interfaces keep the
+representation of their underlying type, so they are numbers, strings, slices,
+maps or functions, with their zero value and operations. The constructor of the
+type stores a value, in field "t", to call its methods; a receiver of pointer is
+the pointer itself:
+
+	type Celsius float64
+	c.String()   => new Celsius(c).String()
+	c.Add(d)     => Celsius.prototype.Add.call(c, d) // c is addressed
+	var s I = c  => var s = new Celsius(c);
+
+See files "testdata/named.{go,js}".
 
 #### Numbers
 
@@ -110,33 +193,285 @@ negative number
 	>>> -3/2 |0
 	-1
 
+#### Constants
+
+The numeric constants are folded by the type checker, with the value of "iota"
+of each spec, so their values are exact; the blank identifiers are skipped:
+
+	const (
+		_  = iota
+		KB = 1 << (10 * iota)  => const KB = 1024;
+	)
+
+The typed constants keep their named type like any value of it, so the package
+"fmt" uses the method "String" of an enumeration:
+
+	fmt.Println(Sunday)  => console.log(g.Text(g.Sprint([new Weekday(Sunday)], true) + "\n"));
+
+See files "testdata/iota.{go,js}".
+
+#### Strings
+
+The strings are JavaScript strings whose characters are the bytes of their
+encoding in UTF-8, so their lengths, indexes and slices are the ones of Go, and
+they keep the bytes which are not valid in UTF-8. The literals are written like
+their bytes, and the strings are decoded to UTF-16 when they are printed; a
+character literal is a rune, so it is a number:
+
+	"año"  => "a\xc3\xb1o"
+	len(s) => g.StrLen(s)
+	s[i]   => g.StrIndex(s, i)
+	s[1:3] => g.StrSlice(s, 1, 3)
+	'a'    => 97
+
+	fmt.Print(s) => console.log(g.Text(g.Sprint([s], false)))
+
+The conversions between strings, bytes and runes are made by the library, and
+the invalid bytes are decoded like the rune U+FFFD:
+
+	[]byte(s) => g.Bytes(s)
+	[]rune(s) => g.Runes(s)
+	string(b) => b.str()
+	string(r) => r.str(true) // []rune
+	string(c) => g.RuneStr(c) // rune
+
+The named types of strings and slices of bytes or runes are converted the same.
+
+See files "testdata/string.{go,js}".
+
+#### Slices
+
+A slice is an object with a backing array, and the offset, length and capacity
+of the elements which are used from it; so the slices got from an array or
+from another slice share its array, and the changes are seen through all them:
+
+	s[i]            => s.at(i)
+	s[i] = v        => s.set([i], v)
+	a[1:3]          => g.SliceFrom(a, 1, 3)
+	a[1:2:3]        => g.SliceFrom(a, 1, 2, 3)
+	str[1:3]        => g.StrSlice(str, 1, 3)
+	append(s, v)    => g.Append(s, [v])
+	append(s, t...) => g.Append(s, t.get())
+	copy(s, t)      => g.Copy(s, t)
+	[]int{1, 2}     => g.Slice(0, [1, 2])
+	[][1]int{{1}}   => g.Slice(g.MkArray([1], 0), [g.MkArray([1], 0, [1])])
+
+The literals are built from their type, so they are values in any expression.
+The arrays of a multi-dimensional array are one only array, while the arrays
+which are elements of a slice or a map are each one an array. A row read like an
+array shares the elements with the multi-dimensional array:
+
+	row := a[0]  => var row = g.Clone(g.Row(a.v[0]), [0]);
+
+The third index limits the capacity of the slice. A pointer to an array is the
+array itself, so it is sliced like it. The slice of a string is a string, got
+by the indexes in bytes. The indexes out of range panic with the message of Go.
+
+The function "append" writes into the array of the slice while its capacity
+allows it, else it copies the elements to a new array whose capacity grows like
+in Go. The function "copy" handles slices which overlap.
+
+See files "testdata/slice.{go,js}".
+
+#### Maps
+
+A map is an object built on the type "Map" of JavaScript, which stores each
+entry with its key, so the length is got at once and the keys are got with
+their type in a range. The keys of basic types are compared by value; the
+structs, arrays and interfaces are stored by a hash of their value, so they are
+compared like in Go; the hash of an interface has the dynamic type, and the
+pointers are used like they are:
+
+	make(map[string]int)  => g.MkMap(0, [])
+	map[point]int{p: 1}   => g.MkMap(0, [[p, 1]], true)
+	m[k]                  => m.get(k)[0]
+	_, ok := m[k]         => var ok = m.get(k)[1]
+	m[k] = v              => m.set(k, v)
+	delete(m, k)          => m.remove(k)
+	len(m)                => m.len()
+	m == nil              => m.isNil()
+
+The assignment to an entry of a nil map panics.
+
+See files "testdata/map.{go,js}".
+
+#### Format
+
+The functions of the package "fmt" are formatted by the library, following the
+rules of Go for the verbs, flags, widths, precisions and argument indexes. The
+values whose type can not be got in JavaScript are passed with the string of
+their type; and the methods "Error" and "String" are called when they exist:
+
+	fmt.Sprintf("%d: %v", n, x) => g.Sprintf("%d: %v", [n, g.Typed(x, "main.T")])
+	fmt.Sprint(a, b)           => g.Sprint([a, b], false)
+	fmt.Sprintln(a)            => g.Sprint([a], true) + "\n"
+	fmt.Fprintf(w, "%d", n)    => g.Fprint(w, g.Sprintf("%d", [n]))
+	fmt.Sprint(f())            => g.Sprint(g.TypedValues(f(), ["int", "string"]), false)
+
+The elements of composite values get the methods from the named types, which are
+registered by "g.Named"; and the types of the exported fields which need them
+are given to the struct by "g.FieldTypes".
+
+The writer "os.Stdout" writes to "console.log", and "os.Stderr" to
+"console.error".
+
+See files "testdata/fmt.{go,js}".
+
+#### Package strings
+
+The functions of the package "strings" are implemented by the library, on the
+strings of JavaScript. Like in Go, the indexes are in bytes of UTF-8 and the
+lists are slices:
+
+	strings.Split(s, ",")  => g.Split(s, ",")
+	strings.Index(s, "é")  => g.Index(s, "é")
+	var b strings.Builder  => var b = new g.Builder("");
+
+The types "Builder" and "Replacer" are objects of the library, whose zero value
+is in the map "Type" of file "library.go".
+
+See files "testdata/strings.{go,js}".
+
 #### Comparison
 
 In JavaScript, when objects are compared then the identity is checked, no
-comparison of properties or elements is done.
+comparison of properties or elements is done. So the values which are not
+literals are compared by the library, according to a descriptor of their type:
+the structs compare their fields, the arrays their elements, the pointers their
+identity, and the interfaces their dynamic type and value. The structs which
+are read from variables are compared field by field in the lowering:
+
+	p == f()  => g.Equal(p, f(), {x: 0, y: 0}) // struct
+	a != b  => !g.Equal(a.v, b.v, [0])      // array
+	x == y  => g.Equal(x, y, 1)             // interface
+
+The dynamic type is compared first, so the values which JavaScript can not tell
+apart are stored into an interface with the name of their type; they are the
+values of the named types without methods, and the basic values but booleans
+and strings. The constants of the cases of a switch on an interface get their
+default type. The pointers to structs and arrays, which are the own objects, are
+marked so they are compared by identity:
+
+	var i interface{} = int32(1)  => var i = g.Typed(g.Int32(1), "int32");
+	var i interface{} = A(1)      => var i = g.Typed(1, "main.A", "int");
+	var i interface{} = &T{}      => var i = g.Pointer(new T(), "*main.T");
+
+The slices, maps and functions can only be compared to nil.
+
+See files "testdata/equal.{go,js}".
+
+#### Values
+
+The arrays and structs are objects in JavaScript, so an assignment shares them
+instead of copying their values. They are copied by the library, according to
+the descriptor of their type, where Go copies them: in the assignments, the
+composite literals, the values given to "append" and to the maps, and the
+results:
+
+	q := p  => var q = g.Clone(p, {x: 0, y: 0});
+
+The copy is skipped when the value is new, like a composite literal or the
+result of a call, and when a result is a local variable. The parameters, the
+receivers and the values of "range" are only copied at the beginning when they
+could be changed into the function or the loop:
+
+	func f(a [3]int) { a[0] = 1 }  => function f(a) { a = g.Clone(a, [0]); a.v[0] = 1; }
+
+See files "testdata/value.{go,js}".
+
+#### Switch
+
+The statement "switch" of JavaScript evaluates the "case" expressions in order
+until one matches, and its clause "default" can be anywhere, like in Go. But it
+compares using identity, so a tag which is not of a basic type is compared like
+in the binary expressions:
+
+	switch p { case point{1, 2}: }
+	=> var $s1 = p; switch (true) { case g.Equal($s1, new point(1, 2), {x: 0, y: 0}): }
+
+The variables declared in the initialization statement which hide another one
+get the suffix "$".
+
+See files "testdata/switch.{go,js}".
+
+#### Range
+
+The loop is translated according to the type of the expression, which is
+evaluated only once. The arrays and slices are ranged using an index, the maps
+through their entries, which keep the keys with their type, and the integers
+like a loop from zero:
+
+	for i, v := range s  => for (var i = 0, $r1 = s, $n2 = $r1.len; i < $n2; i++) { v = $r1.at(i);
+	for k := range m     => for (var $e1 of m.entries()) { k = $e1[0];
+	for i := range 10    => for (var i = 0; i < 10; i++)
+
+The slices are ranged until the length at the beginning, seeing the elements
+changed into the loop, and the arrays are ranged over a copy when they could be
+changed. The strings are ranged over the runes, decoded from UTF-8 by
+"g.RangeString", getting the index in bytes.
+
+The temporary variables start with "$" so they can not be used by Go
+identifiers.
+
+See files "testdata/range.{go,js}".
+
+#### Loop variables
+
+Each iteration has its own loop variables (Go 1.22), so they are declared with
+"let" when they are captured by a closure or addressed. The variables of
+"range" are declared into the body, like the variables of the body which are
+captured:
+
+	for i := 0; i < 3; i++      => for (let i = 0; i < 3; i++)
+	for _, v := range s         => for (var $i1 = 0, $r2 = s, $n3 = $r2.len; $i1 < $n3; $i1++) { let v = $r2.at($i1);
+	w := i                      => let w = i;
+
+An addressed variable gets a new pointer before of the post statement, which
+starts with the value of the last iteration:
+
+	for (let i = {p:0}; i.p < 3; i = {p:i.p}, i.p++)
+
+See files "testdata/loopvar.{go,js}".
 
-One way without a custom comparison function is to compare its string
-representations, but using the JSON object.  
-Here there is an example that shows the why:
+#### Function values
 
-	var a = [1, [1, 1], 1], b = [[1, 1], [1, 1]];
+The function literals are translated to function expressions, so the closures
+capture the variables like in Go. Into a method, the closure is bound to the
+receiver since it is translated to "this":
 
-	console.log("String() => a: \"" + String(a) + "\" b: \"" + String(b) + "\"")
-	if (String(a) === String(b)) { console.log("String(): equals"); }
+	func() { str += h.prefix } => function() { str += this.prefix; }.bind(this)
 
-	console.log("JSON() => a: \"" + JSON.stringify(a) + "\" b: \"" + JSON.stringify(b) + "\"")
-	if (JSON.stringify(a) === JSON.stringify(b)) { console.log("JSON(): equals"); }
+A function not initialized is "undefined", so it can be compared with nil.
 
-		String() => a: "1,1,1,1" b: "1,1,1,1"
-		String(): equals
-		JSON() => a: "[1,[1,1],1]" b: "[[1,1],[1,1]]"
+A method value is bound to its receiver using "g.MethodVal", which copies the
+receivers of value. A method expression is translated to "g.MethodExpr", a
+function which gets the receiver like its first argument:
+
+	p.sum       => g.MethodVal(p, "sum", true)
+	(*T).scale  => g.MethodExpr(T, "scale", false)
+
+#### Variadic functions
+
+The variadic parameter is a slice, so the values are passed into a slice created
+by the caller; a slice followed by "..." is passed like is, sharing its array:
+
+	sum(1, 2)  => sum(g.SliceArgs([1, 2]))
+	sum(s...)  => sum(s)
+
+The values returned by a call used like the arguments are spread, and those of
+the variadic parameter are put into a slice:
+
+	add(two()) => add(...two())
+	sum(two()) => sum(...g.TupleArgs(two(), 0))
+
+See files "testdata/variadic.{go,js}".
 
 #### Return of multiple values
 
 When a Go function returns more than one value then those values are put into an
-array. Then, to access to the different values it is created a variable
-`_` assigned to the return of the function, and the variable's names defined in
-Go are used to access to each value of that array.
+array. Then, to access to the different values it is created a temporary
+variable assigned to the return of the function, and the variable's names
+defined in Go are used to access to each value of that array.
 
 By example, for a Go function like this:
 
@@ -144,15 +479,72 @@ By example, for a Go function like this:
 
 its translation would be:
 
-	var _ = SumAndProduct(x, y), sum = _[0], product = _[1];
+	var $r1 = SumAndProduct(x, y), sum = $r1[0], product = $r1[1];
+
+The named results are lowered to variables declared at the beginning of the
+function, and a "return" without values returns them. Since a variable declared
+into a block is visible in the whole function in JavaScript, a result which is
+shadowed gets a new name:
+
+	func f(n int) (v int) { if n > 0 { v := 1 }; return }
+	=> function f(n) { var v1 = 0; if (n > 0) { var v = 1; } return v1; }
+
+See files "testdata/result.{go,js}".
+
+#### Generics
+
+A generic function is translated to one JavaScript function which gets a
+descriptor for each type parameter before of its parameters. The descriptor is
+built by "g.Type", and it gives the operations which depend on the type like the
+zero value; the values are compared by "g.Equal", whatever their type is:
+
+	func Zero[T any]() T { var z T; return z }
+	=> function Zero(T) { var z = T.zero(); return z; }
+
+	Zero[int]() => Zero(g.Type(function() { return 0; }, "int"))
+
+The result of an arithmetic operation is converted to the type by "conv", given
+the kind of the number; so the integer division drops the decimals, and the sized
+integers wrap around. The assignment operations and the increments on a type
+parameter are lowered to the operation:
+
+	func Div[T ~int](a, b T) T { return a / b }
+	=> function Div(T, a, b) { return T.conv(a / b); }
+
+The arguments of the type are inferred by the type checker, and a type parameter
+is passed like it is to another generic.
+
+A generic type stores the descriptors in the fields "$T" of its constructor, so
+its methods get them through "this".
+
+See files "testdata/generic.{go,js}".
+
+#### Initialization order
+
+The package-level variables are initialized in the order given by the type
+checker, which is the one of the Go specification. They are initialized in
+place while that order is the one of the source; else, they are declared
+without value, and they are initialized after of all declarations:
+
+	var a = b + 1
+	var b = 2
+	=> var a; var b; ... b = 2; a = b + 1;
+
+The functions "init" are translated to functions named "init$N", and they are
+called in order after of the initialization of the variables, before of the
+function "main".
+
+See files "testdata/initorder.{go,js}".
 
 #### Library
 
 JavaScript has several built-in functions and constants which can be translated
-from Go. They are defined in the maps "Constant", and "Function".
+from Go. They are defined in the maps "Constant", and "Function"; and the
+types implemented by the library in the map "Type".
 
 Since the Go functions "print*" are used to debug, they are translated to
-"console.error"; the functions "fmt.Print*" are translated to "console.log"
+"console.error"; the functions "fmt.Print*" are translated to "console.log",
+with the values formatted by the library.
 
 "panic" is translated to "throw new Error()".
 
@@ -168,9 +560,26 @@ By example, for a package named "foo" with names exported "Add" and "Product":
 	var foo = {}; (function() {
 	// Code of your package
 
-	g.Export(foo, [Add, Product])
+	g.Export(foo, [Add, Product]);
 	})();
 
+#### Output
+
+The translation builds a syntax tree of the JavaScript statements (see
+"jsast.go"), which is written by a printer in one of three layouts. The blocks,
+functions and control statements are nodes of the tree; the expressions and the
+simple statements are kept like code, with the references to the function
+literals which they hold, and the printer only changes their spaces:
+
++ By default, every statement is written at the line of the Go source, so the
+errors of JavaScript point to the lines of the Go file.
++ With the flag "-pretty", a statement by line, indented by blocks.
++ With the flag "-min", the code minimized is written too, into a file with
+extension ".min.js".
+
+The layout is not written into the code translated, so a string literal like
+"<<NL>>" is kept like it is. See file "testdata/layout.go".
+
 
 ## Contributing
 
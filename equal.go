@@ -0,0 +1,73 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// JavaScript compares the objects by identity, so the values which are not
+// literals are compared by the library according to a descriptor of their
+// type, which is built from the type information:
+//
+//	x == y  => g.Equal(x, y, desc)
+//	x != y  => !g.Equal(x, y, desc)
+//
+// The descriptor is got by "valueDesc".
+
+// equalType returns the type used to compare the expressions, which is the
+// interface when only one of them is an interface. The slices, maps and
+// functions can only be compared to nil, else it returns nil.
+func (tr *translation) equalType(x, y ast.Expr, pos ast.Node) types.Type {
+	tx, ty := tr.typeOf(x), tr.typeOf(y)
+	if tx == nil || ty == nil {
+		return nil
+	}
+
+	typ := tx
+	if types.IsInterface(ty) && !types.IsInterface(tx) {
+		typ = ty
+	}
+
+	name := ""
+	switch typ.Underlying().(type) {
+	case *types.Slice:
+		name = "slice"
+	case *types.Map:
+		name = "map"
+	case *types.Signature:
+		name = "func"
+	default:
+		return typ
+	}
+
+	tr.addError("%s: invalid operation: %s can only be compared to nil",
+		tr.fset.Position(pos.Pos()), name)
+	return nil
+}
+
+// isConstant reports whether the expression has a constant value, which is
+// compared like a literal.
+func (tr *translation) isConstant(expr ast.Expr) bool {
+	if Bootstrap {
+		return false
+	}
+	tv, ok := tr.info.Types[expr]
+	return ok && tv.Value != nil
+}
+
+// equal returns the comparison of the values of two expressions, negated when
+// "isNot" is true.
+func (tr *translation) equal(x, y string, desc string, isNot bool) string {
+	not := ""
+	if isNot {
+		not = "!"
+	}
+	return fmt.Sprintf("%sg.Equal(%s, %s, %s)", not, x, y, desc)
+}
@@ -10,7 +10,9 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
+	"go/types"
 	"strconv"
 	"strings"
 	"text/template"
@@ -24,6 +26,7 @@ const (
 	//ellipsisKind
 	sliceKind
 	structKind
+	mapKind
 )
 
 // expression represents a Go expression.
@@ -40,21 +43,22 @@ type expression struct {
 
 	// TODO: change all booleans by kind
 	hasError bool
-	useIota  bool
 
 	//isFunc    bool // anonymous function
-	isSliceExpr  bool
-	isIdent      bool
-	isValue      bool // is it on the right of the assignment?
-	isVarAddress bool
-	isPointer    bool
-	isMake       bool
-	isNil        bool
-	addSet       bool
+	isSliceExpr bool
+	isIdent     bool
+	isValue     bool // is it on the right of the assignment?
+	isLHS       bool // is it on the left of the assignment?
+	isMake      bool
+	isNil       bool
+	addSet      bool
+	isCall      bool // is the selector called?
 
 	arrayHasElts bool // does array has elements?
 	isEllipsis   bool
 	isMultiDim   bool // multi-dimensional array
+	isElt        bool // element of an array or a slice
+	isRow        bool // row of a multi-dimensional array, read like an array
 
 	// To handle comparisons
 	isBasicLit     bool
@@ -62,6 +66,7 @@ type expression struct {
 
 	lenArray []string // the lengths of an array
 	index    []string
+	elem     string // element read, when the element is set through "set"
 }
 
 // newExpression initializes a new expression.
@@ -100,8 +105,10 @@ func (tr *translation) newExpression(iVar interface{}) *expression {
 		false,
 		false,
 		false,
+		false,
 		make([]string, 0),
 		make([]string, 0),
+		"",
 	}
 }
 
@@ -131,7 +138,6 @@ func (e *expression) translate(expr ast.Expr) {
 		if typ.Len == nil { // slice
 			break
 		}
-		e.tr.arrays[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
 
 		if _, ok := typ.Len.(*ast.Ellipsis); ok {
 			e.zero, _ = e.tr.zeroValue(true, typ.Elt)
@@ -153,7 +159,7 @@ func (e *expression) translate(expr ast.Expr) {
 			e.translate(typ.Elt)
 		case *ast.Ident, *ast.StarExpr: // the type is initialized
 			e.zero, _ = e.tr.zeroValue(true, typ.Elt)
-			e.WriteString(fmt.Sprintf("],%s", SP+e.zero))
+			e.WriteString(fmt.Sprintf("], %s", e.zero))
 		default:
 			panic(fmt.Sprintf("*expression.translate: type unimplemented: %T", t))
 		}
@@ -162,18 +168,24 @@ func (e *expression) translate(expr ast.Expr) {
 	//  Kind     token.Token // token.INT, token.FLOAT, token.IMAG, token.CHAR, or token.STRING
 	//  Value    string      // literal string
 	case *ast.BasicLit:
-		if typ.Value[0] == '`' { // raw string literal
-			typ.Value = template.JSEscapeString(typ.Value)
-			typ.Value = `"` + typ.Value[1:len(typ.Value)-1] + `"`
+		// The characters are runes, so they are numbers.
+		if typ.Kind == token.CHAR {
+			if value, _, _, err := strconv.UnquoteChar(typ.Value[1:len(typ.Value)-1], '\''); err == nil {
+				e.WriteString(strconv.Itoa(int(value)))
+				e.isBasicLit = true
+				break
+			}
 		}
 
-		// Replace new lines
-		if strings.Contains(typ.Value, "\\n") {
-			typ.Value = strings.Replace(typ.Value, "\\n", Char['\n'], -1)
-		}
-		// Replace tabulators
-		if strings.Contains(typ.Value, "\\t") {
-			typ.Value = strings.Replace(typ.Value, "\\t", Char['\t'], -1)
+		// The strings are written like their bytes, but in the library whose
+		// literals are its own values.
+		if !Bootstrap {
+			if str, err := strconv.Unquote(typ.Value); err == nil {
+				typ.Value = jsString(str)
+			}
+		} else if typ.Value[0] == '`' { // raw string literal
+			typ.Value = template.JSEscapeString(typ.Value)
+			typ.Value = `"` + typ.Value[1:len(typ.Value)-1] + `"`
 		}
 
 		e.WriteString(typ.Value)
@@ -187,6 +199,9 @@ func (e *expression) translate(expr ast.Expr) {
 	//  Op    token.Token // operator
 	//  Y     Expr        // right operand
 	case *ast.BinaryExpr:
+		if e.writeConst(typ) {
+			break
+		}
 		var isBitwise, isComparing, isOpNot bool
 		addSpaces := true
 		op := typ.Op.String()
@@ -210,7 +225,7 @@ func (e *expression) translate(expr ast.Expr) {
 		}
 
 		if addSpaces {
-			op = SP + op + SP
+			op = " " + op + " "
 		}
 		if e.tr.isConst {
 			if isBitwise {
@@ -234,14 +249,14 @@ func (e *expression) translate(expr ast.Expr) {
 			yStr := stripField(y.String())
 
 			// Slice
-			if y.isNil && e.tr.isType(sliceType, xStr) {
+			if y.isNil && (e.tr.isType(sliceType, xStr) || x.isSliceExpr || e.tr.exprKind(typ.X) == sliceType) {
 				if isOpNot {
 					e.WriteString("!")
 				}
 				e.WriteString(xStr + ".isNil()")
 				break
 			}
-			if x.isNil && e.tr.isType(sliceType, yStr) {
+			if x.isNil && (e.tr.isType(sliceType, yStr) || y.isSliceExpr || e.tr.exprKind(typ.Y) == sliceType) {
 				if isOpNot {
 					e.WriteString("!")
 				}
@@ -250,49 +265,59 @@ func (e *expression) translate(expr ast.Expr) {
 			}
 
 			// Map
-			if y.isNil && e.tr.isType(mapType, xStr) {
-				e.WriteString(fmt.Sprintf("%s.v%sundefined", xStr, op))
+			if y.isNil && (e.tr.isType(mapType, xStr) || e.tr.isMap(typ.X)) {
+				if isOpNot {
+					e.WriteString("!")
+				}
+				e.WriteString(xStr + ".isNil()")
 				break
 			}
-			if x.isNil && e.tr.isType(mapType, yStr) {
-				e.WriteString(fmt.Sprintf("%s.v%sundefined", yStr, op))
+			if x.isNil && (e.tr.isType(mapType, yStr) || e.tr.isMap(typ.Y)) {
+				if isOpNot {
+					e.WriteString("!")
+				}
+				e.WriteString(yStr + ".isNil()")
 				break
 			}
 		}
 
 		// * * *
-		stringify := false
-
-		// JavaScript only compares basic literals.
-		if isComparing && !x.isBasicLit && !x.returnBasicLit && !y.isBasicLit && !y.returnBasicLit {
-			stringify = true
+		// JavaScript only compares by value the basic literals.
+		if isComparing && !x.isBasicLit && !x.returnBasicLit && !y.isBasicLit && !y.returnBasicLit &&
+			!x.isNil && !y.isNil && !e.tr.isConstant(typ.X) && !e.tr.isConstant(typ.Y) {
+			// The library is not typed, so it uses the strict equality.
+			if Bootstrap {
+				e.WriteString(x.String() + " " + typ.Op.String() + "= " + y.String())
+				break
+			}
+			if t := e.tr.equalType(typ.X, typ.Y, typ); t != nil {
+				e.WriteString(e.tr.equal(x.String(), y.String(), e.tr.valueDesc(t), isOpNot))
+			}
+			break
 		}
 
-		if stringify {
-			e.WriteString("JSON.stringify(" + x.String() + ")")
-		} else {
-			if isBitwise {
-				e.WriteString("(")
+		// The result of an arithmetic operation on a type parameter is
+		// converted to the type passed.
+		conv := ""
+		switch typ.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO, token.REM, token.SHL:
+			if tp, ok := e.tr.typeOf(typ).(*types.TypeParam); ok {
+				conv = e.tr.typeParamName(tp) + ".conv("
 			}
-			e.WriteString(x.String())
-		}
-		// To know when a pointer is compared with the value nil.
-		if y.isNil && !x.isPointer && !x.isVarAddress {
-			e.WriteString(NIL)
 		}
 
+		if isBitwise {
+			e.WriteString("(")
+		}
+		e.WriteString(conv)
+		e.WriteString(x.String())
 		e.WriteString(op)
-
-		if stringify {
-			e.WriteString("JSON.stringify(" + y.String() + ")")
-		} else {
-			e.WriteString(y.String())
-			if isBitwise {
-				e.WriteString(")")
-			}
+		e.WriteString(y.String())
+		if conv != "" {
+			e.WriteString(")")
 		}
-		if x.isNil && !y.isPointer && !y.isVarAddress {
-			e.WriteString(NIL)
+		if isBitwise {
+			e.WriteString(")")
 		}
 
 	// godoc go/ast CallExpr
@@ -300,38 +325,72 @@ func (e *expression) translate(expr ast.Expr) {
 	//  Args     []Expr    // function arguments; or nil
 	case *ast.CallExpr:
 		callName := ""
+		fun := typ.Fun
+
+		// Generic function instantiated; the arguments of the type are passed
+		// like arguments.
+		if ident, _ := e.tr.instance(fun); ident != nil {
+			fun = ident
+		}
+		// Conversion to an interface, to a named type which is not a struct
+		// or between pointers, like "(*[N]T)(p)".
+		if tv, ok := e.tr.info.Types[fun]; ok && tv.IsType() &&
+			(types.IsInterface(tv.Type) || isNamedValue(tv.Type) ||
+				isPointer(tv.Type) && isPointer(e.tr.typeOf(typ.Args[0]))) {
+			e.writeConversion(typ.Args[0], tv.Type)
+			break
+		}
 S:
-		switch call := typ.Fun.(type) {
+		switch call := fun.(type) {
 		// Built-in function
 		case *ast.Ident:
 			callName = call.Name
 
 		// Library
 		case *ast.SelectorExpr:
+			if s := e.tr.selection(call); s != nil && s.Kind() == types.MethodVal &&
+				isNamedValue(derefType(s.Recv())) {
+				e.writeNamedCall(call, s, typ)
+				break S
+			}
+			e.isCall = true
 			e.translate(call)
 
-			str := fmt.Sprintf("%s", e.tr.GetArgs(e.funcName, typ.Args))
-			if e.funcName != "fmt.Sprintf" && e.funcName != "fmt.Sprint" {
+			str := fmt.Sprintf("%s", e.tr.GetArgs(e.funcName, typ))
+			if jsName, ok := Function[e.funcName]; !ok || jsName != "" {
 				str = "(" + str + ")"
 			}
 
 			e.WriteString(str)
 			break S
 
-		// Conversion: []byte()
+		// Conversion: []byte(), []rune()
 		case *ast.ArrayType:
-			if call.Elt.(*ast.Ident).Name == "byte" {
+			if !e.tr.isString(typ.Args[0]) {
 				e.translate(typ.Args[0])
+				break S
+			}
+			if slice, ok := e.tr.typeOf(call).Underlying().(*types.Slice); ok &&
+				isRuneType(slice.Elem()) {
+				e.WriteString("g.Runes(")
 			} else {
-				panic(fmt.Sprintf("call of conversion unimplemented: []%T()", call))
+				e.WriteString("g.Bytes(")
 			}
+			e.translate(typ.Args[0])
+			e.WriteString(")")
 			break S
 
-		// Anonymous function
-		/*case *ast.FuncLit:
-			//fmt.Println(typ.Args)
-			e.translate(typ.Fun)
-			break S*/
+		// Anonymous function; it is called directly.
+		case *ast.FuncLit:
+			e.WriteString("(" + e.tr.getFuncLit(call) + ")")
+			e.WriteString("(" + e.tr.getArgsCall(typ) + ")")
+			break S
+
+		// Function values got from an expression.
+		case *ast.CallExpr, *ast.IndexExpr, *ast.ParenExpr:
+			e.translate(call)
+			e.WriteString("(" + e.tr.getArgsCall(typ) + ")")
+			break S
 
 		default:
 			panic(fmt.Sprintf("call unimplemented: %T()", call))
@@ -352,20 +411,18 @@ S:
 			case *ast.ArrayType: // For slice
 				zero, _ := e.tr.zeroValue(true, argType.Elt)
 
-				e.WriteString(fmt.Sprintf("%s,%s%s", zero, SP,
+				e.WriteString(fmt.Sprintf("%s, %s", zero,
 					e.tr.getExpression(typ.Args[1]))) // length
 
 				if len(typ.Args) == 3 { // capacity
-					e.WriteString("," + SP + e.tr.getExpression(typ.Args[2]).String())
+					e.WriteString(", " + e.tr.getExpression(typ.Args[2]).String())
 				}
-
-				e.tr.slices[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
 				e.isMake = true
 
 			case *ast.MapType:
-				e.tr.maps[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
 				if !Bootstrap {
-					e.WriteString(fmt.Sprintf("g.Map(%s,%s{})", e.tr.zeroOfMap(argType), SP))
+					e.WriteString(fmt.Sprintf("g.MkMap(%s, []%s)",
+						e.tr.zeroOfMap(argType), e.tr.mapHash(typ)))
 				} else {
 					e.WriteString("{}")
 				}
@@ -378,15 +435,14 @@ S:
 			}
 
 		case "new":
+			// The arrays and structs are lowered to composite literals.
 			switch argType := typ.Args[0].(type) {
-			case *ast.ArrayType:
-				e.tr.arrays[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
-				for _, arg := range typ.Args {
-					e.translate(arg)
-				}
-
 			case *ast.Ident:
 				value, _ := e.tr.zeroValue(true, argType)
+				// The structs are the own pointer.
+				if t := e.tr.typeOf(argType); t != nil && !isValueType(t) {
+					value = box(value)
+				}
 				e.WriteString(value)
 
 			default:
@@ -399,10 +455,33 @@ S:
 			arg := _arg.String()
 			argNoField := stripField(arg)
 
-			if e.tr.isType(sliceType, argNoField) || e.tr.isType(arrayType, argNoField) {
-				e.WriteString(argNoField + ".str()")
-			} else if _arg.isSliceExpr {
-				e.WriteString(arg + ".str()")
+			// The elements of the slice or array are bytes or runes.
+			str := ".str()"
+			isSlice, isInteger := false, false
+			if argType := e.tr.typeOf(typ.Args[0]); argType != nil && !Bootstrap {
+				switch t := argType.Underlying().(type) {
+				case *types.Basic:
+					isInteger = t.Info()&types.IsInteger != 0
+				case *types.Slice:
+					isSlice = true
+					if isRuneType(t.Elem()) {
+						str = ".str(true)"
+					}
+				case *types.Array:
+					isSlice = true
+					if isRuneType(t.Elem()) {
+						str = ".str(true)"
+					}
+				}
+			}
+
+			if isInteger {
+				e.WriteString("g.RuneStr(" + arg + ")")
+				e.returnBasicLit = true
+			} else if e.tr.isType(sliceType, argNoField) || e.tr.isType(arrayType, argNoField) {
+				e.WriteString(argNoField + str)
+			} else if _arg.isSliceExpr || isSlice {
+				e.WriteString(arg + str)
 			} else {
 				e.WriteString(arg)
 				e.returnBasicLit = true
@@ -420,12 +499,30 @@ S:
 
 		case "len":
 			e.returnBasicLit = true
+
+			// The length of a string is in bytes.
+			if e.tr.isString(typ.Args[0]) {
+				if tv, ok := e.tr.info.Types[typ]; ok && tv.Value != nil {
+					e.WriteString(tv.Value.String())
+				} else {
+					e.WriteString("g.StrLen(" + e.tr.getExpression(typ.Args[0]).String() + ")")
+				}
+				break
+			}
+
 			e.tr.returnBasicLit = true
-			arg := e.tr.getExpression(typ.Args[0]).String()
+			if e.writeRowLen(typ) {
+				e.tr.returnBasicLit = false
+				break
+			}
+			argExpr := e.tr.getExpression(typ.Args[0])
+			arg := argExpr.String()
 			argNoField := stripField(arg)
 			argNoIndex, index := splitIndex(arg)
 
-			if e.tr.isType(sliceType, argNoField) {
+			if argExpr.isSliceExpr {
+				e.WriteString(arg + ".len")
+			} else if e.tr.isType(sliceType, argNoField) {
 				e.WriteString(argNoField + ".len")
 
 			} else if e.tr.isType(arrayType, argNoField) || e.tr.isType(mapType, argNoField) {
@@ -434,6 +531,12 @@ S:
 				(e.tr.isType(arrayType, argNoIndex) || e.tr.isType(mapType, argNoIndex)) {
 				e.WriteString(argNoIndex + ".len(" + index + ")")
 
+			} else if e.tr.isSliceCall(typ.Args[0]) || e.tr.fieldKind(typ.Args[0]) == sliceType ||
+				e.tr.exprKind(typ.Args[0]) == sliceType {
+				e.WriteString(arg + ".len")
+			} else if e.tr.fieldKind(typ.Args[0]) == mapType || e.tr.isMap(typ.Args[0]) ||
+				e.tr.exprKind(typ.Args[0]) == mapType || e.tr.isArrayElem(typ.Args[0]) {
+				e.WriteString(stripField(arg) + ".len()")
 			} else {
 				e.WriteString(arg + ".length")
 			}
@@ -441,12 +544,20 @@ S:
 
 		case "cap":
 			e.returnBasicLit = true
+
 			e.tr.returnBasicLit = true
-			arg := e.tr.getExpression(typ.Args[0]).String()
+			if e.writeRowLen(typ) {
+				e.tr.returnBasicLit = false
+				break
+			}
+			argExpr := e.tr.getExpression(typ.Args[0])
+			arg := argExpr.String()
 			argNoField := stripField(arg)
 			argNoIndex, index := splitIndex(arg)
 
-			if e.tr.isType(sliceType, argNoField) {
+			if argExpr.isSliceExpr {
+				e.WriteString(arg + ".cap")
+			} else if e.tr.isType(sliceType, argNoField) {
 				if strings.HasSuffix(arg, FIELD_VALUE) || strings.HasSuffix(arg, FIELD_GET) {
 					e.WriteString(argNoField + ".cap")
 				} else {
@@ -457,30 +568,43 @@ S:
 				e.WriteString(argNoField + ".cap()")
 			} else if argNoIndex != arg && e.tr.isType(arrayType, argNoIndex) {
 				e.WriteString(argNoIndex + ".cap(" + index + ")")
+			} else if e.tr.exprKind(typ.Args[0]) == sliceType {
+				e.WriteString(arg + ".cap")
 			}
 			e.tr.returnBasicLit = false
 
 		case "append":
 			src := ""
-			for i, v := range typ.Args[1:] {
-				if i != 0 {
-					src += "," + SP
-				}
-				src += e.tr.getExpression(v).String()
-			}
 			if typ.Ellipsis != 0 { // last argument is an ellipsis
-				src += FIELD_GET
+				src = e.tr.getExpression(typ.Args[1]).String()
+				if !e.tr.isString(typ.Args[1]) {
+					src = stripField(src) + FIELD_GET
+				}
+			} else {
+				for i, v := range typ.Args[1:] {
+					if i != 0 {
+						src += ", "
+					}
+					src += e.tr.copyValue(v, e.tr.getExpression(v).String())
+				}
+				src = "[" + src + "]"
 			}
 
-			e.WriteString(fmt.Sprintf("g.Append(%s,%s%s)",
-				e.tr.getExpression(typ.Args[0]).String(), SP, src))
+			e.WriteString(fmt.Sprintf("g.Append(%s, %s)",
+				stripField(e.tr.getExpression(typ.Args[0]).String()), src))
 
-		case "copy":
-			e.WriteString(fmt.Sprintf("g.Copy(%s,%s%s)",
-				e.tr.getExpression(typ.Args[0]).String(), SP,
-				e.tr.getExpression(typ.Args[1]).String()))
+		case "copy": // the library gets the slices, not their elements
+			e.WriteString(fmt.Sprintf("g.Copy(%s, %s)",
+				stripField(e.tr.getExpression(typ.Args[0]).String()),
+				stripField(e.tr.getExpression(typ.Args[1]).String())))
 
 		case "delete":
+			if !Bootstrap {
+				e.WriteString(fmt.Sprintf("%s.remove(%s)",
+					e.tr.getExpression(typ.Args[0]).String(),
+					e.tr.getValue(typ.Args[1])))
+				break
+			}
 			e.WriteString(fmt.Sprintf("delete %s%s[%s]",
 				e.tr.getExpression(typ.Args[0]).String(),
 				FIELD_VALUE,
@@ -488,7 +612,7 @@ S:
 
 		case "print", "println":
 			e.WriteString(fmt.Sprintf("%s(%s)",
-				Function[callName], e.tr.GetArgs(callName, typ.Args)))
+				Function[callName], e.tr.GetArgs(callName, typ)))
 
 		case "panic":
 			e.WriteString(fmt.Sprintf("throw new Error(%s)",
@@ -514,20 +638,13 @@ S:
 		default:
 			e.tr.isFunc = true
 
-			args := ""
-			for i, v := range typ.Args {
-				if i != 0 {
-					args += "," + SP
-				}
-				_arg := e.tr.getExpression(v)
-
-				if _arg.kind == sliceKind {
-					args += "g.Slice("
-				}
-				args += _arg.String()
+			// Variable of function type
+			if _, ok := e.tr.info.ObjectOf(fun.(*ast.Ident)).(*types.Var); ok {
+				callName = e.tr.getExpression(fun).String()
+			} else {
+				callName = validIdent(callName)
 			}
-
-			e.WriteString(fmt.Sprintf("%s(%s)", callName, args))
+			e.WriteString(fmt.Sprintf("%s(%s)", callName, e.tr.getArgsCall(typ)))
 			e.tr.isFunc = false
 		}
 
@@ -548,13 +665,26 @@ S:
 	case *ast.CompositeLit:
 		switch compoType := typ.Type.(type) {
 		case *ast.ArrayType:
+			// The literal is built from its type, given by the type checker;
+			// but the anonymous structs have to be declared.
+			if t := e.tr.typeOf(typ); t != nil && !Bootstrap && !hasAnonStruct(t) {
+				e.checkElt(compoType)
+				if _, ok := t.(*types.Array); ok && e.isMultiDim {
+					e.WriteString("[")
+					e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
+					e.WriteString("]")
+					break
+				}
+				e.writeLit(typ, t)
+				break
+			}
 			if !e.arrayHasElts {
 				e.translate(typ.Type)
 			}
 
 			if e.isEllipsis {
-				e.WriteString(fmt.Sprintf("g.MkArray([%s],%s,%s",
-					strconv.Itoa(len(typ.Elts)), SP+e.zero, SP))
+				e.WriteString(fmt.Sprintf("g.MkArray([%s], %s, ",
+					strconv.Itoa(len(typ.Elts)), e.zero))
 
 				e.WriteString("[")
 				e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
@@ -566,26 +696,22 @@ S:
 
 			// Slice
 			if compoType.Len == nil {
-				e.tr.slices[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
 				e.kind = sliceKind
-			} else { // Array
-				e.tr.arrays[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
-				//e.kind = arrayKind //TODO: remove
 			}
 			// Struct
 			if elt, ok := compoType.Elt.(*ast.StructType); ok {
-				e.tr.getStruct(elt, "", false)
+				e.tr.getStruct(elt, nil, "", false)
 				e.kind = structKind
 			}
 			// For arrays with elements
 			if len(typ.Elts) != 0 {
 				if !e.arrayHasElts && compoType.Len != nil {
-					e.WriteString("," + SP)
+					e.WriteString(", ")
 					e.arrayHasElts = true
 				}
 				if e.kind == sliceKind {
 					e.zero, _ = e.tr.zeroValue(true, compoType.Elt)
-					e.WriteString(e.zero + "," + SP)
+					e.WriteString(e.zero + ", ")
 				}
 				e.WriteString("[")
 				e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
@@ -598,17 +724,42 @@ S:
 				e.WriteString("[]")
 			}*/
 
+		case *ast.IndexExpr, *ast.IndexListExpr: // Generic types
+			e.WriteString(e.tr.structLit(typ))
+
 		case *ast.Ident: // Custom types
+			// The named types which are not structs have the literal of their
+			// underlying type.
+			if t := e.tr.typeOf(typ); !Bootstrap && t != nil && isNamedValue(t) {
+				e.writeLit(typ, t.Underlying())
+				break
+			}
+			// The fields specified are set in the constructor, since the
+			// literal could not be assigned to a variable.
+			if len(typ.Elts) != 0 && isKeyValue(typ.Elts[0]) && !Bootstrap && isStructType(e.tr.typeOf(typ)) {
+				e.WriteString(e.tr.structLit(typ))
+				break
+			}
+			if t := e.tr.typeOf(typ); len(typ.Elts) == 0 && !Bootstrap && t != nil {
+				e.WriteString(e.tr.typeZero(t))
+				break
+			}
 			useField := false
-			e.isVarAddress = false // it is the address to a type
-			e.WriteString("new " + typ.Type.(*ast.Ident).Name)
+			e.WriteString("new " + validIdent(compoType.Name))
+
+			// The fields not specified have the zero value.
+			zero, hasZero := e.tr.findZeroType(validIdent(compoType.Name))
 
+			if len(typ.Elts) == 0 && hasZero {
+				e.WriteString("(" + zero + ")")
+				break
+			}
 			if len(typ.Elts) != 0 {
 				// Specify the fields
 				if _, ok := typ.Elts[0].(*ast.KeyValueExpr); ok {
 					useField = true
 
-					e.WriteString("();")
+					e.WriteString("(" + zero + ");")
 					e.writeTypeElts(typ.Elts, typ.Lbrace)
 				}
 			}
@@ -618,20 +769,52 @@ S:
 				e.WriteString(")")
 			}
 
+		case *ast.SelectorExpr: // Types of the JS library
+			zero, ok := e.tr.libZero(e.tr.typeOf(typ))
+			if !ok || len(typ.Elts) != 0 {
+				e.tr.addError(fmt.Errorf("%s: composite literal of %q not supported in JS",
+					e.tr.fset.Position(typ.Pos()), compoType.Sel.Name))
+				e.tr.hasError = true
+				break
+			}
+			e.WriteString(zero)
+
 		case *ast.MapType:
 			// Type checking
 			if e.tr.getExpression(typ.Type).hasError {
 				return
 			}
-			e.tr.maps[e.tr.funcId][e.tr.blockId][e.tr.lastVarName] = void
 
-			e.WriteString(fmt.Sprintf("g.Map(%s,%s{", e.tr.zeroOfMap(compoType), SP))
+			e.kind = mapKind
+			e.WriteString(fmt.Sprintf("g.MkMap(%s, [", e.tr.zeroOfMap(compoType)))
 			e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
-			e.WriteString("})")
+			e.WriteString("]" + e.tr.mapHash(typ) + ")")
 
 		case nil:
+			if _, ok := derefType(e.tr.typeOf(typ)).(*types.Named); ok { // type elided
+				e.WriteString(e.tr.structLit(typ))
+				break
+			}
+			if e.tr.isMap(typ) {
+				e.writeLit(typ, e.tr.typeOf(typ).Underlying())
+				break
+			}
+			// The slices into a map, else they are arrays into the slice. The
+			// arrays of a multi-dimensional array are written like a list.
+			switch t := derefType(e.tr.typeOf(typ)).(type) {
+			case *types.Slice:
+				if e.kind != sliceKind && !Bootstrap {
+					e.writeLit(typ, t)
+					return
+				}
+			case *types.Array:
+				if !e.isMultiDim && !Bootstrap {
+					e.writeLit(typ, t)
+					return
+				}
+			}
 			if e.kind == structKind {
-				e.WriteString("_(")
+				e.WriteString(e.tr.anonType + "(")
 				e.writeElts(typ.Elts, typ.Lbrace, typ.Rbrace)
 				e.WriteString(")")
 			} else {
@@ -640,6 +823,12 @@ S:
 				e.WriteString("]")
 			}
 
+		case *ast.StructType: // Anonymous struct
+			if Bootstrap {
+				panic(fmt.Sprintf("'CompositeLit' unimplemented: %T", compoType))
+			}
+			e.WriteString(e.tr.structLit(typ))
+
 		default:
 			panic(fmt.Sprintf("'CompositeLit' unimplemented: %T", compoType))
 		}
@@ -656,16 +845,24 @@ S:
 	//  Type *FuncType  // function type
 	//  Body *BlockStmt // function body
 	case *ast.FuncLit:
-		e.translate(typ.Type)
-		e.tr.getStatement(typ.Body)
+		e.WriteString(e.tr.getFuncLit(typ))
 
 	// godoc go/ast FuncType
 	//  Func    token.Pos  // position of "func" keyword
 	//  Params  *FieldList // (incoming) parameters; or nil
 	//  Results *FieldList // (outgoing) results; or nil
 	case *ast.FuncType:
-		//e.isFunc = true
-		e.tr.writeFunc(nil, nil, typ)
+		// For type checking
+		for _, list := range []*ast.FieldList{typ.Params, typ.Results} {
+			if list == nil {
+				continue
+			}
+			for _, field := range list.List {
+				if e.tr.getExpression(field.Type).hasError {
+					e.hasError = true
+				}
+			}
+		}
 
 	// godoc go/ast Ident
 	//  Name    string    // identifier name
@@ -674,8 +871,7 @@ S:
 
 		switch name {
 		case "iota":
-			e.WriteString(IOTA)
-			e.useIota = true
+			e.WriteString(strconv.Itoa(e.tr.iota))
 
 		// Undefined value in array / slice
 		case "_":
@@ -698,48 +894,46 @@ S:
 			e.tr.hasError = true
 
 		default:
-			name = validIdent(typ.Name)
-
-			if e.isPointer { // `*x` => `x.FIELD_POINTER`
-				name += FIELD_POINTER
-			} else if e.isVarAddress { // `&x` => `x`
-				e.tr.addPointer(name)
-
-			} else {
-				if !e.tr.isVar {
-					if name == e.tr.recvVar {
-						name = "this" + FIELD_TYPE
+			name = e.tr.varName(typ)
+			base := name
+
+			if !e.tr.isVar {
+				if name == e.tr.recvVar {
+					name = "this"
+					// The value of the types which are not structs.
+					if t := e.tr.typeOf(typ); t == nil || !isStructType(t) {
+						name += FIELD_TYPE
 					}
-					if !e.tr.isFunc {
-						if !e.tr.wasReturn {
-							if e.tr.isType(arrayType, name) {
-								name += FIELD_VALUE
-							} else if e.tr.isType(sliceType, name) &&
-								!e.tr.isType(structType, name) {
-								name += FIELD_GET
-							}
-						} else if !e.tr.resultUseFunc[e.tr.idxResult] { // can return a literal from a composite type
-							if e.tr.isType(arrayType, name) {
-								name += FIELD_VALUE
-							} else if e.tr.isType(sliceType, name) {
-								name += FIELD_GET
-							}
+				}
+				if !e.tr.isFunc {
+					if !e.tr.wasReturn {
+						if e.tr.isArrayVar(typ, name) {
+							name += FIELD_VALUE
+						} else if e.tr.isType(sliceType, name) &&
+							!e.tr.isType(structType, name) {
+							name += FIELD_GET
+						}
+					} else if !e.tr.resultUseFunc[e.tr.idxResult] { // can return a literal from a composite type
+						if e.tr.isArrayVar(typ, name) {
+							name += FIELD_VALUE
+						} else if e.tr.isType(sliceType, name) {
+							name += FIELD_GET
 						}
 					}
-					if _, ok := e.tr.vars[e.tr.funcId][e.tr.blockId][name]; ok {
-						name += tagPointer(false, 'P', e.tr.funcId, e.tr.blockId, name)
-					}
-
-				} else {
-					e.isIdent = true
+				}
+			} else {
+				e.isIdent = true
 
-					if !e.tr.isFunc && !e.tr.wasReturn && !e.tr.returnBasicLit &&
-						e.tr.isType(arrayType, name) {
-						name += FIELD_VALUE
-					}
+				if !e.tr.isFunc && !e.tr.wasReturn && !e.tr.returnBasicLit &&
+					!(e.isLHS && !Bootstrap) && e.tr.isArrayVar(typ, name) {
+					name += FIELD_VALUE
 				}
 			}
 
+			// The variables addressed are stored in an object.
+			if e.tr.usesBox(typ) {
+				name = base + FIELD_POINTER + name[len(base):]
+			}
 			e.WriteString(name)
 		}
 
@@ -750,17 +944,59 @@ S:
 	//  Index  Expr      // index expression
 	//  Rbrack token.Pos // position of "]"
 	case *ast.IndexExpr:
+		// Generic function instantiated
+		if ident, args := e.tr.instance(typ); ident != nil {
+			e.WriteString(e.tr.genericFunc(ident, args))
+			return
+		}
+
+		// A row of a multi-dimensional array is read like an array, which
+		// shares the elements.
+		if !e.isRow && !e.isLHS && len(e.index) == 0 && e.tr.isRow(typ) {
+			start := e.Len()
+			e.isRow = true
+			e.translate(typ)
+			e.isRow = false
+
+			row := e.String()[start:]
+			e.Truncate(start)
+			e.WriteString("g.Row(" + row + ")")
+			return
+		}
+
+		// The index of a string gets a byte.
+		if e.tr.isString(typ.X) {
+			e.WriteString(fmt.Sprintf("g.StrIndex(%s, %s)", e.tr.getExpression(typ.X),
+				e.tr.getExpression(typ.Index).String()))
+			return
+		}
+
 		// == Store indexes
-		e.index = append(e.index, e.tr.getExpression(typ.Index).String())
+		if e.tr.isMap(typ.X) {
+			e.index = append(e.index, e.tr.getValue(typ.Index))
+		} else {
+			e.index = append(e.index, e.tr.getExpression(typ.Index).String())
+		}
 
-		// Could be multi-dimensional
-		if _, ok := typ.X.(*ast.IndexExpr); ok {
+		// Could be multi-dimensional; the element of a map or a slice is got by
+		// itself.
+		if x, ok := typ.X.(*ast.IndexExpr); ok && !e.tr.isMap(x) && !e.tr.isMap(x.X) &&
+			e.tr.isMultiDim(x) {
 			e.translate(typ.X)
 			return
 		}
 		// ==
 
 		x := e.tr.getExpression(typ.X).String()
+		// The arrays which are not variables, like the fields, and the variables
+		// passed like arguments.
+		if !Bootstrap && !strings.HasSuffix(x, FIELD_VALUE) {
+			if t := e.tr.typeOf(typ.X); t != nil {
+				if _, ok := derefType(t).Underlying().(*types.Array); ok {
+					x += FIELD_VALUE
+				}
+			}
+		}
 		index := ""
 		indexArgs := ""
 
@@ -774,7 +1010,23 @@ S:
 			indexArgs += idx
 		}
 
-		if e.tr.isType(mapType, x) {
+		kind := e.tr.exprKind(typ.X)
+		if !Bootstrap && e.tr.isType(sliceType, stripField(x)) && !e.tr.isType(structType, stripField(x)) {
+			kind = sliceType
+		}
+
+		if e.tr.isMap(typ.X) {
+			e.mapName = x
+			e.elem = x + ".get(" + indexArgs + ")[0]"
+
+			if e.isLHS {
+				e.WriteString(fmt.Sprintf("%s.set(%s,", x, indexArgs))
+				e.addSet = true
+			} else {
+				e.WriteString(e.elem)
+			}
+
+		} else if e.tr.isType(mapType, x) || kind == mapType {
 			e.mapName = x
 
 			if e.tr.isVar && !e.isValue {
@@ -783,18 +1035,39 @@ S:
 				e.WriteString(x + ".get(" + indexArgs + ")[0]")
 			}
 
-		} else if e.tr.isType(sliceType, x) && !e.tr.isType(structType, x) {
-			if !e.isValue {
+		} else if e.tr.isType(sliceType, x) && !e.tr.isType(structType, x) ||
+			kind == sliceType {
+			if kind == sliceType {
+				x = stripField(x)
+			}
+			// The element is read from the backing array.
+			first := e.index[len(e.index)-1]
+			e.elem = fmt.Sprintf("%s.at(%s)%s", x, first, index[len(first)+2:])
+
+			if !Bootstrap && e.isLHS && len(e.index) == 1 || Bootstrap && !e.isValue {
 				e.WriteString(fmt.Sprintf("%s.set([%s],", x, indexArgs))
 				e.addSet = true
 			} else {
-				e.WriteString(x + FIELD_GET + index)
+				e.WriteString(e.elem)
 			}
 
 		} else {
 			e.WriteString(x + index)
 		}
 
+	// godoc go/ast IndexListExpr
+	// Represents an expression followed by multiple indices.
+	//  X       Expr      // expression
+	//  Lbrack  token.Pos // position of "["
+	//  Indices []Expr    // index expressions
+	//  Rbrack  token.Pos // position of "]"
+	case *ast.IndexListExpr:
+		ident, args := e.tr.instance(typ)
+		if ident == nil {
+			panic(fmt.Sprintf("unimplemented: %T", typ))
+		}
+		e.WriteString(e.tr.genericFunc(ident, args))
+
 	// godoc go/ast InterfaceType
 	//  Interface  token.Pos  // position of "interface" keyword
 	//  Methods    *FieldList // list of methods
@@ -806,16 +1079,21 @@ S:
 	//  Colon token.Pos // position of ":"
 	//  Value Expr
 	case *ast.KeyValueExpr:
+		if e.kind == mapKind { // entry of a map
+			e.WriteString(fmt.Sprintf("[%s, %s]", e.tr.getValue(typ.Key),
+				e.tr.copyValue(typ.Value, e.tr.getValue(typ.Value))))
+			break
+		}
 		key := e.tr.getExpression(typ.Key).String()
 		exprValue := e.tr.getExpression(typ.Value)
-		value := exprValue.String()
+		value := e.tr.copyValue(typ.Value, exprValue.String())
 
 		if value[0] == '[' { // multi-dimensional index
 			value = "{" + value[1:len(value)-1] + "}"
 		}
 
-		if !e.tr.isArray && e.kind != sliceKind {
-			e.WriteString(key + ":" + SP + value)
+		if !e.tr.isArray && e.kind != sliceKind && !e.isElt {
+			e.WriteString(key + ": " + value)
 		} else {
 			e.WriteString(fmt.Sprintf("{%s:%s}", key, value))
 		}
@@ -825,9 +1103,11 @@ S:
 	//  Key   Expr
 	//  Value Expr
 	case *ast.MapType:
-		// For type checking
+		// For type checking; the array types are neither written nor saved.
+		restore := e.tr.keepArray()
 		e.tr.getExpression(typ.Key)
 		e.tr.getExpression(typ.Value)
+		restore()
 
 	// godoc go/ast ParenExpr
 	//  Lparen token.Pos // position of "("
@@ -845,36 +1125,67 @@ S:
 		isPkg := false
 		x := ""
 
+		isCall := e.isCall
+		e.isCall = false
+
+		// Fields and methods promoted from embedded fields.
+		path := ""
+		sel := e.tr.selection(typ)
+		if sel != nil {
+			path = embeddedPath(sel)
+		}
+
+		// Method used like a value
+		if sel != nil {
+			if sel.Kind() == types.MethodExpr {
+				e.writeMethodExpr(typ, sel)
+				return
+			}
+			if sel.Kind() == types.MethodVal && !isCall {
+				e.writeMethodVal(typ, sel)
+				return
+			}
+		}
+
 		switch t := typ.X.(type) {
 		case *ast.SelectorExpr:
 			e.translate(typ.X)
-		case *ast.CallExpr:
+		case *ast.CallExpr, *ast.ParenExpr, *ast.StarExpr:
 			e.translate(t)
 		case *ast.Ident:
-			x = t.Name
+			if _, ok := e.tr.info.Uses[t].(*types.PkgName); ok {
+				isPkg = true
+				x = t.Name
+				break
+			}
+			x = e.tr.varName(t)
+
+			// The variables addressed are stored in an object.
+			if e.tr.usesBox(t) {
+				x += FIELD_POINTER
+			} else if t.Name == e.tr.recvVar {
+				x = "this"
+			}
 		case *ast.IndexExpr:
-			e.translate(t)
-			e.WriteString("." + typ.Sel.Name) // TODO: validIdent?
+			// The element is read, though the field was assigned.
+			e.WriteString(e.tr.getExpression(t).String())
+			e.WriteString("." + path + validProp(typ.Sel.Name))
 			return
 		default:
 			panic(fmt.Sprintf("'SelectorExpr': unimplemented: %T", t))
 		}
 
-		if x == e.tr.recvVar {
-			x = "this"
-		}
-		goName := x + "." + validIdent(typ.Sel.Name)
-
-		// Check is the selector is a package
-		for _, v := range validImport {
-			if v == x {
-				isPkg = true
-				break
-			}
-		}
+		goName := x + "." + path + validProp(typ.Sel.Name)
 
 		// Check if it can be translated to its equivalent in JavaScript.
 		if isPkg {
+			// The interfaces are only checked at compiling, and the types of
+			// the JS library are got from their zero value.
+			if obj, ok := e.tr.info.Uses[typ.Sel].(*types.TypeName); ok {
+				if _, isLib := Type[goName]; isLib || types.IsInterface(obj.Type()) {
+					break
+				}
+			}
 			jsName, ok := Function[goName]
 			if !ok {
 				jsName, ok = Constant[goName]
@@ -902,23 +1213,44 @@ S:
 	//  Lbrack token.Pos // position of "["
 	//  Low    Expr      // begin of slice range; or nil
 	//  High   Expr      // end of slice range; or nil
+	//  Max    Expr      // maximum capacity of slice; or nil
+	//  Slice3 bool      // true if 3-index slice (2 colons present)
 	//  Rbrack token.Pos // position of "]"
 	case *ast.SliceExpr:
 		slice := "0"
-		x := typ.X.(*ast.Ident).Name
+		x := ""
+		if ident, ok := typ.X.(*ast.Ident); ok {
+			x = e.tr.varName(ident)
+			// The variables addressed are stored in an object.
+			if e.tr.usesBox(ident) {
+				x += FIELD_POINTER
+			}
+		} else {
+			x = e.tr.getExpression(typ.X).String()
+		}
 
 		if typ.Low != nil {
 			slice = e.tr.getExpression(typ.Low).String()
 		}
 		if typ.High != nil {
-			slice += "," + SP + e.tr.getExpression(typ.High).String()
+			slice += ", " + e.tr.getExpression(typ.High).String()
+		}
+		// The capacity is limited by the third index.
+		if typ.Slice3 {
+			slice += ", " + e.tr.getExpression(typ.Max).String()
+		}
+
+		// The indexes of a string are in bytes.
+		if e.tr.isString(typ.X) {
+			e.WriteString(fmt.Sprintf("g.StrSlice(%s, %s)", x, slice))
+			break
 		}
 
 		if e.tr.isVar && e.isValue {
 			// The JS function is handled in file "var.go"; look for SliceFrom.
-			e.WriteString(x + "," + SP + slice)
+			e.WriteString(x + ", " + slice)
 		} else {
-			e.WriteString(fmt.Sprintf("g.SliceFrom(%s,%s)", x, SP+slice))
+			e.WriteString(fmt.Sprintf("g.SliceFrom(%s, %s)", x, slice))
 			//e.tr.slices[e.tr.funcId][e.tr.blockId][x] = void TODO: REMOVE
 		}
 
@@ -928,8 +1260,16 @@ S:
 	//  Star token.Pos // position of "*"
 	//  X    Expr      // operand
 	case *ast.StarExpr:
-		e.isPointer = true
+		// The pointer is read, though the value pointed is assigned.
+		isLHS := e.isLHS
+		e.isLHS = false
 		e.translate(typ.X)
+		e.isLHS = isLHS
+
+		// The value pointed, but the structs and arrays are the own object.
+		if tv, ok := e.tr.info.Types[typ]; ok && tv.IsValue() && !isValueType(tv.Type) {
+			e.WriteString(FIELD_POINTER)
+		}
 
 	// godoc go/ast StructType
 	//  Struct     token.Pos  // position of "struct" keyword
@@ -942,6 +1282,9 @@ S:
 	//  Op    token.Token // operator
 	//  X     Expr        // operand
 	case *ast.UnaryExpr:
+		if e.writeConst(typ) {
+			break
+		}
 		writeOp := true
 		op := typ.Op.String()
 
@@ -949,7 +1292,10 @@ S:
 		case token.XOR: // bitwise complement
 			op = "~"
 		case token.AND: // address operator
-			e.isVarAddress = true
+			if !Bootstrap {
+				e.WriteString(e.tr.address(typ.X))
+				return
+			}
 			writeOp = false
 		case token.ARROW: // channel
 			e.tr.addError("%s: channel operator", e.tr.fset.Position(typ.OpPos))
@@ -973,23 +1319,327 @@ S:
 // == Utility
 //
 
-// writeElts writes the list of composite elements.
-func (e *expression) writeElts(elts []ast.Expr, Lbrace, Rbrace token.Pos) {
-	firstPos := e.tr.getLine(Lbrace)
-	posOldElt := firstPos
-	posNewElt := 0
+// writeConst writes the value of a numeric constant expression, folded by the
+// type checker, since JavaScript has not integer division, it shifts 32 bits
+// and its complement is signed. It reports whether the value was written.
+func (e *expression) writeConst(expr ast.Expr) bool {
+	tv, ok := e.tr.info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() == constant.Bool {
+		return false
+	}
+	lit, ok := constLit(tv.Value)
+	if !ok {
+		return false
+	}
+	if _, ok := expr.(*ast.BinaryExpr); ok && lit[0] == '-' {
+		lit = "(" + lit + ")"
+	}
+	e.WriteString(lit)
+	e.isBasicLit = true
+	return true
+}
 
-	for i, el := range elts {
-		posNewElt = e.tr.getLine(el.Pos())
+// writeMethodVal writes a method value, which is bound to its receiver.
+func (e *expression) writeMethodVal(sel *ast.SelectorExpr, s *types.Selection) {
+	// The receiver is got from the selector translated like a call.
+	method := e.tr.newExpression(nil)
+	method.isCall = true
+	method.translate(sel)
+
+	recv := method.String()
+	recv = recv[:strings.LastIndex(recv, ".")]
+
+	e.WriteString(fmt.Sprintf("g.MethodVal(%s, %q, %t)",
+		recv, validProp(sel.Sel.Name), isValueRecv(s)))
+}
+
+// writeRowLen writes the length or capacity of a row of a multi-dimensional
+// array, which is known unless the index has calls.
+func (e *expression) writeRowLen(call *ast.CallExpr) bool {
+	if !e.tr.isRow(call.Args[0]) {
+		return false
+	}
+	if tv, ok := e.tr.info.Types[call]; ok && tv.Value != nil {
+		e.WriteString(tv.Value.String())
+	} else {
+		e.WriteString(e.tr.getExpression(call.Args[0]).String() + ".len()")
+	}
+	return true
+}
 
+// writeConversion writes the value converted to the type, which is an interface
+// or a named type which is not a struct, so it keeps its representation. To an
+// interface, the values which need the methods of their type are stored into an
+// object of it, the rest of values whose type is not known from JavaScript are
+// stored with the name of their type, and the pointers to structs and arrays
+// are marked with it.
+func (e *expression) writeConversion(arg ast.Expr, to types.Type) {
+	isFunc := e.tr.isFunc
+	e.tr.isFunc = true // the value is passed like an argument
+	value := e.tr.getValue(arg)
+	e.tr.isFunc = isFunc
+
+	from := e.tr.typeOf(arg)
+	// The nil slices and maps are values.
+	if e.tr.info.Types[arg].IsNil() && typeKind(to) != otherType {
+		value = e.tr.typeZero(to)
+	}
+	if types.IsInterface(to) && from != nil {
+		if isNamedValue(from) && hasMethods(from) {
+			value = fmt.Sprintf("new %s(%s)", validIdent(from.(*types.Named).Obj().Name()), value)
+		} else if storesType(from) {
+			value = typedValue(value, from)
+		} else if isObjectPointer(from) {
+			value = fmt.Sprintf("g.Pointer(%s, %s)", value, jsString(typeString(from)))
+		}
+	}
+
+	// The strings are converted to slices of bytes or runes, and back.
+	if from != nil && !types.IsInterface(to) {
+		if slice, ok := to.Underlying().(*types.Slice); ok && isStringType(from) {
+			if isRuneType(slice.Elem()) {
+				value = "g.Runes(" + value + ")"
+			} else {
+				value = "g.Bytes(" + value + ")"
+			}
+		} else if slice, ok := from.Underlying().(*types.Slice); ok && isStringType(to) {
+			if isRuneType(slice.Elem()) {
+				value = stripField(value) + ".str(true)"
+			} else {
+				value = stripField(value) + ".str()"
+			}
+		}
+	}
+	e.WriteString(value)
+}
+
+// writeNamedCall writes the call of a method of a named type which is not a
+// struct. The value is stored into an object of the type to call the method,
+// or the pointer is passed like "this" to a method with receiver of pointer.
+func (e *expression) writeNamedCall(sel *ast.SelectorExpr, s *types.Selection, call *ast.CallExpr) {
+	name := validIdent(derefType(s.Recv()).(*types.Named).Obj().Name())
+	method := validProp(sel.Sel.Name)
+	_, isPointer := s.Recv().(*types.Pointer)
+
+	args := e.tr.getArgsCall(call)
+	if isValueRecv(s) {
+		isFunc := e.tr.isFunc
+		e.tr.isFunc = true // the value is passed like an argument
+		recv := e.tr.getValue(sel.X)
+		e.tr.isFunc = isFunc
+
+		if isPointer && !isValueType(s.Recv()) {
+			recv += FIELD_POINTER
+		}
+		e.WriteString(fmt.Sprintf("new %s(%s).%s(%s)", name, recv, method, args))
+		return
+	}
+
+	recv := ""
+	if isPointer {
+		recv = e.tr.getExpression(sel.X).String()
+	} else {
+		recv = e.tr.address(sel.X)
+	}
+	if args != "" {
+		args = ", " + args
+	}
+	e.WriteString(fmt.Sprintf("%s.prototype.%s.call(%s%s)", name, method, recv, args))
+}
+
+// writeMethodExpr writes a method expression, which is a function that takes
+// the receiver like its first argument.
+func (e *expression) writeMethodExpr(sel *ast.SelectorExpr, s *types.Selection) {
+	typ := "undefined" // interface
+
+	if named, ok := derefType(s.Recv()).(*types.Named); ok {
+		if _, ok = named.Underlying().(*types.Interface); !ok {
+			typ = validIdent(named.Obj().Name())
+		}
+	}
+
+	e.WriteString(fmt.Sprintf("g.MethodExpr(%s, %q, %t)",
+		typ, validProp(sel.Sel.Name), isValueRecv(s)))
+}
+
+// isValueRecv reports whether the method selected has a receiver of value.
+func isValueRecv(s *types.Selection) bool {
+	recv := s.Obj().(*types.Func).Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	if _, ok := recv.Type().(*types.Pointer); ok {
+		return false
+	}
+	_, isInterface := recv.Type().Underlying().(*types.Interface)
+	return !isInterface
+}
+
+// isSliceCall checks if the expression is a call which returns a slice.
+func (tr *translation) isSliceCall(expr ast.Expr) bool {
+	if _, ok := expr.(*ast.CallExpr); !ok || Bootstrap {
+		return false
+	}
+	typ := tr.typeOf(expr)
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Slice)
+	return ok
+}
+
+// getValue returns the expression translated like a value which is complete by
+// itself, like the arguments of a call.
+func (tr *translation) getValue(expr ast.Expr) string {
+	// The composite literals are closed like the arguments of a call.
+	if _, ok := expr.(*ast.CompositeLit); ok {
+		isFunc := tr.isFunc
+		tr.isFunc = true
+		defer func() { tr.isFunc = isFunc }()
+		defer tr.keepArray()()
+	}
+
+	e := tr.getExpression(expr)
+	if e.kind == sliceKind {
+		return "g.Slice(" + e.String()
+	}
+	return e.String()
+}
+
+// keepArray saves whether an array is being written, for expressions which are
+// not the value of the variable; the returned function restores it.
+func (tr *translation) keepArray() func() {
+	isArray := tr.isArray
+	return func() { tr.isArray = isArray }
+}
+
+// getArgsCall returns the arguments in a call to a defined function.
+//
+// The values for a variadic parameter are passed into a slice, unless the
+// call has already a slice followed by "...".
+func (tr *translation) getArgsCall(call *ast.CallExpr) string {
+	isFunc := tr.isFunc
+	tr.isFunc = true
+	defer func() { tr.isFunc = isFunc }()
+
+	args := call.Args
+	variadic := -1 // index of the first value for the variadic parameter
+
+	if sig, ok := tr.typeOf(call.Fun).(*types.Signature); ok && sig.Variadic() &&
+		call.Ellipsis == token.NoPos && !Bootstrap {
+		variadic = sig.Params().Len() - 1
+	}
+
+	// The descriptors of the type parameters are passed before of the values.
+	jsArgs := ""
+	if ident, types_ := tr.instance(call.Fun); ident != nil {
+		jsArgs = tr.typeArgs(types_)
+		if len(args) == 0 && variadic == -1 {
+			jsArgs = strings.TrimSuffix(jsArgs, ", ")
+		}
+	}
+	// The values returned by a call are spread into the arguments.
+	if len(args) == 1 && !Bootstrap {
+		if tuple, ok := tr.typeOf(args[0]).(*types.Tuple); ok && tuple.Len() > 1 {
+			values := tr.getExpression(args[0]).String()
+			if variadic != -1 {
+				values = fmt.Sprintf("g.TupleArgs(%s, %d)", values, variadic)
+			}
+			return jsArgs + "..." + values
+		}
+	}
+	for i, v := range args {
+		if i != 0 {
+			jsArgs += ", "
+		}
+		if i == variadic {
+			jsArgs += "g.SliceArgs(["
+		}
+		_arg := tr.getExpression(v)
+
+		if _arg.kind == sliceKind {
+			jsArgs += "g.Slice("
+		}
+		jsArgs += _arg.String()
+	}
+
+	if variadic != -1 {
+		if variadic >= len(args) {
+			if variadic != 0 {
+				jsArgs += ", "
+			}
+			jsArgs += "g.SliceArgs(["
+		}
+		jsArgs += "])"
+	}
+	return jsArgs
+}
+
+// checkElt reports the type of the elements of an array or slice type when it
+// is not supported, since the literals built from their type don't translate it.
+func (e *expression) checkElt(typ *ast.ArrayType) {
+	elt := typ.Elt
+	for {
+		t, ok := elt.(*ast.ArrayType)
+		if !ok {
+			break
+		}
+		elt = t.Elt
+	}
+
+	if ident, ok := elt.(*ast.Ident); ok {
+		switch ident.Name {
+		case "int64", "uint64", "complex64", "complex128":
+			e.tr.addError("%s: %s type", e.tr.fset.Position(ident.Pos()), ident.Name)
+			e.tr.hasError = true
+		}
+	}
+}
+
+// writeLit writes the composite literal of a map, a slice or an array from its
+// type, given by the type checker.
+func (e *expression) writeLit(lit *ast.CompositeLit, typ types.Type) {
+	switch t := typ.Underlying().(type) {
+	case *types.Map:
+		e.kind = mapKind
+		e.WriteString(fmt.Sprintf("g.MkMap(%s, [", e.tr.typeZero(t.Elem())))
+		e.writeElts(lit.Elts, lit.Lbrace, lit.Rbrace)
+		e.WriteString("]" + e.tr.mapHash(lit) + ")")
+	case *types.Slice:
+		e.isElt = true
+		e.WriteString(fmt.Sprintf("g.Slice(%s, [", e.tr.typeZero(t.Elem())))
+		e.writeElts(lit.Elts, lit.Lbrace, lit.Rbrace)
+		e.WriteString("])")
+	case *types.Array:
+		if len(lit.Elts) == 0 {
+			e.WriteString(e.tr.typeZero(t))
+			break
+		}
+		dims, elem := arrayDims(t)
+		e.isElt = true
+		e.isMultiDim = strings.Contains(dims, ",")
+
+		e.WriteString(fmt.Sprintf("g.MkArray([%s], %s, [", dims, e.tr.typeZero(elem)))
+		e.writeElts(lit.Elts, lit.Lbrace, lit.Rbrace)
+		e.WriteString("])")
+	}
+}
+
+// writeElts writes the list of composite elements. The lines of the elements and
+// of the right brace are referenced, to be kept by the printer.
+func (e *expression) writeElts(elts []ast.Expr, lbrace, rbrace token.Pos) {
+	line := e.tr.getLine(lbrace)
+
+	for i, el := range elts {
 		if i != 0 {
 			e.WriteString(",")
 		}
-		if posNewElt != posOldElt {
-			e.WriteString(strings.Repeat(NL, posNewElt-posOldElt))
-			e.WriteString(strings.Repeat(TAB, e.tr.tabLevel+1))
-		} else if i != 0 { // in the same line
-			e.WriteString(SP)
+		if e.tr.getLine(el.Pos()) != line {
+			line = e.tr.getLine(el.Pos())
+			e.WriteString(lineRef(line))
+		}
+		if i != 0 {
+			e.WriteString(" ")
 		}
 
 		// It is necessary to create a new expression for each element, to avoid
@@ -1000,35 +1650,24 @@ func (e *expression) writeElts(elts []ast.Expr, Lbrace, Rbrace token.Pos) {
 		exprElt.hasError = e.hasError
 		exprElt.arrayHasElts = e.arrayHasElts
 		exprElt.isValue = e.isValue
+		exprElt.isElt = e.isElt
+		exprElt.isMultiDim = e.isMultiDim
 		//exprElt.isEllipsis = e.isEllipsis
-		//exprElt.isMultiDim = e.isMultiDim
-		//exprElt.useIota = e.useIota
 
 		exprElt.translate(el)
-		e.WriteString(exprElt.String())
-
-		posOldElt = posNewElt
+		e.WriteString(e.tr.copyValue(el, exprElt.String()))
 	}
-
-	// The right brace
-	posNewElt = e.tr.getLine(Rbrace)
-	if posNewElt != posOldElt {
-		e.WriteString(strings.Repeat(NL, posNewElt-posOldElt))
-		e.WriteString(strings.Repeat(TAB, e.tr.tabLevel))
+	if e.tr.getLine(rbrace) != line {
+		e.WriteString(lineRef(e.tr.getLine(rbrace)))
 	}
-
-	e.tr.line += posNewElt - firstPos // update the global position
 }
 
 // writeTypeElts writes the list of elements for a custom type.
-func (e *expression) writeTypeElts(elts []ast.Expr, Lbrace token.Pos) {
-	firstPos := e.tr.getLine(Lbrace)
-	posOldElt := firstPos
-	posNewElt := 0
+func (e *expression) writeTypeElts(elts []ast.Expr, lbrace token.Pos) {
+	line := e.tr.getLine(lbrace)
 	useBracket := false
 
 	for i, el := range elts {
-		posNewElt = e.tr.getLine(el.Pos())
 		kv := el.(*ast.KeyValueExpr)
 		key := e.tr.getExpression(kv.Key).String()
 
@@ -1048,26 +1687,45 @@ func (e *expression) writeTypeElts(elts []ast.Expr, Lbrace token.Pos) {
 		if i != 0 {
 			e.WriteString(",")
 		}
-		if posNewElt != posOldElt {
-			e.WriteString(strings.Repeat(NL, posNewElt-posOldElt))
-			e.WriteString(strings.Repeat(TAB, e.tr.tabLevel))
-		} else { // in the same line
-			e.WriteString(SP)
+		if e.tr.getLine(el.Pos()) != line {
+			line = e.tr.getLine(el.Pos())
+			e.WriteString(lineRef(line))
 		}
-
-		e.WriteString(fmt.Sprintf("%s%s=%s",
+		e.WriteString(fmt.Sprintf(" %s%s = %s",
 			e.tr.lastVarName,
-			key+SP,
-			SP+e.tr.getExpression(kv.Value).String(),
+			key,
+			e.tr.copyValue(kv.Value, e.tr.getExpression(kv.Value).String()),
 		))
-
-		posOldElt = posNewElt
 	}
-	e.tr.line += posNewElt - firstPos // update the global position
 }
 
 // * * *
 
+// jsString returns the string literal of JavaScript whose characters are the
+// bytes of "s". The bytes which are not printable in ASCII are escaped, but the
+// new lines and tabulators which are replaced by their characters in "Char".
+func jsString(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\n' || c == '\t':
+			buf.WriteString(Char[int(c)])
+		case c == '"' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c < 0x20 || c >= 0x7F:
+			fmt.Fprintf(&buf, "\\x%02x", c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	buf.WriteByte('"')
+	return buf.String()
+}
+
 // stripField strips the field name FIELD_VALUE or FIELD_GET, if any.
 func stripField(name string) string {
 	if strings.HasSuffix(name, FIELD_VALUE) {
@@ -7,8 +7,10 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
+	"go/types"
 	"strings"
 )
 
@@ -31,16 +33,14 @@ func (tr *translation) getFunc(decl *ast.FuncDecl) {
 		return
 	}
 
-	isFuncInit := false // function init()
-
 	// == Initialization to save variables created on this function
 	//if decl.Name != nil { // discard literal functions //TODO: remove
 		tr.funcTotal++
 		tr.funcId = tr.funcTotal
 		tr.blockId = 0
+		tr.tmpTotal = 0
 
-		tr.vars[tr.funcId] = make(map[int]map[string]bool)
-		tr.addr[tr.funcId] = make(map[int]map[string]bool)
+		tr.vars[tr.funcId] = make(map[int]map[string]struct{})
 		tr.maps[tr.funcId] = make(map[int]map[string]struct{})
 		tr.arrays[tr.funcId] = make(map[int]map[string]struct{})
 		tr.slices[tr.funcId] = make(map[int]map[string]struct{})
@@ -48,36 +48,43 @@ func (tr *translation) getFunc(decl *ast.FuncDecl) {
 		tr.zeroType[tr.funcId] = make(map[int]map[string]string)
 
 		// The blockId 0 holds variables of functions arguments
-		tr.vars[tr.funcId][tr.blockId] = make(map[string]bool)
+		tr.vars[tr.funcId][tr.blockId] = make(map[string]struct{})
 		tr.maps[tr.funcId][tr.blockId] = make(map[string]struct{})
 		tr.arrays[tr.funcId][tr.blockId] = make(map[string]struct{})
 		tr.slices[tr.funcId][tr.blockId] = make(map[string]struct{})
 	//}
 	// ==
 
-	tr.addLine(decl.Pos())
-	tr.addIfExported(decl.Name)
-
-	if decl.Name.Name != "init" {
-		tr.writeFunc(decl.Recv, decl.Name, decl.Type)
-	} else {
-		isFuncInit = true
-		tr.WriteString("(function()" + SP)
+	tr.flush()
+	if decl.Recv == nil { // the methods are got through their type
+		tr.addIfExported(decl.Name)
 	}
+	var fn *jsFunc
 
-	tr.getStatement(decl.Body)
+	if obj := tr.info.Defs[decl.Name]; obj != nil {
+		tr.addResults(obj.Type())
+	}
 
-	if isFuncInit {
-		tr.WriteString("());")
+	if decl.Name.Name == "init" && !Bootstrap {
+		// It is called after of initializing the variables.
+		tr.initTotal++
+		fn = &jsFunc{Head: "function " + initName(tr.initTotal)}
+	} else if decl.Name.Name != "init" {
+		fn = tr.newFunc(decl.Recv, decl.Name, decl.Type, decl.Body)
+	} else {
+		fn = &jsFunc{Head: "(function", Tail: "());"}
 	}
+	fn.Line = tr.getLine(decl.Pos())
+	fn.Body = tr.getBlock(decl.Body)
+	tr.addStmt(fn)
 
 	if decl.Name != nil {
 		// At exiting of the function, it returns at the global scope.
 		tr.funcId = 0
 		tr.blockId = 0
 
-		if decl.Name.Name == "main" {
-			tr.WriteString(SP + "main();") // call to function main
+		if decl.Name.Name == "main" && decl.Recv == nil {
+			tr.hasMain = true
 		}
 	}
 	if decl.Recv != nil {
@@ -85,6 +92,37 @@ func (tr *translation) getFunc(decl *ast.FuncDecl) {
 	}
 }
 
+// getFuncLit returns the reference to the function literal translated.
+func (tr *translation) getFuncLit(lit *ast.FuncLit) string {
+	// The function is translated with its own buffer, and the data of the
+	// function where it is declared is restored at finishing.
+	buf, line := tr.Buffer, tr.line
+	stmt := *tr.dataStmt
+
+	tr.Buffer = new(bytes.Buffer)
+	tr.resultUseFunc = make(map[int]bool)
+	tr.isVar = false
+	tr.isFunc = false
+	tr.wasReturn = false
+
+	tr.addResults(tr.typeOf(lit))
+	fn := tr.newFunc(nil, nil, lit.Type, lit.Body)
+	fn.Line = tr.getLine(lit.Pos())
+	fn.Body = tr.getBlock(lit.Body)
+
+	// In methods, the receiver is "this" into the closures too.
+	if tr.recvVar != "" && tr.recvVar != "_" {
+		fn.Tail = ".bind(this)"
+	}
+	tr.prog.Lits = append(tr.prog.Lits, fn)
+
+	tr.Buffer, tr.line = buf, line
+	stmt.funcTotal = tr.funcTotal
+	*tr.dataStmt = stmt
+
+	return ref(len(tr.prog.Lits) - 1)
+}
+
 // godoc go/ast FuncType
 //  Func    token.Pos  // position of "func" keyword
 //  Params  *FieldList // (incoming) parameters; or nil
@@ -102,58 +140,133 @@ func (tr *translation) getFunc(decl *ast.FuncDecl) {
 //  Tag     *BasicLit     // field tag; or nil
 //  Comment *CommentGroup // line comments; or nil
 
-// writeFunc writes the function declaration.
-func (tr *translation) writeFunc(recv *ast.FieldList, name *ast.Ident, typ *ast.FuncType) {
+// newFunc returns the function declaration, without its body. The parameters
+// and the receiver which are changed into the body are copied at the beginning
+// of the body, which is written by the caller.
+func (tr *translation) newFunc(recv *ast.FieldList, name *ast.Ident, typ *ast.FuncType, body *ast.BlockStmt) *jsFunc {
+	fn := new(jsFunc)
+	recvCopy := ""
+	tr.funcPos, tr.funcBody = typ.Pos(), body
+
 	if recv != nil { // method
 		field := recv.List[0]
 		tr.recvVar = field.Names[0].Name
 
-		fType := tr.getExpression(field.Type).String()
-		if strings.HasSuffix(fType, FIELD_POINTER) { // is it a pointer?
-			fType = fType[:len(fType)-2]
+		// The receiver is a new variable, instead of "this".
+		if obj := tr.info.Defs[field.Names[0]]; !Bootstrap && obj != nil &&
+			tr.isChanged(obj, body) {
+			if _, ok := obj.Type().Underlying().(*types.Struct); ok {
+				recvCopy = fmt.Sprintf("var %s = g.Clone(this, %s);", validIdent(tr.recvVar),
+					tr.valueDesc(obj.Type()))
+				tr.recvVar = "_"
+			}
 		}
+		// The pointer is a new variable when it is assigned, like to walk
+		// through a linked list.
+		if obj := tr.info.Defs[field.Names[0]]; !Bootstrap && obj != nil &&
+			isPointer(obj.Type()) && tr.isAssigned(obj, body) {
+			recvCopy = fmt.Sprintf("var %s = this;", validIdent(tr.recvVar))
+			tr.recvVar = "_"
+		}
+		// The value of the named types which are not structs is stored into
+		// "this", and the pointer is "this" itself.
+		if obj := tr.info.Defs[field.Names[0]]; !Bootstrap && obj != nil &&
+			isNamedValue(derefType(obj.Type())) {
+			recvCopy = tr.namedRecv(obj, body)
+			tr.recvVar = "_"
+		}
+		tr.recvTypeParams(field.Type)
 
-		tr.WriteString(fmt.Sprintf("%s.prototype.%s=%sfunction",
-			fType, validIdent(name)+SP, SP))
+		recvType := field.Type
+		if star, ok := recvType.(*ast.StarExpr); ok { // is it a pointer?
+			recvType = star.X
+		}
+		if ident, _ := tr.instance(recvType); ident != nil { // generic type
+			recvType = ident
+		}
+		fType := tr.getExpression(recvType).String()
+
+		fn.Head = fmt.Sprintf("%s.prototype.%s = function", fType, validProp(name.Name))
+		fn.Tail = ";"
 	} else if name != nil {
-		tr.WriteString(fmt.Sprintf("function %s", validIdent(name)))
+		fn.Head = "function " + validIdent(name)
 		tr.recvVar = "_" // avoid that been added "this" in selectors
 	} else { // Literal function
-		tr.WriteString(fmt.Sprintf("%s=%sfunction", SP, SP))
-		tr.recvVar = "_" // avoid "this" in selectors
+		fn.Head = "function"
 	}
 
 	// Get the parameters
-	paramFix, paramVar := tr.joinParams(typ)
+	params, paramVar := tr.joinParams(typ, body)
+
+	if typ.TypeParams != nil { // generic function
+		typeParams := strings.TrimSuffix(tr.joinTypeParams(typ.TypeParams, ""), ", ")
+		params = append(strings.Split(typeParams, ", "), params...)
+	}
+	fn.Params = params
 
-	tr.WriteString(fmt.Sprintf("(%s)%s", paramFix, SP))
+	tr.resultsUseFunc(typ)
 
-	if paramVar != "" {
-		tr.WriteString("{" + SP + paramVar)
-		tr.skipLbrace = true
+	prologue := []string{}
+	for _, v := range []string{recvCopy, paramVar} {
+		if v != "" {
+			prologue = append(prologue, v)
+		}
 	}
+	tr.prologue = strings.Join(prologue, " ")
 
-	// To return multiple values
-	declResults, declReturn := tr.joinResults(typ)
+	return fn
+}
+
+// namedRecv returns the declaration of the receiver of a named type which is
+// not a struct, like a variable of the function.
+func (tr *translation) namedRecv(obj types.Object, body *ast.BlockStmt) string {
+	name := validIdent(obj.Name())
+	value := "this"
 
-	if declResults != "" {
-		if !tr.skipLbrace {
-			tr.WriteString("{")
-			tr.skipLbrace = true
+	if _, ok := obj.Type().(*types.Pointer); !ok {
+		value += FIELD_TYPE
+		if isValueType(obj.Type()) && tr.isChanged(obj, body) {
+			value = fmt.Sprintf("g.Clone(%s, %s)", value, tr.valueDesc(obj.Type()))
+		}
+		if tr.isBoxed(obj) {
+			value = box(value)
+		}
+
+		switch typeKind(obj.Type()) {
+		case sliceType:
+			tr.vars[tr.funcId][tr.blockId][name] = void
+			tr.slices[tr.funcId][tr.blockId][name] = void
+		case mapType:
+			tr.vars[tr.funcId][tr.blockId][name] = void
+			tr.maps[tr.funcId][tr.blockId][name] = void
 		}
-		tr.WriteString(SP + declResults)
-		tr.results = declReturn
-	} else {
-		tr.results = ""
 	}
+	return fmt.Sprintf("var %s = %s;", name, value)
+}
+
+// isAssigned reports whether the variable is assigned into the node.
+func (tr *translation) isAssigned(obj types.Object, node ast.Node) bool {
+	assigned := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if stmt, ok := n.(*ast.AssignStmt); ok {
+			for _, lhs := range stmt.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && tr.info.Uses[ident] == obj {
+					assigned = true
+				}
+			}
+		}
+		return !assigned
+	})
+	return assigned
 }
 
-// joinParams gets the parameters.
-func (tr *translation) joinParams(f *ast.FuncType) (paramFix, paramVar string) {
+// joinParams gets the parameters, and the copies of the arrays and structs
+// which are changed into the body, or the objects of the parameters which are
+// addressed.
+func (tr *translation) joinParams(f *ast.FuncType, body *ast.BlockStmt) (params []string, paramVar string) {
 	if f.Params == nil {
 		return
 	}
-	isFirst := true
 	i := 0
 
 L:
@@ -162,15 +275,19 @@ L:
 
 		switch t := list.Type.(type) {
 		case *ast.Ellipsis:
-			paramVar = fmt.Sprintf("var %s=%s",
-				validIdent(list.Names[0].Name)+SP, SP)
-
-			if i != 0 {
-				paramVar += fmt.Sprintf("[].slice.call(arguments).slice(%d);", i)
-			} else {
-				paramVar += "arguments;"
+			// The JS library gets the values through "arguments".
+			if Bootstrap {
+				paramVar = fmt.Sprintf("var %s = ", validIdent(list.Names[0].Name))
+
+				if i != 0 {
+					paramVar += fmt.Sprintf("[].slice.call(arguments).slice(%d);", i)
+				} else {
+					paramVar += "arguments;"
+				}
+				break L // an ellipsis is the last parameter
 			}
-			break L // an ellipsis is the last parameter
+			// Else, the caller passes a slice.
+			typ = sliceType
 
 		case *ast.ArrayType:
 			if t.Len != nil {
@@ -180,18 +297,26 @@ L:
 			}
 		case *ast.MapType:
 			typ = mapType
+		case *ast.Ident, *ast.SelectorExpr: // named types
+			if !Bootstrap {
+				typ = typeKind(tr.typeOf(t))
+			}
 		}
 
 		for _, v := range list.Names {
-			if !isFirst {
-				paramFix += "," + SP
-			}
 			i++
 			_name := validIdent(v.Name)
-			paramFix += _name
+			params = append(params, _name)
+
+			if obj := tr.info.Defs[v]; !Bootstrap && obj != nil && tr.isChanged(obj, body) {
+				paramVar += fmt.Sprintf("%s = g.Clone(%s, %s);", _name, _name,
+					tr.valueDesc(obj.Type()))
+			} else if tr.isBoxed(obj) {
+				paramVar += fmt.Sprintf("%s = %s;", _name, box(_name))
+			}
 
 			if typ != otherType {
-				tr.vars[tr.funcId][tr.blockId][_name] = false
+				tr.vars[tr.funcId][tr.blockId][_name] = void
 
 				switch typ {
 				case sliceType:
@@ -202,64 +327,36 @@ L:
 					tr.maps[tr.funcId][tr.blockId][_name] = void
 				}
 			}
-			if isFirst {
-				isFirst = false
-			}
 		}
 	}
 	return
 }
 
-// joinResults gets the results to use both in the declaration and in its return.
-func (tr *translation) joinResults(f *ast.FuncType) (decl, ret string) {
+// addResults saves the named results of the function signature, which are
+// declared like variables at the beginning of the body.
+func (tr *translation) addResults(typ types.Type) {
+	sig, ok := typ.(*types.Signature)
+	if !ok {
+		return
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		tr.results[sig.Results().At(i)] = true
+	}
+}
+
+// resultsUseFunc sets which results are slices, arrays or maps. The named
+// results are lowered to local variables.
+func (tr *translation) resultsUseFunc(f *ast.FuncType) {
 	if f.Results == nil {
 		return
 	}
-	isFirst := true
-	isMultiple := false
-	typeUseFunc := false
-	i := 0
 
-	for _, list := range f.Results.List {
+	for i, list := range f.Results.List {
 		switch list.Type.(type) {
 		case *ast.ArrayType, *ast.MapType, *ast.Ellipsis:
-			typeUseFunc = true
+			tr.resultUseFunc[i] = true
 		default:
-			typeUseFunc = false
+			tr.resultUseFunc[i] = false
 		}
-
-		if list.Names == nil {
-			tr.resultUseFunc[i] = typeUseFunc
-			i++
-			continue
-		}
-
-		value, _ := tr.zeroValue(true, list.Type)
-
-		for _, v := range list.Names {
-			if !isFirst {
-				decl += "," + SP
-				ret += "," + SP
-				isMultiple = true
-			} else {
-				isFirst = false
-			}
-			decl += fmt.Sprintf("%s=%s", validIdent(v.Name)+SP, SP+value)
-			ret += v.Name
-
-			tr.resultUseFunc[i] = typeUseFunc
-			i++
-		}
-	}
-
-	if decl != "" {
-		decl = "var " + decl + ";"
 	}
-
-	if isMultiple {
-		ret = "[" + ret + "]"
-	}
-	ret = "return " + ret + ";"
-
-	return
 }
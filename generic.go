@@ -0,0 +1,240 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// The descriptors of the type parameters are named like them into the generic
+// functions, and like the fields "this.$T" into the methods of a generic type.
+
+// typeParamsOf returns the type parameters declared in the list.
+func typeParamsOf(list *ast.FieldList) []*ast.Ident {
+	if list == nil {
+		return nil
+	}
+	params := make([]*ast.Ident, 0)
+
+	for _, field := range list.List {
+		params = append(params, field.Names...)
+	}
+	return params
+}
+
+// joinTypeParams returns the names of the descriptors of the type parameters,
+// and saves them to be used into the function.
+func (tr *translation) joinTypeParams(list *ast.FieldList, prefix string) string {
+	names := ""
+
+	for _, v := range typeParamsOf(list) {
+		name := prefix + validIdent(v.Name)
+
+		if obj := tr.info.Defs[v]; obj != nil {
+			tr.typeParams[obj] = name
+		}
+		names += name + ", "
+	}
+	return names
+}
+
+// recvTypeParams saves the type parameters of the receiver of a method, which
+// are got from the fields of the generic type.
+func (tr *translation) recvTypeParams(expr ast.Expr) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	var params []ast.Expr
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		params = []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		params = t.Indices
+	default:
+		return
+	}
+
+	for i, v := range params {
+		ident, ok := v.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		obj := tr.info.Defs[ident]
+		if obj == nil {
+			continue
+		}
+		// The receiver can use other names than in the declaration of the type.
+		name := validIdent(ident.Name)
+		if named, ok := tr.typeOf(expr).(*types.Named); ok &&
+			named.Origin().TypeParams().Len() > i {
+			name = validIdent(named.Origin().TypeParams().At(i).Obj().Name())
+		}
+		tr.typeParams[obj] = "this.$" + name
+	}
+}
+
+// typeParamName returns the name of the descriptor of a type parameter.
+func (tr *translation) typeParamName(tp *types.TypeParam) string {
+	if name, ok := tr.typeParams[tp.Obj()]; ok {
+		return name
+	}
+	return validIdent(tp.Obj().Name())
+}
+
+// isTypeParam reports whether the identifier is a type parameter, returning
+// the name of its descriptor.
+func (tr *translation) isTypeParam(ident *ast.Ident) (string, bool) {
+	if obj, ok := tr.info.Uses[ident].(*types.TypeName); ok {
+		if tp, ok := obj.Type().(*types.TypeParam); ok {
+			return tr.typeParamName(tp), true
+		}
+	}
+	return "", false
+}
+
+// instance returns the identifier of the generic function or type which is
+// instantiated in the expression, and the arguments of the type.
+func (tr *translation) instance(expr ast.Expr) (*ast.Ident, *types.TypeList) {
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		expr = t.X
+	case *ast.IndexListExpr:
+		expr = t.X
+	}
+
+	var ident *ast.Ident
+	switch t := expr.(type) {
+	case *ast.Ident:
+		ident = t
+	case *ast.SelectorExpr:
+		ident = t.Sel
+	default:
+		return nil, nil
+	}
+
+	if inst, ok := tr.info.Instances[ident]; ok {
+		return ident, inst.TypeArgs
+	}
+	return nil, nil
+}
+
+// typeArgs returns the descriptors of the arguments of the type, each one
+// followed by a comma.
+func (tr *translation) typeArgs(list *types.TypeList) string {
+	args := ""
+
+	for i := 0; i < list.Len(); i++ {
+		args += tr.typeDesc(list.At(i)) + ", "
+	}
+	return args
+}
+
+// typeDesc returns the descriptor of a type.
+func (tr *translation) typeDesc(typ types.Type) string {
+	if tp, ok := typ.(*types.TypeParam); ok {
+		return tr.typeParamName(tp)
+	}
+	kind := ""
+	if basic, ok := typ.Underlying().(*types.Basic); ok && basic.Info()&types.IsNumeric != 0 {
+		kind = types.Typ[basic.Kind()].Name()
+	}
+	return fmt.Sprintf("g.Type(function() { return %s; }, %q)", tr.typeZero(typ), kind)
+}
+
+// typeZero returns the zero value of a type given by the type checker.
+func (tr *translation) typeZero(typ types.Type) string {
+	switch t := typ.(type) {
+	case *types.TypeParam:
+		return tr.typeParamName(t) + ".zero()"
+
+	case *types.Named:
+		if zero, ok := tr.libZero(t); ok {
+			return zero
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return tr.typeZero(t.Underlying())
+		}
+		args := tr.fieldsZero(st)
+		if t.TypeArgs() != nil {
+			args = strings.TrimSuffix(tr.typeArgs(t.TypeArgs())+args, ", ")
+		}
+		return fmt.Sprintf("new %s(%s)", validIdent(t.Obj().Name()), args)
+
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			return "false"
+		case t.Info()&types.IsString != 0:
+			return EMPTY
+		case t.Info()&types.IsNumeric != 0:
+			return "0"
+		}
+	case *types.Slice:
+		return "g.MkSlice()"
+	case *types.Map:
+		return fmt.Sprintf("g.MkMap(%s)", tr.typeZero(t.Elem()))
+	case *types.Array:
+		dims, elem := arrayDims(t)
+		return fmt.Sprintf("g.MkArray([%s], %s)", dims, tr.typeZero(elem))
+	case *types.Struct: // anonymous
+		fields := ""
+		for i := 0; i < t.NumFields(); i++ {
+			if field := t.Field(i); field.Name() != BLANK {
+				fields += fmt.Sprintf(", %s: %s", validProp(field.Name()), tr.typeZero(field.Type()))
+			}
+		}
+		return "{" + strings.TrimPrefix(fields, ", ") + "}"
+	}
+	return "undefined"
+}
+
+// arrayDims returns the lengths of the dimensions of an array, and the type of
+// its elements; a multi-dimensional array is built like only one.
+func arrayDims(t *types.Array) (dims string, elem types.Type) {
+	dims = strconv.FormatInt(t.Len(), 10)
+	elem = t.Elem()
+
+	for {
+		arr, ok := elem.(*types.Array)
+		if !ok {
+			return
+		}
+		dims += "," + strconv.FormatInt(arr.Len(), 10)
+		elem = arr.Elem()
+	}
+}
+
+// fieldsZero returns the zero values of the fields of a struct, like they are
+// passed to its constructor.
+func (tr *translation) fieldsZero(st *types.Struct) string {
+	zero := ""
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Name() == BLANK {
+			continue
+		}
+		if zero != "" {
+			zero += ", "
+		}
+		zero += tr.typeZero(field.Type())
+	}
+	return zero
+}
+
+// genericFunc returns a generic function instantiated, which is used like a
+// value.
+func (tr *translation) genericFunc(ident *ast.Ident, args *types.TypeList) string {
+	return fmt.Sprintf("%s.bind(null, %s)", validIdent(ident.Name),
+		strings.TrimSuffix(tr.typeArgs(args), ", "))
+}
@@ -14,11 +14,14 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io/ioutil"
 	"log"
 	"os"
 	"path"
 	"strings"
+
+	"github.com/kless/go2js/lower"
 )
 
 const (
@@ -27,18 +30,6 @@ const (
 	EMPTY  = `""` // empty string
 )
 
-const (
-	// To be able to minimize code
-	NL  = "<<NL>>" // new line
-	SP  = "<<SP>>" // space
-	TAB = "<<TAB>>"
-
-	ADDR = "<<&>>" // to mark assignments to addresses
-	IOTA = "<<iota>>"
-	NIL  = "<<nil>>"
-	VERB = "<<%>>"
-)
-
 const (
 	FIELD_GET     = ".get()"
 	FIELD_POINTER = ".p"
@@ -55,12 +46,26 @@ var (
 
 // translation represents information about code being translated to JavaScript.
 type translation struct {
-	line     int // actual line
+	line     int // line of the Go source being translated
 	hasError bool
 
 	fset          *token.FileSet
-	*bytes.Buffer // sintaxis translated to JS
-	*dataStmt     // extra data for a statement
+	info          *types.Info             // types got from the type checker
+	pkg           *types.Package          // package checked
+	renamed       map[types.Object]string // objects translated with another name
+	typeParams    map[types.Object]string // descriptors of the type parameters
+	addressed     map[types.Object]bool   // variables whose address is taken
+	results       map[types.Object]bool   // named results of the functions
+	deferredVars  map[*ast.ValueSpec]bool // variables initialized after of all declarations
+	initDeferred  []*types.Initializer    // initialization deferred, in order
+	initTotal     int                     // number of functions "init"
+	hasMain       bool                    // has function "main"?
+	*bytes.Buffer                         // code of the statement being translated
+	*dataStmt                             // extra data for a statement
+
+	prog *jsProgram // code translated
+	list *[]jsStmt  // statements of the block being translated
+	iota int        // value of "iota" in the constant being translated
 
 	err      []error  // errors
 	warn     []string // warnings
@@ -71,8 +76,7 @@ type translation struct {
 
 	// == Variables defined in each block, for each function.
 	// {Function Id: {Block id: {Name:
-	vars map[int]map[int]map[string]bool // is pointer?
-	addr map[int]map[int]map[string]bool // variable was assigned to an address?
+	vars map[int]map[int]map[string]struct{}
 
 	maps         map[int]map[int]map[string]struct{}
 	arrays       map[int]map[int]map[string]struct{}
@@ -89,9 +93,23 @@ func newTranslation() *translation {
 		false,
 
 		token.NewFileSet(),
+		nil,
+		nil,
+		make(map[types.Object]string),
+		make(map[types.Object]string),
+		make(map[types.Object]bool),
+		make(map[types.Object]bool),
+		make(map[*ast.ValueSpec]bool),
+		nil,
+		0,
+		false,
 		new(bytes.Buffer),
 		&dataStmt{resultUseFunc: make(map[int]bool)},
 
+		new(jsProgram),
+		nil,
+		0,
+
 		make([]error, 0, MaxMessage),
 		make([]string, 0, MaxMessage),
 		make([]string, 0),
@@ -99,8 +117,7 @@ func newTranslation() *translation {
 		//make(map[string]string),
 		//"",
 
-		make(map[int]map[int]map[string]bool),
-		make(map[int]map[int]map[string]bool),
+		make(map[int]map[int]map[string]struct{}),
 		make(map[int]map[int]map[string]struct{}),
 		make(map[int]map[int]map[string]struct{}),
 		make(map[int]map[int]map[string]struct{}),
@@ -111,11 +128,10 @@ func newTranslation() *translation {
 	// == Global variables
 	// Ones related to local variables are set in:
 	// file func: *translation.getFunc()
-	// file stmt: *translation.getStatement() (case: *ast.BlockStmt)
+	// file stmt: *translation.getBlock()
 
 	// funcId = 0
-	tr.vars[0] = make(map[int]map[string]bool)
-	tr.addr[0] = make(map[int]map[string]bool)
+	tr.vars[0] = make(map[int]map[string]struct{})
 	tr.maps[0] = make(map[int]map[string]struct{})
 	tr.arrays[0] = make(map[int]map[string]struct{})
 	tr.slices[0] = make(map[int]map[string]struct{})
@@ -123,14 +139,14 @@ func newTranslation() *translation {
 	tr.zeroType[0] = make(map[int]map[string]string)
 
 	// blockId = 0
-	tr.vars[0][0] = make(map[string]bool)
-	tr.addr[0][0] = make(map[string]bool)
+	tr.vars[0][0] = make(map[string]struct{})
 	tr.maps[0][0] = make(map[string]struct{})
 	tr.arrays[0][0] = make(map[string]struct{})
 	tr.slices[0][0] = make(map[string]struct{})
 	tr.structSlices[0][0] = make(map[string]struct{})
 	tr.zeroType[0][0] = make(map[string]string)
 
+	tr.list = &tr.prog.List
 	return tr
 }
 
@@ -139,25 +155,33 @@ func (tr *translation) getLine(pos token.Pos) int {
 	return tr.fset.Position(pos).Line - 1
 }
 
-// addLine appends new lines according to the position.
-// Returns a boolean to indicate if have been added.
-func (tr *translation) addLine(pos token.Pos) bool {
-	var s string
-
-	new := tr.getLine(pos)
-	dif := new - tr.line
+// setLine sets the line of the Go source where is the code written after. The
+// code written before is added like a statement.
+func (tr *translation) setLine(pos token.Pos) {
+	tr.flush()
+	tr.line = tr.getLine(pos)
+}
 
-	if dif == 0 {
-		return false
+// flush adds the code written like a statement of the block being translated.
+func (tr *translation) flush() {
+	if tr.Len() != 0 {
+		*tr.list = append(*tr.list, &jsCode{tr.line, tr.String()})
+		tr.Reset()
 	}
+}
 
-	for i := 0; i < dif; i++ {
-		s += NL
-	}
+// addStmt adds a statement to the block being translated, after of the code
+// written.
+func (tr *translation) addStmt(stmt jsStmt) {
+	tr.flush()
+	*tr.list = append(*tr.list, stmt)
+}
 
-	tr.WriteString(s)
-	tr.line = new
-	return true
+// takeCode returns the code written, which is not added like a statement.
+func (tr *translation) takeCode() string {
+	code := tr.String()
+	tr.Reset()
+	return code
 }
 
 // addError appends an error.
@@ -194,7 +218,7 @@ func (tr *translation) addIfExported(iName interface{}) {
 
 	switch typ := iName.(type) {
 	case *ast.Ident:
-		name = typ.Name
+		name = validIdent(typ.Name)
 	case string:
 		name = typ
 	}
@@ -231,14 +255,17 @@ func Translate(filename string, write bool) error {
 	if err != nil {
 		return err
 	}
+	trans.checkTypes(node)
+	lower.File(trans.fset, node, trans.info, trans.pkg)
+	trans.initOrder(node)
+	trans.findPointers(node)
 
 	// Package name
 	pkgName = trans.getExpression(node.Name).String()
 
 	if pkgName != "main" {
-		trans.addLine(node.Package)
-		trans.WriteString(fmt.Sprintf("var %s=%s{};%s(function()%s{",
-			pkgName+SP, SP, SP, SP))
+		trans.prog.Package = pkgName
+		trans.prog.Line = trans.getLine(node.Package)
 	}
 
 	for _, decl := range node.Decls {
@@ -256,7 +283,7 @@ func Translate(filename string, write bool) error {
 			case token.IMPORT:
 				trans.getImport(genDecl.Specs)
 			case token.CONST:
-				trans.getConst(genDecl.TokPos, genDecl.Specs, true)
+				trans.getConst(genDecl.Specs, true)
 			case token.VAR:
 				trans.getVar(genDecl.Specs, true)
 			case token.TYPE:
@@ -267,6 +294,8 @@ func Translate(filename string, write bool) error {
 			panic(fmt.Sprintf("unimplemented: %T", decl))
 		}
 	}
+	trans.setLine(node.End())
+	trans.writeInit()
 
 	// Any error?
 	if trans.hasError {
@@ -283,49 +312,32 @@ func Translate(filename string, write bool) error {
 	}
 
 	// Export declarations in packages
-	if pkgName != "main" {
-		if len(trans.exported) != 0 {
-			for i, v := range trans.exported {
-				if i == 0 {
-					trans.WriteString(NL + NL)
-				}
-
-				if !Bootstrap {
-					if i == 0 {
-						trans.WriteString(fmt.Sprintf("g.Export(%s,%s[%s",
-							pkgName, SP, v))
-					} else {
-						trans.WriteString("," + SP + v)
-					}
-				} else {
-					trans.WriteString(fmt.Sprintf("%s.%s=%s;%s",
-						pkgName, v+SP, SP+v, NL))
-				}
-			}
-			if !Bootstrap {
-				trans.WriteString("]);")
-			}
+	if pkgName != "main" && len(trans.exported) != 0 {
+		trans.line++ // a blank line
+
+		if !Bootstrap {
+			trans.line++
+			trans.WriteString(fmt.Sprintf("g.Export(%s, [%s]);", pkgName,
+				strings.Join(trans.exported, ", ")))
 		} else {
-			trans.WriteString(NL)
+			for _, v := range trans.exported {
+				trans.flush()
+				trans.line++
+				trans.WriteString(fmt.Sprintf("%s.%s = %s;", pkgName, v, v))
+			}
 		}
-
-		trans.WriteString(NL + "})();")
+		trans.flush()
 	}
-	trans.WriteString("\n")
-
-	trans.WriteString(HEADER + NL)
 
 	// == Write
 	baseFilename := strings.Replace(filename, path.Ext(filename), "", 1)
-	str := trans.String()
-
-	// Variables addressed
-	trans.replacePointers(&str)
 
 	// Regular code
-	code := strings.Replace(str, NL, "\n", -1)
-	code = strings.Replace(code, TAB, "\t", -1)
-	code = strings.Replace(code, SP, " ", -1)
+	mode := linesMode
+	if *fPretty {
+		mode = prettyMode
+	}
+	code := printProgram(trans.prog, mode) + "\n" + HEADER + "\n"
 
 	if write {
 		if err = ioutil.WriteFile(baseFilename+".js", []byte(code), 0664); err != nil {
@@ -337,9 +349,7 @@ func Translate(filename string, write bool) error {
 
 	// Minimized code
 	if *fMin {
-		min := strings.Replace(str, NL, "", -1)
-		min = strings.Replace(min, TAB, "", -1)
-		min = strings.Replace(min, SP, "", -1)
+		min := printProgram(trans.prog, minMode) + "\n" + HEADER + "\n"
 
 		if write {
 			if err = ioutil.WriteFile(baseFilename+".min.js", []byte(min), 0664); err != nil {
@@ -370,12 +380,13 @@ func Translate(filename string, write bool) error {
 
 // Flags
 var (
-	fMin   = flag.Bool("min", false, "also create code minimized")
-	fWrite = flag.Bool("w", false, "write output to file")
+	fMin    = flag.Bool("min", false, "also create code minimized")
+	fPretty = flag.Bool("pretty", false, "indent the code by blocks, instead of keeping the lines of the Go source")
+	fWrite  = flag.Bool("w", false, "write output to file")
 )
 
 func usage() {
-	fmt.Fprintf(os.Stderr, `Usage: goscript [-min -w] file...
+	fmt.Fprintf(os.Stderr, `Usage: goscript [-min -pretty -w] file...
 Translate Go to JavaScript.
 
 `)
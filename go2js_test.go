@@ -28,32 +28,49 @@ func init() {
 	Char['\t'] = "&nbsp;&nbsp;&nbsp;&nbsp;"
 }
 
-func TestConst(t *testing.T)    { translate('t', "decl_const.go", t) }
-func TestVar(t *testing.T)      { translate('t', "decl_var.go", t) }
-func TestStruct(t *testing.T)   { translate('t', "decl_struct.go", t) }
-func TestReserved(t *testing.T) { translate('t', "decl_reserved.go", t) }
-func TestPointer(t *testing.T)  { translate('t', "pointer.go", t) }
-
-func TestFunc(t *testing.T)  { translate('t', "func.go", t) }
-func TestCompo(t *testing.T) { translate('t', "composite.go", t) }
-func TestSlice(t *testing.T) { translate('t', "slice.go", t) }
-func TestMap(t *testing.T)   { translate('t', "map.go", t) }
-
-func TestMethod(t *testing.T) { translate('t', "method.go", t) }
+func TestConst(t *testing.T)     { translate('t', "decl_const.go", t) }
+func TestVar(t *testing.T)       { translate('t', "decl_var.go", t) }
+func TestStruct(t *testing.T)    { translate('t', "decl_struct.go", t) }
+func TestReserved(t *testing.T)  { translate('t', "decl_reserved.go", t) }
+func TestPointer(t *testing.T)   { translate('t', "pointer.go", t) }
+func TestSwitch(t *testing.T)    { translate('t', "switch.go", t) }
+func TestRange(t *testing.T)     { translate('t', "range.go", t) }
+func TestLoopVar(t *testing.T)   { translate('t', "loopvar.go", t) }
+func TestGeneric(t *testing.T)   { translate('t', "generic.go", t) }
+func TestInitOrder(t *testing.T) { translate('t', "initorder.go", t) }
+func TestIota(t *testing.T)      { translate('t', "iota.go", t) }
+func TestString(t *testing.T)    { translate('t', "string.go", t) }
+func TestStrings(t *testing.T)   { translate('t', "strings.go", t) }
+func TestNaming(t *testing.T)    { translate('t', "naming.go", t) }
+func TestNamed(t *testing.T)     { translate('t', "named.go", t) }
+func TestFmt(t *testing.T)       { translate('t', "fmt.go", t) }
+func TestEqual(t *testing.T)     { translate('t', "equal.go", t) }
+func TestValue(t *testing.T)     { translate('t', "value.go", t) }
+
+func TestFunc(t *testing.T)     { translate('t', "func.go", t) }
+func TestVariadic(t *testing.T) { translate('t', "variadic.go", t) }
+func TestResult(t *testing.T)   { translate('t', "result.go", t) }
+func TestClosure(t *testing.T)  { translate('t', "closure.go", t) }
+func TestCompo(t *testing.T)    { translate('t', "composite.go", t) }
+func TestSlice(t *testing.T)    { translate('t', "slice.go", t) }
+func TestMap(t *testing.T)      { translate('t', "map.go", t) }
+
+func TestMethod(t *testing.T)      { translate('t', "method.go", t) }
+func TestMethodValue(t *testing.T) { translate('t', "method_value.go", t) }
+func TestEmbed(t *testing.T)       { translate('t', "embed.go", t) }
 
 func TestNumeric(t *testing.T) { translate('t', "numeric.go", t) }
 func TestMisc(t *testing.T)    { translate('t', "misc.go", t) }
 
 func ExampleControl() {
+	Bootstrap = false
 	Translate(DIR_TEST+"control.go", true)
 
 	// Output:
-	// == Warnings
-	//
-	// ./testdata/control.go:58:2: 'default' clause above 'case' clause in switch statement
 }
 
 func ExampleDecl() {
+	Bootstrap = false
 	Translate(DIR_TEST+"error_decl.go", true)
 
 	// Output:
@@ -77,13 +94,12 @@ func ExampleDecl() {
 	// ./testdata/error_decl.go:54:12: channel type
 	// ./testdata/error_decl.go:55:12: channel type
 	// ./testdata/error_decl.go:56:7: channel operator
-	// ./testdata/error_decl.go:66:2: function type in struct
 	// ./testdata/error_decl.go:70:4: int64 type
-	// ./testdata/error_decl.go:71:2: anonymous field in struct
 	// ./testdata/error_decl.go:72:4: complex128 type
 }
 
 func ExampleStmt() {
+	Bootstrap = false
 	Translate(DIR_TEST+"error_stmt.go", true)
 
 	// Output:
@@ -95,6 +111,65 @@ func ExampleStmt() {
 	// ./testdata/error_stmt.go:18:2: built-in function recover()
 	// ./testdata/error_stmt.go:24:1: use of label
 	// ./testdata/error_stmt.go:29:3: goto directive
+	// ./testdata/error_stmt.go:36:10: invalid operation: slice can only be compared to nil
+}
+
+// == Layout
+//
+
+func Example_pretty() {
+	Bootstrap = false
+	*fPretty = true
+	defer func() { *fPretty = false }()
+	Translate(DIR_TEST+"layout.go", false)
+
+	// Output:
+	// function main() {
+	// 	var s = g.Slice("", [
+	// 		"a <<NL>> b",
+	// 		"{ }"
+	// 	]);
+	// 	var add = function(a, b) {
+	// 		return a + b;
+	// 	};
+	//
+	// 	for (var i = 0; i < s.len; i++) {
+	// 		if (i == 0) {
+	// 			document.write(g.Text(g.Sprint([s.at(i)], true) + "<br>"));
+	// 		} else {
+	// 			document.write(g.Text(g.Sprint([add(i, 1)], true) + "<br>"));
+	// 		}
+	// 	}
+	// }
+	// main();
+	// /* Generated by Go2js (github.com/kless/go2js) */
+}
+
+func Example_min() {
+	Bootstrap = false
+	*fMin = true
+	defer func() { *fMin = false }()
+	Translate(DIR_TEST+"layout.go", false)
+
+	// Output:
+	// function main() {
+	// 	var s = g.Slice("", [
+	// 		"a <<NL>> b",
+	// 		"{ }"
+	// 	]);
+	// 	var add = function(a, b) { return a + b; };
+	//
+	// 	for (var i = 0; i < s.len; i++) {
+	// 		if (i == 0) {
+	// 			document.write(g.Text(g.Sprint([s.at(i)], true) + "<br>"));
+	// 		} else {
+	// 			document.write(g.Text(g.Sprint([add(i, 1)], true) + "<br>"));
+	// 		}
+	// 	}
+	// } main();
+	// /* Generated by Go2js (github.com/kless/go2js) */
+	// function main(){var s=g.Slice("",["a <<NL>> b","{ }"]);var add=function(a,b){return a+b;};for(var i=0;i<s.len;i++){if(i==0){document.write(g.Text(g.Sprint([s.at(i)],true)+"<br>"));}else{document.write(g.Text(g.Sprint([add(i,1)],true)+"<br>"));}}}main();
+	// /* Generated by Go2js (github.com/kless/go2js) */
 }
 
 // == JavaScript library
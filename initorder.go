@@ -0,0 +1,195 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// initOrder gets the package-level variables whose initialization has to be
+// deferred until all declarations are written, since their order is not the
+// one of the source or they depend on a declaration written later.
+//
+// The variables are initialized in place while they are in the order given by
+// the type checker; after of the first one which is not, the rest are deferred.
+func (tr *translation) initOrder(file *ast.File) {
+	if Bootstrap || len(tr.info.InitOrder) == 0 {
+		return
+	}
+
+	funcs := make(map[types.Object]*ast.FuncDecl)
+	specs := make([]*ast.ValueSpec, 0)
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if obj := tr.info.Defs[d.Name]; obj != nil {
+				funcs[obj] = d
+			}
+		case *ast.GenDecl:
+			if d.Tok != token.VAR {
+				continue
+			}
+			for _, s := range d.Specs {
+				vSpec := s.(*ast.ValueSpec)
+				if vSpec.Values == nil {
+					continue
+				}
+				specs = append(specs, vSpec)
+
+				// The functions can use the variables declared after of them.
+				for _, v := range vSpec.Names {
+					if v.Name != BLANK {
+						tr.declType(tr.varName(v), tr.typeOf(v))
+					}
+				}
+			}
+		}
+	}
+
+	next := 0 // next initializer in the order of the type checker
+	isDeferred := false
+
+	for _, spec := range specs {
+		if !isDeferred {
+			// The initializers of the specification have to be the next ones.
+			for _, name := range spec.Names {
+				obj := tr.info.Defs[name]
+				if obj == nil || name.Name == BLANK && len(spec.Values) != 1 {
+					continue
+				}
+				if next >= len(tr.info.InitOrder) || !hasVar(tr.info.InitOrder[next], obj) {
+					isDeferred = true
+					break
+				}
+				if isLastVar(tr.info.InitOrder[next], obj) {
+					next++
+				}
+			}
+		}
+		if !isDeferred && tr.usesLater(spec, spec.Pos(), funcs) {
+			isDeferred = true
+		}
+		if isDeferred {
+			tr.deferredVars[spec] = true
+		}
+	}
+	if isDeferred {
+		tr.initDeferred = tr.info.InitOrder[next:]
+	}
+}
+
+// hasVar reports whether the variable is initialized by the initializer.
+func hasVar(init *types.Initializer, obj types.Object) bool {
+	for _, v := range init.Lhs {
+		if v == obj {
+			return true
+		}
+	}
+	return false
+}
+
+// isLastVar reports whether the variable is the last one of the initializer.
+func isLastVar(init *types.Initializer, obj types.Object) bool {
+	return init.Lhs[len(init.Lhs)-1] == obj
+}
+
+// usesLater reports whether the node uses a package-level declaration written
+// after of the position pos, even through the functions and methods called.
+func (tr *translation) usesLater(node ast.Node, pos token.Pos, funcs map[types.Object]*ast.FuncDecl) bool {
+	seen := make(map[types.Object]bool)
+	isLater := false
+
+	var inspect func(ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		var obj types.Object
+
+		switch t := n.(type) {
+		case *ast.Ident:
+			obj = tr.info.Uses[t]
+		case *ast.SelectorExpr:
+			if sel := tr.selection(t); sel != nil {
+				obj = sel.Obj()
+			}
+		}
+		if obj == nil || obj.Pkg() != tr.pkg || seen[obj] || isLater {
+			return !isLater
+		}
+		seen[obj] = true
+
+		// Only the package-level declarations and the methods.
+		if obj.Parent() != tr.pkg.Scope() {
+			if fn, ok := obj.(*types.Func); !ok || fn.Type().(*types.Signature).Recv() == nil {
+				return true
+			}
+		}
+		if obj.Pos() > pos {
+			isLater = true
+			return false
+		}
+		if decl, ok := funcs[obj]; ok && decl.Body != nil {
+			ast.Inspect(decl.Body, inspect)
+		}
+		return true
+	}
+	ast.Inspect(node, inspect)
+	return isLater
+}
+
+// writeInit writes the initialization of the package-level variables which was
+// deferred, the calls to the functions "init" and to the function "main".
+func (tr *translation) writeInit() {
+	for _, init := range tr.initDeferred {
+		names := make([]ast.Expr, len(init.Lhs))
+
+		for i, v := range init.Lhs {
+			names[i] = ast.NewIdent(v.Name())
+		}
+		tr.flush()
+		tr.line++
+		tr.writeVar(names, []ast.Expr{init.Rhs}, nil, token.ASSIGN, true)
+	}
+	tr.flush()
+
+	calls := []string{}
+	for i := 1; i <= tr.initTotal; i++ {
+		calls = append(calls, initName(i)+"();")
+	}
+	if tr.hasMain {
+		calls = append(calls, "main();") // call to function main
+	}
+	tr.WriteString(strings.Join(calls, " "))
+	tr.flush()
+}
+
+// initName returns the name of the function "init" with number n.
+func initName(n int) string {
+	return "init$" + strconv.Itoa(n)
+}
+
+// writeDeferredVar declares the variables of the specification without value,
+// since they are initialized by "writeInit".
+func (tr *translation) writeDeferredVar(spec *ast.ValueSpec) {
+	names := make([]string, 0, len(spec.Names))
+
+	for _, v := range spec.Names {
+		if v.Name == BLANK {
+			continue
+		}
+		name := tr.varName(v)
+		tr.addIfExported(name)
+		tr.declType(name, tr.typeOf(v))
+		names = append(names, name)
+	}
+	if len(names) != 0 {
+		tr.WriteString("var " + strings.Join(names, ", ") + ";")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+/*
+## JavaScript syntax tree
+
+The translation builds a tree with the JavaScript statements, and the printer
+writes it with the layout chosen (see "printer.go"), so the code translated has
+not marks of layout which could be mistaken with the values of the Go source.
+
+The simple statements and the expressions are kept like code, with the spaces
+of the readable code, which the printer drops into the code minimized. The
+function literals are nodes, referenced into the code of the expression which
+holds them by their index between two NUL characters; a Go source file can not
+have that character, so the references are not mistaken with the code:
+
+	f := func() { return 1 }  => jsCode{Code: "var f = \x000\x00;"}
+	                             Lits[0] = jsFunc{Head: "function", ...}
+
+The lines of the elements of a composite literal are referenced in the same way,
+with the line after of a colon, so the printer can keep them:
+
+	a := []int{     => "var a = g.Slice(0, [\x00:2\x00 1, \x00:3\x00 2\x00:4\x00]);"
+		1,
+		2,
+	}
+*/
+
+// jsStmt represents a JavaScript statement.
+type jsStmt interface {
+	// line returns the line of the Go source where the statement starts, or
+	// -1 if it is added by the translator.
+	line() int
+}
+
+// jsProgram represents the code of a file translated.
+type jsProgram struct {
+	Package string   // name of the package, if it is not "main"
+	List    []jsStmt // top-level statements
+	Line    int      // line of the package clause
+	Lits    []*jsFunc
+}
+
+// jsBlock represents a block of statements between braces.
+type jsBlock struct {
+	Line   int // line of the left brace
+	List   []jsStmt
+	Rbrace int // line of the right brace
+}
+
+// jsCode represents one or several simple statements, like declarations,
+// assignments or calls.
+type jsCode struct {
+	Line int
+	Code string
+}
+
+// jsFunc represents a function, written like "Head(Params) Body Tail".
+type jsFunc struct {
+	Line   int
+	Head   string // code before of the parameters, like "function f"
+	Params []string
+	Body   *jsBlock
+	Tail   string // code after of the body, like ";"
+}
+
+// jsIf represents the statement "if". Its branch "else" is a *jsIf or a
+// *jsBlock; or nil.
+type jsIf struct {
+	Line int
+	Cond string
+	Body *jsBlock
+	Else jsStmt
+}
+
+// jsFor represents a loop, written like "for (Head) Body".
+type jsFor struct {
+	Line int
+	Head string
+	Body *jsBlock
+}
+
+// jsSwitch represents the statement "switch".
+type jsSwitch struct {
+	Line   int
+	Tag    string
+	Cases  []*jsCase
+	Rbrace int
+}
+
+// jsCase represents a clause of "switch", which is "default" when there is not
+// any value.
+type jsCase struct {
+	Line   int
+	Values []string
+	Body   []jsStmt
+	Break  bool // the clause ends with "break"
+}
+
+func (s *jsBlock) line() int  { return s.Line }
+func (s *jsCode) line() int   { return s.Line }
+func (s *jsFunc) line() int   { return s.Line }
+func (s *jsIf) line() int     { return s.Line }
+func (s *jsFor) line() int    { return s.Line }
+func (s *jsSwitch) line() int { return s.Line }
+
+// refMark delimits the references to the function literals into the code.
+const refMark = "\x00"
+
+// ref returns the reference to the function literal with index i.
+func ref(i int) string {
+	return refMark + strconv.Itoa(i) + refMark
+}
+
+// lineRef returns the reference to a line of the Go source, where the code
+// after of it starts.
+func lineRef(line int) string {
+	return refMark + ":" + strconv.Itoa(line) + refMark
+}
+
+// splitRefs splits the code at the references. The parts with an odd index are
+// the indexes of the function literals, or the lines after of a colon.
+func splitRefs(code string) []string {
+	if !strings.Contains(code, refMark) {
+		return []string{code}
+	}
+	return strings.Split(code, refMark)
+}
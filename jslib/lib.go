@@ -126,9 +126,12 @@ func (a ArrayType) cap(index int) int {
 	return a.len_[len(arguments)]
 }
 
-// str returns the array (of bytes or runes) like a string.
-func (a ArrayType) str() string {
-	return a.v.join("")
+// str returns the array of bytes, or of runes if "isRune", like a string.
+func (a ArrayType) str(isRune bool) string {
+	if isRune {
+		return runesStr(a.v)
+	}
+	return strOf(a.v)
 }
 
 // typ returns the type.
@@ -157,6 +160,18 @@ func MkArray(index []int, zero interface{}, data []interface{}) *ArrayType {
 	return a
 }
 
+// Row returns the row "v" of a multi-dimensional array like an array, which
+// shares the elements with it.
+func Row(v []interface{}) *ArrayType {
+	a := new(ArrayType)
+	a.v = v
+
+	for i, n := range indexArray(v) {
+		a.len_[i] = n
+	}
+	return a
+}
+
 // * * *
 
 // equalIndex reports whether index1 and index2 are equal.
@@ -186,19 +201,55 @@ func indexArray(a []interface{}) (index []int) {
 	return
 }
 
+// isIndexMap reports whether the value is an object with the positions of the
+// elements, and not a value of a struct or a pointer; so its keys are indexes.
+func isIndexMap(v interface{}) bool {
+	if typeof(v) != "object" || v == nil || v.constructor.name != "Object" {
+		return false
+	}
+	keys := Object.keys(v)
+	if len(keys) == 0 {
+		return false
+	}
+	for _, k := range keys {
+		if "" + parseInt(k, 10) != k {
+			return false
+		}
+	}
+	return true
+}
+
 // initArray returns an array of dimension given in "index" initialized to "zero".
 func initArray(index []int, zero interface{}) (a []interface{}) {
 	if len(index) == 0 {
 		return zero
 	}
-	nextArray := initArray(index.slice(1), zero)
-
 	for i := 0; i < index[0]; i++ {
-		a[i] = nextArray
+		a[i] = initArray(index.slice(1), newZero(zero))
 	}
 	return
 }
 
+// newZero returns a copy of the value "zero" to be used by an element, so the
+// structs and arrays are not shared between elements. A zero value has not
+// pointers, and its slices and maps are nil, so the copy is deep.
+func newZero(zero interface{}) interface{} {
+	if typeof(zero) != "object" || zero == nil || zero.isNil != nil {
+		return zero
+	}
+	if Array.isArray(zero) {
+		return zero["map"](newZero)
+	}
+	c := Object.create(Object.getPrototypeOf(zero))
+
+	for k, _ := range zero {
+		if zero.hasOwnProperty(k) {
+			c[k] = newZero(zero[k])
+		}
+	}
+	return c
+}
+
 // mergeArray merges src in array dst.
 func mergeArray(dst, src []interface{}) {
 	for i, srcVal := range src {
@@ -208,7 +259,7 @@ func mergeArray(dst, src []interface{}) {
 			isHashMap := false
 
 			// The position is into a hash map, if any
-			if typeof(srcVal) == "object" {
+			if isIndexMap(srcVal) {
 				for k, v := range srcVal {
 					if srcVal.hasOwnProperty(k) { // identify a hashmap
 						isHashMap = true
@@ -227,15 +278,17 @@ func mergeArray(dst, src []interface{}) {
 // == Slice
 //
 
+// A slice is a view over a backing array shared with the array or slice from
+// which it was got, so the changes through a slice are seen by the others.
+
 // SliceType represents a slice type.
 type SliceType struct {
-	arr interface{}   // the array where data is got or created from scratch using make
-	v   []interface{} // elements appended
+	arr  []interface{} // backing array
+	zero interface{}   // zero value, to fill the array until the capacity
 
-	low  int // indexes for the array
-	high int
-	len  int // total of elements
-	cap  int
+	off int // offset of the first element into the array
+	len int
+	cap int
 
 	nil_ bool // for variables declared like slices
 }
@@ -258,199 +311,246 @@ func MkSlice(zero interface{}, len, cap int) *SliceType {
 		s.nil_ = true
 		return s
 	}
-
-	arr := new(ArrayType)
-	arr.len_[0] = len
-	// The fastest way of fill in an array is when array length is specified first.
-	arr.v = Array(len)
-	for i := 0; i < len; i++ {
-		arr.v[i] = zero
+	if cap == nil || cap < len {
+		cap = len
 	}
 
-	if cap != nil {
-		s.cap = cap
-	} else {
-		s.cap = len
+	// The fastest way of fill in an array is when array length is specified first.
+	s.arr = Array(cap)
+	for i := 0; i < cap; i++ {
+		s.arr[i] = newZero(zero)
 	}
-
-	s.arr = arr
+	s.zero = zero
 	s.len = len
-	s.high = len
-
+	s.cap = cap
 	return s
 }
 
 // Slice creates a new slice with the elements in "data".
+// The zero value is undefined for elements of interface type.
 func Slice(zero interface{}, data []interface{}) *SliceType {
 	s := new(SliceType)
 
-	if zero == nil {
-		s.nil_ = true
-		return s
-	}
-
-	arr := new(ArrayType)
 	for i, srcVal := range data {
 		isHashMap := false
 
 		// The position is into a hash map, if any
-		if typeof(srcVal) == "object" {
+		if isIndexMap(srcVal) {
 			for k, v := range srcVal {
 				if srcVal.hasOwnProperty(k) { // identify a hashmap
 					isHashMap = true
 
 					for i; i < k; i++ {
-						arr.v[i] = zero
+						s.arr[i] = newZero(zero)
 					}
-					arr.v[i] = v
+					s.arr[i] = v
 				}
 			}
 		}
 		if !isHashMap {
-			arr.v[i] = srcVal
+			s.arr[i] = srcVal
 		}
 	}
-	s.len = len(arr.v)
-	arr.len_[0] = s.len
-	s.arr = arr
-
+	s.zero = zero
+	s.len = len(s.arr)
 	s.cap = s.len
-	s.high = s.len
 	return s
 }
 
-// SliceFrom creates a new slice from an array or slice using the indexes low and high.
-func SliceFrom(src interface{}, low, high int) *SliceType {
+// SliceArgs creates a slice with the values passed to a variadic parameter,
+// using "data" like its array. The slice is nil if there are no values.
+func SliceArgs(data []interface{}) *SliceType {
 	s := new(SliceType)
 
+	if len(data) == 0 {
+		s.nil_ = true
+		return s
+	}
+	s.arr = data
+	s.len = len(data)
+	s.cap = s.len
+	return s
+}
+
+// TupleArgs returns the arguments of a call got from the values returned by
+// another one, where the values from the index "variadic" are put into a slice.
+func TupleArgs(values []interface{}, variadic int) []interface{} {
+	args := values.slice(0, variadic)
+	args.push(SliceArgs(values.slice(variadic)))
+	return args
+}
+
+// SliceFrom creates a new slice from an array or slice using the indexes low,
+// high and max, which shares its backing array. The capacity is limited by max.
+func SliceFrom(src interface{}, low, high, max int) *SliceType {
+	length, cap, unit := 0, 0, "length"
+	if src.typ() == sliceT {
+		length, cap, unit = src.len, src.cap, "capacity"
+	} else { // array
+		length = len(src.v)
+		cap = length
+	}
+
 	if low != nil {
-		s.low = low | 0 // to integer
+		low = low | 0 // to integer
 	} else {
-		s.low = 0
+		low = 0
 	}
 	if high != nil {
-		s.high = high | 0 // to integer
+		high = high | 0
 	} else {
-		if src.arr != nil { // slice
-			s.high = src.len
-		} else {
-			s.high = len(src.v)
-		}
+		high = length
+	}
+	if max != nil {
+		max = max | 0
+		checkSlice3(low, high, max, cap, unit)
+	} else {
+		max = cap
+		checkSlice(low, high, cap, unit)
 	}
 
-	s.len = s.high - s.low
+	// The slice of a nil slice is nil.
+	if src.typ() == sliceT && src.isNil() {
+		return src
+	}
+	s := new(SliceType)
 
-	if src.arr != nil { // slice
+	if src.typ() == sliceT {
 		s.arr = src.arr
-		s.cap = src.cap - s.low
-		s.low += src.low
-		s.high += src.low
-	} else { // array
-		s.arr = src
-		s.cap = src.cap() - s.low
+		s.zero = src.zero
+		s.off = src.off + low
+	} else {
+		s.arr = src.v
+		s.off = low
 	}
+	s.len = high - low
+	s.cap = max - low
 	return s
 }
 
-// get gets the slice.
-func (s SliceType) get() []interface{} {
-	if s.arr != nil {
-		arr := s.arr.v.slice(s.low, s.high)
+// checkSlice panics if the indexes "low" and "high" are out of range for a
+// sequence of capacity "cap", like Go does at run time.
+func checkSlice(low, high, cap int, unit string) {
+	if high < 0 || high > cap {
+		panic("runtime error: slice bounds out of range [:" + high + "] with " + unit + " " + cap)
+	}
+	if low < 0 || low > high {
+		panic("runtime error: slice bounds out of range [" + low + ":" + high + "]")
+	}
+}
 
-		if len(s.v) != 0 {
-			return arr.concat(s.v)
-		} else {
-			return arr
-		}
+// checkSlice3 panics if the indexes of a full slice expression are out of
+// range for a sequence of capacity "cap", like Go does at run time.
+func checkSlice3(low, high, max, cap int, unit string) {
+	if max < 0 || max > cap {
+		panic("runtime error: slice bounds out of range [::" + max + "] with " + unit + " " + cap)
+	}
+	if high < 0 || high > max {
+		panic("runtime error: slice bounds out of range [:" + high + ":" + max + "]")
+	}
+	if low < 0 || low > high {
+		panic("runtime error: slice bounds out of range [" + low + ":" + high + ":]")
+	}
+}
+
+// get gets the elements of the slice, into a new array.
+func (s SliceType) get() []interface{} {
+	if s.arr == nil {
+		return Array()
 	}
-	return s.v
+	return s.arr.slice(s.off, s.off+s.len)
+}
+
+// at returns the element at index i.
+func (s SliceType) at(i int) interface{} {
+	return s.arr[s.off+i]
 }
 
 // set sets a value.
 func (s SliceType) set(index []int, v interface{}) {
-	s.arr.v[index[0]+s.low] = v
+	s.arr[s.off+index[0]] = v
 }
 
-// str returns the slice (of bytes or runes) like a string.
-func (s SliceType) str() string {
-	_s := s.get()
-	return _s.join("")
+// str returns the slice of bytes, or of runes if "isRune", like a string.
+func (s SliceType) str(isRune bool) string {
+	if isRune {
+		return runesStr(s.get())
+	}
+	return strOf(s.get())
 }
 
 // * * *
 
-// Append implements the function "append".
-func Append(src []interface{}, elt ...interface{}) (dst SliceType) {
-	// Copy src to the new slice
-	dst.low = src.low
-	dst.high = src.high
-	dst.len = src.len
-	dst.cap = src.cap
-	dst.nil_ = src.nil_
-
-	arr := new(ArrayType)
-	arr.len_[0] = src.arr.len_[0]
-	for _, v := range src.arr.v {
-		arr.v.push(v)
+// Append implements the function "append". The elements are written into the
+// backing array of "src" when its capacity allows it, else into a new array
+// whose capacity grows like in Go. "elt" is an array with the elements, or the
+// string whose bytes are appended.
+func Append(src *SliceType, elt []interface{}) *SliceType {
+	if typeof(elt) == "string" {
+		elt = bytesOf(elt)
 	}
-	dst.arr = arr
-
-	for _, v := range src.v {
-		dst.v.push(v)
+	if len(elt) == 0 {
+		return src
 	}
-	//==
-
-	// TODO: handle len() in interfaces
-	// lastIdxElt := len(elt) - 1
+	dst := new(SliceType)
+	dst.zero = src.zero
+	dst.len = src.len + len(elt)
+
+	if dst.len <= src.cap {
+		dst.arr = src.arr
+		dst.off = src.off
+		dst.cap = src.cap
+	} else {
+		dst.cap = growCap(src.cap, dst.len)
+		dst.arr = Array(dst.cap)
 
-	for _, v := range elt {
-		if /*i == lastIdxElt &&*/ Array.isArray(v) { // The last field could be an ellipsis
-			for _, vArr := range v {
-				dst.v.push(vArr)
-				if dst.len == dst.cap {
-					dst.cap = dst.len * 2
-				}
-				dst.len++
-			}
-			break
+		for i := 0; i < src.len; i++ {
+			dst.arr[i] = src.arr[src.off+i]
 		}
-
-		dst.v.push(v)
-		if dst.len == dst.cap {
-			dst.cap = dst.len * 2
+		for i := dst.len; i < dst.cap; i++ {
+			dst.arr[i] = newZero(src.zero)
 		}
-		dst.len++
+	}
+
+	for i := 0; i < len(elt); i++ {
+		dst.arr[dst.off+src.len+i] = elt[i]
 	}
 	return dst
 }
 
-// Copy implements the function "copy".
-func Copy(dst []interface{}, src interface{}) (n int) {
-	// []T <= []T
-	if src.arr != nil {
-		for i := src.low; i < src.high; i++ {
-			if n == dst.len {
-				return
-			}
-			dst.arr.v[n] = src.arr.v[i]
-			n++
-		}
-		for _, v := range src.v {
-			if n == dst.len {
-				return
-			}
-			dst.v.push(v)
-			n++
-		}
-		return
+// growCap returns the capacity of a slice grown from "cap" to have at least
+// "need" elements.
+func growCap(cap, need int) int {
+	newCap := cap + cap
+	if need > newCap {
+		return need
+	}
+	if cap < 256 {
+		return newCap
 	}
+	newCap = cap
+	for newCap < need {
+		newCap += (newCap + 768) / 4 | 0
+	}
+	return newCap
+}
 
-	// []byte <= string
-	for ; n < len(src); n++ {
-		if n == dst.len {
-			break
-		}
-		dst.arr.v[n] = src[n]
+// Copy implements the function "copy". The slices can overlap.
+func Copy(dst *SliceType, src interface{}) (n int) {
+	elt := src
+
+	if typeof(src) == "string" { // []byte <= string
+		elt = bytesOf(src)
+	} else {
+		elt = src.get()
+	}
+
+	n = len(elt)
+	if dst.len < n {
+		n = dst.len
+	}
+	for i := 0; i < n; i++ {
+		dst.arr[dst.off+i] = elt[i]
 	}
 	return
 }
@@ -458,8 +558,10 @@ func Copy(dst []interface{}, src interface{}) (n int) {
 // == Map
 //
 
-// The length into a map is rarely used so, in JavaScript, I prefer to calculate
-// the length instead of use a field.
+// A map is built on a Map of JavaScript, whose keys are compared like the
+// operator "===", so the keys which are compared by value in Go (structs,
+// arrays and interfaces) are hashed to a string. The entries keep the key like
+// it was given, so it is got with its type when the map is ranged.
 //
 // A map has not built-in function "cap".
 
@@ -467,50 +569,2263 @@ func Copy(dst []interface{}, src interface{}) (n int) {
 // The compiler adds the appropriate zero value for the map (which it is work out
 // from the map type).
 type MapType struct {
-	v    map[interface{}]interface{} // map's value
-	zero interface{}                 // zero value for the map's value
+	v    interface{} // Map from the key, or its hash, to the pair [key, value]
+	zero interface{} // zero value for the map's value
+	hash bool        // are the keys hashed?
+	nil_ bool
 }
 
 // len returns the number of elements.
-func (m MapType) len() int {
-	len := 0
-	for key, _ := range m.v {
-		if m.v.hasOwnProperty(key) {
-			len++
-		}
-	}
-	return len
-}
+func (m MapType) len() int { return m.v.size }
 
 // typ returns the type.
 func (m MapType) typ() int { return mapT }
 
-// Map creates a map storing its zero value.
-func Map(zero interface{}, v map[interface{}]interface{}) *MapType {
-	m := &MapType{v, zero}
+func (m MapType) isNil() bool { return m.nil_ }
+
+// MkMap creates a map storing its zero value, with the entries given like pairs
+// [key, value]. The map is nil if there are not entries; "hash" indicates that
+// the keys are compared by value.
+func MkMap(zero interface{}, entries []interface{}, hash bool) *MapType {
+	m := new(MapType)
+	m.v = jsMap()
+	m.zero = zero
+	m.hash = hash == true
+
+	if entries == nil {
+		m.nil_ = true
+		return m
+	}
+	for _, e := range entries {
+		m.v.set(m.key(e[0]), e)
+	}
 	return m
 }
 
+// key returns the key used into the Map of JavaScript.
+func (m MapType) key(k interface{}) interface{} {
+	if m.hash {
+		return hashKey(k)
+	}
+	// The sized numbers are stored like objects.
+	return valueOf(k)
+}
+
 // get returns the value for the key "k" if it exists and a boolean indicating it.
 // If looking some key up in M's map gets you "nil" ("undefined" in JS),
 // then return a copy of the zero value.
 func (m MapType) get(k interface{}) (interface{}, bool) {
-	v := m.v
+	e := m.v.get(m.key(k))
 
-	// Allow multi-dimensional index (separated by commas)
-	for i := 0; i < len(arguments); i++ {
-		v = v[arguments[i]]
+	if e == nil {
+		return newZero(m.zero), false
 	}
+	return e[1], true
+}
 
-	if v == nil {
-		return m.zero, false
+// set sets the value for the key "k"; a nil map can not be assigned.
+func (m MapType) set(k, v interface{}) {
+	if m.nil_ {
+		panic("assignment to entry in nil map")
 	}
-	return v, true
+	m.v.set(m.key(k), Array(k, v))
 }
 
-// == Utility
+// remove implements the function "delete".
+func (m MapType) remove(k interface{}) {
+	m.v["delete"](m.key(k))
+}
+
+// entries returns an iterator over the pairs [key, value], which reflects the
+// entries added and deleted while it is used.
+func (m MapType) entries() interface{} { return m.v.values() }
+
+// * * *
+
+// jsMapType is the type Map of JavaScript, got when the library is loaded since
+// it could be hidden by a function of the program named "Map".
+var jsMapType = globalThis.Map
+
+// jsMap returns a new Map of JavaScript.
+func jsMap() interface{} {
+	return Reflect.construct(jsMapType, Array())
+}
+
+// hashKey returns a string which identifies the value of a key compared by
+// value; the values of basic types and the pointers are used like they are.
+// The values stored with their type into an interface are hashed with it.
+func hashKey(k interface{}) interface{} {
+	k = valueOf(k)
+	if typeof(k) != "object" || k == nil || isPointer(k) {
+		return k
+	}
+	return "{}" + hashOf(k)
+}
+
+// hashOf returns the representation of a value used to hash it.
+func hashOf(v interface{}) string {
+	v = valueOf(v)
+	switch typeof(v) {
+	case "string":
+		return JSON.stringify(v)
+	case "object":
+		if v == nil {
+			return "nil"
+		}
+	default:
+		return "" + v
+	}
+
+	s := "["
+	if Array.isArray(v) {
+		for _, e := range v {
+			s += hashOf(e) + ","
+		}
+		return s + "]"
+	}
+	if v.constructor.name == "ArrayType" {
+		return hashOf(v.v)
+	}
+
+	// The fields of a struct, but the descriptors of the type parameters.
+	s = v.constructor.name + "{"
+	for k, e := range v {
+		if v.hasOwnProperty(k) && k.charAt(0) != "$" {
+			s += k + ":" + hashOf(e) + ","
+		}
+	}
+	return s + "}"
+}
+
+// compareKeys compares two keys to sort them like the package "fmt" does.
+func compareKeys(a, b interface{}) int {
+	switch typeof(a) {
+	case "number":
+		if isNaN(a) || isNaN(b) {
+			return Number(!isNaN(a)) - Number(!isNaN(b))
+		}
+		return a - b
+	case "boolean":
+		return Number(a) - Number(b)
+	case "string":
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+		return 0
+	case "object":
+		if a == nil || b == nil {
+			return Number(a != nil) - Number(b != nil)
+		}
+	default:
+		return 0
+	}
+
+	if a.constructor.name == "ArrayType" {
+		return compareKeys(a.v, b.v)
+	}
+	for k, _ := range a {
+		if a.hasOwnProperty(k) && k.charAt(0) != "$" {
+			c := compareKeys(a[k], b[k])
+			if c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// == Pointer
+//
+
+// A pointer to a variable is an object with the value in the field "p", and
+// the pointers to the structs and arrays are the own objects. The pointers to
+// the fields and elements get and set the value through "p" too.
+
+// RefType represents a pointer to the field or element "key" of "obj".
+type RefType struct {
+	obj interface{}
+	key interface{}
+}
+
+func init() {
+	prop := Object()
+	prop.get = func() interface{} { return this.obj[this.key] }
+	prop.set = func(v interface{}) { this.obj[this.key] = v }
+	Object.defineProperty(RefType.prototype, "p", prop)
+}
+
+// Ref returns a pointer to the field or element "key" of "obj".
+func Ref(obj, key interface{}) *RefType { return &RefType{obj, key} }
+
+// ref returns a pointer to the element at index i.
+func (s SliceType) ref(i int) *RefType { return Ref(s.arr, s.off+i) }
+
+// Store copies the value "src" into the struct or array "dst", in place, so
+// the pointers to "dst" see the new value. The descriptor is like in "Equal".
+func Store(dst, src, desc interface{}) {
+	if isArrayType(dst) {
+		dst = dst.v
+	}
+	if isArrayType(src) {
+		src = src.v
+	}
+	if Array.isArray(desc) {
+		for i := 0; i < len(dst); i++ {
+			dst[i] = store(dst[i], src[i], desc[0])
+		}
+		return
+	}
+	for k, _ := range desc {
+		dst[k] = store(dst[k], src[k], desc[k])
+	}
+}
+
+// store returns the value to store in place of "dst", which is the own "dst"
+// for the structs and arrays.
+func store(dst, src, desc interface{}) interface{} {
+	if typeof(desc) == "object" {
+		Store(dst, src, desc)
+		return dst
+	}
+	return src
+}
+
+// Pointer marks the struct or array "v" like a pointer of type "t", when it is
+// stored into an interface; so it is compared by identity, and formatted like a
+// pointer. The mark is not enumerable, so the copies of the value are not marked.
+func Pointer(v interface{}, t string) interface{} {
+	if v != nil {
+		prop := Object()
+		prop.value = t
+		prop.configurable = true
+		Object.defineProperty(v, "$ptr", prop)
+	}
+	return v
+}
+
+// isPointer reports whether the value is a struct or array marked like a
+// pointer.
+func isPointer(v interface{}) bool {
+	return typeof(v) == "object" && v != nil && v.hasOwnProperty("$ptr")
+}
+
+// isRef reports whether the value is a pointer to a field or element.
+func isRef(v interface{}) bool {
+	return typeof(v) == "object" && v != nil && v.constructor.name == "RefType"
+}
+
+// == Equality
+//
+
+// Equal reports whether the values are equal, compared like in Go according to
+// the descriptor of their type: 0 for the types compared by value or by
+// identity, 1 for the interfaces, an array with the descriptor of the elements
+// for the arrays, and an object with the descriptor of each field for the
+// structs.
+func Equal(x, y, desc interface{}) bool {
+	switch typeof(desc) {
+	case "number":
+		if desc == 1 {
+			return equalAny(x, y)
+		}
+		if isRef(x) && isRef(y) {
+			return x.obj == y.obj && x.key == y.key
+		}
+		return valueOf(x) == valueOf(y)
+	case "object":
+		if Array.isArray(desc) {
+			break
+		}
+		for k, _ := range desc {
+			if !Equal(x[k], y[k], desc[k]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// An array could be compared with the values of another one.
+	if isArrayType(x) {
+		x = x.v
+	}
+	if isArrayType(y) {
+		y = y.v
+	}
+	for i := 0; i < len(x); i++ {
+		if !Equal(x[i], y[i], desc[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalAny compares two values stored into interfaces, checking first their
+// dynamic type; a value stored without its type is an int, a bool or a string.
+// The slices, maps and functions panic.
+func equalAny(x, y interface{}) bool {
+	tx := typedOf(x)
+	ty := typedOf(y)
+
+	if typeof(tx.v) != "object" && typeof(ty.v) != "object" {
+		if typeName(x) != typeName(y) {
+			return false
+		}
+	} else if tx.t != "" && ty.t != "" && tx.t != ty.t {
+		return false
+	}
+	x = tx.v
+	y = ty.v
+
+	if typeof(x) != typeof(y) {
+		return false
+	}
+	switch typeof(x) {
+	case "function":
+		panic("runtime error: comparing uncomparable type " + typeName(x))
+	case "object":
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+	default:
+		return x == y
+	}
+
+	if x.constructor != y.constructor {
+		return false
+	}
+	if isPointer(x) || isPointer(y) {
+		return x == y
+	}
+	switch x.constructor.name {
+	case "SliceType", "MapType":
+		panic("runtime error: comparing uncomparable type " + typeName(x))
+	case "ArrayType":
+		return equalAny(x.v, y.v)
+	case "Object": // pointer
+		return x == y
+	case "RefType":
+		return x.obj == y.obj && x.key == y.key
+	}
+
+	if Array.isArray(x) {
+		if len(x) != len(y) {
+			return false
+		}
+		for i := 0; i < len(x); i++ {
+			if !equalAny(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	// The fields of a struct, but the descriptors of the type parameters.
+	for k, _ := range x {
+		if x.hasOwnProperty(k) && k.charAt(0) != "$" && !equalAny(x[k], y[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueOf returns the primitive value of the basic types stored like objects,
+// and the own object for the pointers.
+func valueOf(v interface{}) interface{} {
+	if typeof(v) == "object" && v != nil {
+		return v.valueOf()
+	}
+	return v
+}
+
+// isArrayType reports whether the value is an array of the library.
+func isArrayType(v interface{}) bool {
+	return typeof(v) == "object" && v != nil && v.constructor.name == "ArrayType"
+}
+
+// == Method
 //
 
+// MethodVal returns the method "name" bound to the receiver "recv".
+// A receiver of value is copied at evaluating the method value, like in Go.
+func MethodVal(recv interface{}, name string, isValue bool) interface{} {
+	if isValue {
+		recv = Clone(recv)
+	}
+	method := recv[name]
+
+	return func() {
+		return method.apply(recv, arguments)
+	}
+}
+
+// MethodExpr returns a function which calls to the method "name" of the type
+// "typ", passing the receiver like the first argument.
+// The type is nil for interfaces, so the method is got from the receiver.
+func MethodExpr(typ interface{}, name string, isValue bool) interface{} {
+	return func(recv interface{}) {
+		var method interface{}
+
+		if typ != nil {
+			method = typ.prototype[name]
+		} else {
+			method = recv[name]
+		}
+		if isValue {
+			recv = Clone(recv)
+		}
+		return method.apply(recv, Array.prototype.slice.call(arguments, 1))
+	}
+}
+
+// == Generics
+//
+
+// TypeParam describes the type passed to a type parameter, giving the
+// operations which depend on the type.
+type TypeParam struct {
+	zero func() interface{} // returns a new zero value
+	kind string             // kind of a numeric type
+}
+
+// Type returns the descriptor of a type for a type parameter; "kind" is the
+// basic type of a number, or empty.
+func Type(zero func() interface{}, kind string) *TypeParam {
+	return &TypeParam{zero, kind}
+}
+
+// conv converts the result of an arithmetic operation to the type: the integers
+// drop the decimals, and the sized ones wrap around.
+func (t TypeParam) conv(n float64) float64 {
+	bits := 0
+	signed := true
+
+	switch t.kind {
+	case "int", "int64", "uint", "uint64", "uintptr":
+		return Math.trunc(n)
+	case "float32":
+		return Math.fround(n)
+	case "int8":
+		bits = 8
+	case "int16":
+		bits = 16
+	case "int32":
+		bits = 32
+	case "uint8":
+		bits, signed = 8, false
+	case "uint16":
+		bits, signed = 16, false
+	case "uint32":
+		bits, signed = 32, false
+	default:
+		return n
+	}
+
+	m := Math.pow(2, bits)
+	n = Math.trunc(n) % m
+	if n < 0 {
+		n += m
+	}
+	if signed && n >= m/2 {
+		n -= m
+	}
+	return n
+}
+
+// == String
+//
+
+// The strings are JavaScript strings whose characters are the bytes of their
+// encoding in UTF-8, so their lengths, indexes and slices are the ones of Go,
+// and they keep the bytes which are not valid in UTF-8. They are decoded to
+// UTF-16 by "Text" when they are written out of the program.
+
+// RangeString returns the runes of the string "s", each one preceded by its
+// index in bytes. The invalid bytes are decoded like the rune 0xFFFD, one by
+// one.
+func RangeString(s string) (runes []int) {
+	for i := 0; i < len(s); {
+		d := decodeRune(s, i)
+		runes.push(i, d[0])
+		i += d[1]
+	}
+	return
+}
+
+// decodeRune returns the rune which starts at the byte "i" of "s" and its size
+// in bytes, like a pair. An invalid byte is decoded like the rune 0xFFFD, of
+// size 1.
+func decodeRune(s string, i int) []int {
+	c := s.charCodeAt(i)
+	r := 0xFFFD
+	n := 0 // continuation bytes
+	min := 0
+
+	switch {
+	case c < 0x80:
+		return Array.of(c, 1)
+	case c >= 0xC2 && c < 0xE0:
+		r, n, min = c&0x1F, 1, 0x80
+	case c >= 0xE0 && c < 0xF0:
+		r, n, min = c&0x0F, 2, 0x800
+	case c >= 0xF0 && c < 0xF5:
+		r, n, min = c&0x07, 3, 0x10000
+	}
+
+	j := 1
+	for ; j <= n; j++ {
+		if i+j >= len(s) || s.charCodeAt(i+j)&0xC0 != 0x80 {
+			break
+		}
+		r = r<<6 | s.charCodeAt(i+j)&0x3F
+	}
+	if j <= n || r < min || r > 0x10FFFF || r >= 0xD800 && r <= 0xDFFF {
+		return Array.of(0xFFFD, 1)
+	}
+	return Array.of(r, n+1)
+}
+
+// StrLen returns the length in bytes of the string "s".
+func StrLen(s string) int {
+	return len(s)
+}
+
+// StrIndex returns the byte at the index "i" of the string "s".
+func StrIndex(s string, i int) byte {
+	if i < 0 || i >= len(s) {
+		panic("runtime error: index out of range [" + i + "] with length " + len(s))
+	}
+	return s.charCodeAt(i)
+}
+
+// StrSlice returns the string "s" sliced by the byte indexes "low" and "high".
+func StrSlice(s string, low, high int) string {
+	if high == nil {
+		high = len(s)
+	}
+	checkSlice(low, high, len(s), "length")
+	return s.substring(low, high)
+}
+
+// Bytes returns the conversion of the string "s" to a slice of bytes.
+func Bytes(s string) *SliceType {
+	return Slice(0, bytesOf(s))
+}
+
+// Runes returns the conversion of the string "s" to a slice of runes.
+func Runes(s string) *SliceType {
+	return Slice(0, runesOf(s))
+}
+
+// Text returns the string "s" like a string of JavaScript, whose characters are
+// UTF-16 code units, to be written out of the program.
+func Text(s string) string {
+	t := ""
+
+	for i := 0; i < len(s); {
+		d := decodeRune(s, i)
+		t += unitsOf(d[0])
+		i += d[1]
+	}
+	return t
+}
+
+// fromText returns the text "t" of JavaScript, like the names of the types and
+// fields, like a string whose characters are its bytes.
+func fromText(t string) string {
+	s := ""
+	for i := 0; i < len(t); i++ {
+		r := t.codePointAt(i)
+		if r >= 0x10000 {
+			i++
+		}
+		s += RuneStr(r)
+	}
+	return s
+}
+
+// fromCharCode returns the string of an UTF-16 code unit, using the function
+// "String.fromCharCode" of JavaScript which is hidden by the type "String".
+func fromCharCode(unit int) string {
+	s := ""
+	return s.constructor.fromCharCode(unit)
+}
+
+// unitsOf returns the rune "r" like UTF-16 code units; a rune greater than
+// 0xFFFF uses two code units.
+func unitsOf(r rune) string {
+	if r < 0x10000 {
+		return fromCharCode(r)
+	}
+	r -= 0x10000
+	return fromCharCode(0xD800+(r>>10)) + fromCharCode(0xDC00+(r&0x3FF))
+}
+
+// RuneStr returns the conversion of the rune "r" to string.
+func RuneStr(r rune) string {
+	if r < 0 || r > 0x10FFFF || r >= 0xD800 && r <= 0xDFFF {
+		r = 0xFFFD // invalid code point
+	}
+
+	switch {
+	case r < 0x80:
+		return fromCharCode(r)
+	case r < 0x800:
+		return fromCharCode(0xC0|r>>6) + fromCharCode(0x80|r&0x3F)
+	case r < 0x10000:
+		return fromCharCode(0xE0|r>>12) + fromCharCode(0x80|r>>6&0x3F) +
+			fromCharCode(0x80|r&0x3F)
+	}
+	return fromCharCode(0xF0|r>>18) + fromCharCode(0x80|r>>12&0x3F) +
+		fromCharCode(0x80|r>>6&0x3F) + fromCharCode(0x80|r&0x3F)
+}
+
+// runesStr returns the string of the runes "runes".
+func runesStr(runes []int) string {
+	s := ""
+	for _, r := range runes {
+		s += RuneStr(r)
+	}
+	return s
+}
+
+// runesOf returns the runes of the string "s".
+func runesOf(s string) (runes []int) {
+	for i := 0; i < len(s); {
+		d := decodeRune(s, i)
+		runes.push(d[0])
+		i += d[1]
+	}
+	return
+}
+
+// bytesOf returns the bytes of the string "s".
+func bytesOf(s string) (b []int) {
+	for i := 0; i < len(s); i++ {
+		b.push(s.charCodeAt(i))
+	}
+	return
+}
+
+// strOf returns the string of the bytes "b".
+func strOf(b []int) string {
+	s := ""
+	for i := 0; i < len(b); i++ {
+		s += fromCharCode(b[i] & 0xFF)
+	}
+	return s
+}
+
+// runeLen returns the number of bytes required to encode the rune in UTF-8.
+func runeLen(r rune) int {
+	switch {
+	case r < 0x80:
+		return 1
+	case r < 0x800:
+		return 2
+	case r < 0x10000:
+		return 3
+	}
+	return 4
+}
+
+// == Package strings
+//
+
+// The functions of the package "strings" use the methods of the JavaScript
+// strings, which work on the bytes; the runes are decoded when they are needed,
+// and the lists are slices.
+
+// strsSlice returns the slice of the strings "a".
+func strsSlice(a []string) *SliceType {
+	return Slice("", a)
+}
+
+// Contains reports whether "substr" is within "s".
+func Contains(s, substr string) bool {
+	return s.indexOf(substr) != -1
+}
+
+// Index returns the index in bytes of the first instance of "substr" in "s",
+// or -1 if it is not present.
+func Index(s, substr string) int {
+	return s.indexOf(substr)
+}
+
+// HasPrefix reports whether the string "s" begins with "prefix".
+func HasPrefix(s, prefix string) bool {
+	return s.startsWith(prefix)
+}
+
+// HasSuffix reports whether the string "s" ends with "suffix".
+func HasSuffix(s, suffix string) bool {
+	return s.endsWith(suffix)
+}
+
+// Split slices "s" into all substrings separated by "sep". If "sep" is empty,
+// it splits after each rune.
+func Split(s, sep string) *SliceType {
+	if sep == "" {
+		return strsSlice(splitRunes(s))
+	}
+	return strsSlice(s.split(sep))
+}
+
+// splitRunes returns the runes of the string "s", each one like a string; an
+// invalid byte is kept.
+func splitRunes(s string) (a []string) {
+	for i := 0; i < len(s); {
+		n := decodeRune(s, i)[1]
+		a.push(s.substring(i, i+n))
+		i += n
+	}
+	return
+}
+
+// Join concatenates the elements of the slice "elems", placing "sep" between
+// them.
+func Join(elems *SliceType, sep string) string {
+	if elems == nil { // untyped nil
+		return ""
+	}
+	return elems.get().join(sep)
+}
+
+// Fields splits the string "s" around each instance of one or more white
+// spaces, like "unicode.IsSpace".
+func Fields(s string) *SliceType {
+	return strsSlice(fieldsOf(s))
+}
+
+// fieldsOf returns the fields of the string "s", separated by white spaces.
+func fieldsOf(s string) (a []string) {
+	start := -1
+
+	for i := 0; i <= len(s); {
+		n := 1
+		if i < len(s) {
+			d := decodeRune(s, i)
+			n = d[1]
+			if !isSpace(d[0]) {
+				if start == -1 {
+					start = i
+				}
+				i += n
+				continue
+			}
+		}
+		if start != -1 {
+			a.push(s.substring(start, i))
+			start = -1
+		}
+		i += n
+	}
+	return
+}
+
+// TrimSpace returns the string "s" without the white spaces at the beginning
+// and at the end.
+func TrimSpace(s string) string {
+	start, end := -1, 0
+
+	for i := 0; i < len(s); {
+		d := decodeRune(s, i)
+		if !isSpace(d[0]) {
+			if start == -1 {
+				start = i
+			}
+			end = i + d[1]
+		}
+		i += d[1]
+	}
+	if start == -1 {
+		return ""
+	}
+	return s.substring(start, end)
+}
+
+// isSpace reports whether the rune is a white space, like in the function
+// "unicode.IsSpace".
+func isSpace(c int) bool {
+	switch c {
+	case 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x20, 0x85, 0xA0, 0x1680,
+		0x2028, 0x2029, 0x202F, 0x205F, 0x3000:
+		return true
+	}
+	return c >= 0x2000 && c <= 0x200A
+}
+
+// Replace returns a copy of the string "s" with the first "n" instances of
+// "old" replaced by "repl"; if "n" is negative, all of them are replaced. If
+// "old" is empty, it matches at the beginning and after each rune.
+func Replace(s, old, repl string, n int) string {
+	if old == repl || n == 0 {
+		return s
+	}
+	t := ""
+	start := 0 // byte after the last instance
+
+	for i := 0; n < 0 || i < n; i++ {
+		j := start
+		if old == "" {
+			if i != 0 {
+				if start == len(s) {
+					break
+				}
+				j += decodeRune(s, start)[1]
+			}
+		} else {
+			j = s.indexOf(old, start)
+			if j == -1 {
+				break
+			}
+		}
+		t += s.substring(start, j) + repl
+		start = j + len(old)
+	}
+	return t + s.substring(start)
+}
+
+// ReplaceAll returns a copy of the string "s" with all instances of "old"
+// replaced by "repl".
+func ReplaceAll(s, old, repl string) string {
+	return Replace(s, old, repl, -1)
+}
+
+// Repeat returns a new string consisting of "count" copies of the string "s".
+func Repeat(s string, count int) string {
+	if count < 0 {
+		panic("strings: negative Repeat count")
+	}
+	return s.repeat(count)
+}
+
+// ToUpper returns the string "s" with all its runes mapped to upper case.
+func ToUpper(s string) string {
+	return mapCase(s, true)
+}
+
+// ToLower returns the string "s" with all its runes mapped to lower case.
+func ToLower(s string) string {
+	return mapCase(s, false)
+}
+
+// mapCase returns the string "s" with its runes mapped to upper or lower case.
+// The runes whose mapping is not a single rune are kept, like in Go.
+func mapCase(s string, upper bool) string {
+	t := ""
+	for _, r := range runesOf(s) {
+		t += RuneStr(caseOf(r, upper))
+	}
+	return t
+}
+
+// caseOf returns the rune "r" mapped to upper or lower case, by the methods of
+// the JavaScript strings.
+func caseOf(r rune, upper bool) rune {
+	c := unitsOf(r)
+	m := c.toLowerCase()
+	if upper {
+		m = c.toUpperCase()
+	}
+	n := 1 // code units of a rune
+	if m.codePointAt(0) >= 0x10000 {
+		n = 2
+	}
+	if len(m) != n {
+		return r
+	}
+	return m.codePointAt(0)
+}
+
+// EqualFold reports whether the strings "s" and "t" are equal under simple
+// Unicode case-folding.
+func EqualFold(s, t string) bool {
+	a := runesOf(s)
+	b := runesOf(t)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] && caseOf(a[i], true) != caseOf(b[i], true) &&
+			caseOf(a[i], false) != caseOf(b[i], false) {
+			return false
+		}
+	}
+	return true
+}
+
+// * * *
+
+// Builder builds efficiently a string using the Write methods.
+type Builder struct {
+	s string
+}
+
+// String returns the accumulated string.
+func (b *Builder) String() string { return b.s }
+
+// Len returns the number of accumulated bytes.
+func (b *Builder) Len() int { return len(b.s) }
+
+// Cap returns the capacity, which is the length since it is not reserved.
+func (b *Builder) Cap() int { return len(b.s) }
+
+// Grow is a no-op, since the strings are not reserved in JavaScript.
+func (b *Builder) Grow(n int) {
+	if n < 0 {
+		panic("strings.Builder.Grow: negative count")
+	}
+}
+
+// Reset resets the Builder to be empty.
+func (b *Builder) Reset() { b.s = "" }
+
+// Write appends the contents of the slice of bytes "p".
+func (b *Builder) Write(p *SliceType) (int, error) {
+	b.s += p.str()
+	return p.len, nil
+}
+
+// WriteByte appends the byte "c".
+func (b *Builder) WriteByte(c byte) error {
+	b.s += fromCharCode(c)
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of the rune "r".
+func (b *Builder) WriteRune(r rune) (int, error) {
+	b.s += RuneStr(r)
+	return runeLen(r), nil
+}
+
+// WriteString appends the contents of the string "s".
+func (b *Builder) WriteString(s string) (int, error) {
+	b.s += s
+	return len(s), nil
+}
+
+// Replacer replaces a list of strings with replacements.
+type Replacer struct {
+	oldnew []string
+}
+
+// NewReplacer returns a new Replacer from a slice of pairs of old and new
+// strings. The replacements are performed in the order they appear in the
+// target string, without overlapping matches; the old strings are compared in
+// the order of the arguments.
+func NewReplacer(oldnew *SliceType) *Replacer {
+	if oldnew.len%2 == 1 {
+		panic("strings.NewReplacer: odd argument count")
+	}
+	return &Replacer{oldnew.get()}
+}
+
+// Replace returns a copy of "s" with all replacements performed.
+func (r *Replacer) Replace(s string) string {
+	t := ""
+
+	for i := 0; i <= len(s); {
+		matched := false
+
+		for k := 0; k < len(r.oldnew); k += 2 {
+			old := r.oldnew[k]
+			if !s.startsWith(old, i) {
+				continue
+			}
+			t += r.oldnew[k+1]
+			matched = true
+
+			if old != "" {
+				i += len(old)
+				break
+			}
+			// The empty string matches before each rune, which is kept.
+			if i == len(s) {
+				i++
+			} else {
+				n := decodeRune(s, i)[1]
+				t += s.substring(i, i+n)
+				i += n
+			}
+			break
+		}
+		if !matched {
+			if i < len(s) {
+				t += s.charAt(i)
+			}
+			i++
+		}
+	}
+	return t
+}
+
+// == Format
+//
+
+// The values are formatted like in the package "fmt" of Go. Since the type of
+// a value can not be known from JavaScript for all types, the translator
+// passes the type of an argument into a "ValueType" when it is not a boolean,
+// an integer or a string.
+
+// ValueType represents a value of the type "t", written like in Go. The basic
+// type "k" is given for the named types whose underlying type is basic.
+type ValueType struct {
+	v interface{}
+	t string
+	k string
+}
+
+// Typed returns the value "v" of type "t", to be formatted; "k" is optional.
+func Typed(v interface{}, t string, k string) *ValueType {
+	return &ValueType{v, t, k}
+}
+
+// fmtFlags represents the flags, width and precision of a verb.
+type fmtFlags struct {
+	plus   bool
+	minus  bool
+	sharp  bool
+	space  bool
+	zero   bool
+	plusV  bool
+	sharpV bool
+	wid    int // -1 if it is not set
+	prec   int // -1 if it is not set
+}
+
+// newFlags returns the flags of a verb without any one set.
+func newFlags() *fmtFlags {
+	return &fmtFlags{false, false, false, false, false, false, false, -1, -1}
+}
+
+// Sprint formats the values of "a" like "fmt.Sprint", or like "fmt.Sprintln"
+// when "ln" is true, without the new line.
+func Sprint(a []interface{}, ln bool) string {
+	s := ""
+
+	for i, v := range a {
+		if i != 0 {
+			// Spaces are added between operands when neither is a string.
+			if ln || !isStr(a[i-1]) && !isStr(v) {
+				s += " "
+			}
+		}
+		s += fmtArg(v, "v", newFlags(), 0)
+	}
+	return s
+}
+
+// Sprintf formats the values of "a" according to the format, like
+// "fmt.Sprintf".
+func Sprintf(format string, a []interface{}) string {
+	s := ""
+	p := &fmtParser{format, 0, 0, 0, false, false, a}
+
+	for ; p.i < len(format); p.i++ {
+		c := format.charAt(p.i)
+		if c != "%" {
+			s += c
+			continue
+		}
+		f := newFlags()
+		p.badIndex = false
+
+		for p.i++; p.i < len(format); p.i++ {
+			c = format.charAt(p.i)
+			if c == "+" {
+				f.plus = true
+			} else if c == "-" {
+				f.minus = true
+			} else if c == "#" {
+				f.sharp = true
+			} else if c == " " {
+				f.space = true
+			} else if c == "0" {
+				f.zero = true
+			} else {
+				break
+			}
+		}
+
+		// Width
+		p.index()
+		if format.charAt(p.i) == "*" {
+			p.i++
+			if p.intArg() {
+				f.wid = p.n
+				if f.wid < 0 {
+					f.minus = true
+					f.wid = -f.wid
+				}
+			} else {
+				s += "%!(BADWIDTH)"
+			}
+		} else if p.number() {
+			f.wid = p.n
+		}
+
+		// Precision
+		if format.charAt(p.i) == "." {
+			p.i++
+			p.index()
+			if format.charAt(p.i) == "*" {
+				p.i++
+				if p.intArg() {
+					f.prec = p.n
+					if f.prec < 0 {
+						f.prec = -1
+					}
+				} else {
+					s += "%!(BADPREC)"
+				}
+			} else if p.number() {
+				f.prec = p.n
+			} else {
+				f.prec = 0
+			}
+		}
+		p.index()
+
+		if p.i >= len(format) {
+			s += "%!(NOVERB)"
+			break
+		}
+		d := decodeRune(format, p.i)
+		verb := format.substring(p.i, p.i+d[1])
+		p.i += d[1] - 1
+
+		switch {
+		case verb == "%":
+			s += "%"
+		case p.badIndex:
+			s += "%!" + verb + "(BADINDEX)"
+		case p.argNum >= len(a):
+			s += "%!" + verb + "(MISSING)"
+		default:
+			if verb == "v" {
+				f.plusV, f.sharpV = f.plus, f.sharp
+				f.plus, f.sharp = false, false
+			}
+			s += fmtArg(a[p.argNum], verb, f, 0)
+			p.argNum++
+		}
+	}
+
+	if !p.reordered && p.argNum < len(a) {
+		s += "%!(EXTRA "
+		for i := p.argNum; i < len(a); i++ {
+			if i > p.argNum {
+				s += ", "
+			}
+			if typedOf(a[i]).v == nil {
+				s += "<nil>"
+			} else {
+				s += typeName(a[i]) + "=" + fmtArg(a[i], "v", newFlags(), 0)
+			}
+		}
+		s += ")"
+	}
+	return s
+}
+
+// fmtParser represents the state of the parsing of a format.
+type fmtParser struct {
+	format    string
+	i         int  // position into the format
+	n         int  // number parsed
+	argNum    int  // next argument
+	reordered bool // an argument index has been used
+	badIndex  bool
+	a         []interface{}
+}
+
+// index parses an argument index like "[n]", if any.
+func (p *fmtParser) index() {
+	if p.format.charAt(p.i) != "[" {
+		return
+	}
+	p.reordered = true
+	end := p.format.indexOf("]", p.i)
+
+	if end < 0 {
+		p.badIndex = true
+		p.i++
+		return
+	}
+	n := Number(p.format.slice(p.i+1, end))
+	if n >= 1 && n <= len(p.a) {
+		p.argNum = n - 1
+	} else {
+		p.badIndex = true
+	}
+	p.i = end + 1
+}
+
+// number parses a number, reporting whether there is any one.
+func (p *fmtParser) number() bool {
+	start := p.i
+	p.n = 0
+
+	for ; p.format.charAt(p.i) >= "0" && p.format.charAt(p.i) <= "9"; p.i++ {
+		p.n = p.n*10 + Number(p.format.charAt(p.i))
+	}
+	return p.i > start
+}
+
+// intArg gets the next argument like the number parsed, reporting whether it
+// is an integer.
+func (p *fmtParser) intArg() bool {
+	if p.argNum >= len(p.a) {
+		return false
+	}
+	a := p.a[p.argNum]
+	p.argNum++
+
+	if !isInt(a) {
+		return false
+	}
+	p.n = typedOf(a).v
+	return true
+}
+
+// Fprint writes the string formatted "s" to the writer "w", returning the
+// number of bytes written and any error.
+func Fprint(w interface{}, s string) (int, error) {
+	return w.Write(Bytes(s))
+}
+
+// consoleWriter writes to the console of JavaScript.
+type consoleWriter struct {
+	log func(string)
+}
+
+func (w consoleWriter) Write(b []byte) (int, error) {
+	w.log(Text(b.str()))
+	return b.len, nil
+}
+
+// Stdout and Stderr are the writers for "os.Stdout" and "os.Stderr".
+var (
+	Stdout = &consoleWriter{func(s string) { console.log(s) }}
+	Stderr = &consoleWriter{func(s string) { console.error(s) }}
+)
+
+// * * *
+
+// typedOf returns the value "a" like a "ValueType", which has an empty type
+// when it is unknown. The basic type is the type, unless it is given.
+func typedOf(a interface{}) *ValueType {
+	t, k := "", ""
+
+	for isTyped(a) || isNamed(a) {
+		if isNamed(a) {
+			if t == "" {
+				t = "main." + fromText(a.constructor.name)
+			}
+			if k == "" && a.constructor.kind != nil {
+				k = a.constructor.kind
+			}
+			a = a.t
+			continue
+		}
+		if t == "" {
+			t = a.t
+		}
+		if k == "" && a.k != nil {
+			k = a.k
+		}
+		a = a.v
+	}
+	if t == "" && isPointer(a) {
+		t = a["$ptr"]
+	}
+	if k == "" && namedTypes[t] != nil {
+		k = namedTypes[t].kind
+	}
+	if k == "" {
+		k = t
+	}
+	return &ValueType{a, t, k}
+}
+
+// Named marks the constructor of a named type which is not a struct, with the
+// name of its basic type, if any. Its values are stored into an object of the
+// type, in field "t", when they are converted to an interface, so they have the
+// methods of the type.
+func Named(typ interface{}, kind string) {
+	typ.named = true
+	typ.kind = kind
+	namedTypes["main."+fromText(typ.name)] = typ
+}
+
+// namedTypes has the constructors of the named types which are not structs, by
+// the name of the type; the elements of the composite values get their methods
+// from them.
+var namedTypes = make(map[string]interface{})
+
+// FieldTypes saves the types of the fields of a struct which are needed to
+// format them, like the named types with methods.
+func FieldTypes(typ interface{}, fields interface{}) {
+	typ.fields = fields
+}
+
+// isNamed reports whether the value is stored into an object of its named type.
+func isNamed(a interface{}) bool {
+	return typeof(a) == "object" && a != nil && a.constructor.named == true
+}
+
+// isTyped reports whether the value stores its type.
+func isTyped(a interface{}) bool {
+	if typeof(a) != "object" || a == nil {
+		return false
+	}
+	name := a.constructor.name
+	return name == "ValueType" || name == "NumType" || name == "StringType" ||
+		name == "BoolType"
+}
+
+// isStr reports whether the value is a string.
+func isStr(a interface{}) bool {
+	return typeof(typedOf(a).v) == "string"
+}
+
+// isInt reports whether the value is an integer.
+func isInt(a interface{}) bool {
+	tv := typedOf(a)
+	return typeof(tv.v) == "number" && !isFloat(tv.v, tv.k)
+}
+
+// isFloat reports whether the number "n" of type "t" is a float.
+func isFloat(n interface{}, t string) bool {
+	switch t {
+	case "float32", "float64":
+		return true
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16",
+		"uint32", "uint64", "uintptr", "byte", "rune":
+		return false
+	}
+	return !isFinite(n) || n%1 != 0
+}
+
+// kindOf returns the kind of a value which is an object: "slice", "array",
+// "map", "pointer" or "struct".
+func kindOf(v interface{}, t string) string {
+	switch v.constructor.name {
+	case "SliceType":
+		return "slice"
+	case "ArrayType":
+		return "array"
+	case "MapType":
+		return "map"
+	case "Object":
+		if v.hasOwnProperty("p") && Object.keys(v).length == 1 {
+			return "pointer"
+		}
+	}
+	if t.charAt(0) == "*" {
+		return "pointer"
+	}
+	return "struct"
+}
+
+// hasMethod reports whether the value has the method "name".
+func hasMethod(v interface{}, name string) bool {
+	return typeof(v) == "object" && v != nil && typeof(v[name]) == "function"
+}
+
+// typeName returns the name of the type of "a", like the verb "%T".
+func typeName(a interface{}) string {
+	tv := typedOf(a)
+	v := tv.v
+
+	if tv.t != "" {
+		return tv.t
+	}
+	switch typeof(v) {
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	case "number":
+		if isFloat(v, "") {
+			return "float64"
+		}
+		return "int"
+	case "function":
+		return "func()"
+	case "object":
+		if v == nil {
+			return "<nil>"
+		}
+		switch kindOf(v, "") {
+		case "slice":
+			return "[]interface {}"
+		case "array":
+			return "[" + len(v.v) + "]interface {}"
+		case "map":
+			return "map[string]interface {}"
+		case "pointer":
+			return "*" + typeName(v.p)
+		}
+		return "main." + fromText(v.constructor.name)
+	}
+	return "<nil>"
+}
+
+// elemType returns the type of the elements of the type "t" of a slice, an
+// array or a pointer; and the type of the values of a map.
+func elemType(t string) string {
+	switch {
+	case t.indexOf("[]") == 0:
+		return t.slice(2)
+	case t.charAt(0) == "[":
+		return t.slice(t.indexOf("]") + 1)
+	case t.charAt(0) == "*":
+		return t.slice(1)
+	case t.indexOf("map[") == 0:
+		return t.slice(len(keyType(t)) + 5)
+	}
+	return ""
+}
+
+// keyType returns the type of the keys of the type "t" of a map.
+func keyType(t string) string {
+	if t.indexOf("map[") != 0 {
+		return ""
+	}
+	depth := 0
+
+	for i := 3; i < len(t); i++ {
+		c := t.charAt(i)
+		if c == "[" {
+			depth++
+		} else if c == "]" {
+			depth--
+			if depth == 0 {
+				return t.slice(4, i)
+			}
+		}
+	}
+	return ""
+}
+
+// TypedValues returns the values returned by a call, each one with its type.
+func TypedValues(values []interface{}, types []string) []interface{} {
+	for i, t := range types {
+		values[i] = withType(values[i], t)
+	}
+	return values
+}
+
+// withType returns the value "v" with the type "t", if it is known.
+func withType(v interface{}, t string) interface{} {
+	if t == "" || isTyped(v) {
+		return v
+	}
+	return &ValueType{v, t, ""}
+}
+
+// * * *
+
+// fmtArg formats the argument "a" according to the verb and its flags; "depth"
+// is the depth into a composite value.
+func fmtArg(a interface{}, verb string, f *fmtFlags, depth int) string {
+	tv := typedOf(a)
+	v, t, k := tv.v, tv.t, tv.k
+
+	switch verb {
+	case "T":
+		return pad(typeName(a), f)
+	case "p":
+		if typeof(v) == "object" && v != nil || typeof(v) == "function" {
+			return pad("0xc000010000", f)
+		}
+		return badVerb(a, verb)
+	}
+
+	if v == nil {
+		switch {
+		case t.indexOf("[]") == 0 || t.indexOf("map[") == 0:
+			if f.sharpV {
+				return t + "(nil)"
+			}
+			if t.charAt(0) != "[" {
+				return "map[]"
+			}
+			// A slice of bytes is formatted like a string by these verbs.
+			elem := elemType(t)
+			if (elem == "byte" || elem == "uint8") && (verb == "s" || verb == "q" ||
+				verb == "x" || verb == "X") {
+				return fmtString("", verb, f)
+			}
+			return "[]"
+		case verb == "v":
+			if f.sharpV && t != "" {
+				return "(" + t + ")(nil)"
+			}
+			return pad("<nil>", f)
+		}
+		return badVerb(a, verb)
+	}
+
+	// The methods "Error" and "String" are used by the verbs which accept a
+	// string.
+	if !f.sharpV && (verb == "v" || verb == "s" || verb == "q" || verb == "x" ||
+		verb == "X") {
+		// The values of named types which are not structs have the methods
+		// into the object which stores them.
+		recv := v
+		if isNamed(a) {
+			recv = a
+		} else if namedTypes[t] != nil && !isNamed(v) {
+			// Element of a composite value.
+			recv = Reflect.construct(namedTypes[t], Array.of(v))
+		}
+		if hasMethod(recv, "Error") {
+			return fmtString(recv.Error(), verb, f)
+		}
+		if hasMethod(recv, "String") {
+			return fmtString(recv.String(), verb, f)
+		}
+	}
+
+	switch typeof(v) {
+	case "boolean":
+		if verb == "t" || verb == "v" {
+			return pad(""+v, f)
+		}
+		return badVerb(a, verb)
+	case "number":
+		if isFloat(v, k) {
+			return fmtFloat(a, v, k, verb, f)
+		}
+		return fmtInteger(a, v, verb, f)
+	case "string":
+		return fmtString(v, verb, f)
+	case "function":
+		if f.sharpV {
+			return "(" + t + ")(0xc000010000)"
+		}
+		return pad("0xc000010000", f)
+	}
+
+	// The arrays of a multi-dimensional array are stored like arrays of JS.
+	if Array.isArray(v) {
+		return fmtList(v, t, verb, f, depth)
+	}
+	switch kindOf(v, t) {
+	case "slice":
+		if v.isNil() && f.sharpV {
+			return t + "(nil)"
+		}
+		return fmtList(v.get(), t, verb, f, depth)
+	case "array":
+		return fmtList(v.v, t, verb, f, depth)
+	case "map":
+		return fmtMap(v, t, verb, f, depth)
+	case "pointer":
+		ptr := v
+		if v.constructor.name == "Object" && v.hasOwnProperty("p") {
+			ptr = v.p
+		}
+		if depth == 0 && typeof(ptr) == "object" && ptr != nil {
+			return "&" + fmtArg(withType(ptr, elemType(t)), verb, f, depth+1)
+		}
+		if f.sharpV {
+			return "(" + typeName(a) + ")(0xc000010000)"
+		}
+		return pad("0xc000010000", f)
+	}
+	return fmtStruct(v, t, verb, f, depth)
+}
+
+// badVerb returns the error for a verb which is not valid for the argument.
+func badVerb(a interface{}, verb string) string {
+	if typedOf(a).v == nil {
+		return "%!" + verb + "(<nil>)"
+	}
+	return "%!" + verb + "(" + typeName(a) + "=" + fmtArg(a, "v", newFlags(), 0) + ")"
+}
+
+// pad returns the string "s" padded to the width, in runes.
+func pad(s string, f *fmtFlags) string {
+	n := f.wid - len(runesOf(s))
+	if n <= 0 {
+		return s
+	}
+	if f.minus {
+		return s + repeat(" ", n)
+	}
+	if f.zero {
+		return repeat("0", n) + s
+	}
+	return repeat(" ", n) + s
+}
+
+// padNumber returns the number "s" padded to the width; the zeros are added
+// after of the sign and the prefix.
+func padNumber(sign, prefix, s string, f *fmtFlags, zero bool) string {
+	n := f.wid - len(sign) - len(prefix) - len(s)
+	if zero && !f.minus && n > 0 {
+		return sign + prefix + repeat("0", n) + s
+	}
+	return pad(sign+prefix+s, &fmtFlags{false, f.minus, false, false, false,
+		false, false, f.wid, -1})
+}
+
+// repeat returns the string "s" repeated "n" times.
+func repeat(s string, n int) string {
+	r := ""
+	for i := 0; i < n; i++ {
+		r += s
+	}
+	return r
+}
+
+// fmtInteger formats an integer.
+func fmtInteger(a interface{}, n int, verb string, f *fmtFlags) string {
+	digits := ""
+	prefix := ""
+	abs := Math.abs(n)
+
+	switch verb {
+	case "v", "d":
+		digits = abs.toString(10)
+	case "b":
+		digits = abs.toString(2)
+		if f.sharp {
+			prefix = "0b"
+		}
+	case "o", "O":
+		digits = abs.toString(8)
+		if verb == "O" {
+			prefix = "0o"
+		} else if f.sharp {
+			prefix = "0"
+		}
+	case "x":
+		digits = abs.toString(16)
+		if f.sharp {
+			prefix = "0x"
+		}
+	case "X":
+		digits = abs.toString(16).toUpperCase()
+		if f.sharp {
+			prefix = "0X"
+		}
+	case "c":
+		return pad(RuneStr(n), f)
+	case "q":
+		return pad(quoteRune(n), f)
+	case "U":
+		s := abs.toString(16).toUpperCase()
+		if len(s) < 4 {
+			s = repeat("0", 4-len(s)) + s
+		}
+		s = "U+" + s
+		if f.sharp && n <= 0x10FFFF && isPrint(n) {
+			s += " '" + RuneStr(n) + "'"
+		}
+		return pad(s, f)
+	default:
+		return badVerb(a, verb)
+	}
+
+	if f.prec >= 0 {
+		if f.prec == 0 && n == 0 {
+			digits = ""
+		} else if len(digits) < f.prec {
+			digits = repeat("0", f.prec-len(digits)) + digits
+		}
+	}
+	if prefix == "0" && digits.charAt(0) == "0" {
+		prefix = ""
+	}
+
+	sign := ""
+	if n < 0 {
+		sign = "-"
+	} else if f.plus {
+		sign = "+"
+	} else if f.space {
+		sign = " "
+	}
+	return padNumber(sign, prefix, digits, f, f.zero && f.prec < 0)
+}
+
+// * * *
+
+// The floats are formatted from their decimal digits, which are rounded to
+// even like in Go.
+
+// decimal represents the decimal digits "d" of a number, without the trailing
+// zeros, being "dp" the position of the decimal point.
+type decimal struct {
+	d  string
+	dp int
+}
+
+// toDecimal returns the decimal digits of the absolute value of "n", which
+// are the shortest ones to represent it when "shortest"; "size" is 32 or 64.
+func toDecimal(n float64, shortest bool, size int) *decimal {
+	if n == 0 {
+		return &decimal{"", 0}
+	}
+	s := n.toExponential(100)
+
+	if shortest {
+		s = n.toExponential()
+		if size == 32 {
+			for p := 0; p < 9; p++ {
+				if Math.fround(parseFloat(n.toExponential(p))) == Math.fround(n) {
+					s = n.toExponential(p)
+					break
+				}
+			}
+		}
+	}
+	e := s.indexOf("e")
+	d := s.charAt(0) + s.slice(2, e)
+
+	for len(d) > 1 && d.charAt(len(d)-1) == "0" {
+		d = d.slice(0, len(d)-1)
+	}
+	return &decimal{d, parseInt(s.slice(e+1), 10) + 1}
+}
+
+// round rounds the decimal to "nd" digits, to even when it is halfway.
+func (d *decimal) round(nd int) {
+	if nd < 0 || nd >= len(d.d) {
+		if nd < 0 {
+			d.d, d.dp = "", 0
+		}
+		return
+	}
+	up := d.d.charAt(nd) > "5"
+
+	if d.d.charAt(nd) == "5" {
+		if nd+1 < len(d.d) { // greater than halfway
+			up = true
+		} else {
+			up = nd > 0 && parseInt(d.d.charAt(nd-1), 10)%2 == 1
+		}
+	}
+	digits := d.d.slice(0, nd)
+
+	if up {
+		i := nd - 1
+		for ; i >= 0 && digits.charAt(i) == "9"; i-- {
+		}
+		if i < 0 {
+			digits = "1"
+			d.dp++
+		} else {
+			digits = digits.slice(0, i) + (parseInt(digits.charAt(i), 10) + 1)
+		}
+	}
+
+	for len(digits) > 0 && digits.charAt(len(digits)-1) == "0" {
+		digits = digits.slice(0, len(digits)-1)
+	}
+	d.d = digits
+	if d.d == "" {
+		d.dp = 0
+	}
+}
+
+// digit returns the digit at the position "i".
+func (d *decimal) digit(i int) string {
+	if i >= 0 && i < len(d.d) {
+		return d.d.charAt(i)
+	}
+	return "0"
+}
+
+// fmtE returns the decimal like "d.ddde±dd", with "prec" digits after of the
+// decimal point.
+func (d *decimal) fmtE(prec int, verb string) string {
+	s := d.digit(0)
+	if prec > 0 {
+		s += "."
+		for i := 1; i <= prec; i++ {
+			s += d.digit(i)
+		}
+	}
+
+	exp := 0
+	if d.d != "" {
+		exp = d.dp - 1
+	}
+	sign := "+"
+	if exp < 0 {
+		sign = "-"
+		exp = -exp
+	}
+	if exp < 10 {
+		return s + verb + sign + "0" + exp
+	}
+	return s + verb + sign + exp
+}
+
+// fmtF returns the decimal like "ddd.ddd", with "prec" digits after of the
+// decimal point.
+func (d *decimal) fmtF(prec int) string {
+	s := "0"
+	if d.dp > 0 {
+		s = ""
+		for i := 0; i < d.dp; i++ {
+			s += d.digit(i)
+		}
+	}
+	if prec > 0 {
+		s += "."
+		for i := 0; i < prec; i++ {
+			s += d.digit(d.dp + i)
+		}
+	}
+	return s
+}
+
+// fmtFloat formats a float.
+func fmtFloat(a interface{}, n float64, t string, verb string, f *fmtFlags) string {
+	size := 64
+	if t == "float32" {
+		size = 32
+	}
+	prec := f.prec
+	num := ""
+
+	if verb == "v" {
+		verb = "g"
+	}
+
+	if isNaN(n) {
+		num = "NaN"
+	} else if !isFinite(n) {
+		num = "Inf"
+	} else {
+		switch verb {
+		case "e", "E":
+			if prec < 0 {
+				prec = 6
+			}
+			d := toDecimal(Math.abs(n), false, size)
+			d.round(prec + 1)
+			num = d.fmtE(prec, verb)
+
+		case "f", "F":
+			if prec < 0 {
+				prec = 6
+			}
+			d := toDecimal(Math.abs(n), false, size)
+			d.round(d.dp + prec)
+			num = d.fmtF(prec)
+
+		case "g", "G":
+			shortest := prec < 0
+			d := toDecimal(Math.abs(n), shortest, size)
+			if shortest {
+				prec = len(d.d)
+			} else {
+				if prec == 0 {
+					prec = 1
+				}
+				d.round(prec)
+			}
+			nd := len(d.d)
+
+			eprec := prec
+			if eprec > nd && nd >= d.dp {
+				eprec = nd
+			}
+			if shortest {
+				eprec = 6
+			}
+			exp := d.dp - 1
+			if nd == 0 {
+				exp = 0
+			}
+
+			if exp < -4 || exp >= eprec {
+				if prec > nd {
+					prec = nd
+				}
+				e := "e"
+				if verb == "G" {
+					e = "E"
+				}
+				num = d.fmtE(prec-1, e)
+			} else {
+				if prec > d.dp {
+					prec = nd
+				}
+				num = d.fmtF(Math.max(prec-d.dp, 0))
+			}
+
+		default:
+			return badVerb(a, verb)
+		}
+	}
+
+	// The sharp flag forces a decimal point, and it keeps the trailing zeros
+	// of "%g".
+	if f.sharp && isFinite(n) {
+		digits := 0
+		if verb == "g" || verb == "G" {
+			digits = f.prec
+			if digits < 0 {
+				digits = 6
+			}
+		}
+		tail := ""
+		if e := num.search("[eE]"); e >= 0 {
+			tail = num.slice(e)
+			num = num.slice(0, e)
+		}
+		nonzero := false
+
+		for i := 0; i < len(num); i++ {
+			c := num.charAt(i)
+			if c != "." && c != "0" {
+				nonzero = true
+			}
+			if c != "." && nonzero {
+				digits--
+			}
+		}
+		if num.indexOf(".") < 0 {
+			if num == "0" {
+				digits--
+			}
+			num += "."
+		}
+		num += repeat("0", digits) + tail
+	}
+
+	sign := ""
+	switch {
+	case n < 0 || n == 0 && 1/n < 0:
+		sign = "-"
+	case f.plus:
+		sign = "+"
+	case f.space:
+		sign = " "
+	case !isFinite(n) && !isNaN(n):
+		sign = "+"
+	}
+	return padNumber(sign, "", num, f, f.zero && isFinite(n))
+}
+
+// * * *
+
+// fmtString formats a string.
+func fmtString(s string, verb string, f *fmtFlags) string {
+	switch verb {
+	case "v":
+		if f.sharpV {
+			return pad(quote(s, false), f)
+		}
+		fallthrough
+	case "s":
+		if f.prec >= 0 {
+			s = truncate(s, f.prec)
+		}
+		return pad(s, f)
+	case "q":
+		if f.prec >= 0 {
+			s = truncate(s, f.prec)
+		}
+		if f.sharp && canBackquote(s) {
+			return pad("`"+s+"`", f)
+		}
+		return pad(quote(s, f.plus), f)
+	case "x", "X":
+		b := bytesOf(s)
+		if f.prec >= 0 {
+			b = b.slice(0, f.prec)
+		}
+		return pad(fmtHex(b, verb, f), f)
+	}
+	return badVerb(s, verb)
+}
+
+// truncate returns the string "s" truncated to "n" runes.
+func truncate(s string, n int) string {
+	i := 0
+	for ; i < len(s) && n > 0; n-- {
+		i += decodeRune(s, i)[1]
+	}
+	return s.substring(0, i)
+}
+
+// fmtHex returns the bytes like hexadecimal digits.
+func fmtHex(b []int, verb string, f *fmtFlags) string {
+	s := ""
+
+	for i, c := range b {
+		if i > 0 && f.space {
+			s += " "
+		}
+		if f.sharp && (i == 0 || f.space) {
+			s += "0" + verb
+		}
+		h := c.toString(16)
+		if len(h) == 1 {
+			h = "0" + h
+		}
+		if verb == "X" {
+			h = h.toUpperCase()
+		}
+		s += h
+	}
+	return s
+}
+
+// quote returns the string "s" quoted like a Go string literal, with only ASCII
+// characters when "ascii". The invalid bytes are escaped.
+func quote(s string, ascii bool) string {
+	q := "\""
+
+	for i := 0; i < len(s); {
+		d := decodeRune(s, i)
+		switch {
+		case d[0] == 0xFFFD && d[1] == 1:
+			q += "\\x" + s.charCodeAt(i).toString(16)
+		case d[0] == 0x22:
+			q += "\\\""
+		default:
+			q += escapeRune(d[0], ascii)
+		}
+		i += d[1]
+	}
+	return q + "\""
+}
+
+// quoteRune returns the rune "r" quoted like a Go character literal.
+func quoteRune(r rune) string {
+	if r < 0 || r > 0x10FFFF || r >= 0xD800 && r <= 0xDFFF {
+		r = 0xFFFD
+	}
+	if r == 0x27 {
+		return "'\\''"
+	}
+	return "'" + escapeRune(r, false) + "'"
+}
+
+// escapeRune returns the rune "r" escaped like into a Go literal.
+func escapeRune(r rune, ascii bool) string {
+	switch r {
+	case 0x5C:
+		return "\\\\"
+	case 7:
+		return "\\a"
+	case 8:
+		return "\\b"
+	case 12:
+		return "\\f"
+	case 10:
+		return "\\n"
+	case 13:
+		return "\\r"
+	case 9:
+		return "\\t"
+	case 11:
+		return "\\v"
+	}
+
+	if r < 0x80 && isPrint(r) || r >= 0x80 && !ascii && isPrint(r) {
+		return RuneStr(r)
+	}
+	h := r.toString(16)
+
+	switch {
+	case r < 0x80:
+		return "\\x" + repeat("0", 2-len(h)) + h
+	case r < 0x10000:
+		return "\\u" + repeat("0", 4-len(h)) + h
+	}
+	return "\\U" + repeat("0", 8-len(h)) + h
+}
+
+// isPrint reports whether the rune "r" is printable, like "strconv.IsPrint"
+// but only for the control and formatting characters.
+func isPrint(r rune) bool {
+	return !(r < 0x20 || r >= 0x7F && r <= 0xA0 || r == 0xAD ||
+		r >= 0x200B && r <= 0x200F || r >= 0x2028 && r <= 0x202E ||
+		r >= 0x2060 && r <= 0x206F || r == 0xFEFF || r >= 0xFFF9 && r <= 0xFFFB)
+}
+
+// canBackquote reports whether the string "s" can be written like a raw string
+// literal.
+func canBackquote(s string) bool {
+	for i := 0; i < len(s); {
+		d := decodeRune(s, i)
+		r := d[0]
+		if r == 0x60 || r == 0xFEFF || r < 0x20 && r != 9 || r == 0x7F ||
+			r == 0xFFFD && d[1] == 1 {
+			return false
+		}
+		i += d[1]
+	}
+	return true
+}
+
+// * * *
+
+// fmtList formats the elements of a slice or an array.
+func fmtList(list []interface{}, t string, verb string, f *fmtFlags, depth int) string {
+	elem := elemType(t)
+
+	// A slice of bytes is formatted like a string by these verbs.
+	if (elem == "byte" || elem == "uint8") && (verb == "s" || verb == "q" ||
+		verb == "x" || verb == "X") {
+		if verb == "x" || verb == "X" {
+			b := list
+			if f.prec >= 0 {
+				b = b.slice(0, f.prec)
+			}
+			return pad(fmtHex(b, verb, f), f)
+		}
+		return fmtString(strOf(list), verb, f)
+	}
+
+	s := ""
+	if f.sharpV {
+		s = t + "{"
+		if t == "" {
+			s = "[]interface {}{"
+		}
+	} else {
+		s = "["
+	}
+
+	for i, v := range list {
+		if i > 0 {
+			if f.sharpV {
+				s += ", "
+			} else {
+				s += " "
+			}
+		}
+		s += fmtArg(withType(v, elem), verb, f, depth+1)
+	}
+
+	if f.sharpV {
+		return s + "}"
+	}
+	return s + "]"
+}
+
+// fmtMap formats a map, sorting its keys.
+func fmtMap(m interface{}, t string, verb string, f *fmtFlags, depth int) string {
+	if m.isNil() && f.sharpV {
+		return t + "(nil)"
+	}
+	key := keyType(t)
+	elem := elemType(t)
+
+	pairs := Array.from(m.entries())
+	pairs.sort(func(a, b interface{}) int { return compareKeys(a[0], b[0]) })
+
+	s := "map["
+	if f.sharpV {
+		s = t + "{"
+	}
+
+	for i, e := range pairs {
+		if i > 0 {
+			if f.sharpV {
+				s += ", "
+			} else {
+				s += " "
+			}
+		}
+		s += fmtArg(withType(e[0], key), verb, f, depth+1) + ":" +
+			fmtArg(withType(e[1], elem), verb, f, depth+1)
+	}
+
+	if f.sharpV {
+		return s + "}"
+	}
+	return s + "]"
+}
+
+// fmtStruct formats a struct.
+func fmtStruct(v interface{}, t string, verb string, f *fmtFlags, depth int) string {
+	s := "{"
+	if f.sharpV {
+		if t == "" {
+			t = "main." + fromText(v.constructor.name)
+		}
+		s = t + "{"
+	}
+	i := 0
+	fields := v.constructor.fields
+
+	for k, _ := range v {
+		// The descriptors of the type parameters are not fields.
+		if !v.hasOwnProperty(k) || k.charAt(0) == "$" {
+			continue
+		}
+		if i > 0 {
+			if f.sharpV {
+				s += ", "
+			} else {
+				s += " "
+			}
+		}
+		if f.plusV || f.sharpV {
+			s += fromText(k) + ":"
+		}
+		if fields != nil && fields[k] != nil {
+			s += fmtArg(withType(v[k], fields[k]), verb, f, depth+1)
+		} else {
+			s += fmtArg(v[k], verb, f, depth+1)
+		}
+		i++
+	}
+	return s + "}"
+}
+
+// == Utility
+//
+
+// Clone returns a copy of the value "v", keeping the type of the structs. The
+// arrays and structs into it are copied according to the descriptor of its
+// type, like in "Equal"; without a descriptor, the copy is shallow.
+func Clone(v interface{}, desc interface{}) interface{} {
+	if typeof(v) != "object" || v == nil || typeof(desc) == "number" {
+		return v
+	}
+	if Array.isArray(v) { // elements of an array
+		if desc == nil {
+			return v.slice()
+		}
+		return v["map"](func(e interface{}) interface{} { return Clone(e, desc[0]) })
+	}
+	c := Object.create(Object.getPrototypeOf(v))
+
+	for k, _ := range v {
+		if v.hasOwnProperty(k) {
+			c[k] = v[k]
+		}
+	}
+	if desc == nil {
+		return c
+	}
+
+	if Array.isArray(desc) {
+		c.v = Clone(v.v, desc)
+	} else {
+		for k, _ := range desc {
+			c[k] = Clone(v[k], desc[k])
+		}
+	}
+	return c
+}
+
 // Export adds public names from "exported" to the map "pkg".
 func Export(pkg map[interface{}]interface{}, exported []interface{}) {
 	for _, v := range exported {
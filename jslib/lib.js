@@ -9,11 +9,11 @@
 var g = {}; (function() {
 
 
-const 
-invalidT = 0,
-arrayT = 1,
-mapT = 2,
-sliceT = 3;
+
+const invalidT = 0;
+const arrayT = 1;
+const mapT = 2;
+const sliceT = 3;
 
 
 (function() {
@@ -28,7 +28,7 @@ sliceT = 3;
 
 
 	Function.prototype.alias = function(parent) {
-		if (JSON.stringify(parent.constructor) == JSON.stringify(Function)) {
+		if (parent.constructor === Function) {
 			this.prototype = parent;
 			this.prototype.constructor = this;
 			this.prototype.parent = parent.prototype;
@@ -45,13 +45,13 @@ sliceT = 3;
 
 
 function BoolType(v, t) {
-	this.v=v;
-	this.t=t
+	this.v = v;
+	this.t = t;
 }
 
 
 
-BoolType.prototype.valueOf = function() { return this.v; }
+BoolType.prototype.valueOf = function() { return this.v; };
 
 function Bool(b) { return new BoolType(b, "bool"); }
 
@@ -59,11 +59,11 @@ function Bool(b) { return new BoolType(b, "bool"); }
 
 
 function StringType(v, t) {
-	this.v=v;
-	this.t=t
+	this.v = v;
+	this.t = t;
 }
 
-StringType.prototype.valueOf = function() { return this.v; }
+StringType.prototype.valueOf = function() { return this.v; };
 
 function String(s) { return new StringType(s, "string"); }
 
@@ -71,11 +71,11 @@ function String(s) { return new StringType(s, "string"); }
 
 
 function NumType(v, t) {
-	this.v=v;
-	this.t=t
+	this.v = v;
+	this.t = t;
 }
 
-NumType.prototype.valueOf = function() { return this.v; }
+NumType.prototype.valueOf = function() { return this.v; };
 
 
 
@@ -105,9 +105,9 @@ function Rune(n) { return new NumType(n, "rune"); }
 
 
 function ArrayType(v, len_) {
-	this.v=v;
+	this.v = v;
 
-	this.len_=len_
+	this.len_ = len_;
 }
 
 
@@ -116,7 +116,7 @@ ArrayType.prototype.len = function(index) {
 		return this.len_[0];
 	}
 	return this.len_[arguments.length];
-}
+};
 
 
 ArrayType.prototype.cap = function(index) {
@@ -124,20 +124,23 @@ ArrayType.prototype.cap = function(index) {
 		return this.len_[0];
 	}
 	return this.len_[arguments.length];
-}
+};
 
 
-ArrayType.prototype.str = function() {
-	return this.v.join("");
-}
+ArrayType.prototype.str = function(isRune) {
+	if (isRune) {
+		return runesStr(this.v);
+	}
+	return strOf(this.v);
+};
 
 
-ArrayType.prototype.typ = function() { return arrayT; }
+ArrayType.prototype.typ = function() { return arrayT; };
 
 
 
 function MkArray(index, zero, data) {
-	var a = new ArrayType([], g.Map(0));
+	var a = new ArrayType([], g.MkMap(0));
 
 	if (data != undefined) {
 		if (!equalIndex(index, indexArray(data))) {
@@ -159,13 +162,25 @@ function MkArray(index, zero, data) {
 
 
 
+function Row(v) {
+	var a = new ArrayType([], g.MkMap(0));
+	a.v = v;
+
+	var n; for (var i in indexArray(v)) { n = indexArray(v)[i];
+		a.len_[i] = n;
+	}
+	return a;
+}
+
+
+
 
 function equalIndex(index1, index2) {
 	if (index1.length != index2.length) {
 		return false;
 	}
 	var v; for (var i in index1) { v = index1[i];
-		if (JSON.stringify(v) != JSON.stringify(index2[i])) {
+		if (v !== index2[i]) {
 			return false;
 		}
 	}
@@ -187,19 +202,55 @@ function indexArray(a) { var index = [];
 }
 
 
+
+function isIndexMap(v) {
+	if (typeof(v) != "object" || v == undefined || v.constructor.name != "Object") {
+		return false;
+	}
+	var keys = Object.keys(v);
+	if (keys.length == 0) {
+		return false;
+	}
+	var k; for (var _ in keys) { k = keys[_];
+		if ("" + parseInt(k, 10) !== k) {
+			return false;
+		}
+	}
+	return true;
+}
+
+
 function initArray(index, zero) { var a = [];
 	if (index.length == 0) {
 		return zero;
 	}
-	var nextArray = initArray(index.slice(1), zero);
-
 	for (var i = 0; i < index[0]; i++) {
-		a[i] = nextArray;
+		a[i] = initArray(index.slice(1), newZero(zero));
 	}
 	return a;
 }
 
 
+
+
+function newZero(zero) {
+	if (typeof(zero) != "object" || zero == undefined || zero.isNil != undefined) {
+		return zero;
+	}
+	if (Array.isArray(zero)) {
+		return zero["map"](newZero);
+	}
+	var c = Object.create(Object.getPrototypeOf(zero));
+
+	var _; for (var k in zero) { _ = zero[k];
+		if (zero.hasOwnProperty(k)) {
+			c[k] = newZero(zero[k]);
+		}
+	}
+	return c;
+}
+
+
 function mergeArray(dst, src) {
 	var srcVal; for (var i in src) { srcVal = src[i];
 		if (Array.isArray(srcVal)) {
@@ -208,7 +259,7 @@ function mergeArray(dst, src) {
 			var isHashMap = false;
 
 
-			if (typeof(srcVal) == "object") {
+			if (isIndexMap(srcVal)) {
 				var v; for (var k in srcVal) { v = srcVal[k];
 					if (srcVal.hasOwnProperty(k)) {
 						isHashMap = true;
@@ -228,16 +279,18 @@ function mergeArray(dst, src) {
 
 
 
-function SliceType(arr, v, low, high, len, cap, nil_) {
-	this.arr=arr;
-	this.v=v;
 
-	this.low=low;
-	this.high=high;
-	this.len=len;
-	this.cap=cap;
 
-	this.nil_=nil_
+
+function SliceType(arr, zero, off, len, cap, nil_) {
+	this.arr = arr;
+	this.zero = zero;
+
+	this.off = off;
+	this.len = len;
+	this.cap = cap;
+
+	this.nil_ = nil_;
 }
 
 SliceType.prototype.isNil = function() {
@@ -245,218 +298,444 @@ SliceType.prototype.isNil = function() {
 		return false;
 	}
 	return this.nil_;
-}
+};
 
 
-SliceType.prototype.typ = function() { return sliceT; }
+SliceType.prototype.typ = function() { return sliceT; };
 
 
 function MkSlice(zero, len, cap) {
-	var s = new SliceType(undefined, [], 0, 0, 0, 0, false);
+	var s = new SliceType([], undefined, 0, 0, 0, false);
 
 	if (zero == undefined) {
 		s.nil_ = true;
 		return s;
 	}
-
-	var arr = new ArrayType([], g.Map(0));
-	arr.len_[0] = len;
-
-	arr.v = Array(len);
-	for (var i = 0; i < len; i++) {
-		arr.v[i] = zero;
+	if (cap == undefined || cap < len) {
+		cap = len;
 	}
 
-	if (cap != undefined) {
-		s.cap = cap;
-	} else {
-		s.cap = len;
-	}
 
-	s.arr = arr;
+	s.arr = Array(cap);
+	for (var i = 0; i < cap; i++) {
+		s.arr[i] = newZero(zero);
+	}
+	s.zero = zero;
 	s.len = len;
-	s.high = len;
-
+	s.cap = cap;
 	return s;
 }
 
 
-function Slice(zero, data) {
-	var s = new SliceType(undefined, [], 0, 0, 0, 0, false);
 
-	if (zero == undefined) {
-		s.nil_ = true;
-		return s;
-	}
+function Slice(zero, data) {
+	var s = new SliceType([], undefined, 0, 0, 0, false);
 
-	var arr = new ArrayType([], g.Map(0));
 	var srcVal; for (var i in data) { srcVal = data[i];
 		var isHashMap = false;
 
 
-		if (typeof(srcVal) == "object") {
+		if (isIndexMap(srcVal)) {
 			var v; for (var k in srcVal) { v = srcVal[k];
 				if (srcVal.hasOwnProperty(k)) {
 					isHashMap = true;
 
 					for (i; i < k; i++) {
-						arr.v[i] = zero;
+						s.arr[i] = newZero(zero);
 					}
-					arr.v[i] = v;
+					s.arr[i] = v;
 				}
 			}
 		}
 		if (!isHashMap) {
-			arr.v[i] = srcVal;
+			s.arr[i] = srcVal;
 		}
 	}
-	s.len = arr.v.length;
-	arr.len_[0] = s.len;
-	s.arr = arr;
+	s.zero = zero;
+	s.len = s.arr.length;
+	s.cap = s.len;
+	return s;
+}
+
 
+
+function SliceArgs(data) {
+	var s = new SliceType([], undefined, 0, 0, 0, false);
+
+	if (data.length == 0) {
+		s.nil_ = true;
+		return s;
+	}
+	s.arr = data;
+	s.len = data.length;
 	s.cap = s.len;
-	s.high = s.len;
 	return s;
 }
 
 
-function SliceFrom(src, low, high) {
-	var s = new SliceType(undefined, [], 0, 0, 0, 0, false);
+
+function TupleArgs(values, variadic) {
+	var args = values.slice(0, variadic);
+	args.push(SliceArgs(values.slice(variadic)));
+	return args;
+}
+
+
+
+function SliceFrom(src, low, high, max) {
+	var length = 0, cap = 0, unit = "length";
+	if (src.typ() === sliceT) {
+		length = src.len; cap = src.cap; unit = "capacity";
+	} else {
+		length = src.v.length;
+		cap = length;
+	}
 
 	if (low != undefined) {
-		s.low = (low|0);
+		low = (low|0);
 	} else {
-		s.low = 0;
+		low = 0;
 	}
 	if (high != undefined) {
-		s.high = (high|0);
+		high = (high|0);
 	} else {
-		if (src.arr != undefined) {
-			s.high = src.len;
-		} else {
-			s.high = src.v.length;
-		}
+		high = length;
+	}
+	if (max != undefined) {
+		max = (max|0);
+		checkSlice3(low, high, max, cap, unit);
+	} else {
+		max = cap;
+		checkSlice(low, high, cap, unit);
 	}
 
-	s.len = s.high - s.low;
 
-	if (src.arr != undefined) {
+	if (src.typ() === sliceT && src.isNil()) {
+		return src;
+	}
+	var s = new SliceType([], undefined, 0, 0, 0, false);
+
+	if (src.typ() === sliceT) {
 		s.arr = src.arr;
-		s.cap = src.cap - s.low;
-		s.low += src.low;
-		s.high += src.low;
+		s.zero = src.zero;
+		s.off = src.off + low;
 	} else {
-		s.arr = src;
-		s.cap = src.cap() - s.low;
+		s.arr = src.v;
+		s.off = low;
 	}
+	s.len = high - low;
+	s.cap = max - low;
 	return s;
 }
 
 
+
+function checkSlice(low, high, cap, unit) {
+	if (high < 0 || high > cap) {
+		throw new Error("runtime error: slice bounds out of range [:" + high + "] with " + unit + " " + cap);
+	}
+	if (low < 0 || low > high) {
+		throw new Error("runtime error: slice bounds out of range [" + low + ":" + high + "]");
+	}
+}
+
+
+
+function checkSlice3(low, high, max, cap, unit) {
+	if (max < 0 || max > cap) {
+		throw new Error("runtime error: slice bounds out of range [::" + max + "] with " + unit + " " + cap);
+	}
+	if (high < 0 || high > max) {
+		throw new Error("runtime error: slice bounds out of range [:" + high + ":" + max + "]");
+	}
+	if (low < 0 || low > high) {
+		throw new Error("runtime error: slice bounds out of range [" + low + ":" + high + ":]");
+	}
+}
+
+
 SliceType.prototype.get = function() {
-	if (this.arr != undefined) {
-		var arr = this.arr.v.slice(this.low, this.high);
+	if (this.arr == undefined) {
+		return Array();
+	}
+	return this.arr.slice(this.off, this.off + this.len);
+};
 
-		if (this.v.length != 0) {
-			return arr.concat(this.v);
-		} else {
-			return arr;
+
+SliceType.prototype.at = function(i) {
+	return this.arr[this.off + i];
+};
+
+
+SliceType.prototype.set = function(index, v) {
+	this.arr[this.off + index[0]] = v;
+};
+
+
+SliceType.prototype.str = function(isRune) {
+	if (isRune) {
+		return runesStr(this.get());
+	}
+	return strOf(this.get());
+};
+
+
+
+
+
+
+
+function Append(src, elt) {
+	if (typeof(elt) == "string") {
+		elt = bytesOf(elt);
+	}
+	if (elt.length == 0) {
+		return src;
+	}
+	var dst = new SliceType([], undefined, 0, 0, 0, false);
+	dst.zero = src.zero;
+	dst.len = src.len + elt.length;
+
+	if (dst.len <= src.cap) {
+		dst.arr = src.arr;
+		dst.off = src.off;
+		dst.cap = src.cap;
+	} else {
+		dst.cap = growCap(src.cap, dst.len);
+		dst.arr = Array(dst.cap);
+
+		for (var i = 0; i < src.len; i++) {
+			dst.arr[i] = src.arr[src.off + i];
+		}
+		for (var i = dst.len; i < dst.cap; i++) {
+			dst.arr[i] = newZero(src.zero);
 		}
 	}
-	return this.v;
+
+	for (var i = 0; i < elt.length; i++) {
+		dst.arr[dst.off + src.len + i] = elt[i];
+	}
+	return dst;
 }
 
 
-SliceType.prototype.set = function(index, v) {
-	this.arr.v[index[0] + this.low] = v;
+
+function growCap(cap, need) {
+	var newCap = cap + cap;
+	if (need > newCap) {
+		return need;
+	}
+	if (cap < 256) {
+		return newCap;
+	}
+	newCap = cap;
+	for (; newCap < need;) {
+		newCap += ((newCap + 768) / 4|0);
+	}
+	return newCap;
+}
+
+
+function Copy(dst, src) { var n = 0;
+	var elt = src;
+
+	if (typeof(src) == "string") {
+		elt = bytesOf(src);
+	} else {
+		elt = src.get();
+	}
+
+	n = elt.length;
+	if (dst.len < n) {
+		n = dst.len;
+	}
+	for (var i = 0; i < n; i++) {
+		dst.arr[dst.off + i] = elt[i];
+	}
+	return n;
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+function MapType(v, zero, hash, nil_) {
+	this.v = v;
+	this.zero = zero;
+	this.hash = hash;
+	this.nil_ = nil_;
 }
 
 
-SliceType.prototype.str = function() {
-	var _s = this.get();
-	return _s.join("");
+MapType.prototype.len = function() { return this.v.size; };
+
+
+MapType.prototype.typ = function() { return mapT; };
+
+MapType.prototype.isNil = function() { return this.nil_; };
+
+
+
+
+function MkMap(zero, entries, hash) {
+	var m = new MapType(undefined, undefined, false, false);
+	m.v = jsMap();
+	m.zero = zero;
+	m.hash = hash === true;
+
+	if (entries == undefined) {
+		m.nil_ = true;
+		return m;
+	}
+	var e; for (var _ in entries) { e = entries[_];
+		m.v.set(m.key(e[0]), e);
+	}
+	return m;
 }
 
 
+MapType.prototype.key = function(k) {
+	if (this.hash) {
+		return hashKey(k);
+	}
+
+	return valueOf(k);
+};
+
 
 
-function Append(src) { var elt = [].slice.call(arguments).slice(1); var dst = new SliceType(undefined, [], 0, 0, 0, 0, false);
 
-	dst.low = src.low;
-	dst.high = src.high;
-	dst.len = src.len;
-	dst.cap = src.cap;
-	dst.nil_ = src.nil_;
+MapType.prototype.get = function(k) {
+	var e = this.v.get(this.key(k));
 
-	var arr = new ArrayType([], g.Map(0));
-	arr.len_[0] = src.arr.len_[0];
-	var v; for (var _ in src.arr.v) { v = src.arr.v[_];
-		arr.v.push(v);
+	if (e == undefined) {
+		return [newZero(this.zero), false];
 	}
-	dst.arr = arr;
+	return [e[1], true];
+};
+
 
-	var v; for (var _ in src.v) { v = src.v[_];
-		dst.v.push(v);
+MapType.prototype.set = function(k, v) {
+	if (this.nil_) {
+		throw new Error("assignment to entry in nil map");
 	}
+	this.v.set(this.key(k), Array(k, v));
+};
 
 
+MapType.prototype.remove = function(k) {
+	this.v["delete"](this.key(k));
+};
 
 
 
-	var v; for (var _ in elt) { v = elt[_];
-		if (Array.isArray(v)) {
-			var vArr; for (var _ in v) { vArr = v[_];
-				dst.v.push(vArr);
-				if (JSON.stringify(dst.len) == JSON.stringify(dst.cap)) {
-					dst.cap = dst.len * 2;
-				}
-				dst.len++;
-			}
-			break;
-		}
+MapType.prototype.entries = function() { return this.v.values(); };
 
-		dst.v.push(v);
-		if (JSON.stringify(dst.len) == JSON.stringify(dst.cap)) {
-			dst.cap = dst.len * 2;
-		}
-		dst.len++;
+
+
+
+
+var jsMapType = globalThis.Map;
+
+
+function jsMap() {
+	return Reflect.construct(jsMapType, Array());
+}
+
+
+
+
+function hashKey(k) {
+	k = valueOf(k);
+	if (typeof(k) != "object" || k == undefined || isPointer(k)) {
+		return k;
 	}
-	return dst;
+	return "{}" + hashOf(k);
 }
 
 
-function Copy(dst, src) { var n = 0;
+function hashOf(v) {
+	v = valueOf(v);
+	switch (typeof(v)) {
+	case "string":
+		return JSON.stringify(v); break;
+	case "object":
+		if (v == undefined) {
+			return "nil";
+		} break;
+	default:
+		return "" + v;
+	}
 
-	if (src.arr != undefined) {
-		for (var i = src.low; i < src.high; i++) {
-			if (JSON.stringify(n) == JSON.stringify(dst.len)) {
-				return n;
-			}
-			dst.arr.v[n] = src.arr.v[i];
-			n++;
-		}
-		var v; for (var _ in src.v) { v = src.v[_];
-			if (JSON.stringify(n) == JSON.stringify(dst.len)) {
-				return n;
-			}
-			dst.v.push(v);
-			n++;
+	var s = "[";
+	if (Array.isArray(v)) {
+		var e; for (var _ in v) { e = v[_];
+			s += hashOf(e) + ",";
 		}
-		return n;
+		return s + "]";
+	}
+	if (v.constructor.name == "ArrayType") {
+		return hashOf(v.v);
 	}
 
 
-	for (; n < src.length; n++) {
-		if (JSON.stringify(n) == JSON.stringify(dst.len)) {
-			break;
+	s = v.constructor.name + "{";
+	var e; for (var k in v) { e = v[k];
+		if (v.hasOwnProperty(k) && k.charAt(0) != "$") {
+			s += k + ":" + hashOf(e) + ",";
 		}
-		dst.arr.v[n] = src[n];
 	}
-	return n;
+	return s + "}";
 }
 
 
+function compareKeys(a, b) {
+	switch (typeof(a)) {
+	case "number":
+		if (isNaN(a) || isNaN(b)) {
+			return Number(!isNaN(a)) - Number(!isNaN(b));
+		}
+		return a - b; break;
+	case "boolean":
+		return Number(a) - Number(b); break;
+	case "string":
+		if (a < b) {
+			return -1;
+		}
+		if (a > b) {
+			return 1;
+		}
+		return 0; break;
+	case "object":
+		if (a == undefined || b == undefined) {
+			return Number(a != undefined) - Number(b != undefined);
+		} break;
+	default:
+		return 0;
+	}
 
+	if (a.constructor.name == "ArrayType") {
+		return compareKeys(a.v, b.v);
+	}
+	var _; for (var k in a) { _ = a[k];
+		if (a.hasOwnProperty(k) && k.charAt(0) != "$") {
+			var c = compareKeys(a[k], b[k]);
+			if (c != 0) {
+				return c;
+			}
+		}
+	}
+	return 0;
+}
 
 
 
@@ -466,86 +745,2169 @@ function Copy(dst, src) { var n = 0;
 
 
 
-function MapType(v, zero) {
-	this.v=v;
-	this.zero=zero
+function RefType(obj, key) {
+	this.obj = obj;
+	this.key = key;
 }
 
+(function() {
+	var prop = Object();
+	prop.get = function() { return this.obj[this.key]; };
+	prop.set = function(v) { this.obj[this.key] = v; };
+	Object.defineProperty(RefType.prototype, "p", prop);
+}());
+
+
+function Ref(obj, key) { return new RefType(obj, key); }
+
+
+SliceType.prototype.ref = function(i) { return Ref(this.arr, this.off + i); };
+
 
-MapType.prototype.len = function() {
-	var len = 0;
-	var _; for (var key in this.v) { _ = this.v[key];
-		if (this.v.hasOwnProperty(key)) {
-			len++;
+
+function Store(dst, src, desc) {
+	if (isArrayType(dst)) {
+		dst = dst.v;
+	}
+	if (isArrayType(src)) {
+		src = src.v;
+	}
+	if (Array.isArray(desc)) {
+		for (var i = 0; i < dst.length; i++) {
+			dst[i] = store(dst[i], src[i], desc[0]);
 		}
+		return;
+	}
+	var _; for (var k in desc) { _ = desc[k];
+		dst[k] = store(dst[k], src[k], desc[k]);
 	}
-	return len;
 }
 
 
-MapType.prototype.typ = function() { return mapT; }
 
+function store(dst, src, desc) {
+	if (typeof(desc) == "object") {
+		Store(dst, src, desc);
+		return dst;
+	}
+	return src;
+}
 
-function Map(zero, v) {
-	var m = new MapType(v, zero);
-	return m;
+
+
+
+function Pointer(v, t) {
+	if (v != undefined) {
+		var prop = Object();
+		prop.value = t;
+		prop.configurable = true;
+		Object.defineProperty(v, "$ptr", prop);
+	}
+	return v;
 }
 
 
 
+function isPointer(v) {
+	return typeof(v) == "object" && v != undefined && v.hasOwnProperty("$ptr");
+}
+
+
+function isRef(v) {
+	return typeof(v) == "object" && v != undefined && v.constructor.name == "RefType";
+}
 
-MapType.prototype.get = function(k) {
-	var v = this.v;
 
 
-	for (var i = 0; i < arguments.length; i++) {
-		v = v[arguments[i]];
+
+
+
+
+
+
+function Equal(x, y, desc) {
+	switch (typeof(desc)) {
+	case "number":
+		if (desc == 1) {
+			return equalAny(x, y);
+		}
+		if (isRef(x) && isRef(y)) {
+			return x.obj === y.obj && x.key === y.key;
+		}
+		return valueOf(x) === valueOf(y); break;
+	case "object":
+		if (Array.isArray(desc)) {
+			break;
+		}
+		var _; for (var k in desc) { _ = desc[k];
+			if (!Equal(x[k], y[k], desc[k])) {
+				return false;
+			}
+		}
+		return true;
 	}
 
-	if (v == undefined) {
-		return [this.zero, false];
+
+	if (isArrayType(x)) {
+		x = x.v;
+	}
+	if (isArrayType(y)) {
+		y = y.v;
+	}
+	for (var i = 0; i < x.length; i++) {
+		if (!Equal(x[i], y[i], desc[0])) {
+			return false;
+		}
 	}
-	return [v, true];
+	return true;
 }
 
 
 
 
+function equalAny(x, y) {
+	var tx = typedOf(x);
+	var ty = typedOf(y);
 
-function Export(pkg, exported) {
-	var v; for (var _ in exported) { v = exported[_];
-		pkg.v = v;
+	if (typeof(tx.v) != "object" && typeof(ty.v) != "object") {
+		if (typeName(x) !== typeName(y)) {
+			return false;
+		}
+	} else if (tx.t != "" && ty.t != "" && tx.t !== ty.t) {
+		return false;
 	}
-}
+	x = tx.v;
+	y = ty.v;
 
-g.BoolType = BoolType;
-g.Bool = Bool;
-g.StringType = StringType;
-g.String = String;
-g.NumType = NumType;
-g.Uint = Uint;
-g.Uint8 = Uint8;
-g.Uint16 = Uint16;
-g.Uint32 = Uint32;
-g.Int = Int;
-g.Int8 = Int8;
-g.Int16 = Int16;
-g.Int32 = Int32;
-g.Float32 = Float32;
+	if (typeof(x) !== typeof(y)) {
+		return false;
+	}
+	switch (typeof(x)) {
+	case "function":
+		throw new Error("runtime error: comparing uncomparable type " + typeName(x)); break;
+	case "object":
+		if (x == undefined || y == undefined) {
+			return x == undefined && y == undefined;
+		} break;
+	default:
+		return x === y;
+	}
+
+	if (x.constructor !== y.constructor) {
+		return false;
+	}
+	if (isPointer(x) || isPointer(y)) {
+		return x === y;
+	}
+	switch (x.constructor.name) {
+	case "SliceType": case "MapType":
+		throw new Error("runtime error: comparing uncomparable type " + typeName(x)); break;
+	case "ArrayType":
+		return equalAny(x.v, y.v); break;
+	case "Object":
+		return x === y; break;
+	case "RefType":
+		return x.obj === y.obj && x.key === y.key;
+	}
+
+	if (Array.isArray(x)) {
+		if (x.length != y.length) {
+			return false;
+		}
+		for (var i = 0; i < x.length; i++) {
+			if (!equalAny(x[i], y[i])) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	var _; for (var k in x) { _ = x[k];
+		if (x.hasOwnProperty(k) && k.charAt(0) != "$" && !equalAny(x[k], y[k])) {
+			return false;
+		}
+	}
+	return true;
+}
+
+
+
+function valueOf(v) {
+	if (typeof(v) == "object" && v != undefined) {
+		return v.valueOf();
+	}
+	return v;
+}
+
+
+function isArrayType(v) {
+	return typeof(v) == "object" && v != undefined && v.constructor.name == "ArrayType";
+}
+
+
+
+
+
+
+function MethodVal(recv, name, isValue) {
+	if (isValue) {
+		recv = Clone(recv);
+	}
+	var method = recv[name];
+
+	return function() {
+		return method.apply(recv, arguments);
+	};
+}
+
+
+
+
+function MethodExpr(typ, name, isValue) {
+	return function(recv) {
+		var method = undefined;
+
+		if (typ != undefined) {
+			method = typ.prototype[name];
+		} else {
+			method = recv[name];
+		}
+		if (isValue) {
+			recv = Clone(recv);
+		}
+		return method.apply(recv, Array.prototype.slice.call(arguments, 1));
+	};
+}
+
+
+
+
+
+
+function TypeParam(zero, kind) {
+	this.zero = zero;
+	this.kind = kind;
+}
+
+
+
+function Type(zero, kind) {
+	return new TypeParam(zero, kind);
+}
+
+
+
+TypeParam.prototype.conv = function(n) {
+	var bits = 0;
+	var signed = true;
+
+	switch (this.kind) {
+	case "int": case "int64": case "uint": case "uint64": case "uintptr":
+		return Math.trunc(n); break;
+	case "float32":
+		return Math.fround(n); break;
+	case "int8":
+		bits = 8; break;
+	case "int16":
+		bits = 16; break;
+	case "int32":
+		bits = 32; break;
+	case "uint8":
+		bits = 8; signed = false; break;
+	case "uint16":
+		bits = 16; signed = false; break;
+	case "uint32":
+		bits = 32; signed = false; break;
+	default:
+		return n;
+	}
+
+	var m = Math.pow(2, bits);
+	n = Math.trunc(n) % m;
+	if (n < 0) {
+		n += m;
+	}
+	if (signed && n >= m / 2) {
+		n -= m;
+	}
+	return n;
+};
+
+
+
+
+
+
+
+
+
+
+
+
+function RangeString(s) { var runes = [];
+	for (var i = 0; i < s.length;) {
+		var d = decodeRune(s, i);
+		runes.push(i, d[0]);
+		i += d[1];
+	}
+	return runes;
+}
+
+
+
+
+function decodeRune(s, i) {
+	var c = s.charCodeAt(i);
+	var r = 0xFFFD;
+	var n = 0;
+	var min = 0;
+
+	switch (true) {
+	case c < 0x80:
+		return Array.of(c, 1); break;
+	case c >= 0xC2 && c < 0xE0:
+		r = (c&0x1F); n = 1; min = 0x80; break;
+	case c >= 0xE0 && c < 0xF0:
+		r = (c&0x0F); n = 2; min = 0x800; break;
+	case c >= 0xF0 && c < 0xF5:
+		r = (c&0x07); n = 3; min = 0x10000;
+	}
+
+	var j = 1;
+	for (; j <= n; j++) {
+		if (i + j >= s.length || (s.charCodeAt(i + j)&0xC0) != 0x80) {
+			break;
+		}
+		r = (r<<6|(s.charCodeAt(i + j)&0x3F));
+	}
+	if (j <= n || r < min || r > 0x10FFFF || r >= 0xD800 && r <= 0xDFFF) {
+		return Array.of(0xFFFD, 1);
+	}
+	return Array.of(r, n + 1);
+}
+
+
+function StrLen(s) {
+	return s.length;
+}
+
+
+function StrIndex(s, i) {
+	if (i < 0 || i >= s.length) {
+		throw new Error("runtime error: index out of range [" + i + "] with length " + s.length);
+	}
+	return s.charCodeAt(i);
+}
+
+
+function StrSlice(s, low, high) {
+	if (high == undefined) {
+		high = s.length;
+	}
+	checkSlice(low, high, s.length, "length");
+	return s.substring(low, high);
+}
+
+
+function Bytes(s) {
+	return Slice(0, bytesOf(s));
+}
+
+
+function Runes(s) {
+	return Slice(0, runesOf(s));
+}
+
+
+
+function Text(s) {
+	var t = "";
+
+	for (var i = 0; i < s.length;) {
+		var d = decodeRune(s, i);
+		t += unitsOf(d[0]);
+		i += d[1];
+	}
+	return t;
+}
+
+
+
+function fromText(t) {
+	var s = "";
+	for (var i = 0; i < t.length; i++) {
+		var r = t.codePointAt(i);
+		if (r >= 0x10000) {
+			i++;
+		}
+		s += RuneStr(r);
+	}
+	return s;
+}
+
+
+
+function fromCharCode(unit) {
+	var s = "";
+	return s.constructor.fromCharCode(unit);
+}
+
+
+
+function unitsOf(r) {
+	if (r < 0x10000) {
+		return fromCharCode(r);
+	}
+	r -= 0x10000;
+	return fromCharCode(0xD800 + (r>>10)) + fromCharCode(0xDC00 + ((r&0x3FF)));
+}
+
+
+function RuneStr(r) {
+	if (r < 0 || r > 0x10FFFF || r >= 0xD800 && r <= 0xDFFF) {
+		r = 0xFFFD;
+	}
+
+	switch (true) {
+	case r < 0x80:
+		return fromCharCode(r); break;
+	case r < 0x800:
+		return fromCharCode((0xC0|r>>6)) + fromCharCode((0x80|(r&0x3F))); break;
+	case r < 0x10000:
+		return fromCharCode((0xE0|r>>12)) + fromCharCode((0x80|(r>>6&0x3F))) + fromCharCode((0x80|(r&0x3F)));
+
+	}
+	return fromCharCode((0xF0|r>>18)) + fromCharCode((0x80|(r>>12&0x3F))) + fromCharCode((0x80|(r>>6&0x3F))) + fromCharCode((0x80|(r&0x3F)));
+
+}
+
+
+function runesStr(runes) {
+	var s = "";
+	var r; for (var _ in runes) { r = runes[_];
+		s += RuneStr(r);
+	}
+	return s;
+}
+
+
+function runesOf(s) { var runes = [];
+	for (var i = 0; i < s.length;) {
+		var d = decodeRune(s, i);
+		runes.push(d[0]);
+		i += d[1];
+	}
+	return runes;
+}
+
+
+function bytesOf(s) { var b = [];
+	for (var i = 0; i < s.length; i++) {
+		b.push(s.charCodeAt(i));
+	}
+	return b;
+}
+
+
+function strOf(b) {
+	var s = "";
+	for (var i = 0; i < b.length; i++) {
+		s += fromCharCode((b[i]&0xFF));
+	}
+	return s;
+}
+
+
+function runeLen(r) {
+	switch (true) {
+	case r < 0x80:
+		return 1; break;
+	case r < 0x800:
+		return 2; break;
+	case r < 0x10000:
+		return 3;
+	}
+	return 4;
+}
+
+
+
+
+
+
+
+
+
+function strsSlice(a) {
+	return Slice("", a);
+}
+
+
+function Contains(s, substr) {
+	return s.indexOf(substr) != -1;
+}
+
+
+
+function Index(s, substr) {
+	return s.indexOf(substr);
+}
+
+
+function HasPrefix(s, prefix) {
+	return s.startsWith(prefix);
+}
+
+
+function HasSuffix(s, suffix) {
+	return s.endsWith(suffix);
+}
+
+
+
+function Split(s, sep) {
+	if (sep == "") {
+		return strsSlice(splitRunes(s));
+	}
+	return strsSlice(s.split(sep));
+}
+
+
+
+function splitRunes(s) { var a = [];
+	for (var i = 0; i < s.length;) {
+		var n = decodeRune(s, i)[1];
+		a.push(s.substring(i, i + n));
+		i += n;
+	}
+	return a;
+}
+
+
+
+function Join(elems, sep) {
+	if (elems == undefined) {
+		return "";
+	}
+	return elems.get().join(sep);
+}
+
+
+
+function Fields(s) {
+	return strsSlice(fieldsOf(s));
+}
+
+
+function fieldsOf(s) { var a = [];
+	var start = -1;
+
+	for (var i = 0; i <= s.length;) {
+		var n = 1;
+		if (i < s.length) {
+			var d = decodeRune(s, i);
+			n = d[1];
+			if (!isSpace(d[0])) {
+				if (start == -1) {
+					start = i;
+				}
+				i += n;
+				continue;
+			}
+		}
+		if (start != -1) {
+			a.push(s.substring(start, i));
+			start = -1;
+		}
+		i += n;
+	}
+	return a;
+}
+
+
+
+function TrimSpace(s) {
+	var start = -1, end = 0;
+
+	for (var i = 0; i < s.length;) {
+		var d = decodeRune(s, i);
+		if (!isSpace(d[0])) {
+			if (start == -1) {
+				start = i;
+			}
+			end = i + d[1];
+		}
+		i += d[1];
+	}
+	if (start == -1) {
+		return "";
+	}
+	return s.substring(start, end);
+}
+
+
+
+function isSpace(c) {
+	switch (c) {
+	case 0x09: case 0x0A: case 0x0B: case 0x0C: case 0x0D: case 0x20: case 0x85: case 0xA0: case 0x1680: case 0x2028: case 0x2029: case 0x202F: case 0x205F: case 0x3000:
+
+		return true;
+	}
+	return c >= 0x2000 && c <= 0x200A;
+}
+
+
+
+
+function Replace(s, old, repl, n) {
+	if (old === repl || n == 0) {
+		return s;
+	}
+	var t = "";
+	var start = 0;
+
+	for (var i = 0; n < 0 || i < n; i++) {
+		var j = start;
+		if (old == "") {
+			if (i != 0) {
+				if (start == s.length) {
+					break;
+				}
+				j += decodeRune(s, start)[1];
+			}
+		} else {
+			j = s.indexOf(old, start);
+			if (j == -1) {
+				break;
+			}
+		}
+		t += s.substring(start, j) + repl;
+		start = j + old.length;
+	}
+	return t + s.substring(start);
+}
+
+
+
+function ReplaceAll(s, old, repl) {
+	return Replace(s, old, repl, -1);
+}
+
+
+function Repeat(s, count) {
+	if (count < 0) {
+		throw new Error("strings: negative Repeat count");
+	}
+	return s.repeat(count);
+}
+
+
+function ToUpper(s) {
+	return mapCase(s, true);
+}
+
+
+function ToLower(s) {
+	return mapCase(s, false);
+}
+
+
+
+function mapCase(s, upper) {
+	var t = "";
+	var r; for (var _ in runesOf(s)) { r = runesOf(s)[_];
+		t += RuneStr(caseOf(r, upper));
+	}
+	return t;
+}
+
+
+
+function caseOf(r, upper) {
+	var c = unitsOf(r);
+	var m = c.toLowerCase();
+	if (upper) {
+		m = c.toUpperCase();
+	}
+	var n = 1;
+	if (m.codePointAt(0) >= 0x10000) {
+		n = 2;
+	}
+	if (m.length != n) {
+		return r;
+	}
+	return m.codePointAt(0);
+}
+
+
+
+function EqualFold(s, t) {
+	var a = runesOf(s);
+	var b = runesOf(t);
+	if (a.length != b.length) {
+		return false;
+	}
+	for (var i = 0; i < a.length; i++) {
+		if (a[i] !== b[i] && caseOf(a[i], true) !== caseOf(b[i], true) && caseOf(a[i], false) !== caseOf(b[i], false)) {
+
+			return false;
+		}
+	}
+	return true;
+}
+
+
+
+
+function Builder(s) {
+	this.s = s;
+}
+
+
+Builder.prototype.String = function() { return this.s; };
+
+
+Builder.prototype.Len = function() { return this.s.length; };
+
+
+Builder.prototype.Cap = function() { return this.s.length; };
+
+
+Builder.prototype.Grow = function(n) {
+	if (n < 0) {
+		throw new Error("strings.Builder.Grow: negative count");
+	}
+};
+
+
+Builder.prototype.Reset = function() { this.s = ""; };
+
+
+Builder.prototype.Write = function(p) {
+	this.s += p.str();
+	return [p.len, undefined];
+};
+
+
+Builder.prototype.WriteByte = function(c) {
+	this.s += fromCharCode(c);
+	return undefined;
+};
+
+
+Builder.prototype.WriteRune = function(r) {
+	this.s += RuneStr(r);
+	return [runeLen(r), undefined];
+};
+
+
+Builder.prototype.WriteString = function(s) {
+	this.s += s;
+	return [s.length, undefined];
+};
+
+
+function Replacer(oldnew) {
+	this.oldnew = oldnew;
+}
+
+
+
+
+
+function NewReplacer(oldnew) {
+	if (oldnew.len % 2 == 1) {
+		throw new Error("strings.NewReplacer: odd argument count");
+	}
+	return new Replacer(oldnew.get());
+}
+
+
+Replacer.prototype.Replace = function(s) {
+	var t = "";
+
+	for (var i = 0; i <= s.length;) {
+		var matched = false;
+
+		for (var k = 0; k < this.oldnew.length; k += 2) {
+			var old = this.oldnew[k];
+			if (!s.startsWith(old, i)) {
+				continue;
+			}
+			t += this.oldnew[k + 1];
+			matched = true;
+
+			if (old != "") {
+				i += old.length;
+				break;
+			}
+
+			if (i == s.length) {
+				i++;
+			} else {
+				var n = decodeRune(s, i)[1];
+				t += s.substring(i, i + n);
+				i += n;
+			}
+			break;
+		}
+		if (!matched) {
+			if (i < s.length) {
+				t += s.charAt(i);
+			}
+			i++;
+		}
+	}
+	return t;
+};
+
+
+
+
+
+
+
+
+
+
+
+function ValueType(v, t, k) {
+	this.v = v;
+	this.t = t;
+	this.k = k;
+}
+
+
+function Typed(v, t, k) {
+	return new ValueType(v, t, k);
+}
+
+
+function fmtFlags(plus, minus, sharp, space, zero, plusV, sharpV, wid, prec) {
+	this.plus = plus;
+	this.minus = minus;
+	this.sharp = sharp;
+	this.space = space;
+	this.zero = zero;
+	this.plusV = plusV;
+	this.sharpV = sharpV;
+	this.wid = wid;
+	this.prec = prec;
+}
+
+
+function newFlags() {
+	return new fmtFlags(false, false, false, false, false, false, false, -1, -1);
+}
+
+
+
+function Sprint(a, ln) {
+	var s = "";
+
+	var v; for (var i in a) { v = a[i];
+		if (i != 0) {
+
+			if (ln || !isStr(a[i - 1]) && !isStr(v)) {
+				s += " ";
+			}
+		}
+		s += fmtArg(v, "v", newFlags(), 0);
+	}
+	return s;
+}
+
+
+
+function Sprintf(format, a) {
+	var s = "";
+	var p = new fmtParser(format, 0, 0, 0, false, false, a);
+
+	for (; p.i < format.length; p.i++) {
+		var c = format.charAt(p.i);
+		if (c != "%") {
+			s += c;
+			continue;
+		}
+		var f = newFlags();
+		p.badIndex = false;
+
+		for (p.i++; p.i < format.length; p.i++) {
+			c = format.charAt(p.i);
+			if (c == "+") {
+				f.plus = true;
+			} else if (c == "-") {
+				f.minus = true;
+			} else if (c == "#") {
+				f.sharp = true;
+			} else if (c == " ") {
+				f.space = true;
+			} else if (c == "0") {
+				f.zero = true;
+			} else {
+				break;
+			}
+		}
+
+
+		p.index();
+		if (format.charAt(p.i) == "*") {
+			p.i++;
+			if (p.intArg()) {
+				f.wid = p.n;
+				if (f.wid < 0) {
+					f.minus = true;
+					f.wid = -f.wid;
+				}
+			} else {
+				s += "%!(BADWIDTH)";
+			}
+		} else if (p.number()) {
+			f.wid = p.n;
+		}
+
+
+		if (format.charAt(p.i) == ".") {
+			p.i++;
+			p.index();
+			if (format.charAt(p.i) == "*") {
+				p.i++;
+				if (p.intArg()) {
+					f.prec = p.n;
+					if (f.prec < 0) {
+						f.prec = -1;
+					}
+				} else {
+					s += "%!(BADPREC)";
+				}
+			} else if (p.number()) {
+				f.prec = p.n;
+			} else {
+				f.prec = 0;
+			}
+		}
+		p.index();
+
+		if (p.i >= format.length) {
+			s += "%!(NOVERB)";
+			break;
+		}
+		var d = decodeRune(format, p.i);
+		var verb = format.substring(p.i, p.i + d[1]);
+		p.i += d[1] - 1;
+
+		switch (true) {
+		case verb == "%":
+			s += "%"; break;
+		case p.badIndex:
+			s += "%!" + verb + "(BADINDEX)"; break;
+		case p.argNum >= a.length:
+			s += "%!" + verb + "(MISSING)"; break;
+		default:
+			if (verb == "v") {
+				var tmp1 = f.sharp; f.plusV = f.plus; f.sharpV = tmp1;
+				f.plus = false; f.sharp = false;
+			}
+			s += fmtArg(a[p.argNum], verb, f, 0);
+			p.argNum++;
+		}
+	}
+
+	if (!p.reordered && p.argNum < a.length) {
+		s += "%!(EXTRA ";
+		for (var i = p.argNum; i < a.length; i++) {
+			if (i > p.argNum) {
+				s += ", ";
+			}
+			if (typedOf(a[i]).v == undefined) {
+				s += "<nil>";
+			} else {
+				s += typeName(a[i]) + "=" + fmtArg(a[i], "v", newFlags(), 0);
+			}
+		}
+		s += ")";
+	}
+	return s;
+}
+
+
+function fmtParser(format, i, n, argNum, reordered, badIndex, a) {
+	this.format = format;
+	this.i = i;
+	this.n = n;
+	this.argNum = argNum;
+	this.reordered = reordered;
+	this.badIndex = badIndex;
+	this.a = a;
+}
+
+
+fmtParser.prototype.index = function() {
+	if (this.format.charAt(this.i) != "[") {
+		return;
+	}
+	this.reordered = true;
+	var end = this.format.indexOf("]", this.i);
+
+	if (end < 0) {
+		this.badIndex = true;
+		this.i++;
+		return;
+	}
+	var n = Number(this.format.slice(this.i + 1, end));
+	if (n >= 1 && n <= this.a.length) {
+		this.argNum = n - 1;
+	} else {
+		this.badIndex = true;
+	}
+	this.i = end + 1;
+};
+
+
+fmtParser.prototype.number = function() {
+	var start = this.i;
+	this.n = 0;
+
+	for (; this.format.charAt(this.i) >= "0" && this.format.charAt(this.i) <= "9"; this.i++) {
+		this.n = this.n * 10 + Number(this.format.charAt(this.i));
+	}
+	return this.i > start;
+};
+
+
+
+fmtParser.prototype.intArg = function() {
+	if (this.argNum >= this.a.length) {
+		return false;
+	}
+	var a = this.a[this.argNum];
+	this.argNum++;
+
+	if (!isInt(a)) {
+		return false;
+	}
+	this.n = typedOf(a).v;
+	return true;
+};
+
+
+
+function Fprint(w, s) {
+	return w.Write(Bytes(s));
+}
+
+
+function consoleWriter(log) {
+	this.log = log;
+}
+
+consoleWriter.prototype.Write = function(b) {
+	this.log(Text(b.str()));
+	return [b.len, undefined];
+};
+
+
+
+var Stdout = new consoleWriter(function(s) { console.log(s); });
+var Stderr = new consoleWriter(function(s) { console.error(s); });
+
+
+
+
+
+
+function typedOf(a) {
+	var t = "", k = "";
+
+	for (; isTyped(a) || isNamed(a);) {
+		if (isNamed(a)) {
+			if (t == "") {
+				t = "main." + fromText(a.constructor.name);
+			}
+			if (k == "" && a.constructor.kind != undefined) {
+				k = a.constructor.kind;
+			}
+			a = a.t;
+			continue;
+		}
+		if (t == "") {
+			t = a.t;
+		}
+		if (k == "" && a.k != undefined) {
+			k = a.k;
+		}
+		a = a.v;
+	}
+	if (t == "" && isPointer(a)) {
+		t = a["$ptr"];
+	}
+	if (k == "" && namedTypes[t] != undefined) {
+		k = namedTypes[t].kind;
+	}
+	if (k == "") {
+		k = t;
+	}
+	return new ValueType(a, t, k);
+}
+
+
+
+
+
+function Named(typ, kind) {
+	typ.named = true;
+	typ.kind = kind;
+	namedTypes["main." + fromText(typ.name)] = typ;
+}
+
+
+
+
+var namedTypes = {};
+
+
+
+function FieldTypes(typ, fields) {
+	typ.fields = fields;
+}
+
+
+function isNamed(a) {
+	return typeof(a) == "object" && a != undefined && a.constructor.named === true;
+}
+
+
+function isTyped(a) {
+	if (typeof(a) != "object" || a == undefined) {
+		return false;
+	}
+	var name = a.constructor.name;
+	return name == "ValueType" || name == "NumType" || name == "StringType" || name == "BoolType";
+
+}
+
+
+function isStr(a) {
+	return typeof(typedOf(a).v) == "string";
+}
+
+
+function isInt(a) {
+	var tv = typedOf(a);
+	return typeof(tv.v) == "number" && !isFloat(tv.v, tv.k);
+}
+
+
+function isFloat(n, t) {
+	switch (t) {
+	case "float32": case "float64":
+		return true; break;
+	case "int": case "int8": case "int16": case "int32": case "int64": case "uint": case "uint8": case "uint16": case "uint32": case "uint64": case "uintptr": case "byte": case "rune":
+
+		return false;
+	}
+	return !isFinite(n) || n % 1 != 0;
+}
+
+
+
+function kindOf(v, t) {
+	switch (v.constructor.name) {
+	case "SliceType":
+		return "slice"; break;
+	case "ArrayType":
+		return "array"; break;
+	case "MapType":
+		return "map"; break;
+	case "Object":
+		if (v.hasOwnProperty("p") && Object.keys(v).length == 1) {
+			return "pointer";
+		}
+	}
+	if (t.charAt(0) == "*") {
+		return "pointer";
+	}
+	return "struct";
+}
+
+
+function hasMethod(v, name) {
+	return typeof(v) == "object" && v != undefined && typeof(v[name]) == "function";
+}
+
+
+function typeName(a) {
+	var tv = typedOf(a);
+	var v = tv.v;
+
+	if (tv.t != "") {
+		return tv.t;
+	}
+	switch (typeof(v)) {
+	case "boolean":
+		return "bool"; break;
+	case "string":
+		return "string"; break;
+	case "number":
+		if (isFloat(v, "")) {
+			return "float64";
+		}
+		return "int"; break;
+	case "function":
+		return "func()"; break;
+	case "object":
+		if (v == undefined) {
+			return "<nil>";
+		}
+		switch (kindOf(v, "")) {
+		case "slice":
+			return "[]interface {}"; break;
+		case "array":
+			return "[" + v.v.length + "]interface {}"; break;
+		case "map":
+			return "map[string]interface {}"; break;
+		case "pointer":
+			return "*" + typeName(v.p);
+		}
+		return "main." + fromText(v.constructor.name);
+	}
+	return "<nil>";
+}
+
+
+
+function elemType(t) {
+	switch (true) {
+	case t.indexOf("[]") == 0:
+		return t.slice(2); break;
+	case t.charAt(0) == "[":
+		return t.slice(t.indexOf("]") + 1); break;
+	case t.charAt(0) == "*":
+		return t.slice(1); break;
+	case t.indexOf("map[") == 0:
+		return t.slice(keyType(t).length + 5);
+	}
+	return "";
+}
+
+
+function keyType(t) {
+	if (t.indexOf("map[") != 0) {
+		return "";
+	}
+	var depth = 0;
+
+	for (var i = 3; i < t.length; i++) {
+		var c = t.charAt(i);
+		if (c == "[") {
+			depth++;
+		} else if (c == "]") {
+			depth--;
+			if (depth == 0) {
+				return t.slice(4, i);
+			}
+		}
+	}
+	return "";
+}
+
+
+function TypedValues(values, types) {
+	var t; for (var i in types) { t = types[i];
+		values[i] = withType(values[i], t);
+	}
+	return values;
+}
+
+
+function withType(v, t) {
+	if (t == "" || isTyped(v)) {
+		return v;
+	}
+	return new ValueType(v, t, "");
+}
+
+
+
+
+
+function fmtArg(a, verb, f, depth) {
+	var tv = typedOf(a);
+	var v = tv.v, t = tv.t, k = tv.k;
+
+	switch (verb) {
+	case "T":
+		return pad(typeName(a), f); break;
+	case "p":
+		if (typeof(v) == "object" && v != undefined || typeof(v) == "function") {
+			return pad("0xc000010000", f);
+		}
+		return badVerb(a, verb);
+	}
+
+	if (v == undefined) {
+		switch (true) {
+		case t.indexOf("[]") == 0 || t.indexOf("map[") == 0:
+			if (f.sharpV) {
+				return t + "(nil)";
+			}
+			if (t.charAt(0) != "[") {
+				return "map[]";
+			}
+
+			var elem = elemType(t);
+			if ((elem == "byte" || elem == "uint8") && (verb == "s" || verb == "q" || verb == "x" || verb == "X")) {
+
+				return fmtString("", verb, f);
+			}
+			return "[]"; break;
+		case verb == "v":
+			if (f.sharpV && t != "") {
+				return "(" + t + ")(nil)";
+			}
+			return pad("<nil>", f);
+		}
+		return badVerb(a, verb);
+	}
+
+
+
+	if (!f.sharpV && (verb == "v" || verb == "s" || verb == "q" || verb == "x" || verb == "X")) {
+
+
+
+		var recv = v;
+		if (isNamed(a)) {
+			recv = a;
+		} else if (namedTypes[t] != undefined && !isNamed(v)) {
+
+			recv = Reflect.construct(namedTypes[t], Array.of(v));
+		}
+		if (hasMethod(recv, "Error")) {
+			return fmtString(recv.Error(), verb, f);
+		}
+		if (hasMethod(recv, "String")) {
+			return fmtString(recv.String(), verb, f);
+		}
+	}
+
+	switch (typeof(v)) {
+	case "boolean":
+		if (verb == "t" || verb == "v") {
+			return pad("" + v, f);
+		}
+		return badVerb(a, verb); break;
+	case "number":
+		if (isFloat(v, k)) {
+			return fmtFloat(a, v, k, verb, f);
+		}
+		return fmtInteger(a, v, verb, f); break;
+	case "string":
+		return fmtString(v, verb, f); break;
+	case "function":
+		if (f.sharpV) {
+			return "(" + t + ")(0xc000010000)";
+		}
+		return pad("0xc000010000", f);
+	}
+
+
+	if (Array.isArray(v)) {
+		return fmtList(v, t, verb, f, depth);
+	}
+	switch (kindOf(v, t)) {
+	case "slice":
+		if (v.isNil() && f.sharpV) {
+			return t + "(nil)";
+		}
+		return fmtList(v.get(), t, verb, f, depth); break;
+	case "array":
+		return fmtList(v.v, t, verb, f, depth); break;
+	case "map":
+		return fmtMap(v, t, verb, f, depth); break;
+	case "pointer":
+		var ptr = v;
+		if (v.constructor.name == "Object" && v.hasOwnProperty("p")) {
+			ptr = v.p;
+		}
+		if (depth == 0 && typeof(ptr) == "object" && ptr != undefined) {
+			return "&" + fmtArg(withType(ptr, elemType(t)), verb, f, depth + 1);
+		}
+		if (f.sharpV) {
+			return "(" + typeName(a) + ")(0xc000010000)";
+		}
+		return pad("0xc000010000", f);
+	}
+	return fmtStruct(v, t, verb, f, depth);
+}
+
+
+function badVerb(a, verb) {
+	if (typedOf(a).v == undefined) {
+		return "%!" + verb + "(<nil>)";
+	}
+	return "%!" + verb + "(" + typeName(a) + "=" + fmtArg(a, "v", newFlags(), 0) + ")";
+}
+
+
+function pad(s, f) {
+	var n = f.wid - runesOf(s).length;
+	if (n <= 0) {
+		return s;
+	}
+	if (f.minus) {
+		return s + repeat(" ", n);
+	}
+	if (f.zero) {
+		return repeat("0", n) + s;
+	}
+	return repeat(" ", n) + s;
+}
+
+
+
+function padNumber(sign, prefix, s, f, zero) {
+	var n = f.wid - sign.length - prefix.length - s.length;
+	if (zero && !f.minus && n > 0) {
+		return sign + prefix + repeat("0", n) + s;
+	}
+	return pad(sign + prefix + s, new fmtFlags(false, f.minus, false, false, false,
+		false, false, f.wid, -1));
+}
+
+
+function repeat(s, n) {
+	var r = "";
+	for (var i = 0; i < n; i++) {
+		r += s;
+	}
+	return r;
+}
+
+
+function fmtInteger(a, n, verb, f) {
+	var digits = "";
+	var prefix = "";
+	var abs = Math.abs(n);
+
+	switch (verb) {
+	case "v": case "d":
+		digits = abs.toString(10); break;
+	case "b":
+		digits = abs.toString(2);
+		if (f.sharp) {
+			prefix = "0b";
+		} break;
+	case "o": case "O":
+		digits = abs.toString(8);
+		if (verb == "O") {
+			prefix = "0o";
+		} else if (f.sharp) {
+			prefix = "0";
+		} break;
+	case "x":
+		digits = abs.toString(16);
+		if (f.sharp) {
+			prefix = "0x";
+		} break;
+	case "X":
+		digits = abs.toString(16).toUpperCase();
+		if (f.sharp) {
+			prefix = "0X";
+		} break;
+	case "c":
+		return pad(RuneStr(n), f); break;
+	case "q":
+		return pad(quoteRune(n), f); break;
+	case "U":
+		var s = abs.toString(16).toUpperCase();
+		if (s.length < 4) {
+			s = repeat("0", 4 - s.length) + s;
+		}
+		s = "U+" + s;
+		if (f.sharp && n <= 0x10FFFF && isPrint(n)) {
+			s += " '" + RuneStr(n) + "'";
+		}
+		return pad(s, f); break;
+	default:
+		return badVerb(a, verb);
+	}
+
+	if (f.prec >= 0) {
+		if (f.prec == 0 && n == 0) {
+			digits = "";
+		} else if (digits.length < f.prec) {
+			digits = repeat("0", f.prec - digits.length) + digits;
+		}
+	}
+	if (prefix == "0" && digits.charAt(0) == "0") {
+		prefix = "";
+	}
+
+	var sign = "";
+	if (n < 0) {
+		sign = "-";
+	} else if (f.plus) {
+		sign = "+";
+	} else if (f.space) {
+		sign = " ";
+	}
+	return padNumber(sign, prefix, digits, f, f.zero && f.prec < 0);
+}
+
+
+
+
+
+
+
+
+function decimal(d, dp) {
+	this.d = d;
+	this.dp = dp;
+}
+
+
+
+function toDecimal(n, shortest, size) {
+	if (n == 0) {
+		return new decimal("", 0);
+	}
+	var s = n.toExponential(100);
+
+	if (shortest) {
+		s = n.toExponential();
+		if (size == 32) {
+			for (var p = 0; p < 9; p++) {
+				if (Math.fround(parseFloat(n.toExponential(p))) === Math.fround(n)) {
+					s = n.toExponential(p);
+					break;
+				}
+			}
+		}
+	}
+	var e = s.indexOf("e");
+	var d = s.charAt(0) + s.slice(2, e);
+
+	for (; d.length > 1 && d.charAt(d.length - 1) == "0";) {
+		d = d.slice(0, d.length - 1);
+	}
+	return new decimal(d, parseInt(s.slice(e + 1), 10) + 1);
+}
+
+
+decimal.prototype.round = function(nd) {
+	if (nd < 0 || nd >= this.d.length) {
+		if (nd < 0) {
+			this.d = ""; this.dp = 0;
+		}
+		return;
+	}
+	var up = this.d.charAt(nd) > "5";
+
+	if (this.d.charAt(nd) == "5") {
+		if (nd + 1 < this.d.length) {
+			up = true;
+		} else {
+			up = nd > 0 && parseInt(this.d.charAt(nd - 1), 10) % 2 == 1;
+		}
+	}
+	var digits = this.d.slice(0, nd);
+
+	if (up) {
+		var i = nd - 1;
+		for (; i >= 0 && digits.charAt(i) == "9"; i--) {
+		}
+		if (i < 0) {
+			digits = "1";
+			this.dp++;
+		} else {
+			digits = digits.slice(0, i) + (parseInt(digits.charAt(i), 10) + 1);
+		}
+	}
+
+	for (; digits.length > 0 && digits.charAt(digits.length - 1) == "0";) {
+		digits = digits.slice(0, digits.length - 1);
+	}
+	this.d = digits;
+	if (this.d == "") {
+		this.dp = 0;
+	}
+};
+
+
+decimal.prototype.digit = function(i) {
+	if (i >= 0 && i < this.d.length) {
+		return this.d.charAt(i);
+	}
+	return "0";
+};
+
+
+
+decimal.prototype.fmtE = function(prec, verb) {
+	var s = this.digit(0);
+	if (prec > 0) {
+		s += ".";
+		for (var i = 1; i <= prec; i++) {
+			s += this.digit(i);
+		}
+	}
+
+	var exp = 0;
+	if (this.d != "") {
+		exp = this.dp - 1;
+	}
+	var sign = "+";
+	if (exp < 0) {
+		sign = "-";
+		exp = -exp;
+	}
+	if (exp < 10) {
+		return s + verb + sign + "0" + exp;
+	}
+	return s + verb + sign + exp;
+};
+
+
+
+decimal.prototype.fmtF = function(prec) {
+	var s = "0";
+	if (this.dp > 0) {
+		s = "";
+		for (var i = 0; i < this.dp; i++) {
+			s += this.digit(i);
+		}
+	}
+	if (prec > 0) {
+		s += ".";
+		for (var i = 0; i < prec; i++) {
+			s += this.digit(this.dp + i);
+		}
+	}
+	return s;
+};
+
+
+function fmtFloat(a, n, t, verb, f) {
+	var size = 64;
+	if (t == "float32") {
+		size = 32;
+	}
+	var prec = f.prec;
+	var num = "";
+
+	if (verb == "v") {
+		verb = "g";
+	}
+
+	if (isNaN(n)) {
+		num = "NaN";
+	} else if (!isFinite(n)) {
+		num = "Inf";
+	} else {
+		switch (verb) {
+		case "e": case "E":
+			if (prec < 0) {
+				prec = 6;
+			}
+			var d = toDecimal(Math.abs(n), false, size);
+			d.round(prec + 1);
+			num = d.fmtE(prec, verb); break;
+
+		case "f": case "F":
+			if (prec < 0) {
+				prec = 6;
+			}
+			var d = toDecimal(Math.abs(n), false, size);
+			d.round(d.dp + prec);
+			num = d.fmtF(prec); break;
+
+		case "g": case "G":
+			var shortest = prec < 0;
+			var d = toDecimal(Math.abs(n), shortest, size);
+			if (shortest) {
+				prec = d.d.length;
+			} else {
+				if (prec == 0) {
+					prec = 1;
+				}
+				d.round(prec);
+			}
+			var nd = d.d.length;
+
+			var eprec = prec;
+			if (eprec > nd && nd >= d.dp) {
+				eprec = nd;
+			}
+			if (shortest) {
+				eprec = 6;
+			}
+			var exp = d.dp - 1;
+			if (nd == 0) {
+				exp = 0;
+			}
+
+			if (exp < -4 || exp >= eprec) {
+				if (prec > nd) {
+					prec = nd;
+				}
+				var e = "e";
+				if (verb == "G") {
+					e = "E";
+				}
+				num = d.fmtE(prec - 1, e);
+			} else {
+				if (prec > d.dp) {
+					prec = nd;
+				}
+				num = d.fmtF(Math.max(prec - d.dp, 0));
+			} break;
+
+		default:
+			return badVerb(a, verb);
+		}
+	}
+
+
+
+	if (f.sharp && isFinite(n)) {
+		var digits = 0;
+		if (verb == "g" || verb == "G") {
+			digits = f.prec;
+			if (digits < 0) {
+				digits = 6;
+			}
+		}
+		var tail = "";
+		var e = num.search("[eE]"); if (e >= 0) {
+			tail = num.slice(e);
+			num = num.slice(0, e);
+		}
+		var nonzero = false;
+
+		for (var i = 0; i < num.length; i++) {
+			var c = num.charAt(i);
+			if (c != "." && c != "0") {
+				nonzero = true;
+			}
+			if (c != "." && nonzero) {
+				digits--;
+			}
+		}
+		if (num.indexOf(".") < 0) {
+			if (num == "0") {
+				digits--;
+			}
+			num += ".";
+		}
+		num += repeat("0", digits) + tail;
+	}
+
+	var sign = "";
+	switch (true) {
+	case n < 0 || n == 0 && 1 / n < 0:
+		sign = "-"; break;
+	case f.plus:
+		sign = "+"; break;
+	case f.space:
+		sign = " "; break;
+	case !isFinite(n) && !isNaN(n):
+		sign = "+";
+	}
+	return padNumber(sign, "", num, f, f.zero && isFinite(n));
+}
+
+
+
+
+function fmtString(s, verb, f) {
+	switch (verb) {
+	case "v":
+		if (f.sharpV) {
+			return pad(quote(s, false), f);
+		}
+
+	case "s":
+		if (f.prec >= 0) {
+			s = truncate(s, f.prec);
+		}
+		return pad(s, f); break;
+	case "q":
+		if (f.prec >= 0) {
+			s = truncate(s, f.prec);
+		}
+		if (f.sharp && canBackquote(s)) {
+			return pad("`" + s + "`", f);
+		}
+		return pad(quote(s, f.plus), f); break;
+	case "x": case "X":
+		var b = bytesOf(s);
+		if (f.prec >= 0) {
+			b = b.slice(0, f.prec);
+		}
+		return pad(fmtHex(b, verb, f), f);
+	}
+	return badVerb(s, verb);
+}
+
+
+function truncate(s, n) {
+	var i = 0;
+	for (; i < s.length && n > 0; n--) {
+		i += decodeRune(s, i)[1];
+	}
+	return s.substring(0, i);
+}
+
+
+function fmtHex(b, verb, f) {
+	var s = "";
+
+	var c; for (var i in b) { c = b[i];
+		if (i > 0 && f.space) {
+			s += " ";
+		}
+		if (f.sharp && (i == 0 || f.space)) {
+			s += "0" + verb;
+		}
+		var h = c.toString(16);
+		if (h.length == 1) {
+			h = "0" + h;
+		}
+		if (verb == "X") {
+			h = h.toUpperCase();
+		}
+		s += h;
+	}
+	return s;
+}
+
+
+
+function quote(s, ascii) {
+	var q = "\"";
+
+	for (var i = 0; i < s.length;) {
+		var d = decodeRune(s, i);
+		switch (true) {
+		case d[0] == 0xFFFD && d[1] == 1:
+			q += "\\x" + s.charCodeAt(i).toString(16); break;
+		case d[0] == 0x22:
+			q += "\\\""; break;
+		default:
+			q += escapeRune(d[0], ascii);
+		}
+		i += d[1];
+	}
+	return q + "\"";
+}
+
+
+function quoteRune(r) {
+	if (r < 0 || r > 0x10FFFF || r >= 0xD800 && r <= 0xDFFF) {
+		r = 0xFFFD;
+	}
+	if (r == 0x27) {
+		return "'\\''";
+	}
+	return "'" + escapeRune(r, false) + "'";
+}
+
+
+function escapeRune(r, ascii) {
+	switch (r) {
+	case 0x5C:
+		return "\\\\"; break;
+	case 7:
+		return "\\a"; break;
+	case 8:
+		return "\\b"; break;
+	case 12:
+		return "\\f"; break;
+	case 10:
+		return "\\n"; break;
+	case 13:
+		return "\\r"; break;
+	case 9:
+		return "\\t"; break;
+	case 11:
+		return "\\v";
+	}
+
+	if (r < 0x80 && isPrint(r) || r >= 0x80 && !ascii && isPrint(r)) {
+		return RuneStr(r);
+	}
+	var h = r.toString(16);
+
+	switch (true) {
+	case r < 0x80:
+		return "\\x" + repeat("0", 2 - h.length) + h; break;
+	case r < 0x10000:
+		return "\\u" + repeat("0", 4 - h.length) + h;
+	}
+	return "\\U" + repeat("0", 8 - h.length) + h;
+}
+
+
+
+function isPrint(r) {
+	return !(r < 0x20 || r >= 0x7F && r <= 0xA0 || r == 0xAD || r >= 0x200B && r <= 0x200F || r >= 0x2028 && r <= 0x202E || r >= 0x2060 && r <= 0x206F || r == 0xFEFF || r >= 0xFFF9 && r <= 0xFFFB);
+
+
+}
+
+
+
+function canBackquote(s) {
+	for (var i = 0; i < s.length;) {
+		var d = decodeRune(s, i);
+		var r = d[0];
+		if (r == 0x60 || r == 0xFEFF || r < 0x20 && r != 9 || r == 0x7F || r == 0xFFFD && d[1] == 1) {
+
+			return false;
+		}
+		i += d[1];
+	}
+	return true;
+}
+
+
+
+
+function fmtList(list, t, verb, f, depth) {
+	var elem = elemType(t);
+
+
+	if ((elem == "byte" || elem == "uint8") && (verb == "s" || verb == "q" || verb == "x" || verb == "X")) {
+
+		if (verb == "x" || verb == "X") {
+			var b = list;
+			if (f.prec >= 0) {
+				b = b.slice(0, f.prec);
+			}
+			return pad(fmtHex(b, verb, f), f);
+		}
+		return fmtString(strOf(list), verb, f);
+	}
+
+	var s = "";
+	if (f.sharpV) {
+		s = t + "{";
+		if (t == "") {
+			s = "[]interface {}{";
+		}
+	} else {
+		s = "[";
+	}
+
+	var v; for (var i in list) { v = list[i];
+		if (i > 0) {
+			if (f.sharpV) {
+				s += ", ";
+			} else {
+				s += " ";
+			}
+		}
+		s += fmtArg(withType(v, elem), verb, f, depth + 1);
+	}
+
+	if (f.sharpV) {
+		return s + "}";
+	}
+	return s + "]";
+}
+
+
+function fmtMap(m, t, verb, f, depth) {
+	if (m.isNil() && f.sharpV) {
+		return t + "(nil)";
+	}
+	var key = keyType(t);
+	var elem = elemType(t);
+
+	var pairs = Array.from(m.entries());
+	pairs.sort(function(a, b) { return compareKeys(a[0], b[0]); });
+
+	var s = "map[";
+	if (f.sharpV) {
+		s = t + "{";
+	}
+
+	var e; for (var i in pairs) { e = pairs[i];
+		if (i > 0) {
+			if (f.sharpV) {
+				s += ", ";
+			} else {
+				s += " ";
+			}
+		}
+		s += fmtArg(withType(e[0], key), verb, f, depth + 1) + ":" + fmtArg(withType(e[1], elem), verb, f, depth + 1);
+
+	}
+
+	if (f.sharpV) {
+		return s + "}";
+	}
+	return s + "]";
+}
+
+
+function fmtStruct(v, t, verb, f, depth) {
+	var s = "{";
+	if (f.sharpV) {
+		if (t == "") {
+			t = "main." + fromText(v.constructor.name);
+		}
+		s = t + "{";
+	}
+	var i = 0;
+	var fields = v.constructor.fields;
+
+	var _; for (var k in v) { _ = v[k];
+
+		if (!v.hasOwnProperty(k) || k.charAt(0) == "$") {
+			continue;
+		}
+		if (i > 0) {
+			if (f.sharpV) {
+				s += ", ";
+			} else {
+				s += " ";
+			}
+		}
+		if (f.plusV || f.sharpV) {
+			s += fromText(k) + ":";
+		}
+		if (fields != undefined && fields[k] != undefined) {
+			s += fmtArg(withType(v[k], fields[k]), verb, f, depth + 1);
+		} else {
+			s += fmtArg(v[k], verb, f, depth + 1);
+		}
+		i++;
+	}
+	return s + "}";
+}
+
+
+
+
+
+
+
+function Clone(v, desc) {
+	if (typeof(v) != "object" || v == undefined || typeof(desc) == "number") {
+		return v;
+	}
+	if (Array.isArray(v)) {
+		if (desc == undefined) {
+			return v.slice();
+		}
+		return v["map"](function(e) { return Clone(e, desc[0]); });
+	}
+	var c = Object.create(Object.getPrototypeOf(v));
+
+	var _; for (var k in v) { _ = v[k];
+		if (v.hasOwnProperty(k)) {
+			c[k] = v[k];
+		}
+	}
+	if (desc == undefined) {
+		return c;
+	}
+
+	if (Array.isArray(desc)) {
+		c.v = Clone(v.v, desc);
+	} else {
+		var _; for (var k in desc) { _ = desc[k];
+			c[k] = Clone(v[k], desc[k]);
+		}
+	}
+	return c;
+}
+
+
+function Export(pkg, exported) {
+	var v; for (var _ in exported) { v = exported[_];
+		pkg.v = v;
+	}
+}
+
+g.BoolType = BoolType;
+g.Bool = Bool;
+g.StringType = StringType;
+g.String = String;
+g.NumType = NumType;
+g.Uint = Uint;
+g.Uint8 = Uint8;
+g.Uint16 = Uint16;
+g.Uint32 = Uint32;
+g.Int = Int;
+g.Int8 = Int8;
+g.Int16 = Int16;
+g.Int32 = Int32;
+g.Float32 = Float32;
 g.Float64 = Float64;
 g.Byte = Byte;
 g.Rune = Rune;
 g.ArrayType = ArrayType;
 g.MkArray = MkArray;
+g.Row = Row;
 g.SliceType = SliceType;
 g.MkSlice = MkSlice;
 g.Slice = Slice;
+g.SliceArgs = SliceArgs;
+g.TupleArgs = TupleArgs;
 g.SliceFrom = SliceFrom;
 g.Append = Append;
 g.Copy = Copy;
 g.MapType = MapType;
-g.Map = Map;
+g.MkMap = MkMap;
+g.RefType = RefType;
+g.Ref = Ref;
+g.Store = Store;
+g.Pointer = Pointer;
+g.Equal = Equal;
+g.MethodVal = MethodVal;
+g.MethodExpr = MethodExpr;
+g.TypeParam = TypeParam;
+g.Type = Type;
+g.RangeString = RangeString;
+g.StrLen = StrLen;
+g.StrIndex = StrIndex;
+g.StrSlice = StrSlice;
+g.Bytes = Bytes;
+g.Runes = Runes;
+g.Text = Text;
+g.RuneStr = RuneStr;
+g.Contains = Contains;
+g.Index = Index;
+g.HasPrefix = HasPrefix;
+g.HasSuffix = HasSuffix;
+g.Split = Split;
+g.Join = Join;
+g.Fields = Fields;
+g.TrimSpace = TrimSpace;
+g.Replace = Replace;
+g.ReplaceAll = ReplaceAll;
+g.Repeat = Repeat;
+g.ToUpper = ToUpper;
+g.ToLower = ToLower;
+g.EqualFold = EqualFold;
+g.Builder = Builder;
+g.Replacer = Replacer;
+g.NewReplacer = NewReplacer;
+g.ValueType = ValueType;
+g.Typed = Typed;
+g.Sprint = Sprint;
+g.Sprintf = Sprintf;
+g.Fprint = Fprint;
+g.Stdout = Stdout;
+g.Stderr = Stderr;
+g.Named = Named;
+g.FieldTypes = FieldTypes;
+g.TypedValues = TypedValues;
+g.Clone = Clone;
 g.Export = Export;
-
 })();
 /* Generated by Go2js (github.com/kless/go2js) */
@@ -9,69 +9,15 @@ package main
 import (
 	"fmt"
 	"go/ast"
-	"regexp"
+	"go/token"
+	"go/types"
 	"strings"
 )
 
 // JavaScript library name.
 const LIB_RESERVED_NAME = "g"
 
-// Reserved Words in JavaScript.
-//
-// https://developer.mozilla.org/en/JavaScript/Reference/Reserved_Words
-// http://golang.org/ref/spec#Keywords
-var reserved = map[string]struct{}{
-	/* These ones are reserved too in Go.
-	"break":     void,
-	"case":      void,
-	"const":     void,
-	"continue":  void,
-	"default":   void,
-	"delete":    void,
-	"else":      void,
-	"false":     void,
-	"for":       void,
-	"if":        void,
-	"import":    void,
-	"interface": void,
-	"package":   void,
-	"return":    void,
-	"switch":    void,
-	"true":      void,
-	"var":       void,*/
-
-	"catch":      void,
-	"debugger":   void,
-	"do":         void,
-	"finally":    void,
-	"function":   void,
-	"in":         void,
-	"instanceof": void,
-	"new":        void,
-	"this":       void,
-	"throw":      void,
-	"try":        void,
-	"typeof":     void,
-	"void":       void,
-	"while":      void,
-	"with":       void,
-
-	"class":      void,
-	"enum":       void,
-	"export":     void,
-	"extends":    void,
-	"implements": void,
-	"let":        void,
-	"null":       void,
-	"private":    void,
-	"protected":  void,
-	"public":     void,
-	"static":     void,
-	"super":      void,
-	"yield":      void,
-}
-
-var validImport = []string{"fmt", "math", "rand"}
+var validImport = []string{"fmt", "math", "rand", "strings"}
 
 // Constants to translate.
 var Constant = map[string]string{
@@ -82,6 +28,9 @@ var Constant = map[string]string{
 	"math.Log10E": "Math.LOG10E",
 	"math.Pi":     "Math.PI",
 	"math.Sqrt2":  "Math.SQRT2",
+
+	"os.Stderr": "g.Stderr",
+	"os.Stdout": "g.Stdout",
 }
 
 // Functions that can be translated since JavaScript has an equivalent one.
@@ -91,8 +40,13 @@ var Function = map[string]string{
 	"fmt.Print":   "console.log",
 	"fmt.Println": "console.log",
 	"fmt.Printf":  "console.log",
-	"fmt.Sprint":  "",
-	"fmt.Sprintf": "",
+	// The functions without name are translated by "GetArgs".
+	"fmt.Fprint":   "",
+	"fmt.Fprintln": "",
+	"fmt.Fprintf":  "",
+	"fmt.Sprint":   "",
+	"fmt.Sprintln": "",
+	"fmt.Sprintf":  "",
 
 	"math.Abs":   "Math.abs",
 	"math.Acos":  "Math.acos",
@@ -115,6 +69,29 @@ var Function = map[string]string{
 
 	"rand.Float32": "Math.random",
 	"rand.Float64": "Math.random",
+
+	// The indexes of the strings are in bytes, and the results are slices.
+	"strings.Contains":    "g.Contains",
+	"strings.EqualFold":   "g.EqualFold",
+	"strings.Fields":      "g.Fields",
+	"strings.HasPrefix":   "g.HasPrefix",
+	"strings.HasSuffix":   "g.HasSuffix",
+	"strings.Index":       "g.Index",
+	"strings.Join":        "g.Join",
+	"strings.NewReplacer": "g.NewReplacer",
+	"strings.Repeat":      "g.Repeat",
+	"strings.Replace":     "g.Replace",
+	"strings.ReplaceAll":  "g.ReplaceAll",
+	"strings.Split":       "g.Split",
+	"strings.ToLower":     "g.ToLower",
+	"strings.ToUpper":     "g.ToUpper",
+	"strings.TrimSpace":   "g.TrimSpace",
+}
+
+// Zero values of the types of packages which are implemented by the JS library.
+var Type = map[string]string{
+	"strings.Builder":  `new g.Builder("")`,
+	"strings.Replacer": "new g.Replacer([])",
 }
 
 var Char = map[int]string{'\n': "\\n", '\t': "\\t"}
@@ -147,7 +124,8 @@ func (tr *translation) getImport(spec []ast.Spec) {
 				}
 			}
 
-			if !found {
+			// The package "os" is only used by its writers.
+			if !found && !(path == "os" && tr.usesStdWriters()) {
 				tr.addError("%s: import from core library", path)
 				continue
 			}
@@ -158,48 +136,84 @@ func (tr *translation) getImport(spec []ast.Spec) {
 	}
 }
 
+// usesStdWriters reports whether the file uses the writers "os.Stdout" or
+// "os.Stderr", and no other name of the package "os".
+func (tr *translation) usesStdWriters() bool {
+	used := false
+
+	for _, obj := range tr.info.Uses {
+		if obj.Pkg() == nil || obj.Pkg().Path() != "os" {
+			continue
+		}
+		if obj.Name() != "Stdout" && obj.Name() != "Stderr" {
+			return false
+		}
+		used = true
+	}
+	return used
+}
+
 // GetArgs returns the arguments of a Go function, formatted for JS.
-func (tr *translation) GetArgs(funcName string, args []ast.Expr) string {
+func (tr *translation) GetArgs(funcName string, call *ast.CallExpr) string {
 	var jsArgs string
+	args := call.Args
+	isSlice := call.Ellipsis != token.NoPos // the values are into a slice
 
 	switch funcName {
-	case "print", "fmt.Print", "fmt.Sprint":
+	case "print":
 		jsArgs = tr.joinArgsPrint(args, false)
-	case "println", "fmt.Println":
+	case "println":
 		jsArgs = tr.joinArgsPrint(args, true)
+
+	// The values are formatted by the JS library.
+	case "fmt.Print", "fmt.Sprint":
+		jsArgs = tr.fmtSprint(args, isSlice, false)
+	case "fmt.Println", "fmt.Sprintln":
+		jsArgs = tr.fmtSprint(args, isSlice, true)
 	case "fmt.Printf", "fmt.Sprintf":
-		jsArgs = tr.joinArgsPrintf(args)
-	default:
-		for i, v := range args {
-			if i != 0 {
-				jsArgs += "," + SP
-			}
-			jsArgs += tr.getExpression(v).String()
+		jsArgs = tr.fmtSprintf(args, isSlice)
+
+	case "fmt.Fprint", "fmt.Fprintln", "fmt.Fprintf":
+		writer := tr.getExpression(args[0]).String()
+
+		switch funcName {
+		case "fmt.Fprint":
+			jsArgs = tr.fmtSprint(args[1:], isSlice, false)
+		case "fmt.Fprintln":
+			jsArgs = tr.fmtSprint(args[1:], isSlice, true)
+		default:
+			jsArgs = tr.fmtSprintf(args[1:], isSlice)
 		}
+		jsArgs = fmt.Sprintf("g.Fprint(%s, %s)", writer, jsArgs)
+
+	default:
+		jsArgs = tr.getArgsCall(call)
 	}
 
+	// The strings are written out of the program like strings of JavaScript.
+	switch funcName {
+	case "print", "println", "fmt.Print", "fmt.Println", "fmt.Printf":
+		jsArgs = "g.Text(" + jsArgs + ")"
+	}
 	return jsArgs
 }
 
 // == Utility
 //
 
-// validIdent checks if the name is a reserved word in JavaScript, returning a
-// safe name adding "_" at the end of the name.
-// It checks also if the name is the JavaScript library.
-func validIdent(name interface{}) string {
-	name_ := fmt.Sprintf("%s", name)
-
-	if Bootstrap {
-		return name_
+// libZero returns the zero value of a type of other package which is
+// implemented by the JS library.
+func (tr *translation) libZero(typ types.Type) (string, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return "", false
 	}
-	if _, ok := reserved[name_]; ok {
-		return name_ + "_"
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg() == tr.pkg {
+		return "", false
 	}
-	if name_ == LIB_RESERVED_NAME {
-		return name_ + "_"
-	}
-	return name_
+	zero, ok := Type[obj.Pkg().Name()+"."+obj.Name()]
+	return zero, ok
 }
 
 // joinArgsPrint returns arguments of Print, Println.
@@ -212,7 +226,7 @@ func (tr *translation) joinArgsPrint(args []ast.Expr, addLine bool) string {
 		if strings.HasSuffix(s, "\"") {
 			s = s[:len(s)-1] + char + "\""
 		} else {
-			s += SP + "+" + SP + "\"" + char + "\""
+			s += " + \"" + char + "\""
 		}
 		return s
 	}
@@ -221,7 +235,7 @@ func (tr *translation) joinArgsPrint(args []ast.Expr, addLine bool) string {
 		expr := tr.getExpression(v).String()
 
 		if i != 0 {
-			jsArgs += SP + "+" + SP + expr
+			jsArgs += " + " + expr
 		} else {
 			jsArgs = expr
 		}
@@ -238,44 +252,181 @@ func (tr *translation) joinArgsPrint(args []ast.Expr, addLine bool) string {
 	return jsArgs
 }
 
-// Matches verbs for "fmt.Printf"
-// http://golang.org/pkg/fmt/
-var (
-	reVerb      = regexp.MustCompile(`%[+\-# 0]?[bcdefgopqstvxEGTUX]`)
-	reVerbWidth = regexp.MustCompile(`%[0-9]+[.]?[0-9]*[bcdefgoqxEGUXsqxX]`)
-)
-
-// joinArgsPrintf returns arguments of Printf.
-func (tr *translation) joinArgsPrintf(args []ast.Expr) string {
-	result := ""
+// fmtSprint returns the call to the JS function which formats the values like
+// "fmt.Sprint", or like "fmt.Sprintln" if "addLine".
+func (tr *translation) fmtSprint(args []ast.Expr, isSlice, addLine bool) string {
+	jsArgs := fmt.Sprintf("g.Sprint(%s, %t)", tr.fmtArgs(args, isSlice), addLine)
 
-	// == Format
-	format := tr.getExpression(args[0]).String()
+	if addLine {
+		jsArgs += " + \"" + Char['\n'] + "\""
+	}
+	return jsArgs
+}
 
-	format = strings.Replace(format, "%%", "%", -1) // literal percent sign
-	format = reVerb.ReplaceAllString(format, VERB)
+// fmtSprintf returns the call to the JS function which formats the values
+// according to a format, like "fmt.Sprintf".
+func (tr *translation) fmtSprintf(args []ast.Expr, isSlice bool) string {
+	return fmt.Sprintf("g.Sprintf(%s, %s)", tr.getExpression(args[0]),
+		tr.fmtArgs(args[1:], isSlice))
+}
 
-	if reVerbWidth.MatchString(format) {
-		format = reVerbWidth.ReplaceAllString(format, VERB)
+// fmtArgs returns the array of values to format. The values are passed with
+// their type when it can not be known from JavaScript.
+func (tr *translation) fmtArgs(args []ast.Expr, isSlice bool) string {
+	// The values are into a slice.
+	if isSlice {
+		isFunc := tr.isFunc
+		tr.isFunc = true // to get the slice
+		jsArgs := tr.getExpression(args[0]).String() + FIELD_GET
+		tr.isFunc = isFunc
+		return jsArgs
 	}
-	// ==
 
-	values := strings.Split(format, VERB)
+	// The values returned by a call.
+	if len(args) == 1 && !Bootstrap {
+		if tuple, ok := tr.typeOf(args[0]).(*types.Tuple); ok && tuple.Len() > 1 {
+			names := ""
+			for i := 0; i < tuple.Len(); i++ {
+				if i != 0 {
+					names += ", "
+				}
+				names += jsString(typeString(tuple.At(i).Type()))
+			}
+			return fmt.Sprintf("g.TypedValues(%s, [%s])", tr.getExpression(args[0]), names)
+		}
+	}
 
-	for i, v := range args[1:] {
+	jsArgs := ""
+	for i, v := range args {
 		if i != 0 {
-			result += fmt.Sprintf("%s+%s\"", SP, SP)
+			jsArgs += ", "
 		}
-		if values[i] != `"` {
-			result += fmt.Sprintf("%s+%s", values[i]+`"`+SP, SP)
+		jsArgs += tr.fmtArg(v)
+	}
+	return "[" + jsArgs + "]"
+}
+
+// fmtArg returns a value to format, into "g.Typed" when its type is not a
+// boolean, an integer or a string.
+func (tr *translation) fmtArg(arg ast.Expr) string {
+	// The value converted to an interface is formatted from its own type.
+	if call, ok := arg.(*ast.CallExpr); ok && len(call.Args) == 1 && !Bootstrap {
+		if tv, ok := tr.info.Types[call.Fun]; ok && tv.IsType() && types.IsInterface(tv.Type) {
+			arg = call.Args[0]
 		}
-		result += tr.getExpression(v).String()
 	}
-	// Last value
-	last := values[len(values)-1]
-	if last != `"` {
-		result += fmt.Sprintf("%s+%s", SP, SP+`"`+last)
+	value := tr.getValue(arg)
+
+	typ := tr.typeOf(arg)
+	if Bootstrap || typ == nil || types.IsInterface(typ) {
+		return value
+	}
+	if t, ok := typ.(*types.Basic); ok {
+		switch {
+		case t.Kind() == types.UntypedNil, t.Kind() == types.Int, t.Kind() == types.UntypedInt,
+			t.Info()&(types.IsBoolean|types.IsString) != 0:
+			return value
+		}
+	}
+
+	// The arrays and slices are formatted from their objects.
+	switch typ.Underlying().(type) {
+	case *types.Array, *types.Slice:
+		value = stripField(value)
 	}
 
-	return result
+	// The values of named types with methods are stored into an object of it.
+	if isNamedValue(typ) && hasMethods(typ) {
+		return fmt.Sprintf("new %s(%s)", validIdent(typ.(*types.Named).Obj().Name()), value)
+	}
+
+	return typedValue(value, typ)
+}
+
+// typedValue returns the value stored with the name of its type, by "g.Typed".
+func typedValue(value string, typ types.Type) string {
+	name := jsString(typeString(typ))
+	// The named types pass their basic type, to format the numbers.
+	if _, ok := typ.(*types.Named); ok && basicName(typ) != "" {
+		return fmt.Sprintf("g.Typed(%s, %s, %q)", value, name, basicName(typ))
+	}
+	return fmt.Sprintf("g.Typed(%s, %s)", value, name)
+}
+
+// typeString returns the name of a type like the verb "%T" writes it.
+func typeString(typ types.Type) string {
+	str := types.TypeString(types.Default(typ), func(pkg *types.Package) string {
+		return pkg.Name()
+	})
+
+	// The arguments of a generic type are separated without spaces, like in
+	// "reflect": main.Pair[string,int]
+	var out []byte
+	var open []byte // brackets opened
+
+	for i := 0; i < len(str); i++ {
+		switch c := str[i]; c {
+		case '[', '(', '{':
+			open = append(open, c)
+		case ']', ')', '}':
+			open = open[:len(open)-1]
+		case ' ':
+			if len(open) != 0 && open[len(open)-1] == '[' && str[i-1] == ',' {
+				continue
+			}
+		}
+		out = append(out, str[i])
+	}
+	return string(out)
+}
+
+// fieldTypes returns the types of the exported fields of a struct which are
+// needed to format them, since "fmt" uses the methods of their values; or an
+// empty string if there are not.
+func (tr *translation) fieldTypes(named *types.Named) string {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || Bootstrap {
+		return ""
+	}
+	fields := ""
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() || !hasNamedValue(field.Type()) {
+			continue
+		}
+		if fields != "" {
+			fields += ", "
+		}
+		fields += fmt.Sprintf("%s: %s", validProp(field.Name()), jsString(typeString(field.Type())))
+	}
+	if fields == "" {
+		return ""
+	}
+	return "{" + fields + "}"
+}
+
+// hasNamedValue reports whether the type, or the type of its elements, is a
+// named type with methods which is not a struct.
+func hasNamedValue(typ types.Type) bool {
+	for {
+		if isNamedValue(typ) && hasMethods(typ) {
+			return true
+		}
+		switch t := typ.(type) {
+		case *types.Named:
+			typ = t.Underlying()
+		case *types.Slice:
+			typ = t.Elem()
+		case *types.Array:
+			typ = t.Elem()
+		case *types.Map:
+			if hasNamedValue(t.Key()) {
+				return true
+			}
+			typ = t.Elem()
+		default:
+			return false
+		}
+	}
 }
@@ -0,0 +1,107 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package lower
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// arith changes the assignment operations and the increments of the variables
+// whose type is a type parameter to assignments of the operation:
+//
+//	t += v  => t = t + v
+//	t++     => t = t + 1
+//
+// So the result of the operation is converted to the type passed, like in the
+// rest of operations; an int8 wraps around, and an int has not decimals.
+func (l *lowering) arith(file *ast.File) {
+	if !l.hasTypes() {
+		return
+	}
+	rewriteStmts(file, func(list []ast.Stmt) []ast.Stmt {
+		for i, stmt := range list {
+			if assign := l.opAssign(stmt); assign != nil {
+				list[i] = assign
+			}
+			if loop, ok := stmt.(*ast.ForStmt); ok && loop.Post != nil {
+				if assign := l.opAssign(loop.Post); assign != nil {
+					loop.Post = assign
+				}
+			}
+		}
+		return list
+	})
+}
+
+// opAssign returns the assignment of the operation, or nil if the statement is
+// not lowered.
+func (l *lowering) opAssign(stmt ast.Stmt) *ast.AssignStmt {
+	var x, y ast.Expr
+	var op token.Token
+	var pos token.Pos
+
+	switch t := stmt.(type) {
+	case *ast.AssignStmt:
+		if len(t.Lhs) != 1 {
+			return nil
+		}
+		if op = opOf(t.Tok); op == token.ILLEGAL {
+			return nil
+		}
+		x, y, pos = t.Lhs[0], t.Rhs[0], t.TokPos
+
+	case *ast.IncDecStmt:
+		op = token.ADD
+		if t.Tok == token.DEC {
+			op = token.SUB
+		}
+		x, pos = t.X, t.TokPos
+		y = &ast.BasicLit{ValuePos: pos, Kind: token.INT, Value: "1"}
+
+	default:
+		return nil
+	}
+
+	typ := l.typeOf(x)
+	if _, ok := typ.(*types.TypeParam); !ok || !isVariable(x) {
+		return nil
+	}
+	if lit, ok := y.(*ast.BasicLit); ok && l.info.Types[lit].Type == nil {
+		l.info.Types[lit] = types.TypeAndValue{Type: typ}
+	}
+
+	bin := &ast.BinaryExpr{X: x, OpPos: pos, Op: op, Y: y}
+	l.info.Types[bin] = types.TypeAndValue{Type: typ}
+
+	return &ast.AssignStmt{
+		Lhs:    []ast.Expr{x},
+		TokPos: pos,
+		Tok:    token.ASSIGN,
+		Rhs:    []ast.Expr{bin},
+	}
+}
+
+// opOf returns the operator of an assignment operation, or token.ILLEGAL.
+func opOf(tok token.Token) token.Token {
+	switch tok {
+	case token.ADD_ASSIGN:
+		return token.ADD
+	case token.SUB_ASSIGN:
+		return token.SUB
+	case token.MUL_ASSIGN:
+		return token.MUL
+	case token.QUO_ASSIGN:
+		return token.QUO
+	case token.REM_ASSIGN:
+		return token.REM
+	case token.SHL_ASSIGN:
+		return token.SHL
+	}
+	return token.ILLEGAL
+}
@@ -0,0 +1,439 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package lower
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// assigns changes the assignments of several values to one assignment by value.
+//
+// Go evaluates the operands of the indexes and the pointer indirections on the
+// left, and the expressions on the right, before of assigning; so the operands
+// which could be changed by a previous assignment are stored in temporary
+// variables:
+//
+//	i, s[i] = 2, 9  => tmp1 := i; i = 2; s[tmp1] = 9
+//
+// The assignments in the initialization of the statements "if", "switch" and
+// "for" are moved into a block before of them. The one in the post statement
+// of a loop is moved to the end of its body when it needs temporary variables,
+// unless the body has "continue" or the variables of the loop are captured.
+func (l *lowering) assigns(file *ast.File) {
+	rewriteStmts(file, func(list []ast.Stmt) []ast.Stmt {
+		var newList []ast.Stmt
+
+		for _, stmt := range list {
+			switch t := stmt.(type) {
+			case *ast.AssignStmt:
+				if stmts := l.blank(t); stmts != nil {
+					newList = append(newList, stmts...)
+					continue
+				}
+				if stmts := l.assign(t); stmts != nil {
+					newList = append(newList, stmts...)
+					continue
+				}
+			case *ast.DeclStmt:
+				if stmts := l.blankDecl(t); stmts != nil {
+					newList = append(newList, stmts...)
+					continue
+				}
+
+			case *ast.IfStmt:
+				stmt = l.initBlock(stmt, &t.Init)
+			case *ast.SwitchStmt:
+				stmt = l.initBlock(stmt, &t.Init)
+			case *ast.TypeSwitchStmt:
+				stmt = l.initBlock(stmt, &t.Init)
+
+			case *ast.ForStmt:
+				l.post(t)
+				stmt = l.initBlock(stmt, &t.Init)
+			}
+			newList = append(newList, stmt)
+		}
+		return newList
+	})
+}
+
+// initBlock returns the statement into a block with its initialization lowered
+// before, when it has to be lowered.
+func (l *lowering) initBlock(stmt ast.Stmt, init *ast.Stmt) ast.Stmt {
+	assign, ok := (*init).(*ast.AssignStmt)
+	if !ok {
+		return stmt
+	}
+	stmts := l.assign(assign)
+	if stmts == nil {
+		return stmt
+	}
+	*init = nil
+
+	return &ast.BlockStmt{
+		Lbrace: stmt.Pos(),
+		List:   append(stmts, stmt),
+		Rbrace: stmt.End(),
+	}
+}
+
+// post moves the post statement of the loop to the end of its body, when it
+// needs temporary variables.
+func (l *lowering) post(loop *ast.ForStmt) {
+	assign, ok := loop.Post.(*ast.AssignStmt)
+	if !ok || !l.isParallel(assign) || hasContinue(loop.Body) || l.isLoopVarCaptured(loop) {
+		return
+	}
+	loop.Body.List = append(loop.Body.List, l.assign(assign)...)
+	loop.Post = nil
+}
+
+// assign returns the assignments by value of an assignment of several values,
+// or nil if it is not lowered.
+func (l *lowering) assign(stmt *ast.AssignStmt) []ast.Stmt {
+	if stmt.Tok != token.ASSIGN || len(stmt.Lhs) == 1 || len(stmt.Lhs) != len(stmt.Rhs) {
+		return nil
+	}
+	var stmts []ast.Stmt
+
+	// The operands are stored in the order of evaluation. A call could change
+	// the operands evaluated before of it, so they are stored too.
+	operands := l.operands(stmt)
+	last := -1 // last operand with a call which is stored
+
+	for i := len(operands) - 1; i >= 0; i-- {
+		if operands[i].isStored && hasCall(*operands[i].expr) {
+			last = i
+			break
+		}
+	}
+	for i, op := range operands {
+		if op.isStored || i < last && !l.isConstant(*op.expr) {
+			stmts = append(stmts, l.store(op.expr))
+		}
+	}
+
+	for i, lhs := range stmt.Lhs {
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs:    []ast.Expr{lhs},
+			TokPos: stmt.TokPos,
+			Tok:    token.ASSIGN,
+			Rhs:    []ast.Expr{stmt.Rhs[i]},
+		})
+	}
+	return stmts
+}
+
+// blank returns the calls of an assignment to the blank identifier like
+// statements, or nil if it is not lowered:
+//
+//	_ = f()  => f()
+//
+// The values which are not calls are kept, since they could be assigned to
+// use a variable.
+func (l *lowering) blank(stmt *ast.AssignStmt) []ast.Stmt {
+	if stmt.Tok != token.ASSIGN {
+		return nil
+	}
+	for _, lhs := range stmt.Lhs {
+		if ident, ok := lhs.(*ast.Ident); !ok || ident.Name != "_" {
+			return nil
+		}
+	}
+	return l.callStmts(stmt.Rhs)
+}
+
+// blankDecl returns the calls of a declaration of blank variables like
+// statements, or nil if it is not lowered:
+//
+//	var _ = f()  => f()
+func (l *lowering) blankDecl(stmt *ast.DeclStmt) []ast.Stmt {
+	decl, ok := stmt.Decl.(*ast.GenDecl)
+	if !ok || decl.Tok != token.VAR {
+		return nil
+	}
+	var stmts []ast.Stmt
+
+	for _, spec := range decl.Specs {
+		vSpec := spec.(*ast.ValueSpec)
+		for _, ident := range vSpec.Names {
+			if ident.Name != "_" {
+				return nil
+			}
+		}
+		calls := l.callStmts(vSpec.Values)
+		if calls == nil {
+			return nil
+		}
+		stmts = append(stmts, calls...)
+	}
+	return stmts
+}
+
+// callStmts returns the values like statements, or nil if some value is not
+// a call which can be a statement.
+func (l *lowering) callStmts(values []ast.Expr) []ast.Stmt {
+	if len(values) == 0 {
+		return nil
+	}
+	stmts := make([]ast.Stmt, 0, len(values))
+
+	for _, v := range values {
+		if !l.isCallStmt(v) {
+			return nil
+		}
+		stmts = append(stmts, &ast.ExprStmt{X: v})
+	}
+	return stmts
+}
+
+// isCallStmt reports whether the expression is a call which can be used like
+// a statement; that is, neither a conversion nor a call to a builtin function
+// which only returns a value.
+func (l *lowering) isCallStmt(expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if tv, ok := l.info.Types[call.Fun]; ok && tv.IsType() {
+		return false
+	}
+
+	switch t := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		if obj := l.info.Uses[t]; obj != nil {
+			if _, ok := obj.(*types.Builtin); !ok {
+				return true
+			}
+		} else if l.hasTypes() {
+			return true
+		}
+		switch t.Name {
+		case "append", "cap", "complex", "imag", "len", "make", "max", "min",
+			"new", "real":
+			return false
+		}
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType,
+		*ast.InterfaceType, *ast.StructType:
+		return false
+	}
+	return true
+}
+
+// isParallel reports whether the assignment of several values needs temporary
+// variables to be done by value.
+func (l *lowering) isParallel(stmt *ast.AssignStmt) bool {
+	if stmt.Tok != token.ASSIGN || len(stmt.Lhs) == 1 || len(stmt.Lhs) != len(stmt.Rhs) {
+		return false
+	}
+	for _, op := range l.operands(stmt) {
+		if op.isStored {
+			return true
+		}
+	}
+	return false
+}
+
+// operand represents an expression which is evaluated before of assigning.
+type operand struct {
+	expr     *ast.Expr // place of the expression into the tree
+	isStored bool      // it has to be stored in a temporary variable
+}
+
+// operands returns the operands of the assignment in the order of evaluation,
+// checking whether they could be changed by a previous assignment.
+func (l *lowering) operands(stmt *ast.AssignStmt) []operand {
+	var lhs, rhs []operand
+	written := make(map[string]bool) // variables assigned before
+	isMemWritten := false            // something is assigned through a pointer
+
+	for i, x := range stmt.Lhs {
+		// The index and the operand of the pointer indirection on the left
+		// are evaluated before of any assignment, like the right side.
+		switch t := ast.Unparen(x).(type) {
+		case *ast.IndexExpr:
+			if l.isReference(t.X) {
+				lhs = append(lhs, l.operand(&t.X, written, isMemWritten))
+			}
+			lhs = append(lhs, l.operand(&t.Index, written, isMemWritten))
+		case *ast.StarExpr:
+			lhs = append(lhs, l.operand(&t.X, written, isMemWritten))
+		case *ast.SelectorExpr:
+			if l.isReference(t.X) {
+				lhs = append(lhs, l.operand(&t.X, written, isMemWritten))
+			}
+		}
+		rhs = append(rhs, l.operand(&stmt.Rhs[i], written, isMemWritten))
+
+		if ident, ok := ast.Unparen(x).(*ast.Ident); ok {
+			written[ident.Name] = true
+		} else {
+			isMemWritten = true
+			if root := rootIdent(x); root != nil {
+				written[root.Name] = true
+			}
+		}
+	}
+	return append(lhs, rhs...)
+}
+
+// operand returns the operand at the place, which is stored when it reads a
+// variable written before, or it reads through a pointer after of a write
+// through one, or it has a call.
+func (l *lowering) operand(expr *ast.Expr, written map[string]bool, isMemWritten bool) operand {
+	op := operand{expr: expr}
+	if l.isConstant(*expr) {
+		return op
+	}
+
+	ast.Inspect(*expr, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.Ident:
+			if written[t.Name] {
+				op.isStored = true
+			}
+		case *ast.CallExpr:
+			if tv, ok := l.info.Types[t.Fun]; !ok || !tv.IsType() {
+				op.isStored = op.isStored || len(written) != 0 || isMemWritten
+			}
+		case *ast.IndexExpr, *ast.StarExpr, *ast.SelectorExpr:
+			op.isStored = op.isStored || isMemWritten
+		}
+		return true
+	})
+	return op
+}
+
+// store stores the expression in a temporary variable, which is used instead.
+func (l *lowering) store(expr *ast.Expr) ast.Stmt {
+	name := l.newName("tmp")
+	pos := (*expr).Pos()
+
+	def := &ast.Ident{NamePos: pos, Name: name}
+	var obj types.Object
+	if typ := l.typeOf(*expr); typ != nil && l.hasTypes() {
+		obj = types.NewVar(pos, l.pkg, name, types.Default(typ))
+		l.info.Defs[def] = obj
+	}
+
+	stmt := &ast.AssignStmt{
+		Lhs:    []ast.Expr{def},
+		TokPos: pos,
+		Tok:    token.DEFINE,
+		Rhs:    []ast.Expr{*expr},
+	}
+	*expr = l.use(pos, obj, name)
+	return stmt
+}
+
+// isReference reports whether the expression is a slice, a map or a pointer,
+// which can be stored without copying the values which it references.
+func (l *lowering) isReference(expr ast.Expr) bool {
+	typ := l.typeOf(expr)
+	if typ == nil {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Pointer:
+		return true
+	}
+	return false
+}
+
+// rootIdent returns the variable whose value is changed by an assignment to the
+// expression, or nil if it is got through a call.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			return t
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.SelectorExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.StarExpr:
+			expr = t.X
+		default:
+			return nil
+		}
+	}
+}
+
+// hasCall reports whether the expression has a call, out of function literals.
+func hasCall(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// hasContinue reports whether the body of a loop has a "continue" which
+// continues it.
+func hasContinue(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncLit, *ast.ForStmt, *ast.RangeStmt:
+			return false
+		case *ast.BranchStmt:
+			if t.Tok == token.CONTINUE || t.Tok == token.GOTO {
+				found = true
+			}
+		case *ast.LabeledStmt:
+			found = true // the loops inside could continue it
+		}
+		return !found
+	})
+	return found
+}
+
+// isLoopVarCaptured reports whether a variable declared by the loop is used
+// into a function literal or addressed, since it has a new variable in each
+// iteration which is changed by the post statement.
+func (l *lowering) isLoopVarCaptured(loop *ast.ForStmt) bool {
+	init, ok := loop.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE {
+		return false
+	}
+	names := make(map[string]bool)
+	for _, v := range init.Lhs {
+		names[v.(*ast.Ident).Name] = true
+	}
+
+	found := false
+	ast.Inspect(loop.Body, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncLit:
+			ast.Inspect(t.Body, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && names[ident.Name] {
+					found = true
+				}
+				return !found
+			})
+			return false
+		case *ast.UnaryExpr:
+			if root := rootIdent(t.X); t.Op == token.AND && root != nil && names[root.Name] {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,314 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package lower
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// conversions makes explicit the conversions to an interface of the values
+// whose type can not be known from their JavaScript value, since they are
+// stored with their type: the values of named types which are neither structs
+// nor interfaces, the basic values but booleans and strings, and the pointers
+// to structs and arrays, which are compared by identity:
+//
+//	var s fmt.Stringer = c  => var s fmt.Stringer = fmt.Stringer(c)
+//	fmt.Println(c)          => fmt.Println(interface{}(c))
+//	var i interface{} = 1.0 => var i interface{} = interface{}(1.0)
+//
+// The values are converted in assignments, declarations of variables, calls,
+// returns, composite literals, comparisons, the cases of a switch and the keys
+// of the maps.
+func (l *lowering) conversions(file *ast.File) {
+	if !l.hasTypes() {
+		return
+	}
+	var sig *types.Signature // function where the statements are
+
+	var inspect func(ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncDecl:
+			if t.Body != nil {
+				if fn, ok := l.info.Defs[t.Name].(*types.Func); ok {
+					outer := sig
+					sig = fn.Type().(*types.Signature)
+					ast.Inspect(t.Body, inspect)
+					sig = outer
+				}
+			}
+			return false
+		case *ast.FuncLit:
+			if s, ok := l.typeOf(t).(*types.Signature); ok {
+				outer := sig
+				sig = s
+				ast.Inspect(t.Body, inspect)
+				sig = outer
+			}
+			return false
+
+		case *ast.AssignStmt:
+			if len(t.Lhs) == len(t.Rhs) {
+				for i := range t.Rhs {
+					t.Rhs[i] = l.convert(t.Rhs[i], l.typeOf(t.Lhs[i]))
+				}
+			}
+		case *ast.ValueSpec:
+			if len(t.Names) == len(t.Values) {
+				for i := range t.Values {
+					t.Values[i] = l.convert(t.Values[i], l.typeOf(t.Names[i]))
+				}
+			}
+		case *ast.ReturnStmt:
+			if sig != nil && sig.Results().Len() == len(t.Results) {
+				for i := range t.Results {
+					t.Results[i] = l.convert(t.Results[i], sig.Results().At(i).Type())
+				}
+			}
+		case *ast.BinaryExpr:
+			if t.Op == token.EQL || t.Op == token.NEQ {
+				t.X = l.convert(t.X, l.typeOf(t.Y))
+				t.Y = l.convert(t.Y, l.typeOf(t.X))
+			}
+		case *ast.SwitchStmt:
+			if t.Tag != nil {
+				for _, stmt := range t.Body.List {
+					clause := stmt.(*ast.CaseClause)
+					for i := range clause.List {
+						clause.List[i] = l.convert(clause.List[i], l.typeOf(t.Tag))
+					}
+				}
+			}
+		case *ast.IndexExpr:
+			if m, ok := l.typeOf(t.X).Underlying().(*types.Map); ok {
+				t.Index = l.convert(t.Index, m.Key())
+			}
+		case *ast.CallExpr:
+			l.convertArgs(t)
+		case *ast.CompositeLit:
+			l.convertElts(t)
+		}
+		return true
+	}
+	ast.Inspect(file, inspect)
+}
+
+// convertArgs converts the arguments of a call to the types of the parameters.
+func (l *lowering) convertArgs(call *ast.CallExpr) {
+	tv, ok := l.info.Types[call.Fun]
+	if !ok || tv.IsType() {
+		return
+	}
+	if tv.IsBuiltin() {
+		// The key to delete.
+		if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "delete" && len(call.Args) == 2 {
+			if m, ok := l.typeOf(call.Args[0]).Underlying().(*types.Map); ok {
+				call.Args[1] = l.convert(call.Args[1], m.Key())
+			}
+		}
+		return
+	}
+	sig, ok := l.typeOf(call.Fun).Underlying().(*types.Signature)
+	if !ok {
+		return
+	}
+	params := sig.Params()
+
+	for i := range call.Args {
+		var typ types.Type
+		switch {
+		case sig.Variadic() && i >= params.Len()-1:
+			if call.Ellipsis.IsValid() {
+				continue // the slice is passed
+			}
+			typ = params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+		case i < params.Len():
+			typ = params.At(i).Type()
+		default:
+			return // a call with several values like arguments
+		}
+		call.Args[i] = l.convert(call.Args[i], typ)
+	}
+}
+
+// convertElts converts the elements of a composite literal to the types of
+// the elements, keys or fields.
+func (l *lowering) convertElts(lit *ast.CompositeLit) {
+	typ := l.typeOf(lit)
+	if typ == nil {
+		return
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok { // elided &T
+		typ = ptr.Elem()
+	}
+
+	for i, elt := range lit.Elts {
+		kv, isKV := elt.(*ast.KeyValueExpr)
+
+		switch t := typ.Underlying().(type) {
+		case *types.Slice, *types.Array:
+			elem := t.(interface{ Elem() types.Type }).Elem()
+			if isKV {
+				kv.Value = l.convert(kv.Value, elem)
+			} else {
+				lit.Elts[i] = l.convert(elt, elem)
+			}
+		case *types.Map:
+			if isKV {
+				kv.Key = l.convert(kv.Key, t.Key())
+				kv.Value = l.convert(kv.Value, t.Elem())
+			}
+		case *types.Struct:
+			if !isKV {
+				lit.Elts[i] = l.convert(elt, t.Field(i).Type())
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				if field, ok := l.info.Uses[key].(*types.Var); ok {
+					kv.Value = l.convert(kv.Value, field.Type())
+				}
+			}
+		}
+	}
+}
+
+// convert returns the expression converted to the interface "to", when the
+// value has to be stored with its type; else the expression.
+func (l *lowering) convert(expr ast.Expr, to types.Type) ast.Expr {
+	if to == nil || !types.IsInterface(to) {
+		return expr
+	}
+	from := l.typeOf(expr)
+	if from == nil || !storesType(from) {
+		return expr
+	}
+	fun := l.typeExpr(expr, to)
+	if fun == nil {
+		return expr
+	}
+
+	call := &ast.CallExpr{Fun: fun, Lparen: expr.Pos(), Args: []ast.Expr{expr}, Rparen: expr.End()}
+	tv := l.info.Types[expr]
+	tv.Type, tv.Value = to, nil // a constant converted to an interface is a value
+	l.info.Types[call] = tv
+	return call
+}
+
+// typeExpr returns the expression of the interface type, to convert the value
+// at the position of expr; or nil if it can not be written from the file.
+func (l *lowering) typeExpr(expr ast.Expr, typ types.Type) ast.Expr {
+	var texpr ast.Expr
+
+	switch t := typ.(type) {
+	case *types.Named:
+		texpr = l.typeName(expr, t.Obj())
+	case *types.Alias:
+		texpr = l.typeName(expr, t.Obj())
+	case *types.Interface:
+		if t.NumMethods() != 0 || t.NumEmbeddeds() != 0 {
+			return nil
+		}
+		pos := expr.Pos()
+		texpr = &ast.InterfaceType{Interface: pos, Methods: &ast.FieldList{Opening: pos, Closing: pos}}
+	}
+	if texpr == nil {
+		return nil
+	}
+
+	tv, ok := l.typeValue()
+	if !ok {
+		return nil
+	}
+	tv.Type = typ
+	l.info.Types[texpr] = tv
+	return texpr
+}
+
+// typeName returns the name of the type, qualified if it is declared into
+// other package; or nil if the package is not imported by the file.
+func (l *lowering) typeName(expr ast.Expr, obj *types.TypeName) ast.Expr {
+	name := l.use(expr.Pos(), obj, obj.Name())
+	if obj.Pkg() == nil || obj.Pkg() == l.pkg {
+		return name
+	}
+
+	for _, imp := range l.file.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path != obj.Pkg().Path() {
+			continue
+		}
+		pkgName := obj.Pkg().Name()
+		if imp.Name != nil {
+			pkgName = imp.Name.Name
+		}
+		if pkgName == "_" || pkgName == "." {
+			return nil
+		}
+		return &ast.SelectorExpr{X: &ast.Ident{NamePos: expr.Pos(), Name: pkgName}, Sel: name}
+	}
+	return nil
+}
+
+// typeValue returns the type information of an expression which is a type, to
+// be copied in the types written.
+func (l *lowering) typeValue() (types.TypeAndValue, bool) {
+	for _, tv := range l.info.Types {
+		if tv.IsType() {
+			return tv, true
+		}
+	}
+	return types.TypeAndValue{}, false
+}
+
+// storesType reports whether the values of the type are stored into an
+// interface with their type: the named types which are neither structs nor
+// interfaces, the basic types but bool and string, and the pointers to structs
+// and arrays.
+func storesType(typ types.Type) bool {
+	if isNamedValue(typ) || isObjectPointer(typ) {
+		return true
+	}
+	t, ok := typ.(*types.Basic)
+	if !ok || t.Info()&types.IsUntyped != 0 {
+		return false
+	}
+	switch t.Kind() {
+	case types.Bool, types.String, types.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// isObjectPointer reports whether the type is a pointer to a struct or an
+// array, which is the own object pointed.
+func isObjectPointer(typ types.Type) bool {
+	ptr, ok := typ.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	switch ptr.Elem().Underlying().(type) {
+	case *types.Struct, *types.Array:
+		return true
+	}
+	return false
+}
+
+// isNamedValue reports whether the type is a named type which is neither a
+// struct nor an interface.
+func isNamedValue(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.TypeParams() != nil {
+		return false
+	}
+	switch named.Underlying().(type) {
+	case *types.Struct, *types.Interface:
+		return false
+	}
+	return true
+}
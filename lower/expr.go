@@ -0,0 +1,188 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package lower
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+)
+
+// expr returns the expression lowered.
+func (l *lowering) expr(expr ast.Expr) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.CallExpr:
+		if lit := l.newLit(t); lit != nil {
+			return lit
+		}
+	case *ast.BinaryExpr:
+		if cmp := l.compareStruct(t); cmp != nil {
+			return cmp
+		}
+	}
+	return expr
+}
+
+// newLit returns the address of a composite literal for the call to "new" of
+// an array or a struct, or nil if it is other call.
+func (l *lowering) newLit(call *ast.CallExpr) ast.Expr {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != "new" || len(call.Args) != 1 {
+		return nil
+	}
+	if l.hasTypes() {
+		if _, ok := l.info.Uses[ident].(*types.Builtin); !ok {
+			return nil
+		}
+	}
+	typ := call.Args[0]
+
+	// The arrays are known from the syntax.
+	if array, ok := typ.(*ast.ArrayType); !ok || array.Len == nil {
+		if t := l.typeOf(typ); t == nil || !isStruct(t) {
+			return nil
+		}
+	}
+
+	lit := &ast.CompositeLit{Type: typ, Lbrace: typ.End(), Rbrace: call.Rparen}
+	addr := &ast.UnaryExpr{OpPos: call.Pos(), Op: token.AND, X: lit}
+
+	if tv, ok := l.info.Types[call]; ok {
+		l.info.Types[addr] = tv
+		tv.Type = tv.Type.(*types.Pointer).Elem()
+		l.info.Types[lit] = tv
+	}
+	return addr
+}
+
+// compareStruct returns the comparison of the fields, for a comparison of two
+// structs; or nil if it is other comparison. The operands are read once by
+// field, so they have to be variables or fields, without calls.
+//
+// The fields which are structs are compared by their fields too; a struct
+// without fields is equal to any other.
+func (l *lowering) compareStruct(bin *ast.BinaryExpr) ast.Expr {
+	if bin.Op != token.EQL && bin.Op != token.NEQ {
+		return nil
+	}
+	x, y := l.typeOf(bin.X), l.typeOf(bin.Y)
+	if x == nil || y == nil || !types.Identical(x, y) || !isStruct(x) {
+		return nil
+	}
+	if !isVariable(bin.X) || !isVariable(bin.Y) || !l.isAccessible(x) {
+		return nil
+	}
+	tv := l.info.Types[bin]
+
+	cmp := l.compareFields(bin, bin.X, bin.Y, x.Underlying().(*types.Struct))
+	if cmp == nil {
+		cmp = l.use(bin.Pos(), types.Universe.Lookup("true"), "true")
+		if bin.Op == token.NEQ {
+			cmp = l.use(bin.Pos(), types.Universe.Lookup("false"), "false")
+		}
+		tv.Value = constant.MakeBool(bin.Op == token.EQL)
+		l.info.Types[cmp] = tv
+		return cmp
+	}
+
+	paren := &ast.ParenExpr{Lparen: bin.Pos(), X: cmp, Rparen: bin.End()}
+	l.info.Types[paren] = tv
+	return paren
+}
+
+// compareFields returns the comparison of the fields of the struct in both
+// operands, joined like the comparison "bin"; or nil if there is not any field.
+func (l *lowering) compareFields(bin *ast.BinaryExpr, x, y ast.Expr, st *types.Struct) ast.Expr {
+	var cmp ast.Expr
+
+	join := token.LAND
+	if bin.Op == token.NEQ {
+		join = token.LOR
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Name() == "_" {
+			continue
+		}
+		fx, fy := l.selectField(x, field), l.selectField(y, field)
+
+		var fieldCmp ast.Expr
+		if isStruct(field.Type()) {
+			fieldCmp = l.compareFields(bin, fx, fy, field.Type().Underlying().(*types.Struct))
+			if fieldCmp == nil {
+				continue
+			}
+		} else {
+			fieldCmp = &ast.BinaryExpr{X: fx, OpPos: bin.OpPos, Op: bin.Op, Y: fy}
+			l.info.Types[fieldCmp] = l.info.Types[bin]
+		}
+
+		if cmp == nil {
+			cmp = fieldCmp
+		} else {
+			cmp = &ast.BinaryExpr{X: cmp, OpPos: bin.OpPos, Op: join, Y: fieldCmp}
+			l.info.Types[cmp] = l.info.Types[bin]
+		}
+	}
+	return cmp
+}
+
+// selectField returns the selector of the field into the operand.
+func (l *lowering) selectField(x ast.Expr, field *types.Var) ast.Expr {
+	sel := &ast.SelectorExpr{X: x, Sel: l.use(x.Pos(), field, field.Name())}
+
+	if tv, ok := l.info.Types[x]; ok {
+		tv.Type = field.Type()
+		l.info.Types[sel] = tv
+	}
+	return sel
+}
+
+// isAccessible reports whether all fields of the struct, and of the structs of
+// its fields, can be selected from the package lowered.
+func (l *lowering) isAccessible(typ types.Type) bool {
+	st := typ.Underlying().(*types.Struct)
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() && field.Pkg() != l.pkg && field.Name() != "_" {
+			return false
+		}
+		if isStruct(field.Type()) && !l.isAccessible(field.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+// isVariable reports whether the expression reads a variable, or a field or an
+// element of it, without calls.
+func isVariable(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.ParenExpr:
+		return isVariable(t.X)
+	case *ast.SelectorExpr:
+		return isVariable(t.X)
+	case *ast.StarExpr:
+		return isVariable(t.X)
+	case *ast.IndexExpr:
+		_, isLit := t.Index.(*ast.BasicLit)
+		_, isIdent := t.Index.(*ast.Ident)
+		return (isLit || isIdent) && isVariable(t.X)
+	}
+	return false
+}
+
+// isStruct reports whether the type is a struct.
+func isStruct(typ types.Type) bool {
+	_, ok := typ.Underlying().(*types.Struct)
+	return ok
+}
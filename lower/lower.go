@@ -0,0 +1,184 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+/*
+Package lower rewrites a Go syntax tree to "Kernel Go", a subset of Go with less
+constructs, so the translator to JavaScript has to handle only them. The code
+lowered is still Go, which can be compiled.
+
+The constructs with the same meaning are written of one only way:
+
+	new([3]int)       => &[3]int{}
+	new(T)            => &T{}  // T is a struct
+
+The sugar is removed:
+
+	a, b = b, a       => tmp1 := b; tmp2 := a; a = tmp1; b = tmp2
+	func f() (n int)  => func f() int { var n int; ...; return n }
+	_ = f()           => f()
+	x == y            => (x.a == y.a && x.b == y.b)  // x, y are structs
+
+The assignment operations on a type parameter are written like its operation:
+
+	t += v            => t = t + v  // t has a type parameter
+
+The implicit conversions which change the value are made explicit:
+
+	var s fmt.Stringer = c  => var s fmt.Stringer = fmt.Stringer(c)  // c has methods, and it is not a struct
+
+The type information of the nodes added is stored into the one given, so the
+translator can use it like in the rest of the tree. Without type information,
+like in the JavaScript library, only the constructs which can be lowered from
+the syntax are lowered.
+*/
+package lower
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+)
+
+// lowering represents the data of a file being lowered.
+type lowering struct {
+	fset *token.FileSet
+	file *ast.File
+	info *types.Info
+	pkg  *types.Package
+
+	names map[string]bool // names used into the file
+}
+
+// File lowers the file to "Kernel Go". The type information of the file is
+// updated with the one of the nodes added; pkg is nil if the types are unknown.
+func File(fset *token.FileSet, file *ast.File, info *types.Info, pkg *types.Package) {
+	l := &lowering{
+		fset:  fset,
+		file:  file,
+		info:  info,
+		pkg:   pkg,
+		names: make(map[string]bool),
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			l.names[ident.Name] = true
+		}
+		return true
+	})
+
+	l.results(file)
+	l.arith(file)
+	l.assigns(file)
+	l.conversions(file)
+	rewriteExprs(file, l.expr)
+}
+
+// hasTypes reports whether the type information is known.
+func (l *lowering) hasTypes() bool { return l.pkg != nil }
+
+// typeOf returns the type of the expression, or nil if it is unknown.
+func (l *lowering) typeOf(expr ast.Expr) types.Type {
+	if tv, ok := l.info.Types[expr]; ok {
+		return tv.Type
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		if obj := l.info.ObjectOf(ident); obj != nil {
+			return obj.Type()
+		}
+	}
+	return nil
+}
+
+// isConstant reports whether the expression has a constant value.
+func (l *lowering) isConstant(expr ast.Expr) bool {
+	if tv, ok := l.info.Types[expr]; ok {
+		return tv.Value != nil
+	}
+	_, ok := expr.(*ast.BasicLit)
+	return ok
+}
+
+// newName returns a name which is not used into the file.
+func (l *lowering) newName(prefix string) string {
+	for i := 1; ; i++ {
+		name := prefix + strconv.Itoa(i)
+		if !l.names[name] {
+			l.names[name] = true
+			return name
+		}
+	}
+}
+
+// use returns a new identifier which uses the object.
+func (l *lowering) use(pos token.Pos, obj types.Object, name string) *ast.Ident {
+	ident := &ast.Ident{NamePos: pos, Name: name}
+	if obj != nil {
+		l.info.Uses[ident] = obj
+	}
+	return ident
+}
+
+// * * *
+
+var (
+	exprType     = reflect.TypeOf((*ast.Expr)(nil)).Elem()
+	stmtListType = reflect.TypeOf([]ast.Stmt(nil))
+	nodeType     = reflect.TypeOf((*ast.Node)(nil)).Elem()
+)
+
+// rewriteExprs walks the node replacing every expression by the one returned
+// by f, which gets the expression with its operands already replaced.
+func rewriteExprs(node ast.Node, f func(ast.Expr) ast.Expr) {
+	walk(reflect.ValueOf(node), func(v reflect.Value) {
+		if v.Type() == exprType && !v.IsNil() {
+			v.Set(reflect.ValueOf(f(v.Interface().(ast.Expr))))
+		}
+	})
+}
+
+// rewriteStmts walks the node replacing every list of statements by the one
+// returned by f, which gets the list with its statements already walked.
+func rewriteStmts(node ast.Node, f func([]ast.Stmt) []ast.Stmt) {
+	walk(reflect.ValueOf(node), func(v reflect.Value) {
+		if v.Type() == stmtListType && !v.IsNil() {
+			v.Set(reflect.ValueOf(f(v.Interface().([]ast.Stmt))))
+		}
+	})
+}
+
+// walk visits in depth-first order the fields of the syntax tree, calling
+// visit after of walking each one, so it can be replaced.
+func walk(v reflect.Value, visit func(reflect.Value)) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		walk(v.Elem(), visit)
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+
+			switch field.Interface().(type) {
+			case *ast.Object, *ast.Scope, *ast.CommentGroup, []*ast.CommentGroup:
+				continue // they are not part of the tree
+			}
+			if field.Kind() == reflect.Slice || field.Type().Implements(nodeType) {
+				walk(field, visit)
+				visit(field)
+			}
+		}
+
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), visit)
+			visit(v.Index(i))
+		}
+	}
+}
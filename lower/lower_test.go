@@ -0,0 +1,395 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package lower
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+var tests = []struct {
+	name string
+	src  string // body of function "f"
+	want string
+}{
+	{"new array", `
+	p := new([3]int)
+	q := new([2][4]byte)
+	use(p, q)`, `
+	p := &[3]int{}
+	q := &[2][4]byte{}
+	use(interface{}(p), interface{}(q))`},
+
+	{"new struct", `
+	p := new(T)
+	n := new(int)
+	use(p, n)`, `
+	p := &T{}
+	n := new(int)
+	use(interface{}(p), n)`},
+
+	{"swap", `
+	a, b := 1, 2
+	a, b = b, a`, `
+	a, b := 1, 2
+	tmp1 := a
+	a = b
+	b = tmp1`},
+
+	{"no temporary", `
+	a, b := 1, 2
+	a, b = 3, a+b`, `
+	a, b := 1, 2
+	tmp1 := a + b
+	a = 3
+	b = tmp1`},
+
+	{"index", `
+	s := []int{1, 2, 3}
+	i := 0
+	i, s[i] = 2, 9`, `
+	s := []int{1, 2, 3}
+	i := 0
+	tmp1 := i
+	i = 2
+	s[tmp1] = 9`},
+
+	{"independent", `
+	var a, b int
+	a, b = 1, 2
+	use(a, b)`, `
+	var a, b int
+	a = 1
+	b = 2
+	use(interface{}(a), interface{}(b))`},
+
+	{"call", `
+	s := []int{1, 2}
+	s[0], s[1] = s[1], g()`, `
+	s := []int{1, 2}
+	tmp1 := s
+	tmp2 := s
+	tmp3 := s[1]
+	tmp4 := g()
+	tmp1[0] = tmp3
+	tmp2[1] = tmp4`},
+
+	{"init", `
+	a, b := 1, 2
+	if a, b = b, a; a > b {
+	}`, `
+	a, b := 1, 2
+	{
+		tmp1 := a
+		a = b
+		b = tmp1
+		if a > b {
+		}
+	}`},
+
+	{"post", `
+	for a, b := 0, 1; a < 10; a, b = b, a+b {
+	}`, `
+	for a, b := 0, 1; a < 10; {
+		tmp1 := a + b
+		a = b
+		b = tmp1
+	}`},
+
+	{"post with continue", `
+	for a, b := 0, 1; a < 10; a, b = b, a+b {
+		continue
+	}`, `
+	for a, b := 0, 1; a < 10; a, b = b, a+b {
+		continue
+	}`},
+
+	{"struct equal", `
+	var x, y T
+	_ = x == y
+	_ = x != y`, `
+	var x, y T
+	_ = (x.a == y.a && x.b == y.b)
+	_ = (x.a != y.a || x.b != y.b)`},
+
+	{"nested struct", `
+	var x, y U
+	_ = x == y`, `
+	var x, y U
+	_ = (x.t.a == y.t.a && x.t.b == y.t.b && x.n == y.n)`},
+
+	{"empty struct", `
+	var x, y struct{}
+	_ = x == y
+	use(x, y)`, `
+	var x, y struct{}
+	_ = true
+	use(x, y)`},
+
+	{"interface", `
+	var c C
+	var i I = c
+	i = c
+	use(c, i, T{})`, `
+	var c C
+	var i I = I(c)
+	i = I(c)
+	use(interface{}(c), i, T{})`},
+
+	{"interface of basic", `
+	var f float64
+	var i interface{} = f
+	use(1, "a", int8(1), 1.5, i)`, `
+	var f float64
+	var i interface{} = interface{}(f)
+	use(interface{}(1), "a", interface{}(int8(1)), interface{}(1.5), i)`},
+
+	{"interface of case and key", `
+	var i interface{}
+	m := map[interface{}]int{}
+	switch i {
+	case 1.5, "a":
+		m[2] = 1
+		delete(m, 2)
+	}`, `
+	var i interface{}
+	m := map[interface{}]int{}
+	switch i {
+	case interface{}(1.5), "a":
+		m[interface{}(2)] = 1
+		delete(m, interface{}(2))
+	}`},
+
+	{"blank", `
+	s := []int{1, 2}
+	_ = g()
+	_, _ = g(), copy(s, s)
+	var _ = g()
+	_ = len(s)
+	_ = int64(g())`, `
+	s := []int{1, 2}
+	g()
+	g()
+	copy(s, s)
+	g()
+	_ = len(s)
+	_ = int64(g())`},
+
+	{"struct call", `
+	var x T
+	_ = x == h()`, `
+	var x T
+	_ = x == h()`},
+}
+
+var decls = `
+type T struct{ a, b int }
+
+type U struct {
+	t T
+	_ int
+	n int
+}
+
+type C float64
+
+func (c C) String() string { return "" }
+
+type I interface{ String() string }
+
+func g() int { return 0 }
+func h() T   { return T{} }
+
+func use(...interface{}) {}
+`
+
+func TestLower(t *testing.T) {
+	for _, tt := range tests {
+		got := lower(t, "func f() {"+tt.src+"\n}\n")
+		want := format_(t, "func f() {"+tt.want+"\n}\n")
+
+		if got != want {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", tt.name, got, want)
+		}
+	}
+}
+
+func TestResults(t *testing.T) {
+	src := `
+func divMod(n int) (q, r int) {
+	q, r = n/2, n%2
+	return
+}
+
+func shadow(n int) (v int, _ error) {
+	v = n
+	if n > 0 {
+		v := 1
+		_ = v
+	}
+	func() (m int) { return }()
+	return
+}
+`
+	want := `
+func divMod(n int) (int, int) {
+	var q, r int
+	q = n / 2
+	r = n % 2
+	return q, r
+}
+
+func shadow(n int) (int, error) {
+	var (
+		v1      int
+		result1 error
+	)
+	v1 = n
+	if n > 0 {
+		v := 1
+		_ = v
+	}
+	func() int { var m int; return m }()
+	return v1, result1
+}
+`
+	if got := lower(t, src); got != format_(t, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, format_(t, want))
+	}
+}
+
+func TestTypeParams(t *testing.T) {
+	src := `
+func sum[T ~int | ~int8](s []T) T {
+	var n T
+	for i := 0; i < len(s); i++ {
+		n += s[i]
+	}
+	for j := n; j > 0; j-- {
+		n *= 2
+	}
+	return n
+}
+`
+	want := `
+func sum[T ~int | ~int8](s []T) T {
+	var n T
+	for i := 0; i < len(s); i++ {
+		n = n + s[i]
+	}
+	for j := n; j > 0; j = j - 1 {
+		n = n * 2
+	}
+	return n
+}
+`
+	if got := lower(t, src); got != format_(t, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, format_(t, want))
+	}
+}
+
+func TestWithoutTypes(t *testing.T) {
+	src := `
+func f(s []int, i, j int) (n int) {
+	s[i], s[j] = s[j], s[i]
+	a := new([2]int)
+	_ = a
+	return
+}
+`
+	want := `
+func f(s []int, i, j int) int {
+	var n int
+	tmp1 := s[i]
+	s[i] = s[j]
+	s[j] = tmp1
+	a := &[2]int{}
+	_ = a
+	return n
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", "package main\n"+src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	File(fset, file, newInfo(), nil)
+
+	got := strings.TrimPrefix(print(t, fset, file), "package main\n")
+	if got = strings.TrimSpace(got); got != format_(t, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, format_(t, want))
+	}
+}
+
+// lower returns the declarations lowered, which are checked to be valid Go.
+func lower(t *testing.T, src string) string {
+	src = "package main\n" + decls + src
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := newInfo()
+	pkg, err := new(types.Config).Check("main", fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	File(fset, file, info, pkg)
+	out := print(t, fset, file)
+
+	// The code lowered has to be compiled again.
+	fset = token.NewFileSet()
+	if file, err = parser.ParseFile(fset, "test.go", out, 0); err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	if _, err = new(types.Config).Check("main", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(out, "package main\n\n"+format_(t, decls)))
+}
+
+// print returns the file formatted.
+func print(t *testing.T, fset *token.FileSet, file *ast.File) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		t.Fatal(err)
+	}
+	return format_(t, buf.String())
+}
+
+// format_ returns the source formatted; without the package clause, and the
+// spaces around, if it is not a file.
+func format_(t *testing.T, src string) string {
+	isFile := strings.HasPrefix(src, "package ")
+	if !isFile {
+		src = "package main\n" + src
+	}
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		t.Fatalf("%s\n%s", err, src)
+	}
+	if !isFile {
+		return strings.TrimSpace(strings.TrimPrefix(string(out), "package main\n"))
+	}
+	return string(out)
+}
+
+func newInfo() *types.Info {
+	return &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+}
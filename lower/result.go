@@ -0,0 +1,104 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package lower
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// results changes the named results of the functions to local variables,
+// declared at the beginning of the body, and the "return" without values to
+// return them.
+func (l *lowering) results(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncDecl:
+			if t.Body != nil {
+				l.namedResults(t.Type, t.Body)
+			}
+		case *ast.FuncLit:
+			l.namedResults(t.Type, t.Body)
+		}
+		return true
+	})
+}
+
+// namedResults lowers the named results of a function.
+func (l *lowering) namedResults(typ *ast.FuncType, body *ast.BlockStmt) {
+	if typ.Results == nil || typ.Results.List[0].Names == nil {
+		return
+	}
+	decl := &ast.GenDecl{TokPos: body.Lbrace, Tok: token.VAR}
+	var names []*ast.Ident
+	var fields []*ast.Field
+
+	for _, field := range typ.Results.List {
+		decl.Specs = append(decl.Specs, &ast.ValueSpec{Names: field.Names, Type: field.Type})
+
+		for _, ident := range field.Names {
+			if ident.Name == "_" { // it can not be returned by its name
+				ident.Name = l.newName("result")
+				if obj := l.info.Defs[ident]; obj != nil {
+					l.info.Defs[ident] = types.NewVar(obj.Pos(), obj.Pkg(), ident.Name, obj.Type())
+				}
+			} else {
+				l.unshadow(ident)
+			}
+			names = append(names, ident)
+			fields = append(fields, &ast.Field{Type: field.Type})
+		}
+	}
+	typ.Results.List = fields
+	body.List = append([]ast.Stmt{&ast.DeclStmt{Decl: decl}}, body.List...)
+
+	// The functions literals have their own results.
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if t.Results == nil {
+				for _, ident := range names {
+					t.Results = append(t.Results, l.use(t.Return, l.info.Defs[ident], ident.Name))
+				}
+			}
+		}
+		return true
+	})
+}
+
+// unshadow gives an unique name to a result which is shadowed into the body,
+// since a variable declared into a block is visible in the whole function in
+// JavaScript.
+func (l *lowering) unshadow(ident *ast.Ident) {
+	obj := l.info.Defs[ident]
+	if obj == nil || obj.Parent() == nil || !isShadowed(obj.Parent(), obj.Name()) {
+		return
+	}
+	name := l.newName(ident.Name)
+
+	ident.Name = name
+	for use, o := range l.info.Uses {
+		if o == obj {
+			use.Name = name
+		}
+	}
+}
+
+// isShadowed reports whether the name is declared into a scope inside of the
+// given one.
+func isShadowed(scope *types.Scope, name string) bool {
+	for i := 0; i < scope.NumChildren(); i++ {
+		child := scope.Child(i)
+		if child.Lookup(name) != nil || isShadowed(child, name) {
+			return true
+		}
+	}
+	return false
+}
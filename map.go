@@ -0,0 +1,63 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// The maps are built on the type "g.MapType" of the library, which stores the
+// entries into a Map of JavaScript:
+//
+//	make(map[K]V)        => g.MkMap(zero, [])
+//	map[K]V{k: v}        => g.MkMap(zero, [[k, v]])
+//	m[k]                 => m.get(k)[0]
+//	m[k] = v             => m.set(k, v)
+//	delete(m, k)         => m.remove(k)
+//
+// The keys which are compared by value are hashed by the library, which is
+// indicated by a last argument "true".
+
+// isMap reports whether the type of the expression is a map.
+func (tr *translation) isMap(expr ast.Expr) bool {
+	if Bootstrap {
+		return false
+	}
+	typ := tr.typeOf(expr)
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Map)
+	return ok
+}
+
+// isHashKey reports whether the keys of the type are compared by value, so
+// they have to be hashed: structs, arrays and interfaces.
+func isHashKey(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Struct, *types.Array, *types.Interface:
+		return true
+	}
+	return false
+}
+
+// mapHash returns the argument to hash the keys of the map, if they are
+// compared by value.
+func (tr *translation) mapHash(expr ast.Expr) string {
+	if Bootstrap {
+		return ""
+	}
+	typ := tr.typeOf(expr)
+	if typ == nil {
+		return ""
+	}
+	if t, ok := typ.Underlying().(*types.Map); ok && isHashKey(t.Key()) {
+		return ", true"
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// The names of Go are translated to names which can not collide with the ones
+// of JavaScript. The names which are escaped get "_" at the end, and the names
+// of Go which already end with "_" get another one, so two names of Go are
+// never translated to the same one:
+//
+//	class  => class_
+//	class_ => class__
+//
+// The temporary variables start with "$", which can not be used in Go.
+
+// Reserved Words in JavaScript.
+//
+// https://developer.mozilla.org/en/JavaScript/Reference/Reserved_Words
+// http://golang.org/ref/spec#Keywords
+var reserved = map[string]struct{}{
+	/* These ones are reserved too in Go.
+	"break":     void,
+	"case":      void,
+	"const":     void,
+	"continue":  void,
+	"default":   void,
+	"delete":    void,
+	"else":      void,
+	"false":     void,
+	"for":       void,
+	"if":        void,
+	"import":    void,
+	"interface": void,
+	"package":   void,
+	"return":    void,
+	"switch":    void,
+	"true":      void,
+	"var":       void,*/
+
+	"catch":      void,
+	"debugger":   void,
+	"do":         void,
+	"finally":    void,
+	"function":   void,
+	"in":         void,
+	"instanceof": void,
+	"new":        void,
+	"this":       void,
+	"throw":      void,
+	"try":        void,
+	"typeof":     void,
+	"void":       void,
+	"while":      void,
+	"with":       void,
+
+	"class":      void,
+	"enum":       void,
+	"export":     void,
+	"extends":    void,
+	"implements": void,
+	"let":        void,
+	"null":       void,
+	"private":    void,
+	"protected":  void,
+	"public":     void,
+	"static":     void,
+	"super":      void,
+	"yield":      void,
+}
+
+// Global objects in JavaScript which are used by the translation or the library,
+// so they can not be declared by the Go code.
+//
+// https://developer.mozilla.org/en/JavaScript/Reference/Global_Objects
+var globals = map[string]struct{}{
+	"arguments": void,
+	"eval":      void,
+	"undefined": void,
+	"NaN":       void,
+	"Infinity":  void,
+
+	"Array":    void,
+	"Boolean":  void,
+	"Date":     void,
+	"Error":    void,
+	"Function": void,
+	"JSON":     void,
+	"Map":      void,
+	"Math":     void,
+	"Number":   void,
+	"Object":   void,
+	"RegExp":   void,
+	"Set":      void,
+	"String":   void,
+	"Symbol":   void,
+
+	"isFinite":   void,
+	"isNaN":      void,
+	"parseFloat": void,
+	"parseInt":   void,
+
+	"alert":      void,
+	"console":    void,
+	"document":   void,
+	"globalThis": void,
+	"window":     void,
+}
+
+// validIdent returns the name used in JavaScript for a declaration of Go, which
+// is escaped if it is a reserved word, a global object or the library.
+func validIdent(name interface{}) string {
+	name_ := fmt.Sprintf("%s", name)
+
+	if Bootstrap {
+		return name_
+	}
+	base := strings.TrimRight(name_, "_")
+
+	if _, ok := globals[base]; ok {
+		return escapeUnicode(name_) + "_"
+	}
+	return validProp(name_)
+}
+
+// validProp returns the name used in JavaScript for a field or a method, which
+// is only escaped if it is a reserved word or the library, since the global
+// objects are not hidden by the properties.
+func validProp(name string) string {
+	if Bootstrap {
+		return name
+	}
+	base := strings.TrimRight(name, "_")
+
+	if _, ok := reserved[base]; ok || base == LIB_RESERVED_NAME {
+		name += "_"
+	}
+	return escapeUnicode(name)
+}
+
+// escapeUnicode returns the name with the characters which are not ASCII
+// written like escape sequences, which are valid in the JavaScript names.
+func escapeUnicode(name string) string {
+	isASCII := true
+	for i := 0; i < len(name); i++ {
+		if name[i] >= utf8.RuneSelf {
+			isASCII = false
+			break
+		}
+	}
+	if isASCII {
+		return name
+	}
+
+	var s strings.Builder
+	for _, r := range name {
+		switch {
+		case r < utf8.RuneSelf:
+			s.WriteRune(r)
+		case r <= 0xFFFF:
+			fmt.Fprintf(&s, "\\u%04X", r)
+		default:
+			fmt.Fprintf(&s, "\\u{%X}", r)
+		}
+	}
+	return s.String()
+}
+
+// newTmp returns the name for a temporary variable. It starts with "$" so it
+// can not be used by a Go identifier. The counter is restarted by each function
+// declared, since its variables are not visible out of it.
+func (tr *translation) newTmp(prefix string) string {
+	tr.tmpTotal++
+	return fmt.Sprintf("$%s%d", prefix, tr.tmpTotal)
+}
@@ -8,162 +8,188 @@ package main
 
 import (
 	"fmt"
-	"regexp"
+	"go/ast"
+	"go/token"
+	"go/types"
 	"strings"
 )
 
 /*
 ## Pointers
 
-To identify variables that could be addressed ahead, it is used the map:
+The structs and arrays are objects in JavaScript, so a pointer to them is the
+own object. The variables of other types whose address is taken are stored in
+an object with a field named "p", which is the pointer shared by all:
 
-	{number of function: {number of block: {variable name: is pointer?} }}
+	x := 1  => var x = {p:1};
+	x       => x.p
+	&x      => x
+	*p      => p.p
 
-In the generated code, it is added a tag before and after of each new variable
-but pointer. The tag uses the schema `<<side:funcId:blockId:varName>>`
+The fields of the structs and the elements of the arrays and slices are not
+stored in objects, so their pointers are references to the place where they are
+stored, got through the field "p" like the other pointers:
 
-	*side:* *L* or *R* if the tag is on the left or on the right of the variable.
-		*i* indicates that its value is zero for the value type.
-	*funcId:* identifier of function. '0' is for global declarations
-	*blockId:* number of block inner of that function. Start with '1'
-	*varName:* variable's name
+	&s.f    => g.Ref(s, "f")
+	&a[i]   => g.Ref(a.v, i)
+	&s[i]   => s.ref(i)
 
-It is also added the tag `<<P:funcId:blockId:varName>>` after of each variable
-name, and `<<&>>` after of it when the assignment is an address.
-*/
+A struct or array which could be pointed is changed in place when a value is
+assigned to it, so the pointers see the new value:
 
-// To remove tags related to pointers
-var reTagPointer = regexp.MustCompile(`<<z?[LRP]:\d+:\d+:[^>]+>>`)
+	*p = v  => g.Store(p, v, {x: 0, y: 0});
 
-// tagPointer returns a tag to identify pointers.
-// The argument field indicates if the variable is zero.
-func tagPointer(zero bool, typ rune, funcId, blockId int, name string) string {
-	/*if typ != 'L' && typ != 'R' && typ != 'P' {
-		panic("invalid identifier for pointer: " + string(typ))
-	}*/
+The variables and fields addressed are found by "findPointers" before of
+translating, from the objects given by the type checker, so the emitter writes
+the access to the value directly.
+*/
 
-	zeroStr := ""
-	if zero {
-		zeroStr = "z"
+// findPointers marks the variables and fields whose address is taken into the
+// file. The structs and arrays which hold a field or element addressed are
+// marked too, since they have to be changed in place.
+func (tr *translation) findPointers(file *ast.File) {
+	if Bootstrap {
+		return
 	}
-
-	return fmt.Sprintf("<<%s:%d:%d:%s>>", zeroStr+string(typ), funcId, blockId, name)
-}
-
-// addPointer searches the point where the variable was declared for tag it as pointer.
-func (tr *translation) addPointer(name string) {
-	// In the actual function
-	if tr.funcId != 0 {
-		for block := tr.blockId; block >= 1; block-- {
-			if _, ok := tr.vars[tr.funcId][block][name]; ok {
-				tr.vars[tr.funcId][block][name] = true
-				return
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.UnaryExpr:
+			if t.Op == token.AND {
+				tr.markAddressed(t.X)
+			}
+		case *ast.SelectorExpr:
+			// The methods of the named types which are not structs get the
+			// address of a variable, for a receiver of pointer.
+			if s := tr.selection(t); s != nil && s.Kind() == types.MethodVal &&
+				!isValueRecv(s) && isNamedValue(s.Recv()) && !isValueType(s.Recv()) {
+				tr.markAddressed(t.X)
 			}
 		}
+		return true
+	})
+}
+
+// markAddressed marks the variable or field of the expression, and the ones
+// which hold it by value.
+func (tr *translation) markAddressed(x ast.Expr) {
+	var ident *ast.Ident
+	var holder ast.Expr // struct or array which holds the value
+
+	switch t := ast.Unparen(x).(type) {
+	case *ast.Ident:
+		ident = t
+	case *ast.SelectorExpr:
+		ident = t.Sel
+		holder = t.X
+	case *ast.IndexExpr:
+		holder = t.X
+	default:
+		return
 	}
 
-	// Finally, search in the global variables (funcId = 0).
-	for block := tr.blockId; block >= 0; block-- { // block until 0
-		if _, ok := tr.vars[0][block][name]; ok {
-			tr.vars[0][block][name] = true
-			return
+	if obj, ok := tr.info.Uses[ident].(*types.Var); ok {
+		tr.addressed[obj] = true
+	}
+	if holder != nil {
+		switch tr.typeOf(holder).Underlying().(type) {
+		case *types.Struct, *types.Array:
+			tr.markAddressed(holder)
 		}
 	}
-	//fmt.Printf("Function %d, block %d, name %s\n", tr.funcId, tr.blockId, name)
-	panic("addPointer: unreachable")
 }
 
-// replacePointers replaces tags related to variables addressed.
-func (tr *translation) replacePointers(str *string) {
-	// Replaces tags in variables that access to pointers.
-	replaceLocal := func(funcId, startBlock, endBlock int, varName string) {
-		for block := startBlock; block <= endBlock; block++ {
-			// Check if there is a variable named like the pointer in another block.
-			if block != startBlock {
-				if _, ok := tr.vars[funcId][block][varName]; ok {
-					break
-				}
-			}
-
-			pointer := tagPointer(false, 'P', funcId, block, varName)
-
-			// Comparing pointers with value nil
-			reNil := regexp.MustCompile(pointer + NIL)
-			*str = reNil.ReplaceAllString(*str, FIELD_POINTER)
+// isAddressed reports whether the address of the variable is taken.
+func (tr *translation) isAddressed(obj types.Object) bool {
+	return obj != nil && tr.addressed[obj]
+}
 
-			if tr.addr[funcId][block][varName] {
-				*str = strings.Replace(*str, pointer+ADDR, "", -1)
-			} else {
-				*str = strings.Replace(*str, pointer, FIELD_POINTER, -1)
-			}
-		}
+// isBoxed reports whether the variable is stored in an object, since it is
+// addressed and it is not an object by itself. The fields are pointed by
+// references.
+func (tr *translation) isBoxed(obj types.Object) bool {
+	if v, ok := obj.(*types.Var); !ok || v.IsField() {
+		return false
 	}
+	return tr.isAddressed(obj) && !isValueType(obj.Type())
+}
 
-	// In each function
-	for funcId, blocks := range tr.vars {
-		for blockId := 0; blockId <= len(blocks); blockId++ {
-			for name, isPointer := range tr.vars[funcId][blockId] {
-				if isPointer {
-					replaceLocal(funcId, blockId, len(blocks), name)
+// usesBox reports whether the identifier uses a variable stored in an object.
+func (tr *translation) usesBox(ident *ast.Ident) bool {
+	return tr.isBoxed(tr.info.Uses[ident])
+}
 
-					// Replace brackets around variables addressed.
-					lBrack := tagPointer(false, 'L', funcId, blockId, name)
-					rBrack := tagPointer(false, 'R', funcId, blockId, name)
+// isInPlace reports whether a value assigned to the expression has to be
+// stored into the own struct or array, since it could be pointed.
+func (tr *translation) isInPlace(expr ast.Expr) bool {
+	if Bootstrap || !isValueType(tr.typeOf(expr)) {
+		return false
+	}
+	switch t := ast.Unparen(expr).(type) {
+	case *ast.StarExpr:
+		return true
+	case *ast.Ident:
+		return tr.isAddressed(tr.info.Uses[t])
+	case *ast.SelectorExpr:
+		return tr.isAddressed(tr.info.Uses[t.Sel])
+	case *ast.IndexExpr:
+		return tr.isRow(t)
+	}
+	return false
+}
 
-					*str = strings.Replace(*str, lBrack, "{p:", 1)
-					*str = strings.Replace(*str, rBrack, "}", 1)
+// box returns the value stored into an object, for a variable which is
+// addressed.
+func box(value string) string {
+	return "{p:" + value + "}"
+}
 
-					// The declaration of pointers without initial value
-					// have type "nil" in Go
-					iLBrack := tagPointer(true, 'L', funcId, blockId, name)
-					iRBrack := tagPointer(true, 'R', funcId, blockId, name)
-					re := regexp.MustCompile(iLBrack + `[^<]+` + iRBrack)
+// address returns the pointer to the operand of "&".
+func (tr *translation) address(x ast.Expr) string {
+	x = ast.Unparen(x)
+	typ := tr.typeOf(x)
 
-					*str = re.ReplaceAllString(*str, "{p:undefined}")
-				}
-			}
+	switch t := x.(type) {
+	case *ast.Ident:
+		if name := tr.varName(t); name != tr.recvVar {
+			return name
 		}
+		return "this"
+	case *ast.StarExpr: // &*p
+		return tr.getExpression(t.X).String()
+	case *ast.CompositeLit:
+		return tr.getExpression(t).String()
 	}
 
-	// == Global pointers
-	globalScope := 0
-
-	replaceGlobal := func(globVarName string) {
-		for funcId, blocks := range tr.vars {
-			if funcId == globalScope {
-				continue
-			}
-
-			for blockId := 1; blockId <= len(blocks); blockId++ {
-				if _, ok := tr.vars[funcId][blockId][globVarName]; ok {
-					continue
-				}
-
-				pointer := tagPointer(false, 'P', funcId, blockId, globVarName)
-
-				reNil := regexp.MustCompile(pointer + NIL)
-				*str = reNil.ReplaceAllString(*str, FIELD_POINTER)
-
-				if tr.addr[funcId][blockId][globVarName] {
-					*str = strings.Replace(*str, pointer+ADDR, "", -1)
-				} else {
-					*str = strings.Replace(*str, pointer, FIELD_POINTER, -1)
-				}
-			}
+	// The structs and arrays are the own object.
+	if isValueType(typ) {
+		value := tr.getExpression(x).String()
+		if _, ok := typ.Underlying().(*types.Array); ok {
+			value = stripField(value)
 		}
+		return value
 	}
 
-	for globBlockId := 0; globBlockId <= len(tr.vars[globalScope]); globBlockId++ {
-		for globName, isPointer := range tr.vars[globalScope][globBlockId] {
-			if isPointer {
-				replaceGlobal(globName)
+	switch t := x.(type) {
+	case *ast.SelectorExpr:
+		field := tr.getExpression(t).String()
+		i := strings.LastIndex(field, ".")
+		return fmt.Sprintf("g.Ref(%s, %q)", field[:i], field[i+1:])
+
+	case *ast.IndexExpr:
+		index := tr.getExpression(t.Index).String()
+
+		switch derefType(tr.typeOf(t.X)).Underlying().(type) {
+		case *types.Array:
+			array := tr.getExpression(t.X).String()
+			if !strings.HasSuffix(array, FIELD_VALUE) {
+				array += FIELD_VALUE
 			}
+			return fmt.Sprintf("g.Ref(%s, %s)", array, index)
+		case *types.Slice:
+			return fmt.Sprintf("%s.ref(%s)", stripField(tr.getExpression(t.X).String()), index)
 		}
 	}
 
-	// * * *
-
-	// Remove the tags.
-	*str = reTagPointer.ReplaceAllString(*str, "")
-	*str = strings.Replace(*str, NIL, "", -1)
+	tr.addError("%s: unimplemented address of %s", tr.fset.Position(x.Pos()), types.ExprString(x))
+	return ""
 }
@@ -0,0 +1,300 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// printMode is the layout of the code written by the printer.
+type printMode uint8
+
+const (
+	linesMode  printMode = iota // the statements are in the lines of the Go source
+	prettyMode                  // a statement by line, indented by blocks
+	minMode                     // without spaces neither new lines
+)
+
+// printer writes the JavaScript syntax tree.
+type printer struct {
+	bytes.Buffer
+	mode   printMode
+	lits   []*jsFunc // function literals referenced from the code
+	line   int       // line of the Go source where the output is
+	indent int       // number of blocks opened
+	space  bool      // a space was dropped, in the code minimized
+}
+
+// printProgram returns the code of the program written in the mode.
+func printProgram(prog *jsProgram, mode printMode) string {
+	p := &printer{mode: mode, lits: prog.Lits}
+
+	// The code of the packages is written into a function, to don't add its
+	// names to the global scope.
+	if prog.Package != "" {
+		p.sep(prog.Line)
+		p.code(fmt.Sprintf("var %s = {}; (function() {", prog.Package))
+	}
+	p.stmts(prog.List)
+
+	if prog.Package != "" {
+		p.sep(p.line + 1)
+		p.code("})();")
+	}
+	return p.String()
+}
+
+// stmts writes a list of statements.
+func (p *printer) stmts(list []jsStmt) {
+	for _, s := range list {
+		p.sep(s.line())
+		p.stmt(s)
+	}
+}
+
+// stmt writes a statement.
+func (p *printer) stmt(s jsStmt) {
+	switch t := s.(type) {
+	case *jsCode:
+		p.code(t.Code)
+
+	case *jsBlock:
+		p.block(t)
+
+	case *jsFunc:
+		p.fn(t)
+
+	case *jsIf:
+		p.code("if (" + t.Cond + ") ")
+		p.block(t.Body)
+
+		if t.Else != nil {
+			p.code(" else ")
+			p.stmt(t.Else)
+		}
+
+	case *jsFor:
+		p.code("for (" + t.Head + ") ")
+		p.block(t.Body)
+
+	case *jsSwitch:
+		p.code("switch (" + t.Tag + ") {")
+
+		// The clauses are indented like the statement "switch".
+		for _, c := range t.Cases {
+			p.sep(c.Line)
+
+			for i, v := range c.Values {
+				if i != 0 {
+					p.code(" ")
+				}
+				p.code("case " + v + ":")
+			}
+			if c.Values == nil {
+				p.code("default:")
+			}
+
+			p.indent++
+			p.stmts(c.Body)
+			if c.Break {
+				p.sep(-1)
+				p.code("break;")
+			}
+			p.indent--
+		}
+		p.closing(t.Rbrace, len(t.Cases) == 0)
+
+	default:
+		panic(fmt.Sprintf("printer: unexpected statement: %T", s))
+	}
+}
+
+// block writes a block of statements.
+func (p *printer) block(b *jsBlock) {
+	p.code("{")
+	p.indent++
+	p.stmts(b.List)
+	p.indent--
+	p.closing(b.Rbrace, len(b.List) == 0)
+}
+
+// closing writes the right brace of a block at the line of the Go source.
+func (p *printer) closing(line int, isEmpty bool) {
+	switch p.mode {
+	case linesMode:
+		if line > p.line {
+			p.newLines(line - p.line)
+			p.line = line
+		} else if !isEmpty {
+			p.WriteByte(' ')
+		}
+	case prettyMode:
+		if !isEmpty {
+			p.newLines(1)
+		}
+		if line > p.line {
+			p.line = line
+		}
+	}
+	p.code("}")
+}
+
+// fn writes a function.
+func (p *printer) fn(f *jsFunc) {
+	p.code(f.Head + "(" + strings.Join(f.Params, ", ") + ") ")
+	p.block(f.Body)
+	p.code(f.Tail)
+}
+
+// sep writes the separation before of a statement at the line of the Go
+// source, which is -1 for the statements added by the translator.
+func (p *printer) sep(line int) {
+	switch p.mode {
+	case linesMode:
+		if line > p.line {
+			p.newLines(line - p.line)
+			p.line = line
+		} else if p.Len() != 0 {
+			p.WriteByte(' ')
+		}
+	case prettyMode:
+		if p.Len() != 0 {
+			// The statements separated by blank lines keep one of them.
+			if line > p.line+1 && !p.isOpening() {
+				p.WriteByte('\n')
+			}
+			p.newLines(1)
+		}
+		if line > p.line {
+			p.line = line
+		}
+	}
+}
+
+// newLines writes n new lines, and the indentation.
+func (p *printer) newLines(n int) {
+	p.WriteString(strings.Repeat("\n", n))
+	p.WriteString(strings.Repeat("\t", p.indent))
+}
+
+// isOpening reports whether the last code written opens a block or a clause.
+func (p *printer) isOpening() bool {
+	b := p.Bytes()
+	return len(b) != 0 && (b[len(b)-1] == '{' || b[len(b)-1] == ':')
+}
+
+// code writes code, with the function literals and the lines referenced from
+// it.
+func (p *printer) code(s string) {
+	isBreak := false // the last reference was a line written in a new line
+	parts := splitRefs(s)
+
+	for i, part := range parts {
+		if i%2 == 1 {
+			if strings.HasPrefix(part, ":") {
+				isBreak = p.lineBreak(part[1:], parts[i+1])
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				panic("printer: wrong reference: " + part)
+			}
+			p.fn(p.lits[n])
+			continue
+		}
+		if isBreak {
+			part = strings.TrimLeft(part, " ")
+			isBreak = false
+		}
+		if p.mode != minMode {
+			p.WriteString(part)
+			continue
+		}
+		p.minimize(part)
+	}
+}
+
+// lineBreak writes a new line when the line referenced is after of the current
+// one, reporting whether it was written. The code in the new line, which starts
+// with next, is indented one level more, except when it closes the expression.
+func (p *printer) lineBreak(ref, next string) bool {
+	line, err := strconv.Atoi(ref)
+	if err != nil {
+		panic("printer: wrong reference: " + ref)
+	}
+	if p.mode == minMode || line <= p.line {
+		return false
+	}
+
+	indent := 1
+	if next != "" && strings.IndexByte(")]}", next[0]) != -1 {
+		indent = 0
+	}
+	p.indent += indent
+	if p.mode == linesMode {
+		p.newLines(line - p.line)
+	} else {
+		p.newLines(1)
+	}
+	p.indent -= indent
+	p.line = line
+	return true
+}
+
+// minimize writes the code without spaces, excepting into the strings, and
+// between the tokens which would be joined.
+func (p *printer) minimize(s string) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n':
+			p.space = true
+
+		case '"', '\'', '`':
+			end := i + 1
+			for ; end < len(s) && s[end] != c; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				end = len(s) - 1
+			}
+			p.token(s[i : end+1])
+			i = end
+
+		default:
+			p.token(s[i : i+1])
+		}
+	}
+}
+
+// token writes a token of the code minimized, with a space before of it when
+// it would be joined to the last one.
+func (p *printer) token(tok string) {
+	if p.space && p.Len() != 0 {
+		last := p.Bytes()[p.Len()-1]
+		next := tok[0]
+
+		if isWordChar(last) && isWordChar(next) ||
+			(last == '+' || last == '-') && last == next || // "a - -b"
+			last >= '0' && last <= '9' && next == '.' { // "1 .toString()"
+			p.WriteByte(' ')
+		}
+	}
+	p.space = false
+	p.WriteString(tok)
+}
+
+// isWordChar reports whether the character can be part of an identifier, a
+// keyword or a number.
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '$' || c >= 0x80
+}
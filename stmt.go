@@ -10,39 +10,55 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strings"
 )
 
 // dataStmt represents data for the statements.
 type dataStmt struct {
 	funcTotal int // number total of functions
+	tmpTotal  int // number total of temporary variables
 	funcId    int // number of function
 	blockId   int // number of block
-	tabLevel  int // tabulation level
 	idxResult int // for then be used in resultUseFunc
 
-	lenCase int // number of "case" statements
-	idxCase int // index in "case" statements
+	switchTag ast.Expr // tag compared by the library, stored in "switchVar"
+	switchVar string
+	anonType  string // constructor of the last anonymous struct
 
-	initIsPointer  bool // the value initialized is a pointer?
 	insertVar      bool
 	isConst        bool
 	isVar          bool
 	isArray        bool // to close the parenthesis
 	isFunc         bool
+	isLet          bool // declare the variables with "let"?
 	returnBasicLit bool
-	skipLbrace     bool // left brace
-	skipSemicolon  bool
 	wasFallthrough bool // the last statement was "fallthrough"?
 	wasReturn      bool // the last statement was "return"?
 
 	lastVarName string // for composite types
 	recvVar     string // receiver variable (in methods)
-	results     string // variables names that return must use
+	prologue    string // code written at the beginning of the next block
+
+	funcPos  token.Pos      // start of the current function, to know its variables
+	funcBody *ast.BlockStmt // body of the current function
+
+	declVars []string // variables declared at the beginning of the next block
 
 	resultUseFunc map[int]bool // for JS types: array, slice, map
 }
 
+// newBlock initializes the maps to save the variables created in a new block.
+func (tr *translation) newBlock() {
+	tr.blockId++
+	tr.vars[tr.funcId][tr.blockId] = make(map[string]struct{})
+	tr.maps[tr.funcId][tr.blockId] = make(map[string]struct{})
+	tr.arrays[tr.funcId][tr.blockId] = make(map[string]struct{})
+	tr.slices[tr.funcId][tr.blockId] = make(map[string]struct{})
+	tr.structSlices[tr.funcId][tr.blockId] = make(map[string]struct{})
+	tr.zeroType[tr.funcId][tr.blockId] = make(map[string]string)
+}
+
 // getStatement translates the Go statement.
 func (tr *translation) getStatement(stmt ast.Stmt) {
 	switch typ := stmt.(type) {
@@ -57,7 +73,7 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	//  Rhs    []Expr
 	case *ast.AssignStmt:
 		// There is not variable's type in the assignment.
-		tr.writeVar(typ.Lhs, typ.Rhs, nil, typ.Tok, false, false)
+		tr.writeVar(typ.Lhs, typ.Rhs, nil, typ.Tok, false)
 
 	// http://golang.org/doc/go_spec.html#Blocks
 	// https://developer.mozilla.org/en/JavaScript/Reference/Statements/block
@@ -67,54 +83,7 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	//  List   []Stmt
 	//  Rbrace token.Pos // position of "}"
 	case *ast.BlockStmt:
-		tr.blockId++
-		tr.vars[tr.funcId][tr.blockId] = make(map[string]bool)
-		tr.addr[tr.funcId][tr.blockId] = make(map[string]bool)
-		tr.maps[tr.funcId][tr.blockId] = make(map[string]struct{})
-		tr.arrays[tr.funcId][tr.blockId] = make(map[string]struct{})
-		tr.slices[tr.funcId][tr.blockId] = make(map[string]struct{})
-		tr.structSlices[tr.funcId][tr.blockId] = make(map[string]struct{})
-		tr.zeroType[tr.funcId][tr.blockId] = make(map[string]string)
-
-		if !tr.skipLbrace {
-			tr.WriteString("{")
-		} else {
-			tr.skipLbrace = false
-		}
-
-		for i, v := range typ.List {
-			skipTab := false
-
-			// Don't insert tabulation in both "case", "label" clauses
-			switch v.(type) {
-			case *ast.CaseClause, *ast.LabeledStmt:
-				skipTab = true
-			default:
-				tr.tabLevel++
-			}
-
-			// Write tabulation
-			if tr.addLine(v.Pos()) {
-				tr.WriteString(strings.Repeat(TAB, tr.tabLevel))
-			} else if i == 0 {
-				tr.WriteString(SP)
-			}
-
-			tr.getStatement(v)
-
-			if !skipTab {
-				tr.tabLevel--
-			}
-		}
-
-		if tr.addLine(typ.Rbrace) {
-			tr.WriteString(strings.Repeat(TAB, tr.tabLevel))
-		} else {
-			tr.WriteString(SP)
-		}
-
-		tr.WriteString("}")
-		tr.blockId--
+		tr.addStmt(tr.getBlock(typ))
 
 	// godoc go/ast BranchStmt
 	//  TokPos token.Pos   // position of Tok
@@ -123,11 +92,9 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	case *ast.BranchStmt:
 		/*label := ";"
 		if typ.Label != nil {
-			label = SP + typ.Label.Name + ";"
+			label = " " + typ.Label.Name + ";"
 		}*/
 
-		tr.addLine(typ.TokPos)
-
 		switch typ.Tok {
 		// http://golang.org/doc/go_spec.html#Break_statements
 		// https://developer.mozilla.org/en/JavaScript/Reference/Statements/break
@@ -145,50 +112,6 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 			tr.addError("%s: goto directive", tr.fset.Position(typ.TokPos))
 		}
 
-	// godoc go/ast CaseClause
-	//  Case  token.Pos // position of "case" or "default" keyword
-	//  List  []Expr    // list of expressions or types; nil means default case
-	//  Colon token.Pos // position of ":"
-	//  Body  []Stmt    // statement list; or nil
-	case *ast.CaseClause:
-		// To check the last statements
-		tr.wasReturn = false
-		tr.wasFallthrough = false
-
-		tr.idxCase++
-		tr.addLine(typ.Case)
-
-		if typ.List != nil {
-			for i, expr := range typ.List {
-				if i != 0 {
-					tr.WriteString(SP)
-				}
-				tr.WriteString(fmt.Sprintf("case %s:", tr.getExpression(expr).String()))
-			}
-		} else {
-			tr.WriteString("default:")
-
-			if tr.idxCase != tr.lenCase {
-				tr.addWarning("%s: 'default' clause above 'case' clause in switch statement",
-					tr.fset.Position(typ.Pos()))
-			}
-		}
-
-		if typ.Body != nil {
-			for _, v := range typ.Body {
-				if ok := tr.addLine(v.Pos()); ok {
-					tr.WriteString(strings.Repeat(TAB, tr.tabLevel+1))
-				} else {
-					tr.WriteString(SP)
-				}
-				tr.getStatement(v)
-			}
-		}
-
-		if !tr.wasFallthrough && !tr.wasReturn && tr.idxCase != tr.lenCase {
-			tr.WriteString(SP + "break;")
-		}
-
 	// godoc go/ast DeclStmt
 	//  Decl Decl
 	case *ast.DeclStmt:
@@ -196,7 +119,7 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 		case *ast.GenDecl:
 			switch decl.Tok {
 			case token.CONST:
-				tr.getConst(decl.TokPos, decl.Specs, false)
+				tr.getConst(decl.Specs, false)
 			case token.VAR:
 				tr.getVar(decl.Specs, false)
 			case token.TYPE:
@@ -223,28 +146,51 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	//  Post Stmt      // post iteration statement; or nil
 	//  Body *BlockStmt
 	case *ast.ForStmt:
-		tr.WriteString("for" + SP + "(")
+		tr.flush()
+		head := ";"
+
+		// The variables captured by a closure or addressed are declared with
+		// "let" so there is a variable by iteration.
+		addressed := []string{}
+		if init, ok := typ.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+			for _, v := range init.Lhs {
+				isCaptured, isAddressed := tr.loopVar(v.(*ast.Ident), typ)
+				if isCaptured || isAddressed {
+					tr.isLet = true
+				}
+				if isAddressed {
+					addressed = append(addressed, tr.varName(v.(*ast.Ident)))
+				}
+			}
+		}
 
 		if typ.Init != nil {
 			tr.getStatement(typ.Init)
-		} else {
-			tr.WriteString(";")
+			tr.isLet = false
+			head = tr.takeCode()
 		}
-
 		if typ.Cond != nil {
-			tr.WriteString(SP)
-			tr.WriteString(tr.getExpression(typ.Cond).String())
+			head += " " + tr.getExpression(typ.Cond).String()
 		}
-		tr.WriteString(";")
+		head += ";"
 
+		// The addressed variables get a new pointer, with the value of the last
+		// iteration, before of the post statement.
+		for i, v := range addressed {
+			if i != 0 {
+				head += ","
+			}
+			head += " " + v + " = {p:" + v + FIELD_POINTER + "}"
+		}
 		if typ.Post != nil {
-			tr.WriteString(SP)
-			tr.skipSemicolon = true
+			if len(addressed) != 0 {
+				head += ","
+			}
 			tr.getStatement(typ.Post)
+			head += " " + strings.TrimSuffix(tr.takeCode(), ";")
 		}
 
-		tr.WriteString(")" + SP)
-		tr.getStatement(typ.Body)
+		tr.addStmt(&jsFor{tr.getLine(typ.For), head, tr.getBlock(typ.Body)})
 
 	// http://golang.org/doc/go_spec.html#Go_statements
 	//
@@ -264,30 +210,22 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	//  Body *BlockStmt
 	//  Else Stmt // else branch; or nil
 	case *ast.IfStmt:
-		if typ.Init != nil {
-			tr.getStatement(typ.Init)
-			tr.WriteString(SP)
-		}
-
-		tr.WriteString(fmt.Sprintf("if%s(%s)%s", SP, tr.getExpression(typ.Cond).String(), SP))
-		tr.getStatement(typ.Body)
-
-		if typ.Else != nil {
-			tr.WriteString(SP + "else ")
-			tr.getStatement(typ.Else)
-		}
+		tr.addStmt(tr.getIf(typ))
 
 	// godoc go/ast IncDecStmt
 	//  X      Expr
 	//  TokPos token.Pos   // position of Tok
 	//  Tok    token.Token // INC or DEC
 	case *ast.IncDecStmt:
-		tr.WriteString(tr.getExpression(typ.X).String() + typ.Tok.String())
+		expr := tr.newExpression(nil)
+		expr.isLHS = true
+		expr.translate(typ.X)
 
-		if tr.skipSemicolon {
-			tr.skipSemicolon = false
+		if expr.addSet { // the element is set through a function
+			op := typ.Tok.String()[:1]
+			tr.WriteString(fmt.Sprintf("%s %s %s 1);", expr.String(), expr.elem, op))
 		} else {
-			tr.WriteString(";")
+			tr.WriteString(expr.String() + typ.Tok.String() + ";")
 		}
 
 	// http://golang.org/doc/go_spec.html#For_statements
@@ -301,6 +239,10 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	//  X          Expr        // value to range over
 	//  Body       *BlockStmt
 	case *ast.RangeStmt:
+		if tr.writeRange(typ) {
+			break
+		}
+		// The JS library ranges over the arrays and objects of JavaScript.
 		expr := tr.getExpression(typ.X).String()
 		key := tr.getExpression(typ.Key).String()
 		value := ""
@@ -315,28 +257,25 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 		if typ.Value != nil {
 			value = tr.getExpression(typ.Value).String()
 			if typ.Tok == token.DEFINE {
-				tr.WriteString(fmt.Sprintf("var %s;%s", value, SP))
+				tr.WriteString(fmt.Sprintf("var %s;", value))
 			}
 		}
 
-		tr.WriteString(fmt.Sprintf("for%s(var %s in %s", SP, key, expr))
+		head := fmt.Sprintf("var %s in %s", key, expr)
 		if isMap {
-			tr.WriteString(".v")
+			head += ".v"
 		}
-		tr.WriteString(")" + SP)
 
 		if typ.Value != nil {
-			tr.WriteString(fmt.Sprintf("{%s=%s", SP+value+SP, SP+expr))
+			tr.prologue = fmt.Sprintf("%s = %s", value, expr)
 			if isMap {
-				tr.WriteString(".get(" + key + ")[0];")
+				tr.prologue += ".get(" + key + ")[0];"
 			} else {
-				tr.WriteString("[" + key + "];")
+				tr.prologue += "[" + key + "];"
 			}
-
-			tr.skipLbrace = true
 		}
 
-		tr.getStatement(typ.Body)
+		tr.addStmt(&jsFor{tr.getLine(typ.For), head, tr.getBlock(typ.Body)})
 
 	// http://golang.org/doc/go_spec.html#Return_statements
 	// https://developer.mozilla.org/en/JavaScript/Reference/Statements/return
@@ -348,11 +287,7 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 		tr.wasReturn = true
 
 		if typ.Results == nil {
-			if tr.results != "" {
-				tr.WriteString(tr.results)
-			} else {
-				tr.WriteString("return;")
-			}
+			tr.WriteString("return;")
 			tr.wasReturn = false
 			break
 		}
@@ -362,16 +297,24 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 			results := ""
 			for i, v := range typ.Results {
 				if i != 0 {
-					results += "," + SP
+					results += ", "
 				}
 				tr.idxResult = i
-				results += tr.getExpression(v).String()
+				results += tr.copyResult(v, tr.getExpression(v).String())
 			}
 
 			tr.WriteString("return [" + results + "];")
 		} else {
 			tr.idxResult = 0
-			tr.WriteString("return " + tr.getExpression(typ.Results[0]).String() + ";")
+			result := tr.getExpression(typ.Results[0])
+
+			if result.kind == sliceKind && !Bootstrap { // slice literal
+				tr.WriteString("return g.Slice(" + result.String() + ");")
+			} else if result.isMake && !Bootstrap {
+				tr.WriteString("return g.MkSlice(" + result.String() + ");")
+			} else {
+				tr.WriteString("return " + tr.copyResult(typ.Results[0], result.String()) + ";")
+			}
 		}
 		tr.wasReturn = false
 
@@ -384,19 +327,7 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 	//  Tag    Expr       // tag expression; or nil
 	//  Body   *BlockStmt // CaseClauses only
 	case *ast.SwitchStmt:
-		tag := "true"
-		tr.lenCase = len(typ.Body.List)
-		tr.idxCase = 0
-
-		if typ.Tag != nil {
-			tag = tr.getExpression(typ.Tag).String()
-		} else if typ.Init != nil {
-			tr.getStatement(typ.Init)
-			tr.WriteString(SP)
-		}
-
-		tr.WriteString(fmt.Sprintf("switch%s(%s)%s", SP, tag, SP))
-		tr.getStatement(typ.Body)
+		tr.addStmt(tr.getSwitch(typ))
 
 	// == Not supported
 
@@ -422,3 +353,408 @@ func (tr *translation) getStatement(stmt ast.Stmt) {
 		panic(fmt.Sprintf("unimplemented: %T", stmt))
 	}
 }
+
+// getBlock translates a block of statements. The code written before is added
+// like a statement, since the block is added after of it.
+func (tr *translation) getBlock(block *ast.BlockStmt) *jsBlock {
+	tr.flush()
+	list := tr.list
+	b := &jsBlock{Line: tr.getLine(block.Lbrace), Rbrace: tr.getLine(block.Rbrace)}
+	tr.list = &b.List
+
+	tr.newBlock()
+
+	for _, v := range tr.declVars {
+		tr.vars[tr.funcId][tr.blockId][v] = void
+	}
+	tr.declVars = nil
+
+	if tr.prologue != "" {
+		b.List = append(b.List, &jsCode{b.Line, tr.prologue})
+		tr.prologue = ""
+	}
+
+	for _, v := range block.List {
+		tr.setLine(v.Pos())
+		tr.getStatement(v)
+	}
+	tr.flush()
+
+	tr.blockId--
+	tr.list = list
+	return b
+}
+
+// getIf translates the statement "if". Its initialization is written before.
+func (tr *translation) getIf(stmt *ast.IfStmt) *jsIf {
+	if stmt.Init != nil {
+		tr.getStatement(stmt.Init)
+	}
+	s := &jsIf{Line: tr.getLine(stmt.If), Cond: tr.getExpression(stmt.Cond).String()}
+	s.Body = tr.getBlock(stmt.Body)
+
+	switch t := stmt.Else.(type) {
+	case *ast.BlockStmt:
+		s.Else = tr.getBlock(t)
+
+	case *ast.IfStmt:
+		if t.Init == nil {
+			s.Else = tr.getIf(t)
+			break
+		}
+		// The initialization is written into a block, with the statement.
+		list := tr.list
+		b := &jsBlock{Line: tr.getLine(t.If), Rbrace: tr.getLine(t.End())}
+		tr.list = &b.List
+		tr.line = b.Line
+
+		tr.addStmt(tr.getIf(t))
+		tr.list = list
+		s.Else = b
+	}
+	return s
+}
+
+// renameShadowing renames the variables declared into the statements which hide
+// another local variable, since a variable of JavaScript is visible in all the
+// function.
+func (tr *translation) renameShadowing(list []ast.Stmt) {
+	for _, stmt := range list {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch t := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.Ident:
+				obj, ok := tr.info.Defs[t].(*types.Var)
+				if !ok || obj.IsField() || !isShadowing(obj) {
+					break
+				}
+				if _, ok := tr.renamed[obj]; !ok {
+					tr.rename(obj, validIdent(obj.Name()))
+				}
+			}
+			return true
+		})
+	}
+}
+
+// getSwitch translates the statement "switch".
+func (tr *translation) getSwitch(stmt *ast.SwitchStmt) *jsSwitch {
+	tag := "true"
+	switchTag, switchVar := tr.switchTag, tr.switchVar
+	tr.switchTag = nil
+
+	if stmt.Init != nil {
+		// The variables declared are only visible into the switch.
+		if init, ok := stmt.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+			for _, v := range init.Lhs {
+				if obj := tr.info.Defs[v.(*ast.Ident)]; obj != nil && isShadowing(obj) {
+					tr.rename(obj, validIdent(obj.Name()))
+				}
+			}
+		}
+		tr.getStatement(stmt.Init)
+	}
+
+	if stmt.Tag != nil {
+		tag = tr.getExpression(stmt.Tag).String()
+
+		// JavaScript compares using identity, so the values which are not
+		// basic are compared by the library, with the tag evaluated once.
+		if tagType := tr.typeOf(stmt.Tag); tagType != nil {
+			if _, ok := tagType.Underlying().(*types.Basic); !ok {
+				tr.switchTag = stmt.Tag
+				tr.switchVar = tr.newTmp("s")
+				tr.WriteString(fmt.Sprintf("var %s = %s;", tr.switchVar, tag))
+				tag = "true"
+			}
+		}
+	}
+	tr.flush()
+
+	s := &jsSwitch{Line: tr.getLine(stmt.Switch), Tag: tag, Rbrace: tr.getLine(stmt.Body.Rbrace)}
+	list := tr.list
+
+	// godoc go/ast CaseClause
+	//  Case  token.Pos // position of "case" or "default" keyword
+	//  List  []Expr    // list of expressions or types; nil means default case
+	//  Colon token.Pos // position of ":"
+	//  Body  []Stmt    // statement list; or nil
+	for i, v := range stmt.Body.List {
+		clause := v.(*ast.CaseClause)
+		c := &jsCase{Line: tr.getLine(clause.Case)}
+
+		// To check the last statements
+		tr.wasReturn = false
+		tr.wasFallthrough = false
+
+		// Like in JavaScript, the expressions are evaluated in order until
+		// one matches, and the clause "default" can be anywhere.
+		for _, expr := range clause.List {
+			value := tr.getExpression(expr).String()
+			if tr.isArray { // array literal
+				value += ")" + FIELD_VALUE
+				tr.isArray = false
+			}
+			if tr.switchTag != nil {
+				if t := tr.equalType(tr.switchTag, expr, expr); t != nil {
+					value = tr.equal(tr.switchVar, value, tr.valueDesc(t), false)
+				}
+			}
+			c.Values = append(c.Values, value)
+		}
+
+		// Each clause is an implicit block.
+		tr.newBlock()
+		tr.list = &c.Body
+		tr.renameShadowing(clause.Body)
+
+		for _, v := range clause.Body {
+			tr.setLine(v.Pos())
+			tr.getStatement(v)
+		}
+		tr.flush()
+
+		c.Break = !tr.wasFallthrough && !tr.wasReturn && i != len(stmt.Body.List)-1
+		tr.blockId--
+		s.Cases = append(s.Cases, c)
+	}
+
+	tr.list = list
+	tr.switchTag, tr.switchVar = switchTag, switchVar
+	return s
+}
+
+// writeRange translates the statement "range" according to the type of the
+// expression, which is evaluated only once. It returns false if the type is
+// not known.
+func (tr *translation) writeRange(stmt *ast.RangeStmt) bool {
+	typ := tr.typeOf(stmt.X)
+	if typ == nil || Bootstrap {
+		return false
+	}
+	key, value := "", ""
+	isDefine := stmt.Tok == token.DEFINE
+	isFresh := false // a new variable is declared in each iteration
+	isBoxed := map[string]bool{}
+
+	for _, v := range []ast.Expr{stmt.Key, stmt.Value} {
+		if v == nil || isBlank(v) {
+			continue
+		}
+		name := ""
+		if isDefine {
+			isCaptured, isAddressed := tr.loopVar(v.(*ast.Ident), stmt.Body)
+			isFresh = isFresh || isCaptured || isAddressed
+			name = tr.varName(v.(*ast.Ident))
+			isBoxed[name] = tr.isBoxed(tr.info.Defs[v.(*ast.Ident)])
+		} else {
+			name = tr.getExpression(v).String()
+		}
+		if v == stmt.Key {
+			key = name
+		} else {
+			value = name
+		}
+	}
+
+	x := tr.getExpression(stmt.X)
+	expr := x.String()
+	if x.kind == sliceKind { // slice literal
+		expr = "g.Slice(" + expr + ")"
+	}
+	init := ""       // initialization of the loop
+	head := ""       // header of the loop
+	before := ""     // statement before of the loop
+	keyValue := ""   // value assigned to the key in each iteration
+	valueValue := "" // value assigned to the value in each iteration
+
+	switch t := derefType(typ).Underlying().(type) {
+	case *types.Basic:
+		// == Integer (Go 1.22)
+		if t.Info()&types.IsInteger != 0 {
+			index := key
+			if key == "" || !isDefine || isFresh {
+				index = tr.newTmp("i")
+				keyValue = index
+			}
+			if !isSimple(stmt.X) {
+				n := tr.newTmp("n")
+				init = fmt.Sprintf(", %s = %s", n, expr)
+				expr = n
+			}
+			head = fmt.Sprintf("var %s = 0%s; %s < %s; %s++", index, init, index, expr, index)
+			break
+		}
+		if t.Info()&types.IsString == 0 {
+			return false
+		}
+
+		// == String
+		// The runes are decoded from the bytes, getting the index of each one.
+		index, runes := tr.newTmp("i"), tr.newTmp("r")
+
+		head = fmt.Sprintf("var %s = 0, %s = g.RangeString(%s); %s < %s.length; %s += 2",
+			index, runes, expr, index, runes, index)
+
+		keyValue = fmt.Sprintf("%s[%s]", runes, index)
+		valueValue = fmt.Sprintf("%s[%s + 1]", runes, index)
+
+	case *types.Slice:
+		index := key
+		if key == "" || !isDefine || isFresh {
+			index = tr.newTmp("i")
+			keyValue = index
+		}
+		// The elements are read until the length at the beginning, from the
+		// slice saved when it could be other one into the loop.
+		expr = stripField(expr)
+		if value != "" && (tr.isRangeChanged(stmt) || strings.Contains(expr, "(")) {
+			r := tr.newTmp("r")
+			init = fmt.Sprintf(", %s = %s", r, expr)
+			expr = r
+		}
+		n := tr.newTmp("n")
+		init += fmt.Sprintf(", %s = %s.len", n, expr)
+
+		head = fmt.Sprintf("var %s = 0%s; %s < %s; %s++", index, init, index, n, index)
+		valueValue = fmt.Sprintf("%s.at(%s)", expr, index)
+
+	case *types.Array:
+		index := key
+		if key == "" || !isDefine || isFresh {
+			index = tr.newTmp("i")
+			keyValue = index
+		}
+		if _, ok := stmt.X.(*ast.CompositeLit); ok {
+			// The literals are built like the values of the variables.
+			expr += FIELD_VALUE
+		}
+		// The array is copied when it could be changed into the loop, like
+		// in Go, and the arrays got through a function are saved.
+		if _, isPtr := typ.Underlying().(*types.Pointer); value != "" && !isPtr &&
+			tr.isRangeChanged(stmt) {
+			r := tr.newTmp("r")
+			init = fmt.Sprintf(", %s = g.Clone(%s, %s)", r, expr, tr.valueDesc(t))
+			expr = r
+		} else if !isSimple(stmt.X) || strings.Contains(expr, "(") {
+			r := tr.newTmp("r")
+			init = fmt.Sprintf(", %s = %s", r, expr)
+			expr = r
+		}
+		head = fmt.Sprintf("var %s = 0%s; %s < %s.length; %s++", index, init, index, expr, index)
+		valueValue = fmt.Sprintf("%s[%s]", expr, index)
+		// The rows of a multi-dimensional array.
+		if _, ok := t.Elem().(*types.Array); ok {
+			valueValue = "g.Row(" + valueValue + ")"
+		}
+
+	case *types.Map:
+		// The entries are pairs [key, value], got from an iterator which
+		// reflects the entries added or deleted into the loop.
+		entry := tr.newTmp("e")
+
+		head = fmt.Sprintf("var %s of %s.entries()", entry, expr)
+		keyValue = entry + "[0]"
+		valueValue = entry + "[1]"
+
+	default:
+		return false
+	}
+
+	if key == "" || keyValue == "" {
+		key, keyValue = "", ""
+	}
+	if value == "" {
+		valueValue = ""
+	} else if !isDefine || tr.isChanged(tr.info.Defs[stmt.Value.(*ast.Ident)], stmt.Body) {
+		// The element is copied when it could be changed.
+		if t := tr.typeOf(stmt.Value); isValueType(t) {
+			valueValue = fmt.Sprintf("g.Clone(%s, %s)", valueValue, tr.valueDesc(t))
+		}
+	}
+	assign := "" // assignments at the beginning of each iteration
+
+	// The variables captured by a closure or addressed are declared into the
+	// body so there is a variable by iteration, stored in an object when they
+	// are addressed.
+	if isFresh {
+		decl := ""
+		for _, v := range [][2]string{{key, keyValue}, {value, valueValue}} {
+			if v[0] == "" {
+				continue
+			}
+			tr.declVars = append(tr.declVars, v[0])
+			if decl != "" {
+				decl += ", "
+			}
+			if isBoxed[v[0]] {
+				v[1] = box(v[1])
+			}
+			decl += v[0] + " = " + v[1]
+		}
+		assign = "let " + decl + ";"
+	} else {
+		decl := ""
+		for _, v := range [][2]string{{key, keyValue}, {value, valueValue}} {
+			if v[0] == "" {
+				continue
+			}
+			if decl != "" {
+				decl += ", "
+			}
+			decl += v[0]
+			if assign != "" {
+				assign += " "
+			}
+			assign += fmt.Sprintf("%s = %s;", v[0], v[1])
+		}
+		// The variables declared are written before of the loop.
+		if isDefine && decl != "" {
+			before = "var " + decl + ";"
+		}
+	}
+	tr.WriteString(before)
+	tr.prologue = assign
+	tr.addStmt(&jsFor{tr.getLine(stmt.For), head, tr.getBlock(stmt.Body)})
+	return true
+}
+
+// isRangeChanged reports whether the slice or array ranged could be changed
+// into the loop: it is not a new value, nor a local variable which is neither
+// assigned into the loop nor shared.
+func (tr *translation) isRangeChanged(stmt *ast.RangeStmt) bool {
+	if tr.isNewValue(stmt.X) {
+		return false
+	}
+	ident, ok := ast.Unparen(stmt.X).(*ast.Ident)
+	if !ok || tr.funcBody == nil {
+		return true
+	}
+	obj := tr.info.Uses[ident]
+	if obj == nil || obj.Parent() == tr.pkg.Scope() || tr.isAddressed(obj) {
+		return true
+	}
+	return tr.isAssigned(obj, stmt.Body) || tr.isChanged(obj, stmt.Body) ||
+		tr.isShared(obj, tr.funcBody)
+}
+
+// isBlank checks if the expression is the blank identifier.
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == BLANK
+}
+
+// isSimple checks if the expression can be evaluated several times, without
+// side effects.
+func isSimple(expr ast.Expr) bool {
+	switch typ := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return isSimple(typ.X)
+	case *ast.ParenExpr:
+		return isSimple(typ.X)
+	}
+	return false
+}
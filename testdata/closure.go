@@ -0,0 +1,182 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+func apply(f func(int) int, v int) int { return f(v) }
+
+func adder(n int) func(int) int {
+	return func(v int) int {
+		return v + n
+	}
+}
+
+func counter() (func() int, func()) {
+	c := 0
+	incr := func() int {
+		c++
+		return c
+	}
+	reset := func() { c = 0 }
+	return incr, reset
+}
+
+func argument() {
+	pass := true
+
+	if apply(func(v int) int { return v * 2 }, 4) != 8 {
+		fmt.Printf("\tFAIL: literal argument => got %v, want 8\n",
+			apply(func(v int) int { return v * 2 }, 4))
+		pass, PASS = false, false
+	}
+
+	r := func(a, b int) int { return a - b }(9, 2)
+	if r != 7 {
+		fmt.Printf("\tFAIL: called directly => got %v, want 7\n", r)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func closure() {
+	pass := true
+
+	add5 := adder(5)
+	if add5(1) != 6 || adder(2)(3) != 5 {
+		fmt.Printf("\tFAIL: returned => got %v, %v\n", add5(1), adder(2)(3))
+		pass, PASS = false, false
+	}
+
+	incr, reset := counter()
+	incr()
+	incr()
+	if incr() != 3 {
+		fmt.Println("\tFAIL: captured variable")
+		pass, PASS = false, false
+	}
+	reset()
+	if incr() != 1 {
+		fmt.Println("\tFAIL: shared variable")
+		pass, PASS = false, false
+	}
+
+	x := 1
+	p := &x
+	double := func() { x *= 2 }
+	double()
+	double()
+	if *p != 4 {
+		fmt.Printf("\tFAIL: addressed variable => got %v, want 4\n", *p)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type button struct {
+	label   string
+	onClick func(string) string
+}
+
+func (b button) click() string {
+	if b.onClick == nil {
+		return ""
+	}
+	return b.onClick(b.label)
+}
+
+type handler struct {
+	prefix string
+}
+
+func (h handler) handle(names []string) string {
+	str := ""
+	each := func(s string) { str += h.prefix + s }
+	for _, v := range names {
+		each(v)
+	}
+	return str
+}
+
+func value() {
+	pass := true
+
+	b := button{"ok", func(s string) string { return "clicked " + s }}
+	if b.click() != "clicked ok" {
+		fmt.Printf("\tFAIL: field => got %v\n", b.click())
+		pass, PASS = false, false
+	}
+
+	var empty button
+	if empty.click() != "" {
+		fmt.Println("\tFAIL: nil field")
+		pass, PASS = false, false
+	}
+
+	ops := map[string]func(int, int) int{
+		"add": func(a, b int) int { return a + b },
+		"mul": func(a, b int) int { return a * b },
+	}
+	if ops["add"](2, 3) != 5 || ops["mul"](2, 3) != 6 {
+		fmt.Println("\tFAIL: map values")
+		pass, PASS = false, false
+	}
+
+	var f func()
+	if f != nil {
+		fmt.Println("\tFAIL: nil function")
+		pass, PASS = false, false
+	}
+	f = func() {}
+	if f == nil {
+		fmt.Println("\tFAIL: not nil function")
+		pass, PASS = false, false
+	}
+
+	h := handler{"-"}
+	if h.handle([]string{"a", "b"}) != "-a-b" {
+		fmt.Printf("\tFAIL: receiver in closure => got %v\n", h.handle([]string{"a", "b"}))
+		pass, PASS = false, false
+	}
+
+	// The literals into the body are not the value of the variable.
+	size := func() int { return len([]int{1, 2}) + len(map[int]bool{1: true}) }
+	if size == nil || size() != 3 {
+		fmt.Println("\tFAIL: literals in function")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Function values\n\n")
+
+	fmt.Println("=== RUN argument")
+	argument()
+	fmt.Println("=== RUN closure")
+	closure()
+	fmt.Println("=== RUN value")
+	value()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Function values")
+	}
+}
@@ -0,0 +1,183 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function apply(f, v) { return f(v); }
+
+function adder(n) {
+	return function(v) {
+		return v + n;
+	};
+}
+
+function counter() {
+	var c = 0;
+	var incr = function() {
+		c++;
+		return c;
+	};
+	var reset = function() { c = 0; };
+	return [incr, reset];
+}
+
+function argument() {
+	var pass = true;
+
+	if (apply(function(v) { return v * 2; }, 4) != 8) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: literal argument => got %v, want 8<br>", [apply(function(v) {
+			return v * 2; }, 4)])));
+		pass = false; PASS = false;
+	}
+
+	var r = (function(a, b) { return a - b; })(9, 2);
+	if (r != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: called directly => got %v, want 7<br>", [r])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function closure() {
+	var pass = true;
+
+	var add5 = adder(5);
+	if (add5(1) != 6 || adder(2)(3) != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: returned => got %v, %v<br>", [add5(1), adder(2)(3)])));
+		pass = false; PASS = false;
+	}
+
+	var $r1 = counter(), incr = $r1[0], reset = $r1[1];
+	incr();
+	incr();
+	if (incr() != 3) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: captured variable"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+	reset();
+	if (incr() != 1) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: shared variable"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var x = {p:1};
+	var p = x;
+	var double = function() { x.p *= 2; };
+	double();
+	double();
+	if (p.p != 4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: addressed variable => got %v, want 4<br>", [p.p])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function button(label, onClick) {
+	this.label = label;
+	this.onClick = onClick;
+}
+
+button.prototype.click = function() {
+	if (this.onClick == undefined) {
+		return "";
+	}
+	return this.onClick(this.label);
+};
+
+function handler(prefix) {
+	this.prefix = prefix;
+}
+
+handler.prototype.handle = function(names) { var h = g.Clone(this, {prefix: 0});
+	var str = "";
+	var each = function(s) { str += h.prefix + s; };
+	var v; for (var $i1 = 0, $n2 = names.len; $i1 < $n2; $i1++) { v = names.at($i1);
+		each(v);
+	}
+	return str;
+};
+
+function value() {
+	var pass = true;
+
+	var b = new button("ok", function(s) { return "clicked " + s; });
+	if (b.click() != "clicked ok") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: field => got %v<br>", [b.click()])));
+		pass = false; PASS = false;
+	}
+
+	var empty = new button("", undefined);
+	if (empty.click() != "") {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: nil field"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var ops = g.MkMap(undefined, [
+		["add", function(a, b) { return a + b; }],
+		["mul", function(a, b) { return a * b; }]
+	]);
+	if (ops.get("add")[0](2, 3) != 5 || ops.get("mul")[0](2, 3) != 6) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: map values"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var f = undefined;
+	if (f != undefined) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: nil function"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+	f = function() {};
+	if (f == undefined) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: not nil function"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var h = new handler("-");
+	if (h.handle(g.Slice("", ["a", "b"])) != "-a-b") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: receiver in closure => got %v<br>", [h.handle(g.Slice("", ["a", "b"]))])));
+		pass = false; PASS = false;
+	}
+
+
+	var size = function() { return g.Slice(0, [1, 2]).len + g.MkMap(false, [[1, true]]).len(); };
+	if (size == undefined || size() != 3) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: literals in function"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Function values<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN argument"], true) + "<br>"));
+	argument();
+	document.write(g.Text(g.Sprint(["=== RUN closure"], true) + "<br>"));
+	closure();
+	document.write(g.Text(g.Sprint(["=== RUN value"], true) + "<br>"));
+	value();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Function values"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
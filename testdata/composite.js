@@ -11,26 +11,26 @@
 var PASS = true;
 
 function person(name, age) {
-	this.name=name;
-	this.age=age
+	this.name = name;
+	this.age = age;
 }
 
 
 function older(p1, p2) {
 	if (p1.age > p2.age) {
-		return [p1, p1.age - p2.age];
+		return [g.Clone(p1, {name: 0, age: 0}), p1.age - p2.age];
 	}
-	return [p2, p2.age - p1.age];
+	return [g.Clone(p2, {name: 0, age: 0}), p2.age - p1.age];
 }
 
 
 function older10(people) {
-	var older = people.v[0];
+	var older = g.Clone(people.v[0], {name: 0, age: 0});
 
 
 	for (var index = 1; index < 10; index++) {
 		if (people.v[index].age > older.age) {
-			older = people.v[index];
+			older = g.Clone(people.v[index], {name: 0, age: 0});
 		}
 	}
 	return older;
@@ -54,49 +54,49 @@ function builtInArray() {
 	var a5 = g.MkArray([3,4], 0);
 	var a6 = g.MkArray([3,4,2], 0);
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-
-
-		_("len a1", a1.len() == 5, true),
-		_("len a2", a2.len() == 5, true),
-		_("len a3", a3.len() == 5, true),
-		_("len a4", a4.len() == 5, true),
-		_("len a4", a4.len() != 5, false),
-
-		_("cap a1", a1.cap() == 5, true),
-		_("cap a2", a2.cap() == 5, true),
-		_("cap a3", a3.cap() == 5, true),
-		_("cap a4", a4.cap() == 5, true),
-
-		_("len a5", a5.len() == 3, true),
-		_("cap a5", a5.cap() == 3, true),
-		_("len a5[0]", a5.len(0) == 4, true),
-		_("cap a5[0]", a5.cap(0) == 4, true),
-		_("len a5[1000]", a5.len(1000) == 4, true),
-		_("cap a5[1000]", a5.cap(1000) == 4, true),
-
-		_("len a6", a6.len() == 3, true),
-		_("cap a6", a6.cap() == 3, true),
-		_("len a6[0]", a6.len(0) == 4, true),
-		_("cap a6[0]", a6.cap(0) == 4, true),
-		_("len a6[0][0]", a6.len(0,0) == 2, true),
-		_("cap a6[0][0]", a6.cap(0,0) == 2, true),
-		_("len a6[0][1000]", a6.len(0,1000) == 2, true),
-		_("cap a6[0][1000]", a6.cap(0,1000) == 2, true)
+	}; }; var tests = [
+
+
+		$T1("len a1", a1.len() == 5, true),
+		$T1("len a2", a2.len() == 5, true),
+		$T1("len a3", a3.len() == 5, true),
+		$T1("len a4", a4.len() == 5, true),
+		$T1("len a4", a4.len() != 5, false),
+
+		$T1("cap a1", a1.cap() == 5, true),
+		$T1("cap a2", a2.cap() == 5, true),
+		$T1("cap a3", a3.cap() == 5, true),
+		$T1("cap a4", a4.cap() == 5, true),
+
+		$T1("len a5", a5.len() == 3, true),
+		$T1("cap a5", a5.cap() == 3, true),
+		$T1("len a5[0]", 4 == 4, true),
+		$T1("cap a5[0]", 4 == 4, true),
+		$T1("len a5[1000]", 4 == 4, true),
+		$T1("cap a5[1000]", 4 == 4, true),
+
+		$T1("len a6", a6.len() == 3, true),
+		$T1("cap a6", a6.cap() == 3, true),
+		$T1("len a6[0]", 4 == 4, true),
+		$T1("cap a6[0]", 4 == 4, true),
+		$T1("len a6[0][0]", 2 == 2, true),
+		$T1("cap a6[0][0]", 2 == 2, true),
+		$T1("len a6[0][1000]", 2 == 2, true),
+		$T1("cap a6[0][1000]", 2 == 2, true)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -130,24 +130,24 @@ function initArray() {
 		new person("Karl", 10),
 		new person("", 0)]);
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("len", array1.len() == array2.len(), true),
-		_("cap", array1.cap() == array2.cap(), true),
-		_("equality", JSON.stringify(array1.v) == JSON.stringify(array2.v), true)
+	}; }; var tests = [
+		$T1("len", array1.len() == array2.len(), true),
+		$T1("cap", array1.cap() == array2.cap(), true),
+		$T1("equality", g.Equal(array1.v, array2.v, [{name: 0, age: 0}]), true)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -166,9 +166,9 @@ function _array() {
 	var older = older10(array);
 
 	if (older.name == "Sam") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got " + older.name + ", want Sam<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got %v, want Sam<br>", [older.name])));
 		PASS = false;
 	}
 }
@@ -187,10 +187,10 @@ function multiArray() {
 		[5, 6, 7, 8]
 	]);
 
-	if (JSON.stringify(doubleArray_1.v) == JSON.stringify(doubleArray_2.v) && JSON.stringify(doubleArray_2.v) == JSON.stringify(doubleArray_3.v)) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+	if (g.Equal(doubleArray_1.v, doubleArray_2.v, [[0]]) && g.Equal(doubleArray_2.v, doubleArray_3.v, [[0]])) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got different arraies<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got different arraies<br>"], false)));
 		PASS = false;
 	}
 }
@@ -202,64 +202,64 @@ function _struct() {
 	var pass = true;
 
 	var tom = new person("", 0);
-	tom.name = "Tom", tom.age = 18;
+	tom.name = "Tom"; tom.age = 18;
 
-	var bob = new person(); bob.age = 25, bob.name = "Bob";
+	var bob = new person("Bob", 25);
 	var paul = new person("Paul", 43);
 
-	var _ = older(tom, bob), TB_older = _[0], TB_diff = _[1];
-	var _ = older(tom, paul), TP_older = _[0], TP_diff = _[1];
-	var _ = older(bob, paul), BP_older = _[0], BP_diff = _[1];
+	var $r1 = older(tom, bob), TB_older = $r1[0], TB_diff = $r1[1];
+	var $r2 = older(tom, paul), TP_older = $r2[0], TP_diff = $r2[1];
+	var $r3 = older(bob, paul), BP_older = $r3[0], BP_diff = $r3[1];
 
-	var _ = function(msg, inPerson, outPerson, inDiff, outDiff) { return {
+	var $T4 = function(msg, inPerson, outPerson, inDiff, outDiff) { return {
 		msg: msg,
 		inPerson: inPerson,
 		outPerson: outPerson,
 		inDiff: inDiff,
 		outDiff: outDiff
-	};}; var tests = [
-		_("Tom,Bob", TB_older, bob, TB_diff, 7),
-		_("Tom,Paul", TP_older, paul, TP_diff, 25),
-		_("Bob,Paul", BP_older, paul, BP_diff, 18)
+	}; }; var tests = [
+		$T4("Tom,Bob", g.Clone(TB_older, {name: 0, age: 0}), g.Clone(bob, {name: 0, age: 0}), TB_diff, 7),
+		$T4("Tom,Paul", g.Clone(TP_older, {name: 0, age: 0}), g.Clone(paul, {name: 0, age: 0}), TP_diff, 25),
+		$T4("Bob,Paul", g.Clone(BP_older, {name: 0, age: 0}), g.Clone(paul, {name: 0, age: 0}), BP_diff, 18)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.inPerson) != JSON.stringify(t.outPerson)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => person got " + t.inPerson + ", want " + t.outPerson + "<br>");
+	var t; for (var $i5 = 0, $n6 = tests.len; $i5 < $n6; $i5++) { t = tests.at($i5);
+		if ((!g.Equal(t.inPerson.name, t.outPerson.name, 0) || !g.Equal(t.inPerson.age, t.outPerson.age, 0))) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => person got %v, want %v<br>", [t.msg, g.Typed(t.inPerson, "main.person"), g.Typed(t.outPerson, "main.person")])));
 
-			pass = false, PASS = false;
+			pass = false; PASS = false;
 		}
-		if (JSON.stringify(t.inDiff) != JSON.stringify(t.outDiff)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => difference got " + t.inDiff + ", want " + t.outDiff + "<br>");
+		if (!g.Equal(t.inDiff, t.outDiff, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => difference got %v, want %v<br>", [t.msg, t.inDiff, t.outDiff])));
 
-			pass = false, PASS = false;
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function main() {
-	document.write("<br><br>== Composite types<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Composite types<br><br>"], false)));
 
-	document.write("=== RUN builtInArray<br>");
+	document.write(g.Text(g.Sprint(["=== RUN builtInArray"], true) + "<br>"));
 	builtInArray();
-	document.write("=== RUN initArray<br>");
+	document.write(g.Text(g.Sprint(["=== RUN initArray"], true) + "<br>"));
 	initArray();
-	document.write("=== RUN array<br>");
+	document.write(g.Text(g.Sprint(["=== RUN array"], true) + "<br>"));
 	_array();
-	document.write("=== RUN multiArray<br>");
+	document.write(g.Text(g.Sprint(["=== RUN multiArray"], true) + "<br>"));
 	multiArray();
 
-	document.write("=== RUN struct<br>");
+	document.write(g.Text(g.Sprint(["=== RUN struct"], true) + "<br>"));
 	_struct();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Composite types");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Composite types"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
@@ -17,34 +17,34 @@ function _if() {
 	var x = 5;
 
 	if (x > 10) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
 	var x = 12; if (x > 10) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with statement<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with statement<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
 	var i = 7;
 
 	if (i == 3) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple (i == 3)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple (i == 3)<br>"], false)));
+		pass = false; PASS = false;
 	} else if (i < 3) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple (i < 3)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple (i < 3)<br>"], false)));
+		pass = false; PASS = false;
 	} else {
 
 	}
 
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -56,14 +56,14 @@ function _switch() {
 
 	switch (i) {
 	default:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple (default)<br>");
-		pass = false, PASS = false; break;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple (default)<br>"], false)));
+		pass = false; PASS = false; break;
 	case 1:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple (1)<br>");
-		pass = false, PASS = false; break;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple (1)<br>"], false)));
+		pass = false; PASS = false; break;
 	case 2: case 3: case 4:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple (2,3,4)<br>");
-		pass = false, PASS = false; break;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple (2,3,4)<br>"], false)));
+		pass = false; PASS = false; break;
 	case 10:
 
 	}
@@ -73,14 +73,14 @@ function _switch() {
 	case i < 10: break;
 
 	case i > 10: case i < 0:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression (i>10, i<0)<br>");
-		pass = false, PASS = false; break;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression (i>10, i<0)<br>"], false)));
+		pass = false; PASS = false; break;
 	case i == 10:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression (i==10)<br>");
-		pass = false, PASS = false; break;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression (i==10)<br>"], false)));
+		pass = false; PASS = false; break;
 	default:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression (default)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression (default)<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
@@ -88,39 +88,39 @@ function _switch() {
 	case i == 5: break;
 
 	default:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression 2 (default)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: without expression 2 (default)<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
 	switch (i) {
 	case 4:
 		pass = false;
-		
+
 	case 5:
 		pass = false;
-		
+
 	case 6:
 		pass = false;
-		
+
 	case 7:
 		pass = true; break;
 	case 8:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with fallthrough (8)<br>");
-		pass = false, PASS = false; break;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with fallthrough (8)<br>"], false)));
+		pass = false; PASS = false; break;
 	default:
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with fallthrough (default)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with fallthrough (default)<br>"], false)));
+		pass = false; PASS = false;
 	}
 
-	if (JSON.stringify(pass) == JSON.stringify(false) && JSON.stringify(PASS) == JSON.stringify(true)) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with fallthrough (4,5,6)<br>");
+	if (pass == false && PASS == true) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: with fallthrough (4,5,6)<br>"], false)));
 		PASS = false;
 	}
 
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -137,8 +137,8 @@ function _for() {
 	if (sum == 45) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: simple<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
@@ -150,8 +150,8 @@ function _for() {
 	if (sum == 1024) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2 expressions omitted<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2 expressions omitted<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
@@ -163,8 +163,8 @@ function _for() {
 	if (sum == 1024) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2 expressions omitted, no semicolons<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2 expressions omitted, no semicolons<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
@@ -174,7 +174,7 @@ function _for() {
 	for (;;) {
 		i++;
 		if (i == 3) {
-			s = i;
+			s = g.Sprintf("%d", [i]);
 			break;
 		}
 	}
@@ -182,8 +182,8 @@ function _for() {
 	if (s == "3") {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: infinite loop<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: infinite loop<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
@@ -192,14 +192,14 @@ function _for() {
 		if (i < 5) {
 			break;
 		}
-		s += i + " ";
+		s += g.Sprintf("%d ", [i]);
 	}
 
 	if (s == "10 9 8 7 6 5 ") {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: break<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: break<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
@@ -208,19 +208,19 @@ function _for() {
 		if (i == 5) {
 			continue;
 		}
-		s += i + " ";
+		s += g.Sprintf("%d ", [i]);
 	}
 
 	if (s == "10 9 8 7 6 4 3 2 1 ") {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: continue<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: continue<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -229,41 +229,41 @@ function _range() {
 
 	var s = g.Slice(0, [2, 3, 5]);
 
-	var tests = g.Map(0, {
-		0: 2,
-		1: 3,
-		2: 5
-	});
+	var tests = g.MkMap(0, [
+		[0, 2],
+		[1, 3],
+		[2, 5]
+	]);
 
-	var v; for (var i in s.get()) { v = s.get()[i];
-		if (JSON.stringify(tests.get(i)[0]) != JSON.stringify(v)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + i + ". got " + v + ", want " + tests.get(i)[0] + "<br>");
-			pass = false, PASS = false;
+	var v; for (var i = 0, $n1 = s.len; i < $n1; i++) { v = s.at(i);
+		if (!g.Equal(tests.get(i)[0], v, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %d. got %v, want %v<br>", [i, v, tests.get(i)[0]])));
+			pass = false; PASS = false;
 		}
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function main() {
-	document.write("<br><br>== Control statements<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Control statements<br><br>"], false)));
 
-	document.write("=== RUN if<br>");
+	document.write(g.Text(g.Sprint(["=== RUN if"], true) + "<br>"));
 	_if();
-	document.write("=== RUN switch<br>");
+	document.write(g.Text(g.Sprint(["=== RUN switch"], true) + "<br>"));
 	_switch();
-	document.write("=== RUN for<br>");
+	document.write(g.Text(g.Sprint(["=== RUN for"], true) + "<br>"));
 	_for();
-	document.write("=== RUN range<br>");
+	document.write(g.Text(g.Sprint(["=== RUN range"], true) + "<br>"));
 	_range();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Control statements");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Control statements"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
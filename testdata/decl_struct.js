@@ -13,30 +13,30 @@ function s0() {}
 
 
 function s1(a, b, f, A) {
-	this.a=a; this.b=b;
-	this.f=f;
+	this.a = a; this.b = b;
+	this.f = f;
 
-	this.A={p:A}
+	this.A = A;
 
 }
 
 
 function s2(microsec, serverIP6, process) {
-	this.microsec=microsec;
-	this.serverIP6=serverIP6;
-	this.process=process
+	this.microsec = microsec;
+	this.serverIP6 = serverIP6;
+	this.process = process;
 }
 
 
 
 
-function Point(x, y) { this.x=x; this.y=y }
+function Point(x, y) { this.x = x; this.y = y; }
 
 
 
 function main() {
 	function Fa(a) {
-		this.a=a
+		this.a = a;
 	}
 } main();
 
@@ -27,7 +27,7 @@ var o = "OMDB";
 var a1 = g.MkArray([32], 0);
 var a2 = g.MkArray([2,4], 0);
 
-var a4 = g.MkArray([10], {p:undefined});
+var a4 = g.MkArray([10], undefined);
 var a5 = g.MkArray([4], 0);
 var a6 = g.MkArray([3,5], 0);
 var a7 = g.MkArray([2,2,2], 0);
@@ -45,38 +45,38 @@ var s2 = g.MkSlice(0, 10, 20);
 
 var s3 = g.Slice(0, [2, 4, 6]);
 var s4 = g.Slice(0, [1, {2:3}]);
-var s5 = g.MkSlice(0, 0);
+var s5 = g.Slice(0, []);
 
 
 
 
-var m1 = g.Map(0, {});
-var m2 = g.Map(0, {});
-var m3 = g.Map("", {
-	1: "first",
-	2: "second",
-	3: "third"
-});
-var m4 = g.Map(undefined, {
-	1: "first",
-	2: 2,
-	3: 3
-});
+var m1 = g.MkMap(0, []);
+var m2 = g.MkMap(0, []);
+var m3 = g.MkMap("", [
+	[1, "first"],
+	[2, "second"],
+	[3, "third"]
+]);
+var m4 = g.MkMap(undefined, [
+	[1, "first"],
+	[2, g.Typed(2, "int")],
+	[3, g.Typed(3, "int")]
+]);
 
 var found = m4.get(1)[1];
 
 
 
 
-var p0 = {p:undefined};
-var p1 = {p:undefined};
-var p2 = {p:undefined};
+var p0 = undefined;
+var p1 = undefined;
+var p2 = undefined;
 
 
 function main() {
 	var Fa = 0, Fb = 10;
 	var Fc = "c";
-	
+
 	var Fd = g.Uint(20);
 	var Fe = g.Float32(0);
 
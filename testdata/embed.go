@@ -0,0 +1,184 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type Named interface {
+	Name() string
+}
+
+type Describer interface {
+	Named
+	Describe() string
+}
+
+type Base struct {
+	id   int
+	name string
+}
+
+func (b Base) Name() string { return b.name }
+
+func (b Base) Describe() string {
+	return fmt.Sprint("base ", b.name)
+}
+
+func (b *Base) Rename(name string) { b.name = name }
+
+type Counter struct {
+	n int
+}
+
+func (c *Counter) Incr() { c.n++ }
+
+type User struct {
+	Base
+	*Counter
+	email string
+}
+
+type Admin struct {
+	User
+	level int
+}
+
+func (a Admin) Describe() string {
+	return fmt.Sprint("admin ", a.name)
+}
+
+type Grid struct {
+	Base
+	cells [2][2]int
+	row   [3]int
+	tags  []string
+}
+
+func field() {
+	pass := true
+
+	u := User{Base{1, "alice"}, &Counter{}, "alice@example.com"}
+
+	if u.id != 1 || u.name != "alice" {
+		fmt.Printf("\tFAIL: promoted fields => got %v %v, want 1 alice\n", u.id, u.name)
+		pass, PASS = false, false
+	}
+	if u.Base.name != "alice" {
+		fmt.Printf("\tFAIL: embedded field => got %v, want alice\n", u.Base.name)
+		pass, PASS = false, false
+	}
+
+	u.id = 2
+	if u.Base.id != 2 {
+		fmt.Printf("\tFAIL: assignment => got %v, want 2\n", u.Base.id)
+		pass, PASS = false, false
+	}
+
+	a := Admin{User{Base{3, "bob"}, &Counter{}, ""}, 9}
+	if a.name != "bob" || a.level != 9 {
+		fmt.Printf("\tFAIL: depth 2 => got %v %v, want bob 9\n", a.name, a.level)
+		pass, PASS = false, false
+	}
+
+	// The zero value of the fields is built from their type.
+	g1, g2 := &Grid{}, new(Grid)
+	g1.row[1] = 4
+	g2.cells[1][0] = 5
+
+	if g1.row[1] != 4 || g2.row[1] != 0 || g2.cells[1][0] != 5 || g1.cells[1][0] != 0 {
+		fmt.Printf("\tFAIL: zero => got %v %v, %v %v\n", g1.row, g2.row, g1.cells, g2.cells)
+		pass, PASS = false, false
+	}
+	if g1.name != "" || g1.tags != nil || len(g2.tags) != 0 {
+		fmt.Printf("\tFAIL: zero of embedded => got %q %v\n", g1.name, g1.tags)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func method() {
+	pass := true
+
+	u := User{Base{1, "alice"}, &Counter{}, ""}
+
+	if u.Name() != "alice" {
+		fmt.Printf("\tFAIL: value method => got %v, want alice\n", u.Name())
+		pass, PASS = false, false
+	}
+
+	u.Rename("carol")
+	if u.name != "carol" {
+		fmt.Printf("\tFAIL: pointer method => got %v, want carol\n", u.name)
+		pass, PASS = false, false
+	}
+
+	u.Incr()
+	u.Incr()
+	if u.n != 2 {
+		fmt.Printf("\tFAIL: embedded pointer => got %v, want 2\n", u.n)
+		pass, PASS = false, false
+	}
+
+	a := Admin{User{Base{3, "bob"}, &Counter{}, ""}, 9}
+	if a.Describe() != "admin bob" {
+		fmt.Printf("\tFAIL: shadowed method => got %v, want admin bob\n", a.Describe())
+		pass, PASS = false, false
+	}
+	if a.User.Describe() != "base bob" {
+		fmt.Printf("\tFAIL: explicit method => got %v, want base bob\n", a.User.Describe())
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func describe(d Describer) string { return d.Name() + ": " + d.Describe() }
+
+func inInterface() {
+	pass := true
+
+	u := User{Base{1, "alice"}, &Counter{}, ""}
+	a := Admin{User{Base{3, "bob"}, &Counter{}, ""}, 9}
+
+	if describe(u) != "alice: base alice" {
+		fmt.Printf("\tFAIL: user => got %v\n", describe(u))
+		pass, PASS = false, false
+	}
+	if describe(a) != "bob: admin bob" {
+		fmt.Printf("\tFAIL: admin => got %v\n", describe(a))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Embedded fields\n\n")
+
+	fmt.Println("=== RUN field")
+	field()
+	fmt.Println("=== RUN method")
+	method()
+	fmt.Println("=== RUN inInterface")
+	inInterface()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Embedded fields")
+	}
+}
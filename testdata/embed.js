@@ -0,0 +1,185 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+
+
+
+
+
+
+
+
+
+function Base(id, name) {
+	this.id = id;
+	this.name = name;
+}
+
+Base.prototype.Name = function() { return this.name; };
+
+Base.prototype.Describe = function() {
+	return g.Sprint(["base ", this.name], false);
+};
+
+Base.prototype.Rename = function(name) { this.name = name; };
+
+function Counter(n) {
+	this.n = n;
+}
+
+Counter.prototype.Incr = function() { this.n++; };
+
+function User(Base, Counter, email) {
+	this.Base = Base;
+	this.Counter = Counter;
+	this.email = email;
+} User.prototype.Describe = function() { return this.Base.Describe.apply(this.Base, arguments); }; User.prototype.Incr = function() { return this.Counter.Incr.apply(this.Counter, arguments); }; User.prototype.Name = function() { return this.Base.Name.apply(this.Base, arguments); }; User.prototype.Rename = function() { return this.Base.Rename.apply(this.Base, arguments); };
+
+function Admin(User, level) {
+	this.User = User;
+	this.level = level;
+} Admin.prototype.Incr = function() { return this.User.Counter.Incr.apply(this.User.Counter, arguments); }; Admin.prototype.Name = function() { return this.User.Base.Name.apply(this.User.Base, arguments); }; Admin.prototype.Rename = function() { return this.User.Base.Rename.apply(this.User.Base, arguments); };
+
+Admin.prototype.Describe = function() {
+	return g.Sprint(["admin ", this.User.Base.name], false);
+};
+
+function Grid(Base, cells, row, tags) {
+	this.Base = Base;
+	this.cells = cells;
+	this.row = row;
+	this.tags = tags;
+} Grid.prototype.Describe = function() { return this.Base.Describe.apply(this.Base, arguments); }; Grid.prototype.Name = function() { return this.Base.Name.apply(this.Base, arguments); }; Grid.prototype.Rename = function() { return this.Base.Rename.apply(this.Base, arguments); };
+
+function field() {
+	var pass = true;
+
+	var u = new User(new Base(1, "alice"), new Counter(0), "alice@example.com");
+
+	if (u.Base.id != 1 || u.Base.name != "alice") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: promoted fields => got %v %v, want 1 alice<br>", [u.Base.id, u.Base.name])));
+		pass = false; PASS = false;
+	}
+	if (u.Base.name != "alice") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: embedded field => got %v, want alice<br>", [u.Base.name])));
+		pass = false; PASS = false;
+	}
+
+	u.Base.id = 2;
+	if (u.Base.id != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: assignment => got %v, want 2<br>", [u.Base.id])));
+		pass = false; PASS = false;
+	}
+
+	var a = new Admin(new User(new Base(3, "bob"), new Counter(0), ""), 9);
+	if (a.User.Base.name != "bob" || a.level != 9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: depth 2 => got %v %v, want bob 9<br>", [a.User.Base.name, a.level])));
+		pass = false; PASS = false;
+	}
+
+
+	var g1 = new Grid(new Base(0, ""), g.MkArray([2,2], 0), g.MkArray([3], 0), g.MkSlice()), g2 = new Grid(new Base(0, ""), g.MkArray([2,2], 0), g.MkArray([3], 0), g.MkSlice());
+	g1.row.v[1] = 4;
+	g2.cells.v[1][0] = 5;
+
+	if (g1.row.v[1] != 4 || g2.row.v[1] != 0 || g2.cells.v[1][0] != 5 || g1.cells.v[1][0] != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: zero => got %v %v, %v %v<br>", [g.Typed(g1.row, "[3]int"), g.Typed(g2.row, "[3]int"), g.Typed(g1.cells, "[2][2]int"), g.Typed(g2.cells, "[2][2]int")])));
+		pass = false; PASS = false;
+	}
+	if (g1.Base.name != "" || !g1.tags.isNil() || g2.tags.len != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: zero of embedded => got %q %v<br>", [g1.Base.name, g.Typed(g1.tags, "[]string")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function method() {
+	var pass = true;
+
+	var u = new User(new Base(1, "alice"), new Counter(0), "");
+
+	if (u.Base.Name() != "alice") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: value method => got %v, want alice<br>", [u.Base.Name()])));
+		pass = false; PASS = false;
+	}
+
+	u.Base.Rename("carol");
+	if (u.Base.name != "carol") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer method => got %v, want carol<br>", [u.Base.name])));
+		pass = false; PASS = false;
+	}
+
+	u.Counter.Incr();
+	u.Counter.Incr();
+	if (u.Counter.n != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: embedded pointer => got %v, want 2<br>", [u.Counter.n])));
+		pass = false; PASS = false;
+	}
+
+	var a = new Admin(new User(new Base(3, "bob"), new Counter(0), ""), 9);
+	if (a.Describe() != "admin bob") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: shadowed method => got %v, want admin bob<br>", [a.Describe()])));
+		pass = false; PASS = false;
+	}
+	if (a.User.Base.Describe() != "base bob") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: explicit method => got %v, want base bob<br>", [a.User.Base.Describe()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function describe(d) { return d.Name() + ": " + d.Describe(); }
+
+function inInterface() {
+	var pass = true;
+
+	var u = new User(new Base(1, "alice"), new Counter(0), "");
+	var a = new Admin(new User(new Base(3, "bob"), new Counter(0), ""), 9);
+
+	if (describe(u) != "alice: base alice") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: user => got %v<br>", [describe(u)])));
+		pass = false; PASS = false;
+	}
+	if (describe(a) != "bob: admin bob") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: admin => got %v<br>", [describe(a)])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Embedded fields<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN field"], true) + "<br>"));
+	field();
+	document.write(g.Text(g.Sprint(["=== RUN method"], true) + "<br>"));
+	method();
+	document.write(g.Text(g.Sprint(["=== RUN inInterface"], true) + "<br>"));
+	inInterface();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Embedded fields"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,300 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type point struct {
+	x, y float64
+}
+
+type line struct {
+	from, to point
+	name     string
+}
+
+type node struct {
+	value int
+	at    *point
+}
+
+type shape interface {
+	area() float64
+}
+
+type square struct{ side float64 }
+
+func (s square) area() float64 { return s.side * s.side }
+
+type circle struct{ radius float64 }
+
+func (c circle) area() float64 { return 3 * c.radius * c.radius }
+
+type celsius int
+
+type kelvin int
+
+func basic() {
+	pass := true
+
+	zero := 0.0
+	nan := zero / zero
+	negZero := -zero
+	s1, s2 := "go", "g"
+	s2 += "o"
+
+	tests := []struct {
+		msg string
+		in  bool
+		out bool
+	}{
+		{"NaN == NaN", nan == nan, false},
+		{"NaN != NaN", nan != nan, true},
+		{"-0 == 0", negZero == zero, true},
+		{"string", s1 == s2, true},
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %v, want %v\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func structs() {
+	pass := true
+
+	p1 := point{1, 2}
+	p2 := point{y: 2, x: 1}
+	p3 := point{2, 1}
+	l1 := line{p1, p3, "a"}
+	l2 := line{p2, p3, "a"}
+	l3 := line{p2, p3, "b"}
+	zero := 0.0
+	nan := point{zero / zero, 0}
+
+	tests := []struct {
+		msg string
+		in  bool
+		out bool
+	}{
+		{"field order", p1 == p2, true},
+		{"different", p1 != p3, true},
+		{"nested", l1 == l2, true},
+		{"nested field", l1 == l3, false},
+		{"NaN field", nan == nan, false},
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %v, want %v\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func arrays() {
+	pass := true
+
+	a1 := [3]int{1, 2, 3}
+	a2 := [3]int{1, 2, 3}
+	a3 := [3]int{1, 2, 4}
+	m1 := [2][2]float64{{1, 2}, {3, 4}}
+	m2 := [2][2]float64{{1, 2}, {3, 4}}
+	ps1 := [2]point{{1, 2}, {3, 4}}
+	ps2 := [2]point{{1, 2}, {3, 5}}
+
+	tests := []struct {
+		msg string
+		in  bool
+		out bool
+	}{
+		{"equal", a1 == a2, true},
+		{"different", a1 != a3, true},
+		{"multi-dimensional", m1 == m2, true},
+		{"of structs", ps1 == ps2, false},
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %v, want %v\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func pointers() {
+	pass := true
+
+	n1 := &point{1, 2}
+	n2 := &point{1, 2}
+	n3 := n1
+	l1 := node{1, n1}
+	l2 := node{1, n2}
+	l3 := node{1, n1}
+
+	tests := []struct {
+		msg string
+		in  bool
+		out bool
+	}{
+		{"same value", n1 == n2, false},
+		{"same pointer", n1 == n3, true},
+		{"field to other", l1 == l2, false},
+		{"field to same", l1 == l3, true},
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %v, want %v\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func interfaces() {
+	pass := true
+
+	var s1, s2, s3, c shape
+	s1 = square{2}
+	s2 = square{2}
+	s3 = square{3}
+	c = circle{2}
+
+	var i1, i2, i3 interface{}
+	i1 = 1
+	i2 = "1"
+	i3 = point{1, 2}
+
+	var a, b, n, f interface{}
+	a = celsius(1)
+	b = kelvin(1)
+	n = int32(1)
+	f = 1.0
+
+	var p1, p2, p3, v interface{}
+	p1 = &point{1, 2}
+	p2 = &point{1, 2}
+	p3 = p1
+	v = point{1, 2}
+
+	tests := []struct {
+		msg string
+		in  bool
+		out bool
+	}{
+		{"same value", s1 == s2, true},
+		{"different value", s1 == s3, false},
+		{"different type", s1 == c, false},
+		{"concrete value", s1 == square{2}, true},
+		{"int and string", i1 == i2, false},
+		{"struct", i3 == point{1, 2}, true},
+		{"named types", a == b, false},
+		{"named and int", a == i1, false},
+		{"same named type", a == celsius(1), true},
+		{"int32 and int", n == i1, false},
+		{"same sized type", n == int32(1), true},
+		{"float and int", f == i1, false},
+		{"same float", f == 1.0, true},
+		{"untyped and int32", n == 1, false},
+		{"pointers to same value", p1 == p2, false},
+		{"same pointer", p1 == p3, true},
+		{"pointer and value", p1 == v, false},
+	}
+
+	if s := fmt.Sprintf("%v %T %T %T", a, a, n, f); s != "1 main.celsius int32 float64" {
+		fmt.Printf("\tFAIL: dynamic type => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %v, want %v\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func switches() {
+	pass := true
+	p := point{1, 2}
+	got := ""
+
+	switch p {
+	case point{2, 1}:
+		got = "2, 1"
+	case point{1, 2}:
+		got = "1, 2"
+	default:
+		got = "default"
+	}
+	if got != "1, 2" {
+		fmt.Printf("\tFAIL: switch => got %v, want \"1, 2\"\n", got)
+		pass, PASS = false, false
+	}
+
+	// The constants of the cases have their default type.
+	var f interface{} = 3.0
+	switch f {
+	case 3:
+		got = "int"
+	case 3.0:
+		got = "float64"
+	default:
+		got = "default"
+	}
+	if got != "float64" {
+		fmt.Printf("\tFAIL: switch of interface => got %v, want \"float64\"\n", got)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Equality\n\n")
+
+	fmt.Println("=== RUN basic")
+	basic()
+	fmt.Println("=== RUN structs")
+	structs()
+	fmt.Println("=== RUN arrays")
+	arrays()
+	fmt.Println("=== RUN pointers")
+	pointers()
+	fmt.Println("=== RUN interfaces")
+	interfaces()
+	fmt.Println("=== RUN switches")
+	switches()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Equality")
+	}
+}
@@ -0,0 +1,301 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function line(from, to, name) {
+	this.from = from; this.to = to;
+	this.name = name;
+}
+
+function node(value, at) {
+	this.value = value;
+	this.at = at;
+}
+
+
+
+
+
+function square(side) { this.side = side; }
+
+square.prototype.area = function() { return this.side * this.side; };
+
+function circle(radius) { this.radius = radius; }
+
+circle.prototype.area = function() { return 3 * this.radius * this.radius; };
+
+function celsius(t) { this.t = t; } g.Named(celsius, "int");
+
+function kelvin(t) { this.t = t; } g.Named(kelvin, "int");
+
+function basic() {
+	var pass = true;
+
+	var zero = 0.0;
+	var nan = zero / zero;
+	var negZero = -zero;
+	var s1 = "go", s2 = "g";
+	s2 += "o";
+
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("NaN == NaN", g.Equal(nan, nan, 0), false),
+		$T1("NaN != NaN", !g.Equal(nan, nan, 0), true),
+		$T1("-0 == 0", g.Equal(negZero, zero, 0), true),
+		$T1("string", g.Equal(s1, s2, 0), true)
+	];
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function structs() {
+	var pass = true;
+
+	var p1 = new point(1, 2);
+	var p2 = new point(1, 2);
+	var p3 = new point(2, 1);
+	var l1 = new line(g.Clone(p1, {x: 0, y: 0}), g.Clone(p3, {x: 0, y: 0}), "a");
+	var l2 = new line(g.Clone(p2, {x: 0, y: 0}), g.Clone(p3, {x: 0, y: 0}), "a");
+	var l3 = new line(g.Clone(p2, {x: 0, y: 0}), g.Clone(p3, {x: 0, y: 0}), "b");
+	var zero = 0.0;
+	var nan = new point(zero / zero, 0);
+
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("field order", (g.Equal(p1.x, p2.x, 0) && g.Equal(p1.y, p2.y, 0)), true),
+		$T1("different", (!g.Equal(p1.x, p3.x, 0) || !g.Equal(p1.y, p3.y, 0)), true),
+		$T1("nested", (g.Equal(l1.from.x, l2.from.x, 0) && g.Equal(l1.from.y, l2.from.y, 0) && g.Equal(l1.to.x, l2.to.x, 0) && g.Equal(l1.to.y, l2.to.y, 0) && g.Equal(l1.name, l2.name, 0)), true),
+		$T1("nested field", (g.Equal(l1.from.x, l3.from.x, 0) && g.Equal(l1.from.y, l3.from.y, 0) && g.Equal(l1.to.x, l3.to.x, 0) && g.Equal(l1.to.y, l3.to.y, 0) && g.Equal(l1.name, l3.name, 0)), false),
+		$T1("NaN field", (g.Equal(nan.x, nan.x, 0) && g.Equal(nan.y, nan.y, 0)), false)
+	];
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function arrays() {
+	var pass = true;
+
+	var a1 = g.MkArray([3], 0, [1, 2, 3]);
+	var a2 = g.MkArray([3], 0, [1, 2, 3]);
+	var a3 = g.MkArray([3], 0, [1, 2, 4]);
+	var m1 = g.MkArray([2,2], 0, [[1, 2], [3, 4]]);
+	var m2 = g.MkArray([2,2], 0, [[1, 2], [3, 4]]);
+	var ps1 = g.MkArray([2], new point(0, 0), [new point(1, 2), new point(3, 4)]);
+	var ps2 = g.MkArray([2], new point(0, 0), [new point(1, 2), new point(3, 5)]);
+
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("equal", g.Equal(a1.v, a2.v, [0]), true),
+		$T1("different", !g.Equal(a1.v, a3.v, [0]), true),
+		$T1("multi-dimensional", g.Equal(m1.v, m2.v, [[0]]), true),
+		$T1("of structs", g.Equal(ps1.v, ps2.v, [{x: 0, y: 0}]), false)
+	];
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function pointers() {
+	var pass = true;
+
+	var n1 = new point(1, 2);
+	var n2 = new point(1, 2);
+	var n3 = n1;
+	var l1 = new node(1, n1);
+	var l2 = new node(1, n2);
+	var l3 = new node(1, n1);
+
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("same value", g.Equal(n1, n2, 0), false),
+		$T1("same pointer", g.Equal(n1, n3, 0), true),
+		$T1("field to other", (g.Equal(l1.value, l2.value, 0) && g.Equal(l1.at, l2.at, 0)), false),
+		$T1("field to same", (g.Equal(l1.value, l3.value, 0) && g.Equal(l1.at, l3.at, 0)), true)
+	];
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function interfaces() {
+	var pass = true;
+
+	var s1 = undefined, s2 = undefined, s3 = undefined, c = undefined;
+	s1 = new square(2);
+	s2 = new square(2);
+	s3 = new square(3);
+	c = new circle(2);
+
+	var i1 = undefined, i2 = undefined, i3 = undefined;
+	i1 = g.Typed(1, "int");
+	i2 = "1";
+	i3 = new point(1, 2);
+
+	var a = undefined, b = undefined, n = undefined, f = undefined;
+	a = g.Typed(1, "main.celsius", "int");
+	b = g.Typed(1, "main.kelvin", "int");
+	n = g.Typed(g.Int32(1), "int32");
+	f = g.Typed(1.0, "float64");
+
+	var p1 = undefined, p2 = undefined, p3 = undefined, v = undefined;
+	p1 = g.Pointer(new point(1, 2), "*main.point");
+	p2 = g.Pointer(new point(1, 2), "*main.point");
+	p3 = p1;
+	v = new point(1, 2);
+
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("same value", g.Equal(s1, s2, 1), true),
+		$T1("different value", g.Equal(s1, s3, 1), false),
+		$T1("different type", g.Equal(s1, c, 1), false),
+		$T1("concrete value", g.Equal(s1, new square(2), 1), true),
+		$T1("int and string", g.Equal(i1, i2, 1), false),
+		$T1("struct", g.Equal(i3, new point(1, 2), 1), true),
+		$T1("named types", g.Equal(a, b, 1), false),
+		$T1("named and int", g.Equal(a, i1, 1), false),
+		$T1("same named type", g.Equal(a, g.Typed(1, "main.celsius", "int"), 1), true),
+		$T1("int32 and int", g.Equal(n, i1, 1), false),
+		$T1("same sized type", g.Equal(n, g.Typed(g.Int32(1), "int32"), 1), true),
+		$T1("float and int", g.Equal(f, i1, 1), false),
+		$T1("same float", g.Equal(f, g.Typed(1.0, "float64"), 1), true),
+		$T1("untyped and int32", g.Equal(n, g.Typed(1, "int"), 1), false),
+		$T1("pointers to same value", g.Equal(p1, p2, 1), false),
+		$T1("same pointer", g.Equal(p1, p3, 1), true),
+		$T1("pointer and value", g.Equal(p1, v, 1), false)
+	];
+
+	var s = g.Sprintf("%v %T %T %T", [a, a, n, f]); if (s != "1 main.celsius int32 float64") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: dynamic type => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function switches() {
+	var pass = true;
+	var p = new point(1, 2);
+	var got = "";
+
+	var $s1 = p; switch (true) {
+	case g.Equal($s1, new point(2, 1), {x: 0, y: 0}):
+		got = "2, 1"; break;
+	case g.Equal($s1, new point(1, 2), {x: 0, y: 0}):
+		got = "1, 2"; break;
+	default:
+		got = "default";
+	}
+	if (got != "1, 2") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: switch => got %v, want \"1, 2\"<br>", [got])));
+		pass = false; PASS = false;
+	}
+
+
+	var f = g.Typed(3.0, "float64");
+	var $s2 = f; switch (true) {
+	case g.Equal($s2, g.Typed(3, "int"), 1):
+		got = "int"; break;
+	case g.Equal($s2, g.Typed(3.0, "float64"), 1):
+		got = "float64"; break;
+	default:
+		got = "default";
+	}
+	if (got != "float64") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: switch of interface => got %v, want \"float64\"<br>", [got])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Equality<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN basic"], true) + "<br>"));
+	basic();
+	document.write(g.Text(g.Sprint(["=== RUN structs"], true) + "<br>"));
+	structs();
+	document.write(g.Text(g.Sprint(["=== RUN arrays"], true) + "<br>"));
+	arrays();
+	document.write(g.Text(g.Sprint(["=== RUN pointers"], true) + "<br>"));
+	pointers();
+	document.write(g.Text(g.Sprint(["=== RUN interfaces"], true) + "<br>"));
+	interfaces();
+	document.write(g.Text(g.Sprint(["=== RUN switches"], true) + "<br>"));
+	switches();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Equality"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -30,3 +30,8 @@ _skipPoint:
 		print("This part is skipped")
 	}
 }
+
+func uncomparable() {
+	s1, s2 := []int{}, []int{}
+	println(s1 == s2)
+}
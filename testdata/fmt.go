@@ -0,0 +1,280 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type point struct {
+	x, y int
+}
+
+type celsius struct {
+	degrees float64
+}
+
+func (c celsius) String() string { return fmt.Sprintf("%.1f°C", c.degrees) }
+
+type failure struct {
+	msg string
+}
+
+func (f *failure) Error() string { return "failure: " + f.msg }
+
+type buffer struct {
+	data string
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.data += string(p)
+	return len(p), nil
+}
+
+type Weekday int
+
+func (d Weekday) String() string { return [...]string{"Sun", "Mon"}[d] }
+
+type degrees float64
+
+func (d degrees) String() string { return fmt.Sprintf("%.1f°", float64(d)) }
+
+type day struct {
+	Day  Weekday
+	Temp []degrees
+	n    Weekday
+}
+
+func two() (int, Weekday) { return 3, 1 }
+
+func check(msg, got, want string) bool {
+	if got != want {
+		fmt.Printf("\tFAIL: %s => got %q, want %q\n", msg, got, want)
+		PASS = false
+		return false
+	}
+	return true
+}
+
+func integers() {
+	pass := true
+	n := 42
+
+	tests := []struct{ msg, got, want string }{
+		{"d", fmt.Sprintf("%d", n), "42"},
+		{"width", fmt.Sprintf("[%5d]", n), "[   42]"},
+		{"minus", fmt.Sprintf("[%-5d]", n), "[42   ]"},
+		{"zero", fmt.Sprintf("[%05d]", -n), "[-0042]"},
+		{"plus", fmt.Sprintf("%+d", n), "+42"},
+		{"precision", fmt.Sprintf("%.4d", n), "0042"},
+		{"binary", fmt.Sprintf("%b %#b", 5, 5), "101 0b101"},
+		{"octal", fmt.Sprintf("%o %#o %O", 8, 8, 8), "10 010 0o10"},
+		{"hex", fmt.Sprintf("%x %X %#x", 255, 255, -255), "ff FF -0xff"},
+		{"char", fmt.Sprintf("%c %q %U %#U", 'é', 'a', 0x1F600, 'x'), "é 'a' U+1F600 U+0078 'x'"},
+		{"star", fmt.Sprintf("[%*d] [%-*d]", 4, 7, 3, 7), "[   7] [7  ]"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func floats() {
+	pass := true
+	f := 3.14159
+
+	tests := []struct{ msg, got, want string }{
+		{"v", fmt.Sprintf("%v %v %v", 1.0, 0.1, f), "1 0.1 3.14159"},
+		{"v exponent", fmt.Sprintf("%v %v %v", 1e6, 123456.0, 1e-5), "1e+06 123456 1e-05"},
+		{"f", fmt.Sprintf("%f %.2f %8.3f", f, f, -f), "3.141590 3.14   -3.142"},
+		{"round to even", fmt.Sprintf("%.0f %.0f %.1f", 0.5, 1.5, 0.25), "0 2 0.2"},
+		{"e", fmt.Sprintf("%e %.2E", 123456.789, 0.000123), "1.234568e+05 1.23E-04"},
+		{"g", fmt.Sprintf("%g %.3g %g", 100000.0, 1234.5678, 1e21), "100000 1.23e+03 1e+21"},
+		{"sharp", fmt.Sprintf("%#g %#.0f", 1.0, 2.0), "1.00000 2."},
+		{"zero", fmt.Sprintf("%08.3f", -3.14159), "-003.142"},
+		{"float32", fmt.Sprintf("%v", float32(0.1)), "0.1"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func stringsAndBools() {
+	pass := true
+	s := "héllo"
+
+	tests := []struct{ msg, got, want string }{
+		{"s", fmt.Sprintf("%s|%8s|%-8s|", s, s, s), "héllo|   héllo|héllo   |"},
+		{"precision", fmt.Sprintf("%.2s", s), "hé"},
+		{"q", fmt.Sprintf("%q %+q", s, s), `"héllo" "h\u00e9llo"`},
+		{"q escapes", fmt.Sprintf("%q", "a\"b\\c\x07"), `"a\"b\\c\a"`},
+		{"q backquote", fmt.Sprintf("%#q", "raw"), "`raw`"},
+		{"x", fmt.Sprintf("%x % X", "hi", "hi"), "6869 68 69"},
+		{"bytes", fmt.Sprintf("%s %x %v", []byte("go"), []byte("go"), []byte("go")), "go 676f [103 111]"},
+		{"t", fmt.Sprintf("%t %v", true, false), "true false"},
+		{"percent", fmt.Sprintf("100%%"), "100%"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func composites() {
+	pass := true
+	p := point{1, 2}
+	m := map[string]int{"b": 2, "a": 1}
+	mInt := map[int]string{10: "x", 2: "y"}
+	array := [3]bool{true}
+	var nilSlice []int
+
+	tests := []struct{ msg, got, want string }{
+		{"struct", fmt.Sprintf("%v %+v", p, p), "{1 2} {x:1 y:2}"},
+		{"struct sharp", fmt.Sprintf("%#v", p), "main.point{x:1, y:2}"},
+		{"slice", fmt.Sprintf("%v %d", []int{1, 2, 3}, []int{4, 5}), "[1 2 3] [4 5]"},
+		{"slice sharp", fmt.Sprintf("%#v", []string{"a"}), `[]string{"a"}`},
+		{"nil slice", fmt.Sprintf("%v %#v", nilSlice, nilSlice), "[] []int(nil)"},
+		{"array", fmt.Sprintf("%v", array), "[true false false]"},
+		{"map", fmt.Sprintf("%v", m), "map[a:1 b:2]"},
+		{"map of ints", fmt.Sprintf("%v", mInt), "map[2:y 10:x]"},
+		{"type", fmt.Sprintf("%T %T %T %T", 1, "s", p, m), "int string main.point map[string]int"},
+		{"multi-dimensional array", fmt.Sprint([2][2]int{{1, 2}, {3, 4}}), "[[1 2] [3 4]]"},
+		{"arrays into slice", fmt.Sprint([][2]int{{5, 6}}), "[[5 6]]"},
+		{"nil", fmt.Sprintf("%v", nil), "<nil>"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func methods() {
+	pass := true
+	c := celsius{21.5}
+	temps := []celsius{{1}, {2}}
+	var err error = &failure{"disk"}
+
+	tests := []struct{ msg, got, want string }{
+		{"String", fmt.Sprintf("%v %s", c, c), "21.5°C 21.5°C"},
+		{"String into slice", fmt.Sprint(temps), "[1.0°C 2.0°C]"},
+		{"String of named types", fmt.Sprint([]Weekday{0, 1}, map[Weekday]int{1: 2}, []degrees{1.5}),
+			"[Sun Mon] map[Mon:2] [1.5°]"},
+		{"String of fields", fmt.Sprintf("%v %+v", day{1, []degrees{2}, 1}, day{}), "{Mon [2.0°] 1} {Day:Sun Temp:[] n:0}"},
+		{"multiple values", fmt.Sprint(two()), "3 Mon"},
+		{"Error", fmt.Sprintf("%v", err), "failure: disk"},
+		{"sharp", fmt.Sprintf("%#v", c), "main.celsius{degrees:21.5}"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func errors() {
+	pass := true
+
+	tests := []struct{ msg, got, want string }{
+		{"bad verb", fmt.Sprintf("%d", "hi"), "%!d(string=hi)"},
+		{"missing", fmt.Sprintf("%d %d", 1), "1 %!d(MISSING)"},
+		{"extra", fmt.Sprintf("%d", 1, "a"), "1%!(EXTRA string=a)"},
+		{"index", fmt.Sprintf("%[2]d %[1]d", 1, 2), "2 1"},
+		{"bad index", fmt.Sprintf("%[3]d", 1), "%!d(BADINDEX)"},
+		{"no verb", fmt.Sprintf("%"), "%!(NOVERB)"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func printing() {
+	pass := true
+
+	tests := []struct{ msg, got, want string }{
+		{"Sprint", fmt.Sprint("a", 1, 2, "b", 3.5), "a1 2b3.5"},
+		{"Sprint slice", fmt.Sprint([]int{1, 2}), "[1 2]"},
+		{"Sprintln", fmt.Sprintln("a", 1), "a 1\n"},
+	}
+	for _, t := range tests {
+		if !check(t.msg, t.got, t.want) {
+			pass = false
+		}
+	}
+
+	b := &buffer{}
+	fmt.Fprintf(b, "%d-%s", 7, "x")
+	fmt.Fprint(b, "|", 8)
+	n, err := fmt.Fprintln(b, "|")
+	if !check("Fprintf", b.data, "7-x|8|\n") {
+		pass = false
+	}
+	if n != len(b.data)-len("7-x|8") || err != nil {
+		fmt.Printf("\tFAIL: Fprintln => got (%d, %v)\n", n, err)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Format\n\n")
+
+	fmt.Println("=== RUN integers")
+	integers()
+	fmt.Println("=== RUN floats")
+	floats()
+	fmt.Println("=== RUN stringsAndBools")
+	stringsAndBools()
+	fmt.Println("=== RUN composites")
+	composites()
+	fmt.Println("=== RUN methods")
+	methods()
+	fmt.Println("=== RUN errors")
+	errors()
+	fmt.Println("=== RUN printing")
+	printing()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Format")
+	}
+}
@@ -0,0 +1,281 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function celsius(degrees) {
+	this.degrees = degrees;
+}
+
+celsius.prototype.String = function() { return g.Sprintf("%.1f\xc2\xb0C", [g.Typed(this.degrees, "float64")]); };
+
+function failure(msg) {
+	this.msg = msg;
+}
+
+failure.prototype.Error = function() { return "failure: " + this.msg; };
+
+function buffer(data) {
+	this.data = data;
+}
+
+buffer.prototype.Write = function(p) {
+	this.data += p.str();
+	return [p.len, undefined];
+};
+
+function Weekday(t) { this.t = t; } g.Named(Weekday, "int");
+
+Weekday.prototype.String = function() { var d = this.t; return g.MkArray([2], "", ["Sun", "Mon"]).v[d]; };
+
+function degrees(t) { this.t = t; } g.Named(degrees, "float64");
+
+degrees.prototype.String = function() { var d = this.t; return g.Sprintf("%.1f\xc2\xb0", [g.Typed(g.Float64(d), "float64")]); };
+
+function day(Day, Temp, n) {
+	this.Day = Day;
+	this.Temp = Temp;
+	this.n = n;
+} g.FieldTypes(day, {Day: "main.Weekday", Temp: "[]main.degrees"});
+
+function two() { return [3, 1]; }
+
+function check(msg, got, want) {
+	if (!g.Equal(got, want, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %q, want %q<br>", [msg, got, want])));
+		PASS = false;
+		return false;
+	}
+	return true;
+}
+
+function integers() {
+	var pass = true;
+	var n = 42;
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("d", g.Sprintf("%d", [n]), "42"),
+		$T1("width", g.Sprintf("[%5d]", [n]), "[   42]"),
+		$T1("minus", g.Sprintf("[%-5d]", [n]), "[42   ]"),
+		$T1("zero", g.Sprintf("[%05d]", [-n]), "[-0042]"),
+		$T1("plus", g.Sprintf("%+d", [n]), "+42"),
+		$T1("precision", g.Sprintf("%.4d", [n]), "0042"),
+		$T1("binary", g.Sprintf("%b %#b", [5, 5]), "101 0b101"),
+		$T1("octal", g.Sprintf("%o %#o %O", [8, 8, 8]), "10 010 0o10"),
+		$T1("hex", g.Sprintf("%x %X %#x", [255, 255, -255]), "ff FF -0xff"),
+		$T1("char", g.Sprintf("%c %q %U %#U", [g.Typed(233, "rune"), g.Typed(97, "rune"), 0x1F600, g.Typed(120, "rune")]), "\xc3\xa9 'a' U+1F600 U+0078 'x'"),
+		$T1("star", g.Sprintf("[%*d] [%-*d]", [4, 7, 3, 7]), "[   7] [7  ]")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function floats() {
+	var pass = true;
+	var f = 3.14159;
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("v", g.Sprintf("%v %v %v", [g.Typed(1.0, "float64"), g.Typed(0.1, "float64"), g.Typed(f, "float64")]), "1 0.1 3.14159"),
+		$T1("v exponent", g.Sprintf("%v %v %v", [g.Typed(1e6, "float64"), g.Typed(123456.0, "float64"), g.Typed(1e-5, "float64")]), "1e+06 123456 1e-05"),
+		$T1("f", g.Sprintf("%f %.2f %8.3f", [g.Typed(f, "float64"), g.Typed(f, "float64"), g.Typed(-f, "float64")]), "3.141590 3.14   -3.142"),
+		$T1("round to even", g.Sprintf("%.0f %.0f %.1f", [g.Typed(0.5, "float64"), g.Typed(1.5, "float64"), g.Typed(0.25, "float64")]), "0 2 0.2"),
+		$T1("e", g.Sprintf("%e %.2E", [g.Typed(123456.789, "float64"), g.Typed(0.000123, "float64")]), "1.234568e+05 1.23E-04"),
+		$T1("g", g.Sprintf("%g %.3g %g", [g.Typed(100000.0, "float64"), g.Typed(1234.5678, "float64"), g.Typed(1e21, "float64")]), "100000 1.23e+03 1e+21"),
+		$T1("sharp", g.Sprintf("%#g %#.0f", [g.Typed(1.0, "float64"), g.Typed(2.0, "float64")]), "1.00000 2."),
+		$T1("zero", g.Sprintf("%08.3f", [g.Typed(-3.14159, "float64")]), "-003.142"),
+		$T1("float32", g.Sprintf("%v", [g.Typed(g.Float32(0.1), "float32")]), "0.1")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function stringsAndBools() {
+	var pass = true;
+	var s = "h\xc3\xa9llo";
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("s", g.Sprintf("%s|%8s|%-8s|", [s, s, s]), "h\xc3\xa9llo|   h\xc3\xa9llo|h\xc3\xa9llo   |"),
+		$T1("precision", g.Sprintf("%.2s", [s]), "h\xc3\xa9"),
+		$T1("q", g.Sprintf("%q %+q", [s, s]), "\"h\xc3\xa9llo\" \"h\\u00e9llo\""),
+		$T1("q escapes", g.Sprintf("%q", ["a\"b\\c\x07"]), "\"a\\\"b\\\\c\\a\""),
+		$T1("q backquote", g.Sprintf("%#q", ["raw"]), "`raw`"),
+		$T1("x", g.Sprintf("%x % X", ["hi", "hi"]), "6869 68 69"),
+		$T1("bytes", g.Sprintf("%s %x %v", [g.Typed(g.Bytes("go"), "[]byte"), g.Typed(g.Bytes("go"), "[]byte"), g.Typed(g.Bytes("go"), "[]byte")]), "go 676f [103 111]"),
+		$T1("t", g.Sprintf("%t %v", [true, false]), "true false"),
+		$T1("percent", g.Sprintf("100%%", []), "100%")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function composites() {
+	var pass = true;
+	var p = new point(1, 2);
+	var m = g.MkMap(0, [["b", 2], ["a", 1]]);
+	var mInt = g.MkMap("", [[10, "x"], [2, "y"]]);
+	var array = g.MkArray([3], false, [true]);
+	var nilSlice = g.MkSlice();
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("struct", g.Sprintf("%v %+v", [g.Typed(p, "main.point"), g.Typed(p, "main.point")]), "{1 2} {x:1 y:2}"),
+		$T1("struct sharp", g.Sprintf("%#v", [g.Typed(p, "main.point")]), "main.point{x:1, y:2}"),
+		$T1("slice", g.Sprintf("%v %d", [g.Typed(g.Slice(0, [1, 2, 3]), "[]int"), g.Typed(g.Slice(0, [4, 5]), "[]int")]), "[1 2 3] [4 5]"),
+		$T1("slice sharp", g.Sprintf("%#v", [g.Typed(g.Slice("", ["a"]), "[]string")]), "[]string{\"a\"}"),
+		$T1("nil slice", g.Sprintf("%v %#v", [g.Typed(nilSlice, "[]int"), g.Typed(nilSlice, "[]int")]), "[] []int(nil)"),
+		$T1("array", g.Sprintf("%v", [g.Typed(array, "[3]bool")]), "[true false false]"),
+		$T1("map", g.Sprintf("%v", [g.Typed(m, "map[string]int")]), "map[a:1 b:2]"),
+		$T1("map of ints", g.Sprintf("%v", [g.Typed(mInt, "map[int]string")]), "map[2:y 10:x]"),
+		$T1("type", g.Sprintf("%T %T %T %T", [1, "s", g.Typed(p, "main.point"), g.Typed(m, "map[string]int")]), "int string main.point map[string]int"),
+		$T1("multi-dimensional array", g.Sprint([g.Typed(g.MkArray([2,2], 0, [[1, 2], [3, 4]]), "[2][2]int")], false), "[[1 2] [3 4]]"),
+		$T1("arrays into slice", g.Sprint([g.Typed(g.Slice(g.MkArray([2], 0), [g.MkArray([2], 0, [5, 6])]), "[][2]int")], false), "[[5 6]]"),
+		$T1("nil", g.Sprintf("%v", [undefined]), "<nil>")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function methods() {
+	var pass = true;
+	var c = new celsius(21.5);
+	var temps = g.Slice(new celsius(0), [new celsius(1), new celsius(2)]);
+	var err = g.Pointer(new failure("disk"), "*main.failure");
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("String", g.Sprintf("%v %s", [g.Typed(c, "main.celsius"), g.Typed(c, "main.celsius")]), "21.5\xc2\xb0C 21.5\xc2\xb0C"),
+		$T1("String into slice", g.Sprint([g.Typed(temps, "[]main.celsius")], false), "[1.0\xc2\xb0C 2.0\xc2\xb0C]"),
+		$T1("String of named types", g.Sprint([g.Typed(g.Slice(0, [0, 1]), "[]main.Weekday"), g.Typed(g.MkMap(0, [[1, 2]]), "map[main.Weekday]int"), g.Typed(g.Slice(0, [1.5]), "[]main.degrees")], false),
+		"[Sun Mon] map[Mon:2] [1.5\xc2\xb0]"),
+		$T1("String of fields", g.Sprintf("%v %+v", [g.Typed(new day(1, g.Slice(0, [2]), 1), "main.day"), g.Typed(new day(0, g.MkSlice(), 0), "main.day")]), "{Mon [2.0\xc2\xb0] 1} {Day:Sun Temp:[] n:0}"),
+		$T1("multiple values", g.Sprint(g.TypedValues(two(), ["int", "main.Weekday"]), false), "3 Mon"),
+		$T1("Error", g.Sprintf("%v", [err]), "failure: disk"),
+		$T1("sharp", g.Sprintf("%#v", [g.Typed(c, "main.celsius")]), "main.celsius{degrees:21.5}")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function errors() {
+	var pass = true;
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("bad verb", g.Sprintf("%d", ["hi"]), "%!d(string=hi)"),
+		$T1("missing", g.Sprintf("%d %d", [1]), "1 %!d(MISSING)"),
+		$T1("extra", g.Sprintf("%d", [1, "a"]), "1%!(EXTRA string=a)"),
+		$T1("index", g.Sprintf("%[2]d %[1]d", [1, 2]), "2 1"),
+		$T1("bad index", g.Sprintf("%[3]d", [1]), "%!d(BADINDEX)"),
+		$T1("no verb", g.Sprintf("%", []), "%!(NOVERB)")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function printing() {
+	var pass = true;
+
+	var $T1 = function(msg, got, want) { return {msg: msg, got: got, want: want}; }; var tests = [
+		$T1("Sprint", g.Sprint(["a", 1, 2, "b", g.Typed(3.5, "float64")], false), "a1 2b3.5"),
+		$T1("Sprint slice", g.Sprint([g.Typed(g.Slice(0, [1, 2]), "[]int")], false), "[1 2]"),
+		$T1("Sprintln", g.Sprint(["a", 1], true) + "<br>", "a 1<br>")
+	];
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!check(t.msg, t.got, t.want)) {
+			pass = false;
+		}
+	}
+
+	var b = new buffer("");
+	g.Fprint(b, g.Sprintf("%d-%s", [7, "x"]));
+	g.Fprint(b, g.Sprint(["|", 8], false));
+	var $r4 = g.Fprint(b, g.Sprint(["|"], true) + "<br>"), n = $r4[0], err = $r4[1];
+	if (!check("Fprintf", b.data, "7-x|8|<br>")) {
+		pass = false;
+	}
+	if (!g.Equal(n, g.StrLen(b.data) - 5, 0) || err != undefined) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Fprintln => got (%d, %v)<br>", [n, err])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Format<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN integers"], true) + "<br>"));
+	integers();
+	document.write(g.Text(g.Sprint(["=== RUN floats"], true) + "<br>"));
+	floats();
+	document.write(g.Text(g.Sprint(["=== RUN stringsAndBools"], true) + "<br>"));
+	stringsAndBools();
+	document.write(g.Text(g.Sprint(["=== RUN composites"], true) + "<br>"));
+	composites();
+	document.write(g.Text(g.Sprint(["=== RUN methods"], true) + "<br>"));
+	methods();
+	document.write(g.Text(g.Sprint(["=== RUN errors"], true) + "<br>"));
+	errors();
+	document.write(g.Text(g.Sprint(["=== RUN printing"], true) + "<br>"));
+	printing();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Format"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
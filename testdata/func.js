@@ -15,20 +15,20 @@ var PASS = true;
 
 var x = 10;
 
-(function() {
+function init$1() {
 	x = 13;
-}());
+}
 
 function _init() {
 	if (x == 13) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got " + x + ", want 13<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got %v, want 13<br>", [x])));
 		PASS = false;
 	}
 }
 
-function singleLine() { document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>"); }
+function singleLine() { document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>")); }
 
 function simpleFunc() {
 	var pass = true;
@@ -47,23 +47,23 @@ function simpleFunc() {
 
 	var max_xy = max(x, y);
 	if (max_xy != 4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: max(x,y) => got " + max_xy + ", want 4)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: max(x,y) => got %v, want 4)<br>", [max_xy])));
+		pass = false; PASS = false;
 	}
 
 	var max_xz = max(x, z);
 	if (max_xz != 5) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: max(x,z) => got " + max_xz + ", want 5)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: max(x,z) => got %v, want 5)<br>", [max_xz])));
+		pass = false; PASS = false;
 	}
 
 	if (max(y, z) != 5) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: max(y,z) => got " + max(y, z) + ", want 5)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: max(y,z) => got %v, want 5)<br>", [max(y, z)])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -77,19 +77,19 @@ function twoOuputValues() {
 
 	var x = 3;
 	var y = 4;
-	var _ = SumAndProduct(x, y), xPLUSy = _[0], xTIMESy = _[1];
+	var $r1 = SumAndProduct(x, y), xPLUSy = $r1[0], xTIMESy = $r1[1];
 
 	if (xPLUSy != 7) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sum => got " + xPLUSy + ", want 7)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sum => got %v, want 7)<br>", [xPLUSy])));
+		pass = false; PASS = false;
 	}
 	if (xTIMESy != 12) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: product => got " + xTIMESy + ", want 12)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: product => got %v, want 12)<br>", [xTIMESy])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -98,70 +98,70 @@ function resultVariable() {
 
 
 
-	var MySqrt = function(f) { var s = 0, ok = false;
+	var MySqrt = function(f) { var s = 0; var ok = false;
 		if (f > 0) {
-			s = Math.sqrt(f), ok = true;
+			s = Math.sqrt(f); ok = true;
 		}
 		return [s, ok];
 	};
 
-	var tests = g.Map(0, {
-		1: 1,
-		2: 1.4142135623730951,
-		3: 1.7320508075688772,
-		4: 2,
-		5: 2.23606797749979,
-		6: 2.449489742783178,
-		7: 2.6457513110645907,
-		8: 2.8284271247461903,
-		9: 3,
-		10: 3.1622776601683795
-	});
-
-	for (var i = -2.0; i <= 10; i++) {
-		var _ = MySqrt(i), sqroot = _[0], ok = _[1];
+	var tests = g.MkMap(0, [
+		[1, 1],
+		[2, 1.4142135623730951],
+		[3, 1.7320508075688772],
+		[4, 2],
+		[5, 2.23606797749979],
+		[6, 2.449489742783178],
+		[7, 2.6457513110645907],
+		[8, 2.8284271247461903],
+		[9, 3],
+		[10, 3.1622776601683795]
+	]);
+
+	for (var i = -2; i <= 10; i++) {
+		var $r1 = MySqrt(i), sqroot = $r1[0], ok = $r1[1];
 		if (ok) {
-			if (JSON.stringify(sqroot) != JSON.stringify(tests.get(i)[0])) {
-				document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: square(" + i + ") => got " + sqroot + ", want " + tests.get(i)[0] + "<br>");
+			if (!g.Equal(sqroot, tests.get(i)[0], 0)) {
+				document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: square(%v) => got %v, want %v<br>", [g.Typed(i, "float64"), g.Typed(sqroot, "float64"), g.Typed(tests.get(i)[0], "float64")])));
 
-				pass = false, PASS = false;
+				pass = false; PASS = false;
 			}
 		} else {
-			if (i != -2.0 && i != -1.0 && i != 0) {
-				document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: square(" + i + ") => should no be run<br>");
-				pass = false, PASS = false;
+			if (i != -2 && i != -1 && i != 0) {
+				document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: square(%v) => should no be run<br>", [g.Typed(i, "float64")])));
+				pass = false; PASS = false;
 			}
 		}
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function _return() {
 	var pass = true;
 
-	var MySqrt = function(f) { var squareroot = 0, ok = false;
+	var MySqrt = function(f) { var squareroot = 0; var ok = false;
 		if (f > 0) {
-			squareroot = Math.sqrt(f), ok = true;
+			squareroot = Math.sqrt(f); ok = true;
 		}
 		return [squareroot, ok];
 	};
 
 	var ok = MySqrt(5)[1];
 	if (!ok) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: MySqrt(5) => got " + ok + ", want " + !ok + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: MySqrt(5) => got %v, want %v<br>", [ok, !ok])));
+		pass = false; PASS = false;
 	}
 
 	var ok = MySqrt(0)[1]; if (ok) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: MySqrt(0) => got " + ok + ", want " + !ok + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: MySqrt(0) => got %v, want %v<br>", [ok, !ok])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -169,28 +169,28 @@ function variadic() {
 	var pass = true;
 
 	function person(name, age) {
-		this.name=name;
-		this.age=age
+		this.name = name;
+		this.age = age;
 	}
 
 
 
-	var getOlder = function() { var people = arguments;
-		if (people.length == 0) {
-			return [new person(), false];
+	var getOlder = function(people) {
+		if (people.len == 0) {
+			return [new person("", 0), false];
 		}
 
-		var older = people[0];
+		var older = g.Clone(people.at(0), {name: 0, age: 0});
 
-		var value; for (var _ in people) { value = people[_];
+		var value; for (var $i1 = 0, $n2 = people.len; $i1 < $n2; $i1++) { value = people.at($i1);
 			if (value.age > older.age) {
-				older = value;
+				older = g.Clone(value, {name: 0, age: 0});
 			}
 		}
 		return [older, true];
 	};
 
-	
+
 	var ok = false;
 	var older = new person("", 0);
 
@@ -202,59 +202,59 @@ function variadic() {
 	var rob = new person("Rob", 54);
 	var karl = new person("Karl", 19);
 
-	var _ = function(msg, out) { return {
+	var $T1 = function(msg, out) { return {
 		msg: msg,
 		out: out
-	};}; var tests = [
-		_("paul,jim", "Jim"),
-		_("paul,jim,sam", "Sam"),
-		_("paul,jim,sam,rob", "Sam"),
-		_("karl", "Karl")
+	}; }; var tests = [
+		$T1("paul,jim", "Jim"),
+		$T1("paul,jim,sam", "Sam"),
+		$T1("paul,jim,sam,rob", "Sam"),
+		$T1("karl", "Karl")
 	];
 
-	older = getOlder(paul, jim)[0];
-	if (JSON.stringify(older.name) != JSON.stringify(tests[0].out)) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder " + tests[0].msg + ") => got " + older.name + ", want " + tests[0].out + "<br>");
+	older = getOlder(g.SliceArgs([paul, jim]))[0];
+	if (!g.Equal(older.name, tests[0].out, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder %s) => got %v, want %v<br>", [tests[0].msg, older.name, tests[0].out])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
-	older = getOlder(paul, jim, sam)[0];
-	if (JSON.stringify(older.name) != JSON.stringify(tests[1].out)) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder " + tests[1].msg + ") => got " + older.name + ", want " + tests[1].out + "<br>");
+	older = getOlder(g.SliceArgs([paul, jim, sam]))[0];
+	if (!g.Equal(older.name, tests[1].out, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder %s) => got %v, want %v<br>", [tests[1].msg, older.name, tests[1].out])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
-	older = getOlder(paul, jim, sam, rob)[0];
-	if (JSON.stringify(older.name) != JSON.stringify(tests[2].out)) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder " + tests[2].msg + ") => got " + older.name + ", want " + tests[2].out + "<br>");
+	older = getOlder(g.SliceArgs([paul, jim, sam, rob]))[0];
+	if (!g.Equal(older.name, tests[2].out, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder %s) => got %v, want %v<br>", [tests[2].msg, older.name, tests[2].out])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
-	older = getOlder(karl)[0];
-	if (JSON.stringify(older.name) != JSON.stringify(tests[3].out)) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder " + tests[3].msg + ") => got " + older.name + ", want " + tests[3].out + "<br>");
+	older = getOlder(g.SliceArgs([karl]))[0];
+	if (!g.Equal(older.name, tests[3].out, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder %s) => got %v, want %v<br>", [tests[3].msg, older.name, tests[3].out])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 
-	_ = getOlder(), older = _[0], ok = _[1];
+	var $r2 = getOlder(g.SliceArgs([])); older = $r2[0], ok = $r2[1];
 	if (ok) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder) => got " + ok + ", want " + !ok + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (getOlder) => got %v, want %v<br>", [ok, !ok])));
+		pass = false; PASS = false;
 	}
 
 
 
-	var getUser = function(name, surname, age) { var email = [].slice.call(arguments).slice(3);
+	var getUser = function(name, surname, age, email) {
 		var emails = "";
-		var v; for (var _ in email) { v = email[_];
+		var v; for (var $i3 = 0, $n4 = email.len; $i3 < $n4; $i3++) { v = email.at($i3);
 			emails += " " + v;
 		}
-		return name + " " + surname + ", age " + age + ", emails:" + emails;
+		return g.Sprintf("%s %s, age %d, emails:%s", [name, surname, age, emails]);
 	};
 
 	var name = "John";
@@ -263,21 +263,21 @@ function variadic() {
 	var email1 = "foo@mail.se";
 	var email2 = "bar@mail.se";
 
-	var dataUser = getUser(name, surname, age, email1, email2);
-	if (JSON.stringify(dataUser) != JSON.stringify(name + " " + surname + ", age " + age + ", emails: " + email1 + " " + email2)) {
+	var dataUser = getUser(name, surname, age, g.SliceArgs([email1, email2]));
+	if (!g.Equal(dataUser, g.Sprintf("%s %s, age %d, emails: %s %s", [name, surname, age, email1, email2]), 0)) {
 
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple parameters => got " + dataUser + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple parameters => got %q<br>", [dataUser])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function Max(slice) {
 	if (slice.len == 1) {
-		return slice.get()[0];
+		return slice.at(0);
 	}
 
 	var middle = slice.len / 2;
@@ -293,7 +293,7 @@ function Max(slice) {
 function Invert(slice) {
 	var length = slice.len;
 	if (length > 1) {
-		slice.set([0], slice.get()[length - 1]), slice.set([length - 1], slice.get()[0]);
+		var tmp1 = slice; var tmp2 = slice.at(0); slice.set([0], slice.at(length - 1)); tmp1.set([length - 1], tmp2);
 		Invert(g.SliceFrom(slice, 1, length - 1));
 	}
 }
@@ -304,11 +304,11 @@ function recursive() {
 	var s = g.Slice(0, [1, 2, 3, 4, 6, 8]);
 
 	if (Max(s) != 8) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Max => got " + Max(s) + ", want 8<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Max => got %d, want 8<br>", [Max(s)])));
+		pass = false; PASS = false;
 	}
 
-	var slice = g.Slice(0, ['1', '2', '3', '4', '5']);
+	var slice = g.Slice(0, [49, 50, 51, 52, 53]);
 	Invert(slice);
 
 
@@ -318,16 +318,16 @@ function recursive() {
 
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function A() {
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;Running function A<br>");
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;Running function A"], true) + "<br>"));
 }
 
 function B(name) {
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;Running function " + name + "<br>");
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;Running function " + name], true) + "<br>"));
 }
 
 
@@ -345,35 +345,35 @@ function B(name) {
 
 
 function main() {
-	document.write("<br><br>== Functions<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Functions<br><br>"], false)));
 
-	document.write("=== RUN init<br>");
+	document.write(g.Text(g.Sprint(["=== RUN init"], true) + "<br>"));
 	_init();
-	document.write("=== RUN singleLine<br>");
+	document.write(g.Text(g.Sprint(["=== RUN singleLine"], true) + "<br>"));
 	singleLine();
-	document.write("=== RUN simpleFunc<br>");
+	document.write(g.Text(g.Sprint(["=== RUN simpleFunc"], true) + "<br>"));
 	simpleFunc();
-	document.write("=== RUN twoOuputValues<br>");
+	document.write(g.Text(g.Sprint(["=== RUN twoOuputValues"], true) + "<br>"));
 	twoOuputValues();
-	document.write("=== RUN resultVariable<br>");
+	document.write(g.Text(g.Sprint(["=== RUN resultVariable"], true) + "<br>"));
 	resultVariable();
-	document.write("=== RUN return<br>");
+	document.write(g.Text(g.Sprint(["=== RUN return"], true) + "<br>"));
 	_return();
-	document.write("=== RUN variadic<br>");
+	document.write(g.Text(g.Sprint(["=== RUN variadic"], true) + "<br>"));
 	variadic();
-	document.write("=== RUN recursive<br>");
+	document.write(g.Text(g.Sprint(["=== RUN recursive"], true) + "<br>"));
 	recursive();
 
 
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Functions");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Functions"));
 	}
 
 	throw new Error("unreachable");
-	throw new Error("not implemented: " + "foo");
-} main();
+	throw new Error(g.Sprintf("not implemented: %s", ["foo"]));
+} init$1(); main();
 /* Generated by Go2js (github.com/kless/go2js) */
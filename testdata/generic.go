@@ -0,0 +1,297 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type Number interface {
+	~int | ~int8 | ~float64
+}
+
+type celsius float64
+
+type level int8
+
+type point struct {
+	x, y int
+}
+
+func Map[T, U any](s []T, f func(T) U) []U {
+	r := make([]U, 0, len(s))
+	for _, v := range s {
+		r = append(r, f(v))
+	}
+	return r
+}
+
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var r []T
+	for _, v := range s {
+		if keep(v) {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+func Sum[T Number](s []T) T {
+	var total T
+	for _, v := range s {
+		total += v
+	}
+	return total
+}
+
+func Max[T Number](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func Index[T comparable](s []T, v T) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+func Fill[T any](n int) []T {
+	return make([]T, n)
+}
+
+func Div[T ~int | ~int8](a, b T) T { return a / b }
+
+func Count[T ~int | ~int8](n int) T {
+	var c T
+	for i := 0; i < n; i++ {
+		c++
+	}
+	return c
+}
+
+func Unique[T comparable](s []T) map[T]struct{} {
+	m := make(map[T]struct{})
+	for _, v := range s {
+		m[v] = struct{}{}
+	}
+	return m
+}
+
+func functions() {
+	pass := true
+
+	s := []int{1, 2, 3}
+	strs := Map(s, func(v int) string { return fmt.Sprint(v * 2) })
+	if len(strs) != 3 || strs[0] != "2" || strs[2] != "6" {
+		fmt.Printf("\tFAIL: inferred => got %v\n", strs)
+		pass, PASS = false, false
+	}
+	if n := Map[int, int](s, func(v int) int { return v + 1 }); n[1] != 3 {
+		fmt.Printf("\tFAIL: explicit => got %v, want 3\n", n[1])
+		pass, PASS = false, false
+	}
+
+	double := Map[int, int]
+	if n := double(s, func(v int) int { return v * 2 }); n[2] != 6 {
+		fmt.Printf("\tFAIL: function value => got %v, want 6\n", n[2])
+		pass, PASS = false, false
+	}
+
+	even := Filter(s, func(v int) bool { return v%2 == 0 })
+	if len(even) != 1 || even[0] != 2 {
+		fmt.Printf("\tFAIL: filter => got %v\n", even)
+		pass, PASS = false, false
+	}
+
+	if Sum(s) != 6 || Sum([]float64{1.5, 2}) != 3.5 || Sum([]celsius{10, 5}) != 15 {
+		fmt.Printf("\tFAIL: approximate constraint => got %v\n", Sum(s))
+		pass, PASS = false, false
+	}
+	if Max(3, 7) != 7 || Max(2.5, 1.5) != 2.5 {
+		fmt.Printf("\tFAIL: comparison => got %v, %v\n", Max(3, 7), Max(2.5, 1.5))
+		pass, PASS = false, false
+	}
+
+	points := []point{{1, 2}, {3, 4}}
+	if Index(points, point{3, 4}) != 1 || Index([]string{"a", "b"}, "c") != -1 {
+		fmt.Printf("\tFAIL: comparable => got %v\n", Index(points, point{3, 4}))
+		pass, PASS = false, false
+	}
+
+	if Index([][2]int{{1, 2}, {3, 4}}, [2]int{3, 4}) != 1 {
+		fmt.Printf("\tFAIL: comparable array => got %v\n", Index([][2]int{{1, 2}, {3, 4}}, [2]int{3, 4}))
+		pass, PASS = false, false
+	}
+	if u := Unique([]int{1, 1, 2}); len(u) != 2 {
+		fmt.Printf("\tFAIL: empty struct => got %v\n", len(u))
+		pass, PASS = false, false
+	}
+
+	if Zero[int]() != 0 || Zero[string]() != "" || Zero[point]().y != 0 || Zero[*point]() != nil {
+		fmt.Println("\tFAIL: zero value")
+		pass, PASS = false, false
+	}
+	if f := Fill[bool](2); len(f) != 2 || f[1] {
+		fmt.Printf("\tFAIL: make => got %v\n", f)
+		pass, PASS = false, false
+	}
+	if f := Fill[point](2); f[0].x != 0 {
+		fmt.Printf("\tFAIL: make struct => got %v\n", f[0].x)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+func (s *Stack[T]) Pop() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	v := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return v, true
+}
+
+func (s *Stack[T]) Len() int { return len(s.items) }
+
+type Set[T comparable] struct {
+	m map[T]bool
+}
+
+func NewSet[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{map[T]bool{}}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *Set[T]) Add(v T)        { s.m[v] = true }
+func (s *Set[T]) Has(v T) bool   { return s.m[v] }
+func (s *Set[T]) Len() int       { return len(s.m) }
+func (s *Set[T]) Clone() *Set[T] { return NewSet(Keys(s.m)...) }
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func arithmetic() {
+	pass := true
+
+	if Div(7, 2) != 3 || Div[level](-9, 2) != -4 {
+		fmt.Printf("\tFAIL: integer division => got %v, %v\n", Div(7, 2), Div[level](-9, 2))
+		pass, PASS = false, false
+	}
+	if n := Sum([]int8{100, 100}); n != -56 {
+		fmt.Printf("\tFAIL: wrap around => got %v, want -56\n", n)
+		pass, PASS = false, false
+	}
+	if n := Count[int8](130); n != -126 {
+		fmt.Printf("\tFAIL: increment => got %v, want -126\n", n)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func types() {
+	pass := true
+
+	s := &Stack[string]{}
+	s.Push("a")
+	s.Push("b")
+	if v, ok := s.Pop(); v != "b" || !ok || s.Len() != 1 {
+		fmt.Printf("\tFAIL: stack => got %v, %v\n", v, ok)
+		pass, PASS = false, false
+	}
+	s.Pop()
+	if v, ok := s.Pop(); v != "" || ok {
+		fmt.Printf("\tFAIL: stack empty => got %q, %v\n", v, ok)
+		pass, PASS = false, false
+	}
+
+	var n Stack[int]
+	n.Push(5)
+	n.Pop()
+	if v, _ := n.Pop(); v != 0 {
+		fmt.Printf("\tFAIL: zero value of generic type => got %v\n", v)
+		pass, PASS = false, false
+	}
+
+	set := NewSet(1, 2, 2, 3)
+	if set.Len() != 3 || !set.Has(2) || set.Has(4) {
+		fmt.Printf("\tFAIL: set => got %v\n", set.Len())
+		pass, PASS = false, false
+	}
+	if c := set.Clone(); c.Len() != 3 || !c.Has(3) {
+		fmt.Printf("\tFAIL: clone => got %v\n", c.Len())
+		pass, PASS = false, false
+	}
+
+	p := Pair[string, int]{"a", 1}
+	if p.Key != "a" || p.Value != 1 {
+		fmt.Printf("\tFAIL: pair => got %v, %v\n", p.Key, p.Value)
+		pass, PASS = false, false
+	}
+	if t := fmt.Sprintf("%T", p); t != "main.Pair[string,int]" {
+		fmt.Printf("\tFAIL: type name => got %q\n", t)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Generics\n\n")
+
+	fmt.Println("=== RUN functions")
+	functions()
+	fmt.Println("=== RUN arithmetic")
+	arithmetic()
+	fmt.Println("=== RUN types")
+	types()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Generics")
+	}
+}
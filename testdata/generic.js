@@ -0,0 +1,298 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+
+
+
+
+function celsius(t) { this.t = t; } g.Named(celsius, "float64");
+
+function level(t) { this.t = t; } g.Named(level, "int8");
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function Map_(T, U, s, f) {
+	var r = g.MkSlice(U.zero(), 0, s.len);
+	var v; for (var $i1 = 0, $n2 = s.len; $i1 < $n2; $i1++) { v = s.at($i1);
+		r = g.Append(r, [f(v)]);
+	}
+	return r;
+}
+
+function Filter(T, s, keep) {
+	var r = g.MkSlice();
+	var v; for (var $i1 = 0, $n2 = s.len; $i1 < $n2; $i1++) { v = s.at($i1);
+		if (keep(v)) {
+			r = g.Append(r, [v]);
+		}
+	}
+	return r;
+}
+
+function Sum(T, s) {
+	var total = T.zero();
+	var v; for (var $i1 = 0, $n2 = s.len; $i1 < $n2; $i1++) { v = s.at($i1);
+		total = T.conv(total + v);
+	}
+	return total;
+}
+
+function Max(T, a, b) {
+	if (a > b) {
+		return a;
+	}
+	return b;
+}
+
+function Index(T, s, v) {
+	var x; for (var i = 0, $n1 = s.len; i < $n1; i++) { x = s.at(i);
+		if (g.Equal(x, v, 1)) {
+			return i;
+		}
+	}
+	return -1;
+}
+
+function Zero(T) {
+	var zero = T.zero();
+	return zero;
+}
+
+function Fill(T, n) {
+	return g.MkSlice(T.zero(), n);
+}
+
+function Div(T, a, b) { return T.conv(a / b); }
+
+function Count(T, n) {
+	var c = T.zero();
+	for (var i = 0; i < n; i++) {
+		c = T.conv(c + 1);
+	}
+	return c;
+}
+
+function Unique(T, s) {
+	var m = g.MkMap({}, [], true);
+	var v; for (var $i1 = 0, $n2 = s.len; $i1 < $n2; $i1++) { v = s.at($i1);
+		m.set(v, {});
+	}
+	return m;
+}
+
+function functions() {
+	var pass = true;
+
+	var s = g.Slice(0, [1, 2, 3]);
+	var strs = Map_(g.Type(function() { return 0; }, "int"), g.Type(function() { return ""; }, ""), s, function(v) { return g.Sprint([v * 2], false); });
+	if (strs.len != 3 || strs.at(0) != "2" || strs.at(2) != "6") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: inferred => got %v<br>", [g.Typed(strs, "[]string")])));
+		pass = false; PASS = false;
+	}
+	var n = Map_(g.Type(function() { return 0; }, "int"), g.Type(function() { return 0; }, "int"), s, function(v) { return v + 1; }); if (n.at(1) != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: explicit => got %v, want 3<br>", [n.at(1)])));
+		pass = false; PASS = false;
+	}
+
+	var double = Map_.bind(null, g.Type(function() { return 0; }, "int"), g.Type(function() { return 0; }, "int"));
+	var n = double(s, function(v) { return v * 2; }); if (n.at(2) != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: function value => got %v, want 6<br>", [n.at(2)])));
+		pass = false; PASS = false;
+	}
+
+	var even = Filter(g.Type(function() { return 0; }, "int"), s, function(v) { return v % 2 == 0; });
+	if (even.len != 1 || even.at(0) != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: filter => got %v<br>", [g.Typed(even, "[]int")])));
+		pass = false; PASS = false;
+	}
+
+	if (Sum(g.Type(function() { return 0; }, "int"), s) != 6 || Sum(g.Type(function() { return 0; }, "float64"), g.Slice(0, [1.5, 2])) != 3.5 || Sum(g.Type(function() { return 0; }, "float64"), g.Slice(0, [10, 5])) != 15) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: approximate constraint => got %v<br>", [Sum(g.Type(function() { return 0; }, "int"), s)])));
+		pass = false; PASS = false;
+	}
+	if (Max(g.Type(function() { return 0; }, "int"), 3, 7) != 7 || Max(g.Type(function() { return 0; }, "float64"), 2.5, 1.5) != 2.5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: comparison => got %v, %v<br>", [Max(g.Type(function() { return 0; }, "int"), 3, 7), g.Typed(Max(g.Type(function() { return 0; }, "float64"), 2.5, 1.5), "float64")])));
+		pass = false; PASS = false;
+	}
+
+	var points = g.Slice(new point(0, 0), [new point(1, 2), new point(3, 4)]);
+	if (Index(g.Type(function() { return new point(0, 0); }, ""), points, new point(3, 4)) != 1 || Index(g.Type(function() { return ""; }, ""), g.Slice("", ["a", "b"]), "c") != -1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: comparable => got %v<br>", [Index(g.Type(function() { return new point(0, 0); }, ""), points, new point(3, 4))])));
+		pass = false; PASS = false;
+	}
+
+	if (Index(g.Type(function() { return g.MkArray([2], 0); }, ""), g.Slice(g.MkArray([2], 0), [g.MkArray([2], 0, [1, 2]), g.MkArray([2], 0, [3, 4])]), g.MkArray([2], 0, [3, 4])) != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: comparable array => got %v<br>", [Index(g.Type(function() { return g.MkArray([2], 0); }, ""), g.Slice(g.MkArray([2], 0), [g.MkArray([2], 0, [1, 2]), g.MkArray([2], 0, [3, 4])]), g.MkArray([2], 0, [3, 4]))])));
+		pass = false; PASS = false;
+	}
+	var u = Unique(g.Type(function() { return 0; }, "int"), g.Slice(0, [1, 1, 2])); if (u.len() != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: empty struct => got %v<br>", [u.len()])));
+		pass = false; PASS = false;
+	}
+
+	if (Zero(g.Type(function() { return 0; }, "int")) != 0 || Zero(g.Type(function() { return ""; }, "")) != "" || Zero(g.Type(function() { return new point(0, 0); }, "")).y != 0 || Zero(g.Type(function() { return undefined; }, "")) != undefined) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: zero value"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+	var f = Fill(g.Type(function() { return false; }, ""), 2); if (f.len != 2 || f.at(1)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: make => got %v<br>", [g.Typed(f, "[]bool")])));
+		pass = false; PASS = false;
+	}
+	var f = Fill(g.Type(function() { return new point(0, 0); }, ""), 2); if (f.at(0).x != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: make struct => got %v<br>", [f.at(0).x])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function Stack($T, items) { this.$T = $T;
+	this.items = items;
+}
+
+Stack.prototype.Push = function(v) {
+	this.items = g.Append(this.items, [v]);
+};
+
+Stack.prototype.Pop = function() {
+	if (this.items.len == 0) {
+		var zero = this.$T.zero();
+		return [zero, false];
+	}
+	var v = this.items.at(this.items.len - 1);
+	this.items = g.SliceFrom(this.items, 0, this.items.len - 1);
+	return [v, true];
+};
+
+Stack.prototype.Len = function() { return this.items.len; };
+
+function Set_($T, m) { this.$T = $T;
+	this.m = m;
+}
+
+function NewSet(T, values) {
+	var s = new Set_(T, g.MkMap(false, [], true));
+	var v; for (var $i1 = 0, $n2 = values.len; $i1 < $n2; $i1++) { v = values.at($i1);
+		s.Add(v);
+	}
+	return s;
+}
+
+Set_.prototype.Add = function(v) { this.m.set(v, true); };
+Set_.prototype.Has = function(v) { return this.m.get(v)[0]; };
+Set_.prototype.Len = function() { return this.m.len(); };
+Set_.prototype.Clone = function() { return NewSet(this.$T, Keys(this.$T, g.Type(function() { return false; }, ""), this.m)); };
+function Keys(K, V, m) {
+	var keys = g.MkSlice(K.zero(), 0, m.len());
+	var k; for (var $e1 of m.entries()) { k = $e1[0];
+		keys = g.Append(keys, [k]);
+	}
+	return keys;
+}
+
+function arithmetic() {
+	var pass = true;
+
+	if (Div(g.Type(function() { return 0; }, "int"), 7, 2) != 3 || Div(g.Type(function() { return 0; }, "int8"), -9, 2) != -4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: integer division => got %v, %v<br>", [Div(g.Type(function() { return 0; }, "int"), 7, 2), g.Typed(Div(g.Type(function() { return 0; }, "int8"), -9, 2), "main.level", "int8")])));
+		pass = false; PASS = false;
+	}
+	var n = Sum(g.Type(function() { return 0; }, "int8"), g.Slice(0, [100, 100])); if (n != -56) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: wrap around => got %v, want -56<br>", [g.Typed(n, "int8")])));
+		pass = false; PASS = false;
+	}
+	var n = Count(g.Type(function() { return 0; }, "int8"), 130); if (n != -126) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: increment => got %v, want -126<br>", [g.Typed(n, "int8")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function Pair($K, $V, Key, Value) { this.$K = $K; this.$V = $V;
+	this.Key = Key;
+	this.Value = Value;
+}
+
+function types() {
+	var pass = true;
+
+	var s = new Stack(g.Type(function() { return ""; }, ""), g.MkSlice());
+	s.Push("a");
+	s.Push("b");
+	var $r1 = s.Pop(), v = $r1[0], ok = $r1[1]; if (v != "b" || !ok || s.Len() != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: stack => got %v, %v<br>", [v, ok])));
+		pass = false; PASS = false;
+	}
+	s.Pop();
+	var $r2 = s.Pop(), v = $r2[0], ok = $r2[1]; if (v != "" || ok) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: stack empty => got %q, %v<br>", [v, ok])));
+		pass = false; PASS = false;
+	}
+
+	var n = new Stack(g.Type(function() { return 0; }, "int"), g.MkSlice());
+	n.Push(5);
+	n.Pop();
+	var v = n.Pop()[0]; if (v != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: zero value of generic type => got %v<br>", [v])));
+		pass = false; PASS = false;
+	}
+
+	var set = NewSet(g.Type(function() { return 0; }, "int"), g.SliceArgs([1, 2, 2, 3]));
+	if (set.Len() != 3 || !set.Has(2) || set.Has(4)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: set => got %v<br>", [set.Len()])));
+		pass = false; PASS = false;
+	}
+	var c = set.Clone(); if (c.Len() != 3 || !c.Has(3)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: clone => got %v<br>", [c.Len()])));
+		pass = false; PASS = false;
+	}
+
+	var p = new Pair(g.Type(function() { return ""; }, ""), g.Type(function() { return 0; }, "int"), "a", 1);
+	if (p.Key != "a" || p.Value != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pair => got %v, %v<br>", [p.Key, p.Value])));
+		pass = false; PASS = false;
+	}
+	var t = g.Sprintf("%T", [g.Typed(p, "main.Pair[string,int]")]); if (t != "main.Pair[string,int]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: type name => got %q<br>", [t])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Generics<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN functions"], true) + "<br>"));
+	functions();
+	document.write(g.Text(g.Sprint(["=== RUN arithmetic"], true) + "<br>"));
+	arithmetic();
+	document.write(g.Text(g.Sprint(["=== RUN types"], true) + "<br>"));
+	types();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Generics"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,108 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+var trace = ""
+
+var (
+	a = c + b // == 9
+	b = f()   // == 4
+	c = f()   // == 5
+	d = 3     // == 5 after of initialization has finished
+)
+
+func f() int {
+	d++
+	return d
+}
+
+var total = sum() // depends on "items" through the function
+
+func sum() int {
+	n := 0
+	for _, v := range items {
+		n += v
+	}
+	return n
+}
+
+var items = []int{1, 2, 3}
+
+var area = sq.Area() // depends on "side" through the method
+
+type square struct{ side int }
+
+func (s square) Area() int { return s.side * s.side }
+
+var sq = square{side}
+
+var side = 4
+
+func init() {
+	trace += "1"
+	if total != 6 {
+		trace += "!"
+	}
+}
+
+func init() {
+	trace += "2"
+}
+
+func order() {
+	pass := true
+
+	if a != 9 || b != 4 || c != 5 || d != 5 {
+		fmt.Printf("\tFAIL: variables => got %v %v %v %v, want 9 4 5 5\n", a, b, c, d)
+		pass, PASS = false, false
+	}
+	if total != 6 {
+		fmt.Printf("\tFAIL: through function => got %v, want 6\n", total)
+		pass, PASS = false, false
+	}
+	if area != 16 {
+		fmt.Printf("\tFAIL: through method => got %v, want 16\n", area)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func inits() {
+	pass := true
+
+	if trace != "12" {
+		fmt.Printf("\tFAIL: init => got %q, want \"12\"\n", trace)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Initialization order\n\n")
+
+	fmt.Println("=== RUN order")
+	order()
+	fmt.Println("=== RUN inits")
+	inits()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Initialization order")
+	}
+}
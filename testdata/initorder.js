@@ -0,0 +1,118 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+var trace = "";
+
+
+var a;
+var b;
+var c;
+var d;
+
+
+function f() {
+	d++;
+	return d;
+}
+
+var total;
+
+function sum() {
+	var n = 0;
+	var v; for (var $i1 = 0, $r2 = items, $n3 = $r2.len; $i1 < $n3; $i1++) { v = $r2.at($i1);
+		n += v;
+	}
+	return n;
+}
+
+var items;
+
+var area;
+
+function square(side) { this.side = side; }
+
+square.prototype.Area = function() { return this.side * this.side; };
+
+var sq;
+
+var side;
+
+function init$1() {
+	trace += "1";
+	if (total != 6) {
+		trace += "!";
+	}
+}
+
+function init$2() {
+	trace += "2";
+}
+
+function order() {
+	var pass = true;
+
+	if (a != 9 || b != 4 || c != 5 || d != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: variables => got %v %v %v %v, want 9 4 5 5<br>", [a, b, c, d])));
+		pass = false; PASS = false;
+	}
+	if (total != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: through function => got %v, want 6<br>", [total])));
+		pass = false; PASS = false;
+	}
+	if (area != 16) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: through method => got %v, want 16<br>", [area])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function inits() {
+	var pass = true;
+
+	if (trace != "12") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: init => got %q, want \"12\"<br>", [trace])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Initialization order<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN order"], true) + "<br>"));
+	order();
+	document.write(g.Text(g.Sprint(["=== RUN inits"], true) + "<br>"));
+	inits();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Initialization order"));
+	}
+}
+d = 3;
+b = f();
+c = f();
+a = c + b;
+items = g.Slice(0, [1, 2, 3]);
+total = sum();
+side = 4;
+sq = new square(side);
+area = sq.Area(); init$1(); init$2(); main();
+/* Generated by Go2js (github.com/kless/go2js) */
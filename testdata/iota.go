@@ -0,0 +1,125 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	_ // Wednesday is skipped
+	Thursday
+)
+
+var dayNames = [...]string{"Sun", "Mon", "Tue", "Wed", "Thu"}
+
+func (d Weekday) String() string { return dayNames[d] }
+
+func enum() {
+	pass := true
+
+	if s := fmt.Sprint(Sunday, Monday, Thursday); s != "Sun Mon Thu" {
+		fmt.Printf("\tFAIL: Stringer => got %q\n", s)
+		pass, PASS = false, false
+	}
+	if s := fmt.Sprintf("%v %d %s %T", Tuesday, Tuesday, Thursday, Sunday); s != "Tue 2 Thu main.Weekday" {
+		fmt.Printf("\tFAIL: verbs => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	day := Monday
+	day++
+	if day != Tuesday || fmt.Sprint(day) != "Tue" || fmt.Sprint(day+2) != "Thu" {
+		fmt.Printf("\tFAIL: variable => got %v\n", day)
+		pass, PASS = false, false
+	}
+
+	var i interface{} = Thursday
+	if i != Thursday || i == Monday || fmt.Sprint(i) != "Thu" {
+		fmt.Printf("\tFAIL: interface => got %v\n", i)
+		pass, PASS = false, false
+	}
+
+	switch day {
+	case Sunday, Monday:
+		fmt.Println("\tFAIL: switch")
+		pass, PASS = false, false
+	case Tuesday:
+	default:
+		fmt.Println("\tFAIL: switch default")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type ByteSize float64
+
+const (
+	_           = iota // ignore the first value
+	KB ByteSize = 1 << (10 * iota)
+	MB
+	GB
+	TB
+)
+
+const (
+	bit0, mask0 = 1 << iota, 1<<iota - 1
+	bit1, mask1
+	_, _
+	bit3, mask3
+)
+
+const big = 1 << 40
+
+func folding() {
+	pass := true
+
+	if KB != 1024 || MB != 1048576 || GB != 1073741824 || TB != 1099511627776 {
+		fmt.Printf("\tFAIL: sizes => got %v %v %v %v\n", float64(KB), float64(MB), float64(GB), float64(TB))
+		pass, PASS = false, false
+	}
+	if bit0 != 1 || mask0 != 0 || bit1 != 2 || mask1 != 1 || bit3 != 8 || mask3 != 7 {
+		fmt.Printf("\tFAIL: pairs => got %v %v %v %v %v %v\n", bit0, mask0, bit1, mask1, bit3, mask3)
+		pass, PASS = false, false
+	}
+	if big>>38 != 4 || big/1024 != 1073741824 || 7/2 != 3 || -1<<3 != -8 {
+		fmt.Printf("\tFAIL: expressions => got %v %v\n", big>>38, big/1024)
+		pass, PASS = false, false
+	}
+	if s := fmt.Sprintf("%T %v", KB, GB); s != "main.ByteSize 1.073741824e+09" {
+		fmt.Printf("\tFAIL: typed => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Constants\n\n")
+
+	fmt.Println("=== RUN enum")
+	enum()
+	fmt.Println("=== RUN folding")
+	folding()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Constants")
+	}
+}
@@ -0,0 +1,126 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function Weekday(t) { this.t = t; } g.Named(Weekday, "int");
+
+
+const Sunday = 0;
+const Monday = 1;
+const Tuesday = 2;
+
+const Thursday = 4;
+
+
+var dayNames = g.MkArray([5], "", ["Sun", "Mon", "Tue", "Wed", "Thu"]);
+
+Weekday.prototype.String = function() { var d = this.t; return dayNames.v[d]; };
+
+function enum_() {
+	var pass = true;
+
+	var s = g.Sprint([new Weekday(Sunday), new Weekday(Monday), new Weekday(Thursday)], false); if (s != "Sun Mon Thu") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Stringer => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+	var s = g.Sprintf("%v %d %s %T", [new Weekday(Tuesday), new Weekday(Tuesday), new Weekday(Thursday), new Weekday(Sunday)]); if (s != "Tue 2 Thu main.Weekday") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: verbs => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	var day = Monday;
+	day++;
+	if (day != Tuesday || g.Sprint([new Weekday(day)], false) != "Tue" || g.Sprint([new Weekday(day + 2)], false) != "Thu") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: variable => got %v<br>", [new Weekday(day)])));
+		pass = false; PASS = false;
+	}
+
+	var i = new Weekday(Thursday);
+	if (!g.Equal(i, new Weekday(Thursday), 1) || g.Equal(i, new Weekday(Monday), 1) || g.Sprint([i], false) != "Thu") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface => got %v<br>", [i])));
+		pass = false; PASS = false;
+	}
+
+	switch (day) {
+	case Sunday: case Monday:
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: switch"], true) + "<br>"));
+		pass = false; PASS = false; break;
+	case Tuesday: break;
+	default:
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: switch default"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function ByteSize(t) { this.t = t; } g.Named(ByteSize, "float64");
+
+
+
+const KB = 1024;
+const MB = 1.048576e+06;
+const GB = 1.073741824e+09;
+const TB = 1.099511627776e+12;
+
+
+
+const bit0 = 1, mask0 = 0;
+const bit1 = 2, mask1 = 1;
+
+const bit3 = 8, mask3 = 7;
+
+
+const big = 1099511627776;
+
+function folding() {
+	var pass = true;
+
+	if (KB != 1024 || MB != 1048576 || GB != 1073741824 || TB != 1099511627776) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sizes => got %v %v %v %v<br>", [g.Typed(g.Float64(KB), "float64"), g.Typed(g.Float64(MB), "float64"), g.Typed(g.Float64(GB), "float64"), g.Typed(g.Float64(TB), "float64")])));
+		pass = false; PASS = false;
+	}
+	if (bit0 != 1 || mask0 != 0 || bit1 != 2 || mask1 != 1 || bit3 != 8 || mask3 != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pairs => got %v %v %v %v %v %v<br>", [bit0, mask0, bit1, mask1, bit3, mask3])));
+		pass = false; PASS = false;
+	}
+	if (4 != 4 || 1073741824 != 1073741824 || 3 != 3 || (-8) != -8) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: expressions => got %v %v<br>", [4, 1073741824])));
+		pass = false; PASS = false;
+	}
+	var s = g.Sprintf("%T %v", [g.Typed(KB, "main.ByteSize", "float64"), g.Typed(GB, "main.ByteSize", "float64")]); if (s != "main.ByteSize 1.073741824e+09") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: typed => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Constants<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN enum"], true) + "<br>"));
+	enum_();
+	document.write(g.Text(g.Sprint(["=== RUN folding"], true) + "<br>"));
+	folding();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Constants"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,25 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+func main() {
+	s := []string{
+		"a <<NL>> b",
+		"{ }",
+	}
+	add := func(a, b int) int { return a + b }
+
+	for i := 0; i < len(s); i++ {
+		if i == 0 {
+			fmt.Println(s[i])
+		} else {
+			fmt.Println(add(i, 1))
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+func closures() {
+	pass := true
+
+	funcs := []func() int{}
+	for i := 0; i < 3; i++ {
+		funcs = append(funcs, func() int { return i })
+	}
+	sum := 0
+	for _, f := range funcs {
+		sum = sum*10 + f()
+	}
+	if sum != 12 {
+		fmt.Printf("\tFAIL: for => got %v, want 12\n", sum)
+		pass, PASS = false, false
+	}
+
+	funcs = []func() int{}
+	for i := 0; i < 6; i++ {
+		i++ // the change is seen by the closure and the next iteration
+		funcs = append(funcs, func() int { return i })
+	}
+	sum = 0
+	for _, f := range funcs {
+		sum = sum*10 + f()
+	}
+	if sum != 135 {
+		fmt.Printf("\tFAIL: changed into the body => got %v, want 135\n", sum)
+		pass, PASS = false, false
+	}
+
+	funcs = []func() int{}
+	for i, v := range []int{4, 5, 6} {
+		funcs = append(funcs, func() int { return i*10 + v })
+	}
+	sum = 0
+	for _, f := range funcs {
+		sum += f()
+	}
+	if sum != 45 {
+		fmt.Printf("\tFAIL: range => got %v, want 45\n", sum)
+		pass, PASS = false, false
+	}
+
+	funcs = []func() int{}
+	for k := range map[int]bool{7: true, 8: true} {
+		funcs = append(funcs, func() int { return k })
+	}
+	sum = 0
+	for _, f := range funcs {
+		sum += f()
+	}
+	if sum != 15 {
+		fmt.Printf("\tFAIL: range map => got %v, want 15\n", sum)
+		pass, PASS = false, false
+	}
+
+	funcs = []func() int{}
+	for i := range 3 {
+		funcs = append(funcs, func() int { return i })
+	}
+	sum = 0
+	for _, f := range funcs {
+		sum = sum*10 + f()
+	}
+	if sum != 12 {
+		fmt.Printf("\tFAIL: range integer => got %v, want 12\n", sum)
+		pass, PASS = false, false
+	}
+
+	// The variables declared into the body are new in each iteration too.
+	funcs = []func() int{}
+	for i := 0; i < 3; i++ {
+		w := i
+		switch {
+		case i%2 == 0:
+			even := w * 10
+			funcs = append(funcs, func() int { return even })
+		default:
+			even := w * 20
+			funcs = append(funcs, func() int { return even })
+		}
+		funcs = append(funcs, func() int { return w })
+	}
+	sum = 0
+	for _, f := range funcs {
+		sum = sum*100 + f()
+	}
+	if sum != 20012002 {
+		fmt.Printf("\tFAIL: body => got %v, want 20012002\n", sum)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func pointers() {
+	pass := true
+
+	ptrs := []*int{}
+	for i := 0; i < 3; i++ {
+		ptrs = append(ptrs, &i)
+	}
+	sum := 0
+	for _, p := range ptrs {
+		sum = sum*10 + *p
+	}
+	if sum != 12 {
+		fmt.Printf("\tFAIL: for => got %v, want 12\n", sum)
+		pass, PASS = false, false
+	}
+
+	ptrs = []*int{}
+	for _, v := range []int{4, 5, 6} {
+		ptrs = append(ptrs, &v)
+	}
+	sum = 0
+	for _, p := range ptrs {
+		sum = sum*10 + *p
+	}
+	if sum != 456 {
+		fmt.Printf("\tFAIL: range => got %v, want 456\n", sum)
+		pass, PASS = false, false
+	}
+
+	n := 0
+	for i := 0; i < 6; i++ {
+		p := &i
+		*p += 1 // the loop sees the change
+		n++
+	}
+	if n != 3 {
+		fmt.Printf("\tFAIL: change through pointer => got %v, want 3\n", n)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Loop variables\n\n")
+
+	fmt.Println("=== RUN closures")
+	closures()
+	fmt.Println("=== RUN pointers")
+	pointers()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Loop variables")
+	}
+}
@@ -0,0 +1,170 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function closures() {
+	var pass = true;
+
+	var funcs = g.Slice(undefined, []);
+	for (let i = 0; i < 3; i++) {
+		funcs = g.Append(funcs, [function() { return i; }]);
+	}
+	var sum = 0;
+	var f; for (var $i1 = 0, $n2 = funcs.len; $i1 < $n2; $i1++) { f = funcs.at($i1);
+		sum = sum * 10 + f();
+	}
+	if (sum != 12) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: for => got %v, want 12<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	funcs = g.Slice(undefined, []);
+	for (let i = 0; i < 6; i++) {
+		i++;
+		funcs = g.Append(funcs, [function() { return i; }]);
+	}
+	sum = 0;
+	var f; for (var $i3 = 0, $n4 = funcs.len; $i3 < $n4; $i3++) { f = funcs.at($i3);
+		sum = sum * 10 + f();
+	}
+	if (sum != 135) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: changed into the body => got %v, want 135<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	funcs = g.Slice(undefined, []);
+	for (var $i5 = 0, $r6 = g.Slice(0, [4, 5, 6]), $n7 = $r6.len; $i5 < $n7; $i5++) { let i = $i5, v = $r6.at($i5);
+		funcs = g.Append(funcs, [function() { return i * 10 + v; }]);
+	}
+	sum = 0;
+	var f; for (var $i8 = 0, $n9 = funcs.len; $i8 < $n9; $i8++) { f = funcs.at($i8);
+		sum += f();
+	}
+	if (sum != 45) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range => got %v, want 45<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	funcs = g.Slice(undefined, []);
+	for (var $e10 of g.MkMap(false, [[7, true], [8, true]]).entries()) { let k = $e10[0];
+		funcs = g.Append(funcs, [function() { return k; }]);
+	}
+	sum = 0;
+	var f; for (var $i11 = 0, $n12 = funcs.len; $i11 < $n12; $i11++) { f = funcs.at($i11);
+		sum += f();
+	}
+	if (sum != 15) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range map => got %v, want 15<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	funcs = g.Slice(undefined, []);
+	for (var $i13 = 0; $i13 < 3; $i13++) { let i = $i13;
+		funcs = g.Append(funcs, [function() { return i; }]);
+	}
+	sum = 0;
+	var f; for (var $i14 = 0, $n15 = funcs.len; $i14 < $n15; $i14++) { f = funcs.at($i14);
+		sum = sum * 10 + f();
+	}
+	if (sum != 12) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range integer => got %v, want 12<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+
+	funcs = g.Slice(undefined, []);
+	for (var i = 0; i < 3; i++) {
+		let w = i;
+		switch (true) {
+		case i % 2 == 0:
+			let even$ = w * 10;
+			funcs = g.Append(funcs, [function() { return even$; }]); break;
+		default:
+			let even$1 = w * 20;
+			funcs = g.Append(funcs, [function() { return even$1; }]);
+		}
+		funcs = g.Append(funcs, [function() { return w; }]);
+	}
+	sum = 0;
+	var f; for (var $i16 = 0, $n17 = funcs.len; $i16 < $n17; $i16++) { f = funcs.at($i16);
+		sum = sum * 100 + f();
+	}
+	if (sum != 20012002) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: body => got %v, want 20012002<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function pointers() {
+	var pass = true;
+
+	var ptrs = g.Slice(undefined, []);
+	for (let i = {p:0}; i.p < 3; i = {p:i.p}, i.p++) {
+		ptrs = g.Append(ptrs, [i]);
+	}
+	var sum = 0;
+	var p; for (var $i1 = 0, $n2 = ptrs.len; $i1 < $n2; $i1++) { p = ptrs.at($i1);
+		sum = sum * 10 + p.p;
+	}
+	if (sum != 12) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: for => got %v, want 12<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	ptrs = g.Slice(undefined, []);
+	for (var $i3 = 0, $r4 = g.Slice(0, [4, 5, 6]), $n5 = $r4.len; $i3 < $n5; $i3++) { let v = {p:$r4.at($i3)};
+		ptrs = g.Append(ptrs, [v]);
+	}
+	sum = 0;
+	var p; for (var $i6 = 0, $n7 = ptrs.len; $i6 < $n7; $i6++) { p = ptrs.at($i6);
+		sum = sum * 10 + p.p;
+	}
+	if (sum != 456) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range => got %v, want 456<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	var n = 0;
+	for (let i = {p:0}; i.p < 6; i = {p:i.p}, i.p++) {
+		var p = i;
+		p.p += 1;
+		n++;
+	}
+	if (n != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: change through pointer => got %v, want 3<br>", [n])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Loop variables<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN closures"], true) + "<br>"));
+	closures();
+	document.write(g.Text(g.Sprint(["=== RUN pointers"], true) + "<br>"));
+	pointers();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Loop variables"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
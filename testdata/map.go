@@ -110,6 +110,15 @@ func reference() {
 	}
 }
 
+type catalog struct {
+	rating map[string]float32
+}
+
+func (c *catalog) has(name string) bool {
+	_, ok := c.rating[name]
+	return ok
+}
+
 func keyNoExistent() {
 	pass := true
 
@@ -139,6 +148,13 @@ func keyNoExistent() {
 		fmt.Printf("\tFAIL: using comma => got %v, want %v\n", found, !found)
 		pass, PASS = false, false
 	}
+
+	// The map is a field.
+	c := catalog{rating}
+	if _, found := c.rating["C#"]; found || !c.has("Go") || c.has("C#") {
+		fmt.Printf("\tFAIL: using comma with a field => got %v, %v, %v\n", found, c.has("Go"), c.has("C#"))
+		pass, PASS = false, false
+	}
 	if pass {
 		fmt.Println("\tpass")
 	}
@@ -239,6 +255,185 @@ func blankIdInRange() {
 	}
 }
 
+type point struct {
+	x, y int
+}
+
+type sizedKey struct {
+	n int8
+	s string
+}
+
+// keys returns the sum of the keys of a map, which keep their type.
+func sumKeys[K int | float64, V any](m map[K]V) K {
+	var sum K
+	for k := range m {
+		sum += k
+	}
+	return sum
+}
+
+func keyTypes() {
+	pass := true
+
+	// == 1. The keys keep their type.
+	ints := map[int]string{1: "a", 20: "b", 300: "c"}
+	sum := 0
+	for k := range ints {
+		sum += k
+	}
+	if sum != 321 || sumKeys(ints) != 321 {
+		fmt.Printf("\tFAIL: 1. int keys => got %v, %v\n", sum, sumKeys(ints))
+		pass, PASS = false, false
+	}
+	if _, ok := ints[1]; !ok {
+		fmt.Println("\tFAIL: 1. int key not found")
+		pass, PASS = false, false
+	}
+
+	bools := map[bool]int{true: 1}
+	bools[false]++
+	for k, v := range bools {
+		if k && v != 1 || !k && v != 1 {
+			fmt.Printf("\tFAIL: 1. bool keys => got %v:%v\n", k, v)
+			pass, PASS = false, false
+		}
+	}
+
+	// == 2. The structs and arrays are compared by value.
+	points := map[point]string{{1, 2}: "a"}
+	points[point{3, 4}] = "b"
+	points[point{1, 2}] = "c"
+
+	if len(points) != 2 || points[point{1, 2}] != "c" || points[point{3, 4}] != "b" {
+		fmt.Printf("\tFAIL: 2. struct keys => got %v\n", points)
+		pass, PASS = false, false
+	}
+
+	arrays := make(map[[2]int]int)
+	arrays[[2]int{1, 2}] = 5
+	arrays[[2]int{1, 2}] += 5
+
+	if len(arrays) != 1 || arrays[[2]int{1, 2}] != 10 {
+		fmt.Printf("\tFAIL: 2. array keys => got %v\n", arrays)
+		pass, PASS = false, false
+	}
+
+	// == 3. The interfaces are compared by their dynamic values.
+	any := map[interface{}]int{1: 1, "1": 2, point{1, 1}: 3}
+
+	if len(any) != 3 || any[1] != 1 || any["1"] != 2 || any[point{1, 1}] != 3 {
+		fmt.Printf("\tFAIL: 3. interface keys => got %v\n", any)
+		pass, PASS = false, false
+	}
+
+	// The dynamic type is part of the key, and the pointers are compared by
+	// identity.
+	p1, p2 := &point{1, 1}, &point{1, 1}
+	any[1.0] = 4
+	any[int32(1)] = 5
+	any[p1] = 6
+	any[p2] = 7
+
+	if len(any) != 7 || any[1] != 1 || any[1.0] != 4 || any[point{1, 1}] != 3 || any[p1] != 6 {
+		fmt.Printf("\tFAIL: 3. dynamic type of keys => got %v, %v, %v, %v, %v\n",
+			len(any), any[1], any[1.0], any[point{1, 1}], any[p1])
+		pass, PASS = false, false
+	}
+
+	// == 4. The sized numbers are compared by their value.
+	var small int8
+	int8s := map[int8]string{0: "zero"}
+	int8s[small+1] = "one"
+
+	if len(int8s) != 2 || int8s[small] != "zero" || int8s[1] != "one" {
+		fmt.Printf("\tFAIL: 4. int8 keys => got %v\n", int8s)
+		pass, PASS = false, false
+	}
+
+	sized := map[sizedKey]int{{2, "x"}: 5}
+	sized[sizedKey{small + 2, "x"}]++
+
+	if len(sized) != 1 || sized[sizedKey{2, "x"}] != 6 {
+		fmt.Printf("\tFAIL: 4. struct keys with int8 => got %v\n", sized)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type box struct {
+	a [2]int
+	n int
+}
+
+func elements() {
+	pass := true
+
+	// == 1. Nested maps.
+	nested := map[string]map[string]int{"a": {"x": 1}}
+	nested["a"]["y"] = 2
+	nested["b"] = map[string]int{}
+	nested["b"]["z"]++
+
+	if len(nested["a"]) != 2 || nested["a"]["y"] != 2 || nested["b"]["z"] != 1 ||
+		nested["c"] != nil || nested["c"]["w"] != 0 {
+		fmt.Printf("\tFAIL: 1. nested => got %v\n", nested)
+		pass, PASS = false, false
+	}
+
+	// == 2. Slices into a map.
+	lists := map[string][]int{"odd": {1, 3}}
+	lists["odd"] = append(lists["odd"], 5)
+	lists["odd"][0] = 7
+
+	if fmt.Sprint(lists) != "map[odd:[7 3 5]]" {
+		fmt.Printf("\tFAIL: 2. slices => got %v\n", lists)
+		pass, PASS = false, false
+	}
+
+	// == 3. Delete while it is ranged.
+	m := map[int]bool{1: true, 2: true, 3: true, 4: true}
+	n := 0
+	for k := range m {
+		delete(m, k)
+		n++
+	}
+	if n != 4 || len(m) != 0 {
+		fmt.Printf("\tFAIL: 3. delete => got %v, n=%d\n", m, n)
+		pass, PASS = false, false
+	}
+
+	// == 4. Formatted with the keys sorted.
+	sorted := map[int]string{10: "x", 2: "y", -1: "z"}
+	if s := fmt.Sprint(sorted); s != "map[-1:z 2:y 10:x]" {
+		fmt.Printf("\tFAIL: 4. sorted => got %s\n", s)
+		pass, PASS = false, false
+	}
+
+	// == 5. The zero of the values is a new copy.
+	boxes := map[string]box{}
+	b := boxes["a"]
+	b.a[0] = 3
+
+	if boxes["a"].a[0] != 0 || b.a[0] != 3 || len(boxes) != 0 {
+		fmt.Printf("\tFAIL: 5. struct zero => got %v, %v\n", boxes["a"], b)
+		pass, PASS = false, false
+	}
+
+	pairs := make(map[string][2]int)
+	if pairs["x"][1] != 0 {
+		fmt.Printf("\tFAIL: 5. array zero => got %v\n", pairs["x"])
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
 func main() {
 	fmt.Print("\n\n== Maps\n\n")
 
@@ -256,6 +451,10 @@ func main() {
 	_range()
 	fmt.Println("=== RUN blankIdInRange")
 	blankIdInRange()
+	fmt.Println("=== RUN keyTypes")
+	keyTypes()
+	fmt.Println("=== RUN elements")
+	elements()
 
 	if PASS {
 		fmt.Println("PASS")
@@ -9,192 +9,208 @@
 
 
 var PASS = true;
-var rating = g.Map(0, {"C": 5, "Go": 4.5, "Python": 4.5, "C++": 2});
+var rating = g.MkMap(0, [["C", 5], ["Go", 4.5], ["Python", 4.5], ["C++", 2]]);
 
 function builtIn() {
 	var pass = true;
 
-	var m1 = g.Map(0);
-	var m2 = g.Map(0, {});
-	var m3 = g.Map(0, {});
-	var m4 = g.Map(0, {});
+	var m1 = g.MkMap(0);
+	var m2 = g.MkMap(0, []);
+	var m3 = g.MkMap(0, []);
+	var m4 = g.MkMap(0, []);
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("nil m1", m1.v == undefined, true),
-		_("nil m2", m2.v == undefined, false),
-		_("nil m3", m3.v == undefined, false),
-		_("nil m4", m4.v == undefined, false),
-		_("nil m4", m4.v != undefined, true),
-
-		_("len m1", m1.len() == 0, true),
-		_("len m2", m2.len() == 0, true),
-		_("len m3", m3.len() == 0, true),
-		_("len m4", m4.len() == 0, true),
-
-		_("nil rating", rating.v != undefined, true),
-		_("len rating", rating.len() == 4, true)
+	}; }; var tests = [
+		$T1("nil m1", m1.isNil(), true),
+		$T1("nil m2", m2.isNil(), false),
+		$T1("nil m3", m3.isNil(), false),
+		$T1("nil m4", m4.isNil(), false),
+		$T1("nil m4", !m4.isNil(), true),
+
+		$T1("len m1", m1.len() == 0, true),
+		$T1("len m2", m2.len() == 0, true),
+		$T1("len m3", m3.len() == 0, true),
+		$T1("len m4", m4.len() == 0, true),
+
+		$T1("nil rating", !rating.isNil(), true),
+		$T1("len rating", rating.len() == 4, true)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function declaration() {
 	var pass = true;
 
-	var numbers = g.Map(0);
-	numbers = g.Map(0, {});
-	numbers.v["one"] = 1;
-	numbers.v["ten"] = 10;
-	numbers.v["trois"] = 3;
+	var numbers = g.MkMap(0);
+	numbers = g.MkMap(0, []);
+	numbers.set("one", 1);
+	numbers.set("ten", 10);
+	numbers.set("trois", 3);
 
 
-	var rating1 = g.Map(0, {"C": 5, "Go": 4.5, "Python": 4.5, "C++": 2});
+	var rating1 = g.MkMap(0, [["C", 5], ["Go", 4.5], ["Python", 4.5], ["C++", 2]]);
 
 
-	var rating2 = g.Map(0, {});
-	rating2.v["C"] = 5;
-	rating2.v["Go"] = 4.5;
-	rating2.v["Python"] = 4.5;
-	rating2.v["C++"] = 2;
+	var rating2 = g.MkMap(0, []);
+	rating2.set("C", 5);
+	rating2.set("Go", 4.5);
+	rating2.set("Python", 4.5);
+	rating2.set("C++", 2);
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("numbers[\"one\"]", numbers.get("one")[0], 1),
-		_("numbers[\"ten\"]", numbers.get("ten")[0], 10),
-		_("numbers[\"trois\"]", numbers.get("trois")[0], 3),
-
-		_("rating[\"C\"]", rating1.get("C")[0], rating2.get("C")[0]),
-		_("rating[\"Go\"]", rating1.get("Go")[0], rating2.get("Go")[0]),
-		_("rating[\"Python\"]", rating1.get("Python")[0], rating2.get("Python")[0]),
-		_("rating[\"C++\"]", rating1.get("C++")[0], rating2.get("C++")[0])
+	}; }; var tests = [
+		$T1("numbers[\"one\"]", numbers.get("one")[0], 1),
+		$T1("numbers[\"ten\"]", numbers.get("ten")[0], 10),
+		$T1("numbers[\"trois\"]", numbers.get("trois")[0], 3),
+
+		$T1("rating[\"C\"]", rating1.get("C")[0], rating2.get("C")[0]),
+		$T1("rating[\"Go\"]", rating1.get("Go")[0], rating2.get("Go")[0]),
+		$T1("rating[\"Python\"]", rating1.get("Python")[0], rating2.get("Python")[0]),
+		$T1("rating[\"C++\"]", rating1.get("C++")[0], rating2.get("C++")[0])
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, g.Typed(t.in_, "float32"), g.Typed(t.out, "float32")])));
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function reference() {
-	var m = g.Map("", {});
-	m.v["Hello"] = "Bonjour";
+	var m = g.MkMap("", []);
+	m.set("Hello", "Bonjour");
 
 	var m1 = m;
-	m1.v["Hello"] = "Salut";
+	m1.set("Hello", "Salut");
 
-	if (JSON.stringify(m.get("Hello")[0]) == JSON.stringify(m1.get("Hello")[0])) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+	if (g.Equal(m.get("Hello")[0], m1.get("Hello")[0], 0)) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: m[\"Hello\"] => got " + m.get("Hello")[0] + ", want " + m1.get("Hello")[0] + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: m[\"Hello\"] => got %v, want %v<br>", [m.get("Hello")[0], m1.get("Hello")[0]])));
 		PASS = false;
 	}
 }
 
+function catalog(rating) {
+	this.rating = rating;
+}
+
+catalog.prototype.has = function(name) {
+	var ok = this.rating.get(name)[1];
+	return ok;
+};
+
 function keyNoExistent() {
 	var pass = true;
 
 	var csharp_rating = rating.get("C#")[0];
-	var _ = rating.get("C#"), csharp_rating2 = _[0], found = _[1];
+	var $r1 = rating.get("C#"), csharp_rating2 = $r1[0], found = $r1[1];
 
-	var multiDim = g.Map(0, {1: {1: 1.1}, 2: {2: 2.2}});
-	var k_multiDim = multiDim.get(1,2)[0];
+	var multiDim = g.MkMap(g.MkMap(0), [[1, g.MkMap(0, [[1, 1.1]])], [2, g.MkMap(0, [[2, 2.2]])]]);
+	var k_multiDim = multiDim.get(1)[0].get(2)[0];
 
-	var _ = function(msg, in_, out) { return {
+	var $T2 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("csharp_rating", csharp_rating, 0.00),
-		_("csharp_rating2", csharp_rating2, 0),
-		_("k_multiDim", k_multiDim, 0)
+	}; }; var tests = [
+		$T2("csharp_rating", csharp_rating, 0.00),
+		$T2("csharp_rating2", csharp_rating2, 0),
+		$T2("k_multiDim", k_multiDim, 0)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i3 = 0, $n4 = tests.len; $i3 < $n4; $i3++) { t = tests.at($i3);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, g.Typed(t.in_, "float32"), g.Typed(t.out, "float32")])));
+			pass = false; PASS = false;
 		}
 	}
 	if (found) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: using comma => got " + found + ", want " + !found + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: using comma => got %v, want %v<br>", [found, !found])));
+		pass = false; PASS = false;
+	}
+
+
+	var c = new catalog(rating);
+	var found = c.rating.get("C#")[1]; if (found || !c.has("Go") || c.has("C#")) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: using comma with a field => got %v, %v, %v<br>", [found, c.has("Go"), c.has("C#")])));
+		pass = false; PASS = false;
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function deleteKey() {
 	var pass = true;
 
-	delete rating.v["C++"];
+	rating.remove("C++");
 	var found = rating.get("C++")[1];
 
 	if (found) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got " + found + ", want " + !found + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: got %v, want %v<br>", [found, !found])));
+		pass = false; PASS = false;
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function _range() {
 	var pass = true;
 
-	var value; for (var key in rating.v) { value = rating.get(key)[0];
+	var key, value; for (var $e1 of rating.entries()) { key = $e1[0]; value = $e1[1];
 		switch (key) {
 		case "C":
 			if (value != 5) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + key + " => got " + value + ", want 5<br>");
-			pass = false, PASS = false;
-		} break;
+				document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want 5<br>", [key, g.Typed(value, "float32")])));
+				pass = false; PASS = false;
+			} break;
 		case "Go":
 			if (value != 4.5) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + key + " => got " + value + ", want 4.5<br>");
-			pass = false, PASS = false;
-		} break;
+				document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want 4.5<br>", [key, g.Typed(value, "float32")])));
+				pass = false; PASS = false;
+			} break;
 		case "Python":
 			if (value != 4.5) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + key + " => got " + value + ", want 4.5<br>");
-			pass = false, PASS = false;
-		} break;
+				document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want 4.5<br>", [key, g.Typed(value, "float32")])));
+				pass = false; PASS = false;
+			} break;
 		default:
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + key + " => no expected<br>");
-			pass = false, PASS = false;
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => no expected<br>", [key])));
+			pass = false; PASS = false;
 		}
 	}
 
 
-	for (var key in rating.v) {
+	var key; for (var $e2 of rating.entries()) { key = $e2[0];
 		if (key != "C" && key != "Go" && key != "Python") {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: key " + key + " no expected<br>");
-			pass = false, PASS = false;
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: key %q no expected<br>", [key])));
+			pass = false; PASS = false;
 		}
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -203,8 +219,8 @@ function blankIdInRange() {
 
 
 	var Max = function(slice) {
-		var max = slice.get()[0];
-		var value; for (var _ in slice.get()) { value = slice.get()[_];
+		var max = slice.at(0);
+		var value; for (var $i1 = 0, $n2 = slice.len; $i1 < $n2; $i1++) { value = slice.at($i1);
 			if (value > max) {
 				max = value;
 			}
@@ -220,48 +236,231 @@ function blankIdInRange() {
 
 	slice = g.SliceFrom(A1, 0);
 	if (Max(slice) != 9) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A1 => got " + Max(slice) + ", want 9<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A1 => got %v, want 9<br>", [Max(slice)])));
+		pass = false; PASS = false;
 	}
 	slice = g.SliceFrom(A2, 0);
 	if (Max(slice) != 4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A2 => got " + Max(slice) + ", want 4<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A2 => got %v, want 4<br>", [Max(slice)])));
+		pass = false; PASS = false;
 	}
 	slice = g.SliceFrom(A3, 0);
 	if (Max(slice) != 1) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A3 => got " + Max(slice) + ", want 1<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A3 => got %v, want 1<br>", [Max(slice)])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function sizedKey(n, s) {
+	this.n = n;
+	this.s = s;
+}
+
+
+function sumKeys(K, V, m) {
+	var sum = K.zero();
+	var k; for (var $e1 of m.entries()) { k = $e1[0];
+		sum = K.conv(sum + k);
+	}
+	return sum;
+}
+
+function keyTypes() {
+	var pass = true;
+
+
+	var ints = g.MkMap("", [[1, "a"], [20, "b"], [300, "c"]]);
+	var sum = 0;
+	var k; for (var $e1 of ints.entries()) { k = $e1[0];
+		sum += k;
+	}
+	if (sum != 321 || sumKeys(g.Type(function() { return 0; }, "int"), g.Type(function() { return ""; }, ""), ints) != 321) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. int keys => got %v, %v<br>", [sum, sumKeys(g.Type(function() { return 0; }, "int"), g.Type(function() { return ""; }, ""), ints)])));
+		pass = false; PASS = false;
+	}
+	var ok = ints.get(1)[1]; if (!ok) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. int key not found"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var bools = g.MkMap(0, [[true, 1]]);
+	bools.set(false, bools.get(false)[0] + 1);
+	var k, v; for (var $e2 of bools.entries()) { k = $e2[0]; v = $e2[1];
+		if (k && v != 1 || !k && v != 1) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. bool keys => got %v:%v<br>", [k, v])));
+			pass = false; PASS = false;
+		}
+	}
+
+
+	var points = g.MkMap("", [[new point(1, 2), "a"]], true);
+	points.set(new point(3, 4), "b");
+	points.set(new point(1, 2), "c");
+
+	if (points.len() != 2 || points.get(new point(1, 2))[0] != "c" || points.get(new point(3, 4))[0] != "b") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. struct keys => got %v<br>", [g.Typed(points, "map[main.point]string")])));
+		pass = false; PASS = false;
+	}
+
+	var arrays = g.MkMap(0, [], true);
+	arrays.set(g.MkArray([2], 0, [1, 2]), 5);
+	arrays.set(g.MkArray([2], 0, [1, 2]), arrays.get(g.MkArray([2], 0, [1, 2]))[0] + (5));
+
+	if (arrays.len() != 1 || arrays.get(g.MkArray([2], 0, [1, 2]))[0] != 10) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. array keys => got %v<br>", [g.Typed(arrays, "map[[2]int]int")])));
+		pass = false; PASS = false;
+	}
+
+
+	var any = g.MkMap(0, [[g.Typed(1, "int"), 1], ["1", 2], [new point(1, 1), 3]], true);
+
+	if (any.len() != 3 || any.get(g.Typed(1, "int"))[0] != 1 || any.get("1")[0] != 2 || any.get(new point(1, 1))[0] != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. interface keys => got %v<br>", [g.Typed(any, "map[interface{}]int")])));
+		pass = false; PASS = false;
+	}
+
+
+
+	var p1 = new point(1, 1), p2 = new point(1, 1);
+	any.set(g.Typed(1.0, "float64"), 4);
+	any.set(g.Typed(g.Int32(1), "int32"), 5);
+	any.set(g.Pointer(p1, "*main.point"), 6);
+	any.set(g.Pointer(p2, "*main.point"), 7);
+
+	if (any.len() != 7 || any.get(g.Typed(1, "int"))[0] != 1 || any.get(g.Typed(1.0, "float64"))[0] != 4 || any.get(new point(1, 1))[0] != 3 || any.get(g.Pointer(p1, "*main.point"))[0] != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. dynamic type of keys => got %v, %v, %v, %v, %v<br>", [any.len(), any.get(g.Typed(1, "int"))[0], any.get(g.Typed(1.0, "float64"))[0], any.get(new point(1, 1))[0], any.get(g.Pointer(p1, "*main.point"))[0]])));
+
+		pass = false; PASS = false;
+	}
+
+
+	var small = g.Int8(0);
+	var int8s = g.MkMap("", [[0, "zero"]]);
+	int8s.set(small + 1, "one");
+
+	if (int8s.len() != 2 || int8s.get(small)[0] != "zero" || int8s.get(1)[0] != "one") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. int8 keys => got %v<br>", [g.Typed(int8s, "map[int8]string")])));
+		pass = false; PASS = false;
+	}
+
+	var sized = g.MkMap(0, [[new sizedKey(2, "x"), 5]], true);
+	sized.set(new sizedKey(small + 2, "x"), sized.get(new sizedKey(small + 2, "x"))[0] + 1);
+
+	if (sized.len() != 1 || sized.get(new sizedKey(2, "x"))[0] != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. struct keys with int8 => got %v<br>", [g.Typed(sized, "map[main.sizedKey]int")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function box(a, n) {
+	this.a = a;
+	this.n = n;
+}
+
+function elements() {
+	var pass = true;
+
+
+	var nested = g.MkMap(g.MkMap(0), [["a", g.MkMap(0, [["x", 1]])]]);
+	nested.get("a")[0].set("y", 2);
+	nested.set("b", g.MkMap(0, []));
+	nested.get("b")[0].set("z", nested.get("b")[0].get("z")[0] + 1);
+
+	if (nested.get("a")[0].len() != 2 || nested.get("a")[0].get("y")[0] != 2 || nested.get("b")[0].get("z")[0] != 1 || !nested.get("c")[0].isNil() || nested.get("c")[0].get("w")[0] != 0) {
+
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. nested => got %v<br>", [g.Typed(nested, "map[string]map[string]int")])));
+		pass = false; PASS = false;
+	}
+
+
+	var lists = g.MkMap(g.MkSlice(), [["odd", g.Slice(0, [1, 3])]]);
+	lists.set("odd", g.Append(lists.get("odd")[0], [5]));
+	lists.get("odd")[0].set([0], 7);
+
+	if (g.Sprint([g.Typed(lists, "map[string][]int")], false) != "map[odd:[7 3 5]]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. slices => got %v<br>", [g.Typed(lists, "map[string][]int")])));
+		pass = false; PASS = false;
+	}
+
+
+	var m = g.MkMap(false, [[1, true], [2, true], [3, true], [4, true]]);
+	var n = 0;
+	var k; for (var $e1 of m.entries()) { k = $e1[0];
+		m.remove(k);
+		n++;
+	}
+	if (n != 4 || m.len() != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. delete => got %v, n=%d<br>", [g.Typed(m, "map[int]bool"), n])));
+		pass = false; PASS = false;
+	}
+
+
+	var sorted = g.MkMap("", [[10, "x"], [2, "y"], [-1, "z"]]);
+	var s = g.Sprint([g.Typed(sorted, "map[int]string")], false); if (s != "map[-1:z 2:y 10:x]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. sorted => got %s<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+
+	var boxes = g.MkMap(new box(g.MkArray([2], 0), 0), []);
+	var b = g.Clone(boxes.get("a")[0], {a: [0], n: 0});
+	b.a.v[0] = 3;
+
+	if (boxes.get("a")[0].a.v[0] != 0 || b.a.v[0] != 3 || boxes.len() != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 5. struct zero => got %v, %v<br>", [g.Typed(boxes.get("a")[0], "main.box"), g.Typed(b, "main.box")])));
+		pass = false; PASS = false;
+	}
+
+	var pairs = g.MkMap(g.MkArray([2], 0), []);
+	if (pairs.get("x")[0].v[1] != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 5. array zero => got %v<br>", [g.Typed(pairs.get("x")[0], "[2]int")])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function main() {
-	document.write("<br><br>== Maps<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Maps<br><br>"], false)));
 
-	document.write("=== RUN builtIn<br>");
+	document.write(g.Text(g.Sprint(["=== RUN builtIn"], true) + "<br>"));
 	builtIn();
-	document.write("=== RUN declaration<br>");
+	document.write(g.Text(g.Sprint(["=== RUN declaration"], true) + "<br>"));
 	declaration();
-	document.write("=== RUN reference<br>");
+	document.write(g.Text(g.Sprint(["=== RUN reference"], true) + "<br>"));
 	reference();
-	document.write("=== RUN keyNoExistent<br>");
+	document.write(g.Text(g.Sprint(["=== RUN keyNoExistent"], true) + "<br>"));
 	keyNoExistent();
-	document.write("=== RUN deleteKey<br>");
+	document.write(g.Text(g.Sprint(["=== RUN deleteKey"], true) + "<br>"));
 	deleteKey();
-	document.write("=== RUN range<br>");
+	document.write(g.Text(g.Sprint(["=== RUN range"], true) + "<br>"));
 	_range();
-	document.write("=== RUN blankIdInRange<br>");
+	document.write(g.Text(g.Sprint(["=== RUN blankIdInRange"], true) + "<br>"));
 	blankIdInRange();
+	document.write(g.Text(g.Sprint(["=== RUN keyTypes"], true) + "<br>"));
+	keyTypes();
+	document.write(g.Text(g.Sprint(["=== RUN elements"], true) + "<br>"));
+	elements();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Maps");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Maps"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
@@ -14,7 +14,7 @@
 var PASS = true;
 
 function Rectangle(width, height) {
-	this.width=width; this.height=height
+	this.width = width; this.height = height;
 }
 
 function noMethod() {
@@ -27,17 +27,17 @@ function noMethod() {
 	var r1 = new Rectangle(12, 2);
 
 	if (area(r1) != 24) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: area r1 => got " + area(r1) + ", want 24)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: area r1 => got %v, want 24)<br>", [g.Typed(area(r1), "float64")])));
+		pass = false; PASS = false;
 	}
 	if (area(new Rectangle(9, 4)) != 36) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: area Rectangle{9,4} => got " + area(new Rectangle(9, 4)) + ", want 36)<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: area Rectangle{9,4} => got %v, want 36)<br>", [g.Typed(area(new Rectangle(9, 4)), "float64")])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -45,15 +45,15 @@ function noMethod() {
 
 Rectangle.prototype.area = function() {
 	return this.width * this.height;
-}
+};
 
 function Circle(radius) {
-	this.radius=radius
+	this.radius = radius;
 }
 
 Circle.prototype.area = function() {
 	return this.radius * this.radius * Math.PI;
-}
+};
 
 function method() {
 	var pass = true;
@@ -63,199 +63,199 @@ function method() {
 	var c1 = new Circle(10);
 	var c2 = new Circle(25);
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("Rectangle{12,2}", r1.area(), 24),
-		_("Rectangle{9,4}", r2.area(), 36),
-		_("Circle{10}", c1.area(), 314.1592653589793),
-		_("Circle{25}", c2.area(), 1963.4954084936207)
+	}; }; var tests = [
+		$T1("Rectangle{12,2}", r1.area(), 24),
+		$T1("Rectangle{9,4}", r2.area(), 36),
+		$T1("Circle{10}", c1.area(), 314.1592653589793),
+		$T1("Circle{25}", c2.area(), 1963.4954084936207)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, g.Typed(t.in_, "float64"), g.Typed(t.out, "float64")])));
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 
 
-function sliceOfints(){} sliceOfints.alias(g.SliceType);
-function agesByNames(){} agesByNames.alias(g.MapType);
+function sliceOfints(t) { this.t = t; } g.Named(sliceOfints);
+function agesByNames(t) { this.t = t; } g.Named(agesByNames);
 
-sliceOfints.prototype.sum = function() {
+sliceOfints.prototype.sum = function() { var s = this.t;
 	var sum = 0;
-	var value; for (var _ in this.t) { value = this.t[_];
+	var value; for (var $i1 = 0, $n2 = s.len; $i1 < $n2; $i1++) { value = s.at($i1);
 		sum += value;
 	}
 	return sum;
-}
+};
 
-agesByNames.prototype.older = function() {
+agesByNames.prototype.older = function() { var people = this.t;
 	var a = 0;
 	var n = "";
-	var value; for (var key in this.t) { value = this.t[key];
+	var key, value; for (var $e1 of people.entries()) { key = $e1[0]; value = $e1[1];
 		if (value > a) {
 			a = value;
 			n = key;
 		}
 	}
 	return n;
-}
+};
 
 function withNamedType() {
 	var pass = true;
 
-	var s = new sliceOfints(1, 2, 3, 4, 5);
-	var folks = new agesByNames();
-	folks["Bob"] = 36,
-	folks["Mike"] = 44,
-	folks["Jane"] = 30,
-	folks["Popey"] = 100;
-
-
-	if (s.sum() != 15) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: s.sum => got " + s.sum() + ", want 15)<br>");
-		pass = false, PASS = false;
+	var s = g.Slice(0, [1, 2, 3, 4, 5]);
+	var folks = g.MkMap(0, [
+		["Bob", 36],
+		["Mike", 44],
+		["Jane", 30],
+		["Popey", 100]
+	]);
+
+	if (new sliceOfints(s).sum() != 15) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: s.sum => got %v, want 15)<br>", [new sliceOfints(s).sum()])));
+		pass = false; PASS = false;
 	}
-	if (folks.older() != "Popey") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: folks.older => got " + folks.older() + ", want Popey)<br>");
+	if (new agesByNames(folks).older() != "Popey") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: folks.older => got %s, want Popey)<br>", [new agesByNames(folks).older()])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 
 
-const 
-WHITE = 0,
-BLACK = 1,
-BLUE = 2,
-RED = 3,
-YELLOW = 4;
 
+const WHITE = 0;
+const BLACK = 1;
+const BLUE = 2;
+const RED = 3;
+const YELLOW = 4;
 
-function Color(t) { this.t=t; }
+
+function Color(t) { this.t = t; } g.Named(Color, "byte");
 
 function Box(width, height, depth, color) {
-	this.width=width; this.height=height; this.depth=depth;
-	this.color=color
+	this.width = width; this.height = height; this.depth = depth;
+	this.color = color;
 }
 
-function BoxList(){} BoxList.alias(g.SliceType);
+function BoxList(t) { this.t = t; } g.Named(BoxList);
 
 Box.prototype.Volume = function() {
 	return this.width * this.height * this.depth;
-}
+};
 
 Box.prototype.SetColor = function(c) {
 	this.color = c;
-}
+};
 
-BoxList.prototype.BiggestsColor = function() {
+BoxList.prototype.BiggestsColor = function() { var bl = this.t;
 	var v = 0.00;
-	var k = Color(WHITE);
-	var b; for (var _ in this.t) { b = this.t[_];
+	var k = WHITE;
+	var b; for (var $i1 = 0, $n2 = bl.len; $i1 < $n2; $i1++) { b = bl.at($i1);
 		if (b.Volume() > v) {
 			v = b.Volume();
 			k = b.color;
 		}
 	}
 	return k;
-}
+};
 
-BoxList.prototype.PaintItBlack = function() {
-	var _; for (var i in this.t) { _ = this.t[i];
-		this.t[i].SetColor(BLACK);
+BoxList.prototype.PaintItBlack = function() { var bl = this.t;
+	for (var i = 0, $n1 = bl.len; i < $n1; i++) {
+		bl.at(i).SetColor(BLACK);
 	}
-}
+};
 
-Color.prototype.String = function() {
+Color.prototype.String = function() { var c = this.t;
 	var strings = g.Slice("", ["WHITE", "BLACK", "BLUE", "RED", "YELLOW"]);
-	return strings.get()[this.t];
-}
+	return strings.at(c);
+};
 
 function complexNamedType() {
 	var pass = true;
 
-	var boxes = new BoxList(
+	var boxes = g.Slice(new Box(0, 0, 0, 0), [
 		new Box(4, 4, 4, RED),
 		new Box(10, 10, 1, YELLOW),
 		new Box(1, 1, 20, BLACK),
 		new Box(10, 10, 1, BLUE),
 		new Box(20, 20, 20, YELLOW),
 		new Box(10, 30, 1, WHITE)
-	);
+	]);
 
-	if (boxes.length != 6) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: len boxes => got " + boxes.length + ", want 6<br>");
-		pass = false, PASS = false;
+	if (boxes.len != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: len boxes => got %d, want 6<br>", [boxes.len])));
+		pass = false; PASS = false;
 	}
-	if (boxes[0].Volume() != 64) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the volume of the first one => got " + boxes[0].Volume() + ", want 64<br>");
+	if (boxes.at(0).Volume() != 64) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the volume of the first one => got %d, want 64<br>", [g.Typed(boxes.at(0).Volume(), "float64")])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
-	if (boxes[boxes.length - 1].color.String() != "WHITE") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the color of the last one => got " + boxes[boxes.length - 1].color.String() + ", want WHITE<br>");
+	if (new Color(boxes.at(boxes.len - 1).color).String() != "WHITE") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the color of the last one => got %s, want WHITE<br>", [new Color(boxes.at(boxes.len - 1).color).String()])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
-	if (boxes.BiggestsColor().String() != "YELLOW") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the biggest one => got " + boxes.BiggestsColor().String() + ", want YELLOW<br>");
+	if (new Color(new BoxList(boxes).BiggestsColor()).String() != "YELLOW") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the biggest one => got %s, want YELLOW<br>", [new Color(new BoxList(boxes).BiggestsColor()).String()])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 
-	boxes.PaintItBlack();
+	new BoxList(boxes).PaintItBlack();
 
-	if (boxes[1].color.String() != "BLACK") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the color of the second one => got " + boxes[1].color.String() + ", want BLACK<br>");
+	if (new Color(boxes.at(1).color).String() != "BLACK") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: the color of the second one => got %s, want BLACK<br>", [new Color(boxes.at(1).color).String()])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
-	if (boxes.BiggestsColor().String() != "BLACK") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: finally, the biggest one => got " + boxes.BiggestsColor().String() + ", want BLACK<br>");
+	if (new Color(new BoxList(boxes).BiggestsColor()).String() != "BLACK") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: finally, the biggest one => got %s, want BLACK<br>", [new Color(new BoxList(boxes).BiggestsColor()).String()])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 
 
 function main() {
-	document.write("<br><br>== Methods<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Methods<br><br>"], false)));
 
-	document.write("=== RUN noMethod<br>");
+	document.write(g.Text(g.Sprint(["=== RUN noMethod"], true) + "<br>"));
 	noMethod();
-	document.write("=== RUN method<br>");
+	document.write(g.Text(g.Sprint(["=== RUN method"], true) + "<br>"));
 	method();
-	document.write("=== RUN withNamedType<br>");
+	document.write(g.Text(g.Sprint(["=== RUN withNamedType"], true) + "<br>"));
 	withNamedType();
-	document.write("=== RUN complexNamedType<br>");
+	document.write(g.Text(g.Sprint(["=== RUN complexNamedType"], true) + "<br>"));
 	complexNamedType();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Methods");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Methods"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
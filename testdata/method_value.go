@@ -0,0 +1,131 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type point struct {
+	x, y int
+}
+
+func (p point) sum() int { return p.x + p.y }
+
+func (p point) add(n int) int { return p.x + p.y + n }
+
+func (p *point) scale(n int) {
+	p.x *= n
+	p.y *= n
+}
+
+type summer interface {
+	sum() int
+}
+
+type labeled struct {
+	point
+	label string
+}
+
+func call(f func() int) int { return f() }
+
+func methodValue() {
+	pass := true
+
+	p := point{1, 2}
+	sum := p.sum
+	add := p.add
+
+	if sum() != 3 || add(10) != 13 {
+		fmt.Printf("\tFAIL: bound => got %v, %v\n", sum(), add(10))
+		pass, PASS = false, false
+	}
+
+	// The receiver of value is copied at evaluating the method value.
+	p.x = 100
+	if sum() != 3 {
+		fmt.Printf("\tFAIL: copy of receiver => got %v, want 3\n", sum())
+		pass, PASS = false, false
+	}
+
+	// The pointer receiver is shared.
+	scale := p.scale
+	scale(2)
+	if p.x != 200 || p.y != 4 {
+		fmt.Printf("\tFAIL: pointer receiver => got %v, %v\n", p.x, p.y)
+		pass, PASS = false, false
+	}
+
+	if call(p.sum) != 204 {
+		fmt.Printf("\tFAIL: like argument => got %v, want 204\n", call(p.sum))
+		pass, PASS = false, false
+	}
+
+	l := labeled{point{3, 4}, "a"}
+	if call(l.sum) != 7 {
+		fmt.Printf("\tFAIL: promoted => got %v, want 7\n", call(l.sum))
+		pass, PASS = false, false
+	}
+
+	var s summer = point{5, 5}
+	if call(s.sum) != 10 {
+		fmt.Printf("\tFAIL: interface => got %v, want 10\n", call(s.sum))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func methodExpr() {
+	pass := true
+
+	p := point{1, 2}
+	sum := point.sum
+	add := point.add
+	scale := (*point).scale
+
+	if sum(p) != 3 || add(p, 1) != 4 || point.sum(p) != 3 {
+		fmt.Printf("\tFAIL: value receiver => got %v, %v\n", sum(p), add(p, 1))
+		pass, PASS = false, false
+	}
+
+	pp := &point{1, 2}
+	scale(pp, 3)
+	if pp.x != 3 || pp.y != 6 {
+		fmt.Printf("\tFAIL: pointer receiver => got %v, %v\n", pp.x, pp.y)
+		pass, PASS = false, false
+	}
+
+	isum := summer.sum
+	if isum(p) != 3 {
+		fmt.Printf("\tFAIL: interface => got %v, want 3\n", isum(p))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Method values\n\n")
+
+	fmt.Println("=== RUN methodValue")
+	methodValue()
+	fmt.Println("=== RUN methodExpr")
+	methodExpr()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Method values")
+	}
+}
@@ -0,0 +1,132 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+point.prototype.sum = function() { return this.x + this.y; };
+
+point.prototype.add = function(n) { return this.x + this.y + n; };
+
+point.prototype.scale = function(n) {
+	this.x *= n;
+	this.y *= n;
+};
+
+
+
+
+
+function labeled(point, label) {
+	this.point = point;
+	this.label = label;
+} labeled.prototype.add = function() { return this.point.add.apply(this.point, arguments); }; labeled.prototype.scale = function() { return this.point.scale.apply(this.point, arguments); }; labeled.prototype.sum = function() { return this.point.sum.apply(this.point, arguments); };
+
+function call(f) { return f(); }
+
+function methodValue() {
+	var pass = true;
+
+	var p = new point(1, 2);
+	var sum = g.MethodVal(p, "sum", true);
+	var add = g.MethodVal(p, "add", true);
+
+	if (sum() != 3 || add(10) != 13) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: bound => got %v, %v<br>", [sum(), add(10)])));
+		pass = false; PASS = false;
+	}
+
+
+	p.x = 100;
+	if (sum() != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: copy of receiver => got %v, want 3<br>", [sum()])));
+		pass = false; PASS = false;
+	}
+
+
+	var scale = g.MethodVal(p, "scale", false);
+	scale(2);
+	if (p.x != 200 || p.y != 4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer receiver => got %v, %v<br>", [p.x, p.y])));
+		pass = false; PASS = false;
+	}
+
+	if (call(g.MethodVal(p, "sum", true)) != 204) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: like argument => got %v, want 204<br>", [call(g.MethodVal(p, "sum", true))])));
+		pass = false; PASS = false;
+	}
+
+	var l = new labeled(new point(3, 4), "a");
+	if (call(g.MethodVal(l.point, "sum", true)) != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: promoted => got %v, want 7<br>", [call(g.MethodVal(l.point, "sum", true))])));
+		pass = false; PASS = false;
+	}
+
+	var s = new point(5, 5);
+	if (call(g.MethodVal(s, "sum", false)) != 10) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface => got %v, want 10<br>", [call(g.MethodVal(s, "sum", false))])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function methodExpr() {
+	var pass = true;
+
+	var p = new point(1, 2);
+	var sum = g.MethodExpr(point, "sum", true);
+	var add = g.MethodExpr(point, "add", true);
+	var scale = g.MethodExpr(point, "scale", false);
+
+	if (sum(p) != 3 || add(p, 1) != 4 || g.MethodExpr(point, "sum", true)(p) != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: value receiver => got %v, %v<br>", [sum(p), add(p, 1)])));
+		pass = false; PASS = false;
+	}
+
+	var pp = new point(1, 2);
+	scale(pp, 3);
+	if (pp.x != 3 || pp.y != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer receiver => got %v, %v<br>", [pp.x, pp.y])));
+		pass = false; PASS = false;
+	}
+
+	var isum = g.MethodExpr(undefined, "sum", false);
+	if (isum(p) != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface => got %v, want 3<br>", [isum(p)])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Method values<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN methodValue"], true) + "<br>"));
+	methodValue();
+	document.write(g.Text(g.Sprint(["=== RUN methodExpr"], true) + "<br>"));
+	methodExpr();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Method values"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -17,14 +17,14 @@ function argArray(arr) {
 
 	if (arr.len() == 3 && arr.cap() == 3 && arr.v[0] == 1 && arr.v[1] == 2 && arr.v[2] == 3) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argArray<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argArray<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
-	return arr;
+	return g.Clone(arr, [0]);
 }
 
 function argEllipsis(arr) {
@@ -32,27 +32,27 @@ function argEllipsis(arr) {
 
 	if (arr.len() == 2 && arr.cap() == 2 && arr.v[0] == 5 && arr.v[1] == 6) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argEllipsis<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argEllipsis<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
-	return arr;
+	return g.Clone(arr, [0]);
 }
 
 function argSlice(s) {
 	var pass = true;
 
-	if (s.len == 2 && s.cap == 2 && s.str() == "89" && s.get()[0] == '8' && s.get()[1] == '9') {
+	if (s.len == 2 && s.cap == 2 && s.str() == "89" && s.at(0) == 56 && s.at(1) == 57) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argSlice<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argSlice<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 	return s;
 }
@@ -62,44 +62,44 @@ function argMap(m) {
 
 	if (m.len() == 2 && m.get(1)[0] == "foo" && m.get(2)[0] == "bar") {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argSlice<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: argSlice<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 	return m;
 }
 
 function main() {
-	document.write("<br><br>== Miscellaneous<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Miscellaneous<br><br>"], false)));
 
-	document.write("=== RUN argArray<br>");
+	document.write(g.Text(g.Sprint(["=== RUN argArray"], true) + "<br>"));
 	var a = g.MkArray([3], 0, [1, 2, 3]);
 	a = argArray(a);
 	argArray(g.MkArray([3], 0, [1, 2, 3]));
 
-	document.write("=== RUN argEllipsis<br>");
+	document.write(g.Text(g.Sprint(["=== RUN argEllipsis"], true) + "<br>"));
 	var ell = g.MkArray([2], 0, [5, 6]);
 	ell = argEllipsis(ell);
 	argEllipsis(g.MkArray([2], 0, [5, 6]));
 
-	document.write("=== RUN argSlice<br>");
-	var s = g.Slice(0, ['8', '9']);
+	document.write(g.Text(g.Sprint(["=== RUN argSlice"], true) + "<br>"));
+	var s = g.Slice(0, [56, 57]);
 	s = argSlice(s);
-	argSlice(g.Slice(0, ['8', '9']));
+	argSlice(g.Slice(0, [56, 57]));
 
-	document.write("=== RUN argMap<br>");
-	var m = g.Map("", {1: "foo", 2: "bar"});
+	document.write(g.Text(g.Sprint(["=== RUN argMap"], true) + "<br>"));
+	var m = g.MkMap("", [[1, "foo"], [2, "bar"]]);
 	m = argMap(m);
-	argMap(g.Map("", {1: "foo", 2: "bar"}));
+	argMap(g.MkMap("", [[1, "foo"], [2, "bar"]]));
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Miscellaneous");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Miscellaneous"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
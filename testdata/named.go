@@ -0,0 +1,186 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type Celsius float64
+
+func (c Celsius) String() string { return fmt.Sprintf("%.1f°C", float64(c)) }
+
+func (c *Celsius) Add(d Celsius) { *c += d }
+
+func basic() {
+	pass := true
+
+	var c Celsius = 20
+	c.Add(1.5)
+	d := c * 2
+	if c != 21.5 || d != 43 || float64(d) != 43 {
+		fmt.Printf("\tFAIL: arithmetic => got %v %v\n", float64(c), float64(d))
+		pass, PASS = false, false
+	}
+	if s := fmt.Sprint(c, " ", d); s != "21.5°C 43.0°C" {
+		fmt.Printf("\tFAIL: Stringer => got %q\n", s)
+		pass, PASS = false, false
+	}
+	if s := fmt.Sprintf("%.2f %T", c, c); s != "21.50 main.Celsius" {
+		fmt.Printf("\tFAIL: verbs => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	var s fmt.Stringer = c
+	if s.String() != "21.5°C" || fmt.Sprint(s) != "21.5°C" {
+		fmt.Printf("\tFAIL: interface => got %q\n", s.String())
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type IDs []int
+
+func (s IDs) Sum() int {
+	n := 0
+	for _, v := range s {
+		n += v
+	}
+	return n
+}
+
+func (s *IDs) Push(v int) { *s = append(*s, v) }
+
+type Index map[string]int
+
+func (m Index) Get(k string) int { return m[k] }
+
+func sliceAndMap() {
+	pass := true
+
+	ids := IDs{1, 2}
+	ids.Push(3)
+	if ids.Sum() != 6 || len(ids) != 3 || ids[2] != 3 {
+		fmt.Printf("\tFAIL: slice => got %v %v %v\n", ids.Sum(), len(ids), ids)
+		pass, PASS = false, false
+	}
+	if s := fmt.Sprint(ids); s != "[1 2 3]" {
+		fmt.Printf("\tFAIL: slice format => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	ids[0] = 9
+	if part := ids[1:]; cap(ids) < 3 || len(part) != 2 || part[0] != 2 || ids[0] != 9 {
+		fmt.Printf("\tFAIL: slice addressed => got %v %v %v\n", cap(ids), part, ids)
+		pass, PASS = false, false
+	}
+	p := &ids
+	if len(*p) != 3 || (*p)[1] != 2 {
+		fmt.Printf("\tFAIL: pointer to slice => got %v\n", *p)
+		pass, PASS = false, false
+	}
+
+	// The zero value is a nil slice.
+	var zero IDs
+	if zero != nil || len(zero) != 0 || cap(zero) != 0 || IDs(nil) != nil {
+		fmt.Printf("\tFAIL: nil slice => got %v %v\n", zero, len(zero))
+		pass, PASS = false, false
+	}
+	zero = append(zero, 4)
+	if zero == nil || zero.Sum() != 4 || zero[0] != 4 {
+		fmt.Printf("\tFAIL: append to nil slice => got %v\n", zero)
+		pass, PASS = false, false
+	}
+
+	var none Index
+	if none != nil || len(none) != 0 || none["a"] != 0 {
+		fmt.Printf("\tFAIL: nil map => got %v\n", len(none))
+		pass, PASS = false, false
+	}
+
+	m := Index{"a": 1}
+	m["b"] = 2
+	if m.Get("b") != 2 || m.Get("z") != 0 || len(m) != 2 {
+		fmt.Printf("\tFAIL: map => got %v %v %v\n", m.Get("b"), m.Get("z"), len(m))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type Op func(int) int
+
+func (f Op) Twice(v int) int { return f(f(v)) }
+
+func function() {
+	pass := true
+
+	var inc Op = func(v int) int { return v + 1 }
+	if inc.Twice(1) != 3 || inc(5) != 6 {
+		fmt.Printf("\tFAIL: function => got %v %v\n", inc.Twice(1), inc(5))
+		pass, PASS = false, false
+	}
+	double := Op(func(v int) int { return v * 2 })
+	if double.Twice(3) != 12 {
+		fmt.Printf("\tFAIL: conversion => got %v\n", double.Twice(3))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func stringers() []fmt.Stringer {
+	return []fmt.Stringer{Celsius(1), Celsius(2.5)}
+}
+
+func pointer() {
+	pass := true
+
+	c := Celsius(20)
+	p := &c
+	p.Add(2.5)
+	if *p != 22.5 || c != 22.5 || p.String() != "22.5°C" {
+		fmt.Printf("\tFAIL: pointer => got %v %v\n", float64(*p), p.String())
+		pass, PASS = false, false
+	}
+
+	if s := fmt.Sprint(stringers()[1]); s != "2.5°C" {
+		fmt.Printf("\tFAIL: returned => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Named types\n\n")
+
+	fmt.Println("=== RUN basic")
+	basic()
+	fmt.Println("=== RUN sliceAndMap")
+	sliceAndMap()
+	fmt.Println("=== RUN function")
+	function()
+	fmt.Println("=== RUN pointer")
+	pointer()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Named types")
+	}
+}
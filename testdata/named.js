@@ -0,0 +1,187 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function Celsius(t) { this.t = t; } g.Named(Celsius, "float64");
+
+Celsius.prototype.String = function() { var c = this.t; return g.Sprintf("%.1f\xc2\xb0C", [g.Typed(g.Float64(c), "float64")]); };
+
+Celsius.prototype.Add = function(d) { var c = this; c.p += d; };
+
+function basic() {
+	var pass = true;
+
+	var c = {p:20};
+	Celsius.prototype.Add.call(c, 1.5);
+	var d = c.p * 2;
+	if (c.p != 21.5 || d != 43 || g.Float64(d) != 43) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: arithmetic => got %v %v<br>", [g.Typed(g.Float64(c.p), "float64"), g.Typed(g.Float64(d), "float64")])));
+		pass = false; PASS = false;
+	}
+	var s = g.Sprint([new Celsius(c.p), " ", new Celsius(d)], false); if (s != "21.5\xc2\xb0C 43.0\xc2\xb0C") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Stringer => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+	var s = g.Sprintf("%.2f %T", [new Celsius(c.p), new Celsius(c.p)]); if (s != "21.50 main.Celsius") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: verbs => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	var s = new Celsius(c.p);
+	if (s.String() != "21.5\xc2\xb0C" || g.Sprint([s], false) != "21.5\xc2\xb0C") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface => got %q<br>", [s.String()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function IDs(t) { this.t = t; } g.Named(IDs);
+
+IDs.prototype.Sum = function() { var s = this.t;
+	var n = 0;
+	var v; for (var $i1 = 0, $n2 = s.len; $i1 < $n2; $i1++) { v = s.at($i1);
+		n += v;
+	}
+	return n;
+};
+
+IDs.prototype.Push = function(v) { var s = this; s.p = g.Append(s.p, [v]); };
+
+function Index(t) { this.t = t; } g.Named(Index);
+
+Index.prototype.Get = function(k) { var m = this.t; return m.get(k)[0]; };
+
+function sliceAndMap() {
+	var pass = true;
+
+	var ids = {p:g.Slice(0, [1, 2])};
+	IDs.prototype.Push.call(ids, 3);
+	if (new IDs(ids.p).Sum() != 6 || ids.p.len != 3 || ids.p.at(2) != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice => got %v %v %v<br>", [new IDs(ids.p).Sum(), ids.p.len, new IDs(ids.p)])));
+		pass = false; PASS = false;
+	}
+	var s = g.Sprint([new IDs(ids.p)], false); if (s != "[1 2 3]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice format => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	ids.p.set([0], 9);
+	var part = g.SliceFrom(ids.p, 1); if (ids.p.cap < 3 || part.len != 2 || part.at(0) != 2 || ids.p.at(0) != 9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice addressed => got %v %v %v<br>", [ids.p.cap, new IDs(part), new IDs(ids.p)])));
+		pass = false; PASS = false;
+	}
+	var p = ids;
+	if (p.p.len != 3 || (p.p).at(1) != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer to slice => got %v<br>", [new IDs(p.p)])));
+		pass = false; PASS = false;
+	}
+
+
+	var zero = g.MkSlice();
+	if (!zero.isNil() || zero.len != 0 || zero.cap != 0 || !g.MkSlice().isNil()) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: nil slice => got %v %v<br>", [new IDs(zero), zero.len])));
+		pass = false; PASS = false;
+	}
+	zero = g.Append(zero, [4]);
+	if (zero.isNil() || new IDs(zero).Sum() != 4 || zero.at(0) != 4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: append to nil slice => got %v<br>", [new IDs(zero)])));
+		pass = false; PASS = false;
+	}
+
+	var none = g.MkMap(0);
+	if (!none.isNil() || none.len() != 0 || none.get("a")[0] != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: nil map => got %v<br>", [none.len()])));
+		pass = false; PASS = false;
+	}
+
+	var m = g.MkMap(0, [["a", 1]]);
+	m.set("b", 2);
+	if (new Index(m).Get("b") != 2 || new Index(m).Get("z") != 0 || m.len() != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: map => got %v %v %v<br>", [new Index(m).Get("b"), new Index(m).Get("z"), m.len()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function Op(t) { this.t = t; } g.Named(Op);
+
+Op.prototype.Twice = function(v) { var f = this.t; return f(f(v)); };
+
+function function_() {
+	var pass = true;
+
+	var inc = function(v) { return v + 1; };
+	if (new Op(inc).Twice(1) != 3 || inc(5) != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: function => got %v %v<br>", [new Op(inc).Twice(1), inc(5)])));
+		pass = false; PASS = false;
+	}
+	var double = function(v) { return v * 2; };
+	if (new Op(double).Twice(3) != 12) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: conversion => got %v<br>", [new Op(double).Twice(3)])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function stringers() {
+	return g.Slice(undefined, [new Celsius(1), new Celsius(2.5)]);
+}
+
+function pointer() {
+	var pass = true;
+
+	var c = {p:20};
+	var p = c;
+	Celsius.prototype.Add.call(p, 2.5);
+	if (p.p != 22.5 || c.p != 22.5 || new Celsius(p.p).String() != "22.5\xc2\xb0C") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer => got %v %v<br>", [g.Typed(g.Float64(p.p), "float64"), new Celsius(p.p).String()])));
+		pass = false; PASS = false;
+	}
+
+	var s = g.Sprint([stringers().at(1)], false); if (s != "2.5\xc2\xb0C") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: returned => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Named types<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN basic"], true) + "<br>"));
+	basic();
+	document.write(g.Text(g.Sprint(["=== RUN sliceAndMap"], true) + "<br>"));
+	sliceAndMap();
+	document.write(g.Text(g.Sprint(["=== RUN function"], true) + "<br>"));
+	function_();
+	document.write(g.Text(g.Sprint(["=== RUN pointer"], true) + "<br>"));
+	pointer();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Named types"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,145 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type Object struct {
+	Array  bool
+	Math   int
+	String string
+}
+
+func (o Object) JSON() string { return fmt.Sprint(o.Math) }
+
+func Map(s []int) int { return len(s) }
+
+func (o *Object) Get() int { return o.Math }
+
+func globals() {
+	pass := true
+
+	undefined := 1
+	NaN := 2
+	arguments := 3
+	window, document := 4, 5
+	if undefined+NaN+arguments+window+document != 15 {
+		fmt.Printf("\tFAIL: variables => got %v, want 15\n",
+			undefined+NaN+arguments+window+document)
+		pass, PASS = false, false
+	}
+
+	o := Object{Array: true, Math: 7}
+	if Map([]int{1, 2}) != 2 || o.JSON() != "7" {
+		fmt.Printf("\tFAIL: type and function => got %v %q, want 2 \"7\"\n",
+			Map([]int{1, 2}), o.JSON())
+		pass, PASS = false, false
+	}
+	if s := fmt.Sprintf("%+v", o); s != "{Array:true Math:7 String:}" {
+		fmt.Printf("\tFAIL: fields => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	g := &Object{Math: 8}
+	Math := Object{Math: 9}
+	if g.Get() != 8 || Math.Math != 9 {
+		fmt.Printf("\tFAIL: selector of variables => got %v, %v, want 8, 9\n", g.Get(), Math.Math)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func suffix() {
+	pass := true
+
+	class, class_ := 1, 2
+	g, g_ := 3, 4
+	Array, Array_ := 5, 6
+	if class != 1 || class_ != 2 || g != 3 || g_ != 4 || Array != 5 || Array_ != 6 {
+		fmt.Printf("\tFAIL: escaped names => got %v %v %v %v %v %v\n",
+			class, class_, g, g_, Array, Array_)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func unicode() {
+	pass := true
+
+	café, π := "crème", 3
+	type señal struct{ año int }
+	s := señal{año: π}
+	if café != "crème" || s.año != 3 {
+		fmt.Printf("\tFAIL: unicode names => got %q %v\n", café, s.año)
+		pass, PASS = false, false
+	}
+	if v := fmt.Sprintf("%+v", s); v != "{año:3}" {
+		fmt.Printf("\tFAIL: unicode field => got %q, want %q\n", v, "{año:3}")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func pair(a, b int) (int, int) { return b, a }
+
+func temporaries() {
+	pass := true
+
+	a, b := pair(1, 2)
+	tests := []struct{ x, y int }{{a, b}}
+	c, d := pair(3, 4)
+	more := []struct{ x, y int }{{c, d}}
+
+	a, b = pair(b, a)
+	if a != 2 || b != 1 || tests[0].x != 2 || more[0].y != 3 {
+		fmt.Printf("\tFAIL: results => got %v %v %v %v\n", a, b, tests, more)
+		pass, PASS = false, false
+	}
+
+	m := map[string]int{"k": 5}
+	v, ok := m["k"]
+	v, ok = m["z"]
+	if v != 0 || ok {
+		fmt.Printf("\tFAIL: map index => got %v %v\n", v, ok)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Naming\n\n")
+
+	fmt.Println("=== RUN globals")
+	globals()
+	fmt.Println("=== RUN suffix")
+	suffix()
+	fmt.Println("=== RUN unicode")
+	unicode()
+	fmt.Println("=== RUN temporaries")
+	temporaries()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Naming")
+	}
+}
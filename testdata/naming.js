@@ -0,0 +1,146 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function Object_(Array, Math, String) {
+	this.Array = Array;
+	this.Math = Math;
+	this.String = String;
+}
+
+Object_.prototype.JSON = function() { return g.Sprint([this.Math], false); };
+
+function Map_(s) { return s.len; }
+
+Object_.prototype.Get = function() { return this.Math; };
+
+function globals() {
+	var pass = true;
+
+	var undefined_ = 1;
+	var NaN_ = 2;
+	var arguments_ = 3;
+	var window_ = 4, document_ = 5;
+	if (undefined_ + NaN_ + arguments_ + window_ + document_ != 15) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: variables => got %v, want 15<br>", [undefined_ + NaN_ + arguments_ + window_ + document_])));
+
+		pass = false; PASS = false;
+	}
+
+	var o = new Object_(true, 7, "");
+	if (Map_(g.Slice(0, [1, 2])) != 2 || o.JSON() != "7") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: type and function => got %v %q, want 2 \"7\"<br>", [Map_(g.Slice(0, [1, 2])), o.JSON()])));
+
+		pass = false; PASS = false;
+	}
+	var s = g.Sprintf("%+v", [g.Typed(o, "main.Object")]); if (s != "{Array:true Math:7 String:}") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: fields => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	var g_ = new Object_(false, 8, "");
+	var Math_ = new Object_(false, 9, "");
+	if (g_.Get() != 8 || Math_.Math != 9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: selector of variables => got %v, %v, want 8, 9<br>", [g_.Get(), Math_.Math])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function suffix() {
+	var pass = true;
+
+	var class_ = 1, class__ = 2;
+	var g_ = 3, g__ = 4;
+	var Array_ = 5, Array__ = 6;
+	if (class_ != 1 || class__ != 2 || g_ != 3 || g__ != 4 || Array_ != 5 || Array__ != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: escaped names => got %v %v %v %v %v %v<br>", [class_, class__, g_, g__, Array_, Array__])));
+
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function unicode() {
+	var pass = true;
+
+	var caf\u00E9 = "cr\xc3\xa8me", \u03C0 = 3;
+	function se\u00F1al(a\u00F1o) { this.a\u00F1o = a\u00F1o; }
+	var s = new se\u00F1al(\u03C0);
+	if (caf\u00E9 != "cr\xc3\xa8me" || s.a\u00F1o != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: unicode names => got %q %v<br>", [caf\u00E9, s.a\u00F1o])));
+		pass = false; PASS = false;
+	}
+	var v = g.Sprintf("%+v", [g.Typed(s, "main.se\xc3\xb1al")]); if (v != "{a\xc3\xb1o:3}") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: unicode field => got %q, want %q<br>", [v, "{a\xc3\xb1o:3}"])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function pair(a, b) { return [b, a]; }
+
+function temporaries() {
+	var pass = true;
+
+	var $r1 = pair(1, 2), a = $r1[0], b = $r1[1];
+	var $T2 = function(x, y) { return {x: x, y: y}; }; var tests = [$T2(a, b)];
+	var $r3 = pair(3, 4), c = $r3[0], d = $r3[1];
+	var $T4 = function(x, y) { return {x: x, y: y}; }; var more = [$T4(c, d)];
+
+	var $r5 = pair(b, a); a = $r5[0], b = $r5[1];
+	if (a != 2 || b != 1 || tests[0].x != 2 || more[0].y != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: results => got %v %v %v %v<br>", [a, b, g.Typed(tests, "[]struct{x int; y int}"), g.Typed(more, "[]struct{x int; y int}")])));
+		pass = false; PASS = false;
+	}
+
+	var m = g.MkMap(0, [["k", 5]]);
+	var $r6 = m.get("k"), v = $r6[0], ok = $r6[1];
+	var $r7 = m.get("z"); v = $r7[0], ok = $r7[1];
+	if (v != 0 || ok) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: map index => got %v %v<br>", [v, ok])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Naming<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN globals"], true) + "<br>"));
+	globals();
+	document.write(g.Text(g.Sprint(["=== RUN suffix"], true) + "<br>"));
+	suffix();
+	document.write(g.Text(g.Sprint(["=== RUN unicode"], true) + "<br>"));
+	unicode();
+	document.write(g.Text(g.Sprint(["=== RUN temporaries"], true) + "<br>"));
+	temporaries();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Naming"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
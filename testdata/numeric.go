@@ -117,6 +117,13 @@ func calculation() {
 	}
 }
 
+type Bits uint8
+
+const (
+	F0 Bits = 1 << iota
+	F1
+)
+
 func bitwise() {
 	pass := true
 
@@ -145,6 +152,13 @@ func bitwise() {
 		pass, PASS = false, false
 	}
 
+	// The constant expressions are folded.
+	x, y := -7, 9
+	if x&y != 9 || x|y != -7 || x^y != -16 || x&^y != -16 {
+		fmt.Print("\tFAIL: bitwise operators of variables\n")
+		pass, PASS = false, false
+	}
+
 	n := 7
 	n &^= 9
 	if n != 6 {
@@ -156,6 +170,10 @@ func bitwise() {
 		fmt.Print("\tFAIL: NOT\n")
 		pass, PASS = false, false
 	}
+	if ^F0 != 254 || ^F1&F1 != 0 {
+		fmt.Printf("\tFAIL: NOT of typed constant => got %v, want 254\n", ^F0)
+		pass, PASS = false, false
+	}
 
 	if pass {
 		fmt.Println("\tpass")
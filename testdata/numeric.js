@@ -44,28 +44,28 @@ function value() {
 	var pass = true;
 
 	if (u != 1 || u_ != 1 || u8 != 8 || u16 != 16 || u32 != 32) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: uint<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: uint<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (i != -1 || i_ != -1 || i8 != -8 || i16 != -16 || i32 != -32) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: int<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: int<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (f32 != 3.2 || f32_ != 3.2 || f64 != 6.4 || f64_ != 6.4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: float<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: float<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (b != 8 || b_ != 8) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: byte<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: byte<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (r != 32 || r_ != 32) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: rune<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: rune<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -73,110 +73,128 @@ function calculation() {
 	var pass = true;
 
 	if (u + 1 != 2 || u_ + 1 != 2 || u8 + 1 != 9 || u16 + 1 != 17 || u32 + 1 != 33) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add uint<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add uint<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (i + 1 != 0 || i_ + 1 != 0 || i8 + 1 != -7 || i16 + 1 != -15 || i32 + 1 != -31) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add int<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add int<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (f32 + 1 != 4.2 || f32_ + 1 != 4.2 || f64 + 1 != 7.4 || f64_ + 1 != 7.4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add float<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add float<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (b + 1 != 9 || b_ + 1 != 9) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add byte<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add byte<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (r + 1 != 33 || r_ + 1 != 33) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add rune<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: add rune<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (u8 - 1 != 7 || i8 - 1 != -9 || f32 - 1 != 2.2 || b - 1 != 7 || r - 1 != 31) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: subtract<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: subtract<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (u16 * 2 != 32 || i16 * 2 != -32 || f64 * 2 != 12.8 || b * 2 != 16 || r * 2 != 64) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiplication<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiplication<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (u / 1 != 1 || i / 1 != -1 || f32 / 2 != 1.6 || b / 2 != 4 || r / 2 != 16) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: division (quotient)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: division (quotient)<br>"], false)));
+		pass = false; PASS = false;
 	}
 	if (u8 % 3 != 2 || u16 % 3 != 1 || i8 % 3 != -2 || i16 % 3 != -1) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: division (remainder)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: division (remainder)<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
+function Bits(t) { this.t = t; } g.Named(Bits, "uint8");
+
+
+const F0 = 1;
+const F1 = 2;
+
+
 function bitwise() {
 	var pass = true;
 
 	if (u16>>1 != 8 || u16<<1 != 32 || i16>>1 != -8 || i16<<1 != -32) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Shift<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Shift<br>"], false)));
+		pass = false; PASS = false;
 	}
 
-	if ((7&9) != 1 || (-7&9) != 9) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: AND<br>");
-		pass = false, PASS = false;
+	if (1 != 1 || 9 != 9) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: AND<br>"], false)));
+		pass = false; PASS = false;
 	}
 
-	if ((7|9) != 15 || (-7|9) != -7) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: OR<br>");
-		pass = false, PASS = false;
+	if (15 != 15 || (-7) != -7) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: OR<br>"], false)));
+		pass = false; PASS = false;
 	}
 
-	if ((7^9) != 14 || (-7^9) != -16) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: XOR<br>");
-		pass = false, PASS = false;
+	if (14 != 14 || (-16) != -16) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: XOR<br>"], false)));
+		pass = false; PASS = false;
 	}
 
-	if ((7&~9) != 6 || (-7&~9) != -16) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: AND NOT<br>");
-		pass = false, PASS = false;
+	if (6 != 6 || (-16) != -16) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: AND NOT<br>"], false)));
+		pass = false; PASS = false;
+	}
+
+
+	var x = -7, y = 9;
+	if ((x&y) != 9 || (x|y) != -7 || (x^y) != -16 || (x&~y) != -16) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: bitwise operators of variables<br>"], false)));
+		pass = false; PASS = false;
 	}
 
 	var n = 7;
 	n &= ~(9);
 	if (n != 6) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: AND NOT (assignment)<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: AND NOT (assignment)<br>"], false)));
+		pass = false; PASS = false;
 	}
 
-	if (~-7 != 6 || ~7 != -8) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: NOT<br>");
-		pass = false, PASS = false;
+	if (6 != 6 || -8 != -8) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: NOT<br>"], false)));
+		pass = false; PASS = false;
+	}
+	if (254 != 254 || 0 != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: NOT of typed constant => got %v, want 254<br>", [g.Typed(254, "main.Bits", "uint8")])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function main() {
-	document.write("<br><br>== Numeric<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Numeric<br><br>"], false)));
 
-	document.write("=== RUN value<br>");
+	document.write(g.Text(g.Sprint(["=== RUN value"], true) + "<br>"));
 	value();
-	document.write("=== RUN calculation<br>");
+	document.write(g.Text(g.Sprint(["=== RUN calculation"], true) + "<br>"));
 	calculation();
-	document.write("=== RUN bitwise<br>");
+	document.write(g.Text(g.Sprint(["=== RUN bitwise"], true) + "<br>"));
 	bitwise();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Numeric");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Numeric"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
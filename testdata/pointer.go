@@ -225,6 +225,190 @@ func byReference_3() {
 	}
 }
 
+type point struct{ x, y int }
+
+type line struct {
+	from, to point
+	n        int
+}
+
+func fields() {
+	pass := true
+
+	var l line
+	pn := &l.n
+	*pn = 4
+	*pn++
+	pf := &l.from
+	pf.x = 1
+
+	if l.n != 5 || l.from.x != 1 {
+		fmt.Printf("\tFAIL: field => got %v, %v\n", l.n, l.from.x)
+		pass, PASS = false, false
+	}
+	if pn != &l.n || pf != &l.from {
+		fmt.Printf("\tFAIL: same field => got %v, %v\n", pn == &l.n, pf == &l.from)
+		pass, PASS = false, false
+	}
+
+	// The struct which holds the field is changed in place.
+	l = line{n: 7}
+	l.from = point{2, 3}
+	if *pn != 7 || pf.x != 2 {
+		fmt.Printf("\tFAIL: assignment => got %v, %v\n", *pn, pf.x)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func elements() {
+	pass := true
+
+	a := [3]int{1, 2, 3}
+	pa := &a[1]
+	*pa = 20
+	a = [3]int{7, 8, 9}
+
+	s := []int{1, 2, 3}
+	ps := &s[2]
+	*ps = 30
+	s2 := s[1:]
+	s2[1]++
+
+	if a[1] != 8 || *pa != 8 {
+		fmt.Printf("\tFAIL: array => got %v, %v\n", a, *pa)
+		pass, PASS = false, false
+	}
+	if s[2] != 31 || *ps != 31 {
+		fmt.Printf("\tFAIL: slice => got %v, %v\n", s, *ps)
+		pass, PASS = false, false
+	}
+	if ps != &s2[1] || ps == &s[1] {
+		fmt.Printf("\tFAIL: same element => got %v, %v\n", ps == &s2[1], ps == &s[1])
+		pass, PASS = false, false
+	}
+
+	x, y := 1, 2
+	ptrs := []*int{&x, &y}
+	i := 1
+	*ptrs[i] = 5
+	mp := map[string]*int{"x": &x}
+	*mp["x"] = 7
+	if x != 7 || y != 5 || *ptrs[0] != 7 {
+		fmt.Printf("\tFAIL: pointer element => got %v, %v\n", x, y)
+		pass, PASS = false, false
+	}
+
+	var parr *[3]int = &a
+	parr[0] = 70
+	if a[0] != 70 || parr[2] != 9 {
+		fmt.Printf("\tFAIL: declared pointer to array => got %v, %v\n", a, *parr)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func structs() {
+	pass := true
+
+	p := &point{3, 4}
+	q := p
+	*p = point{5, 6}
+	v := *q
+	v.x = 10
+
+	if q.x != 5 || (*q).y != 6 || p.x != 5 {
+		fmt.Printf("\tFAIL: store => got %v, %v\n", *p, *q)
+		pass, PASS = false, false
+	}
+
+	x := 1
+	px := &x
+	ppx := &px
+	**ppx = 2
+	if x != 2 {
+		fmt.Printf("\tFAIL: pointer to pointer => got %v\n", x)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func (p *point) move(dx, dy int) { p.x += dx; p.y += dy }
+
+func (p point) sum() int { return p.x + p.y }
+
+type node struct {
+	v    int
+	next *node
+}
+
+func (n *node) push(v int) *node { return &node{v, n} }
+
+// The receiver is assigned to walk through the list.
+func (n *node) len() int {
+	c := 0
+	for ; n != nil; n = n.next {
+		c++
+	}
+	return c
+}
+
+func methods() {
+	pass := true
+
+	// == 1. The fields and methods are got through the pointer.
+	p := new(point)
+	p.x = 1
+	p.move(1, 2)
+	if p.x != 2 || p.y != 2 || p.sum() != 4 || (*p).sum() != 4 {
+		fmt.Printf("\tFAIL: 1. pointer => got %v\n", *p)
+		pass, PASS = false, false
+	}
+
+	// == 2. The address is taken to call a method with pointer receiver.
+	var v point
+	v.move(3, 4)
+	l := line{}
+	l.to.move(1, 1)
+	if v.sum() != 7 || l.to.x != 1 {
+		fmt.Printf("\tFAIL: 2. addressable => got %v, %v\n", v, l.to)
+		pass, PASS = false, false
+	}
+
+	// == 3. The elements of arrays and slices have their own value.
+	a := [3]point{}
+	a[1].move(5, 5)
+	ps := []*point{{1, 1}, {2, 2}}
+	for _, e := range ps {
+		e.move(10, 0)
+	}
+	if a[0].x != 0 || a[1].x != 5 || ps[0].x != 11 || ps[1].sum() != 14 {
+		fmt.Printf("\tFAIL: 3. elements => got %v, %v, %v\n", a, *ps[0], *ps[1])
+		pass, PASS = false, false
+	}
+
+	// == 4. A linked list.
+	n := &node{v: 1}
+	n = n.push(2).push(3)
+	if n.v != 3 || n.next.v != 2 || n.next.next.v != 1 || n.len() != 3 {
+		fmt.Printf("\tFAIL: 4. list => got %v, len=%d\n", n.v, n.len())
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
 func main() {
 	fmt.Print("\n\n== Pointers\n\n")
 
@@ -248,6 +432,14 @@ func main() {
 	byReference_2()
 	fmt.Println("=== RUN byReference_3")
 	byReference_3()
+	fmt.Println("=== RUN fields")
+	fields()
+	fmt.Println("=== RUN elements")
+	elements()
+	fmt.Println("=== RUN structs")
+	structs()
+	fmt.Println("=== RUN methods")
+	methods()
 
 	if PASS {
 		fmt.Println("PASS")
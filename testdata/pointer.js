@@ -11,65 +11,65 @@
 var PASS = true;
 
 
-var i = g.Int({p:undefined});
-var hello = {p:undefined};
-var p = {p:undefined};
+var i = {p:g.Int(0)};
+var hello = {p:""};
+var p = undefined;
 
-(function() {
+function init$1() {
 	p = i;
 	var helloPtr = hello;
 
-	document.write("== init()<br>");
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;\"helloPtr\": " + helloPtr);
-}());
+	document.write(g.Text(g.Sprint(["== init()"], true) + "<br>"));
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;\"helloPtr\": ", g.Typed(helloPtr, "*string")], false)));
+}
 
 function declaration() {
-	var i = g.Int({p:undefined});
-	var hello = {p:undefined};
-	var p = {p:undefined};
+	var i = {p:g.Int(0)};
+	var hello = {p:""};
+	var p = undefined;
 
 	p = i;
 	var helloPtr = hello;
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;\"p\": " + p + " " + "<br>&nbsp;&nbsp;&nbsp;&nbsp;\"helloPtr\": " + helloPtr + "<br>");
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;\"p\":", g.Typed(p, "*int"), "<br>&nbsp;&nbsp;&nbsp;&nbsp;\"helloPtr\":", g.Typed(helloPtr, "*string")], true) + "<br>"));
 }
 
 function showAddress() {
-	
-	var i = g.Int({p:9});
+
+	var i = {p:g.Int(9)};
 	var hello = {p:"Hello world"};
-	var pi = g.Float32({p:3.14});
+	var pi = {p:g.Float32(3.14)};
 	var b = {p:true};
 
 
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;\"i\": " + i + "<br>");
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;\"hello\": " + hello + "<br>");
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;\"pi\": " + pi + "<br>");
-	document.write("&nbsp;&nbsp;&nbsp;&nbsp;\"b\": " + b + "<br>");
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;\"i\":", g.Typed(i, "*int")], true) + "<br>"));
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;\"hello\":", g.Typed(hello, "*string")], true) + "<br>"));
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;\"pi\":", g.Typed(pi, "*float32")], true) + "<br>"));
+	document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;\"b\":", g.Typed(b, "*bool")], true) + "<br>"));
 }
 
 function nilValue() {
 	var pass = true;
 
 	var num = {p:10};
-	var p = {p:undefined};
+	var p = undefined;
 
-	if (p.p == undefined) {
+	if (p == undefined) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: declaration => got " + p == undefined + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: declaration => got %v<br>", [p == undefined])));
+		pass = false; PASS = false;
 	}
 
 	p = num;
-	if (p.p != undefined) {
+	if (p != undefined) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: assignment => got " + p == undefined + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: assignment => got %v<br>", [p == undefined])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -77,19 +77,19 @@ function access() {
 	var pass = true;
 
 	var hello = {p:"Hello, mina-san!"};
-	var helloPtr = {p:undefined};
+	var helloPtr = undefined;
 	helloPtr = hello;
 
 	var i = {p:6};
 	var iPtr = i;
 
 	if (helloPtr.p != "Hello, mina-san!") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: *helloPtr => got " + helloPtr.p + ", want " + hello + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: *helloPtr => got %v, want %v<br>", [helloPtr.p, hello.p])));
+		pass = false; PASS = false;
 	}
 	if (iPtr.p != 6) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: *iPtr => got " + iPtr.p + ", want " + i + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: *iPtr => got %v, want %v<br>", [iPtr.p, i.p])));
+		pass = false; PASS = false;
 	}
 
 
@@ -99,35 +99,35 @@ function access() {
 
 	y.p++;
 	if (x.p != 4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: x => got " + x + ", want 4<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: x => got %v, want 4<br>", [x.p])));
+		pass = false; PASS = false;
 	}
 
 	y.p++;
 	if (x.p != 5) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: x => got " + x + ", want 5<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: x => got %v, want 5<br>", [x.p])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function allocation() {
 	var sum = 0;
-	var doubleSum = {p:undefined};
+	var doubleSum = undefined;
 	for (var i = 0; i < 10; i++) {
 		sum += i;
 	}
 
-	doubleSum.p = 0;
+	doubleSum = {p:0};
 	doubleSum.p = sum * 2;
 
 	if (sum == 45 && doubleSum.p == 90) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sum=" + sum + ", *doubleSum=" + doubleSum.p + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sum=%v, *doubleSum=%v<br>", [sum, doubleSum.p])));
 		PASS = false;
 	}
 }
@@ -143,9 +143,9 @@ function parameterByValue() {
 	var x1 = add(x);
 
 	if (x == 3 && x1 == 4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: x=" + x + ", x1=" + x1 + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: x=%v, x1=%v<br>", [x, x1])));
 		PASS = false;
 	}
 }
@@ -164,20 +164,20 @@ function byReference_1() {
 	if (x1 == 4 && x.p == 4) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. x=" + x + ", x1=" + x1 + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. x=%v, x1=%v<br>", [x.p, x1])));
+		pass = false; PASS = false;
 	}
 
 	x1 = add(x);
 	if (x.p == 5 && x1 == 5) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. x=" + x + ", x1=" + x1 + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. x=%v, x1=%v<br>", [x.p, x1])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -192,20 +192,20 @@ function byReference_2() {
 	if (value.p == 7) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. value=" + value + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. value=%v<br>", [value.p])));
+		pass = false; PASS = false;
 	}
 
 	add(value, incr);
 	if (value.p == 8) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. value=" + value + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. value=%v<br>", [value.p])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -218,42 +218,234 @@ function byReference_3() {
 
 	f();
 	if (y.p == 4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: *y=" + y.p + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: *y=%v<br>", [y.p])));
 		PASS = false;
 	}
 }
 
+function point(x, y) { this.x = x; this.y = y; }
+
+function line(from, to, n) {
+	this.from = from; this.to = to;
+	this.n = n;
+}
+
+function fields() {
+	var pass = true;
+
+	var l = new line(new point(0, 0), new point(0, 0), 0);
+	var pn = g.Ref(l, "n");
+	pn.p = 4;
+	pn.p++;
+	var pf = l.from;
+	pf.x = 1;
+
+	if (l.n != 5 || l.from.x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: field => got %v, %v<br>", [l.n, l.from.x])));
+		pass = false; PASS = false;
+	}
+	if (!g.Equal(pn, g.Ref(l, "n"), 0) || !g.Equal(pf, l.from, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: same field => got %v, %v<br>", [g.Equal(pn, g.Ref(l, "n"), 0), g.Equal(pf, l.from, 0)])));
+		pass = false; PASS = false;
+	}
+
+
+	g.Store(l, new line(new point(0, 0), new point(0, 0), 7), {from: {x: 0, y: 0}, to: {x: 0, y: 0}, n: 0});
+	g.Store(l.from, new point(2, 3), {x: 0, y: 0});
+	if (pn.p != 7 || pf.x != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: assignment => got %v, %v<br>", [pn.p, pf.x])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function elements() {
+	var pass = true;
+
+	var a = g.MkArray([3], 0, [1, 2, 3]);
+	var pa = g.Ref(a.v, 1);
+	pa.p = 20;
+	g.Store(a, g.MkArray([3], 0, [7, 8, 9]), [0]);
+
+	var s = g.Slice(0, [1, 2, 3]);
+	var ps = s.ref(2);
+	ps.p = 30;
+	var s2 = g.SliceFrom(s, 1);
+	s2.set([1], s2.at(1) + 1);
+
+	if (a.v[1] != 8 || pa.p != 8) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: array => got %v, %v<br>", [g.Typed(a, "[3]int"), pa.p])));
+		pass = false; PASS = false;
+	}
+	if (s.at(2) != 31 || ps.p != 31) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice => got %v, %v<br>", [g.Typed(s, "[]int"), ps.p])));
+		pass = false; PASS = false;
+	}
+	if (!g.Equal(ps, s2.ref(1), 0) || g.Equal(ps, s.ref(1), 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: same element => got %v, %v<br>", [g.Equal(ps, s2.ref(1), 0), g.Equal(ps, s.ref(1), 0)])));
+		pass = false; PASS = false;
+	}
+
+	var x = {p:1}, y = {p:2};
+	var ptrs = g.Slice(undefined, [x, y]);
+	var i = 1;
+	ptrs.at(i).p = 5;
+	var mp = g.MkMap(undefined, [["x", x]]);
+	mp.get("x")[0].p = 7;
+	if (x.p != 7 || y.p != 5 || ptrs.at(0).p != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer element => got %v, %v<br>", [x.p, y.p])));
+		pass = false; PASS = false;
+	}
+
+	var parr = a;
+	parr.v[0] = 70;
+	if (a.v[0] != 70 || parr.v[2] != 9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: declared pointer to array => got %v, %v<br>", [g.Typed(a, "[3]int"), g.Typed(parr, "[3]int")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function structs() {
+	var pass = true;
+
+	var p = new point(3, 4);
+	var q = p;
+	g.Store(p, new point(5, 6), {x: 0, y: 0});
+	var v = g.Clone(q, {x: 0, y: 0});
+	v.x = 10;
+
+	if (q.x != 5 || (q).y != 6 || p.x != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: store => got %v, %v<br>", [g.Typed(p, "main.point"), g.Typed(q, "main.point")])));
+		pass = false; PASS = false;
+	}
+
+	var x = {p:1};
+	var px = {p:x};
+	var ppx = px;
+	ppx.p.p = 2;
+	if (x.p != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer to pointer => got %v<br>", [x.p])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+point.prototype.move = function(dx, dy) { this.x += dx; this.y += dy; };
+
+point.prototype.sum = function() { return this.x + this.y; };
+
+function node(v, next) {
+	this.v = v;
+	this.next = next;
+}
+
+node.prototype.push = function(v) { return new node(v, this); };
+
+
+node.prototype.len = function() { var n = this;
+	var c = 0;
+	for (; n != undefined; n = n.next) {
+		c++;
+	}
+	return c;
+};
+
+function methods() {
+	var pass = true;
+
+
+	var p = new point(0, 0);
+	p.x = 1;
+	p.move(1, 2);
+	if (p.x != 2 || p.y != 2 || p.sum() != 4 || (p).sum() != 4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. pointer => got %v<br>", [g.Typed(p, "main.point")])));
+		pass = false; PASS = false;
+	}
+
+
+	var v = new point(0, 0);
+	v.move(3, 4);
+	var l = new line(new point(0, 0), new point(0, 0), 0);
+	l.to.move(1, 1);
+	if (v.sum() != 7 || l.to.x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. addressable => got %v, %v<br>", [g.Typed(v, "main.point"), g.Typed(l.to, "main.point")])));
+		pass = false; PASS = false;
+	}
+
+
+	var a = g.MkArray([3], new point(0, 0));
+	a.v[1].move(5, 5);
+	var ps = g.Slice(undefined, [new point(1, 1), new point(2, 2)]);
+	var e; for (var $i1 = 0, $n2 = ps.len; $i1 < $n2; $i1++) { e = ps.at($i1);
+		e.move(10, 0);
+	}
+	if (a.v[0].x != 0 || a.v[1].x != 5 || ps.at(0).x != 11 || ps.at(1).sum() != 14) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. elements => got %v, %v, %v<br>", [g.Typed(a, "[3]main.point"), g.Typed(ps.at(0), "main.point"), g.Typed(ps.at(1), "main.point")])));
+		pass = false; PASS = false;
+	}
+
+
+	var n = new node(1, undefined);
+	n = n.push(2).push(3);
+	if (n.v != 3 || n.next.v != 2 || n.next.next.v != 1 || n.len() != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. list => got %v, len=%d<br>", [n.v, n.len()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
 function main() {
-	document.write("<br><br>== Pointers<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Pointers<br><br>"], false)));
 
 
 
 
 
 
-	document.write("=== RUN nilValue<br>");
+	document.write(g.Text(g.Sprint(["=== RUN nilValue"], true) + "<br>"));
 	nilValue();
-	document.write("=== RUN access<br>");
+	document.write(g.Text(g.Sprint(["=== RUN access"], true) + "<br>"));
 	access();
-	document.write("=== RUN allocation<br>");
+	document.write(g.Text(g.Sprint(["=== RUN allocation"], true) + "<br>"));
 	allocation();
 
-	document.write("=== RUN parameterByValue<br>");
+	document.write(g.Text(g.Sprint(["=== RUN parameterByValue"], true) + "<br>"));
 	parameterByValue();
-	document.write("=== RUN byReference_1<br>");
+	document.write(g.Text(g.Sprint(["=== RUN byReference_1"], true) + "<br>"));
 	byReference_1();
-	document.write("=== RUN byReference_2<br>");
+	document.write(g.Text(g.Sprint(["=== RUN byReference_2"], true) + "<br>"));
 	byReference_2();
-	document.write("=== RUN byReference_3<br>");
+	document.write(g.Text(g.Sprint(["=== RUN byReference_3"], true) + "<br>"));
 	byReference_3();
+	document.write(g.Text(g.Sprint(["=== RUN fields"], true) + "<br>"));
+	fields();
+	document.write(g.Text(g.Sprint(["=== RUN elements"], true) + "<br>"));
+	elements();
+	document.write(g.Text(g.Sprint(["=== RUN structs"], true) + "<br>"));
+	structs();
+	document.write(g.Text(g.Sprint(["=== RUN methods"], true) + "<br>"));
+	methods();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Pointers");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Pointers"));
 	}
-} main();
+} init$1(); main();
 /* Generated by Go2js (github.com/kless/go2js) */
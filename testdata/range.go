@@ -0,0 +1,249 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+var calls = 0
+
+func numbers() []int {
+	calls++
+	return []int{1, 2, 3}
+}
+
+func rangeSlice() {
+	pass := true
+
+	s := []int{10, 20, 30}
+	sum, sumIdx := 0, 0
+	for i, v := range s {
+		sum += v
+		sumIdx += i
+	}
+	if sum != 60 || sumIdx != 3 {
+		fmt.Printf("\tFAIL: index and value => got %v, %v, want 60, 3\n", sum, sumIdx)
+		pass, PASS = false, false
+	}
+
+	n := 0
+	for i := range s {
+		n += i
+	}
+	for range s {
+		n++
+	}
+	if n != 6 {
+		fmt.Printf("\tFAIL: only index => got %v, want 6\n", n)
+		pass, PASS = false, false
+	}
+
+	calls = 0
+	sum = 0
+	for _, v := range numbers() {
+		sum += v
+	}
+	if calls != 1 || sum != 6 {
+		fmt.Printf("\tFAIL: evaluated once => got %v calls, sum %v\n", calls, sum)
+		pass, PASS = false, false
+	}
+
+	var i, v int
+	for i, v = range s {
+	}
+	if i != 2 || v != 30 {
+		fmt.Printf("\tFAIL: assignment => got %v, %v, want 2, 30\n", i, v)
+		pass, PASS = false, false
+	}
+
+	// The elements changed are seen, until the length at the beginning.
+	got := []int{}
+	for i, v := range s {
+		if i == 0 {
+			s[2] = 100
+			s = append(s, 40)
+		}
+		got = append(got, v)
+	}
+	if len(got) != 3 || got[2] != 100 || len(s) != 4 {
+		fmt.Printf("\tFAIL: changed => got %v, len %v, want [10 20 100], 4\n", got, len(s))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func rangeArray() {
+	pass := true
+
+	a := [4]string{"a", "b", "c", "d"}
+	str := ""
+	for i, v := range a {
+		if i%2 == 0 {
+			str += v
+		}
+	}
+	if str != "ac" {
+		fmt.Printf("\tFAIL: array => got %q, want %q\n", str, "ac")
+		pass, PASS = false, false
+	}
+
+	// The array ranged is a copy.
+	n := [3]int{1, 2, 3}
+	last := 0
+	for i, v := range n {
+		if i == 0 {
+			n[2] = 100
+		}
+		last = v
+	}
+	if last != 3 || n[2] != 100 {
+		fmt.Printf("\tFAIL: copy => got %v, %v, want 3, 100\n", last, n[2])
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func rangeString() {
+	pass := true
+
+	idx := []int{}
+	runes := []rune{}
+	for i, r := range "aé世😀" {
+		idx = append(idx, i)
+		runes = append(runes, r)
+	}
+
+	// An invalid byte is the rune of replacement.
+	for i, r := range "\xffa" {
+		if i == 0 && r != 65533 {
+			fmt.Printf("\tFAIL: invalid => got %v, want 65533\n", r)
+			pass, PASS = false, false
+		}
+	}
+
+	wantIdx := []int{0, 1, 3, 6}
+	wantRunes := []rune{97, 233, 19990, 128512}
+	if len(idx) != 4 {
+		fmt.Printf("\tFAIL: number of runes => got %v, want 4\n", len(idx))
+		pass, PASS = false, false
+	} else {
+		for i := range 4 {
+			if idx[i] != wantIdx[i] || runes[i] != wantRunes[i] {
+				fmt.Printf("\tFAIL: %d. got %v %v, want %v %v\n",
+					i, idx[i], runes[i], wantIdx[i], wantRunes[i])
+				pass, PASS = false, false
+			}
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func rangeMap() {
+	pass := true
+
+	m := map[int]string{1: "a", 2: "b", 3: "c"}
+	sum := 0
+	for k := range m {
+		sum += k
+	}
+	if sum != 6 {
+		fmt.Printf("\tFAIL: integer keys => got %v, want 6\n", sum)
+		pass, PASS = false, false
+	}
+
+	str := ""
+	for k, v := range m {
+		if k+1 == 3 {
+			str = v
+		}
+	}
+	if str != "b" {
+		fmt.Printf("\tFAIL: key and value => got %q, want %q\n", str, "b")
+		pass, PASS = false, false
+	}
+
+	b := map[bool]int{true: 1, false: 2}
+	for k, v := range b {
+		if k && v != 1 || !k && v != 2 {
+			fmt.Printf("\tFAIL: boolean keys => got %v: %v\n", k, v)
+			pass, PASS = false, false
+		}
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func rangeInt() {
+	pass := true
+
+	sum := 0
+	for i := range 5 {
+		sum += i
+	}
+	if sum != 10 {
+		fmt.Printf("\tFAIL: range 5 => got %v, want 10\n", sum)
+		pass, PASS = false, false
+	}
+
+	n := 3
+	count := 0
+	for range n {
+		count++
+	}
+	if count != 3 {
+		fmt.Printf("\tFAIL: range n => got %v, want 3\n", count)
+		pass, PASS = false, false
+	}
+
+	calls = 0
+	count = 0
+	for range len(numbers()) {
+		count++
+	}
+	if calls != 1 || count != 3 {
+		fmt.Printf("\tFAIL: evaluated once => got %v calls, count %v\n", calls, count)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Range\n\n")
+
+	fmt.Println("=== RUN slice")
+	rangeSlice()
+	fmt.Println("=== RUN array")
+	rangeArray()
+	fmt.Println("=== RUN string")
+	rangeString()
+	fmt.Println("=== RUN map")
+	rangeMap()
+	fmt.Println("=== RUN int")
+	rangeInt()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Range")
+	}
+}
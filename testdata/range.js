@@ -0,0 +1,250 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+var calls = 0;
+
+function numbers() {
+	calls++;
+	return g.Slice(0, [1, 2, 3]);
+}
+
+function rangeSlice() {
+	var pass = true;
+
+	var s = g.Slice(0, [10, 20, 30]);
+	var sum = 0, sumIdx = 0;
+	var v; for (var i = 0, $n1 = s.len; i < $n1; i++) { v = s.at(i);
+		sum += v;
+		sumIdx += i;
+	}
+	if (sum != 60 || sumIdx != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: index and value => got %v, %v, want 60, 3<br>", [sum, sumIdx])));
+		pass = false; PASS = false;
+	}
+
+	var n = 0;
+	for (var i = 0, $n2 = s.len; i < $n2; i++) {
+		n += i;
+	}
+	for (var $i3 = 0, $n4 = s.len; $i3 < $n4; $i3++) {
+		n++;
+	}
+	if (n != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: only index => got %v, want 6<br>", [n])));
+		pass = false; PASS = false;
+	}
+
+	calls = 0;
+	sum = 0;
+	var v; for (var $i5 = 0, $r6 = numbers(), $n7 = $r6.len; $i5 < $n7; $i5++) { v = $r6.at($i5);
+		sum += v;
+	}
+	if (calls != 1 || sum != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: evaluated once => got %v calls, sum %v<br>", [calls, sum])));
+		pass = false; PASS = false;
+	}
+
+	var i = g.Int(0), v = g.Int(0);
+	for (var $i8 = 0, $n9 = s.len; $i8 < $n9; $i8++) { i = $i8; v = s.at($i8);
+	}
+	if (i != 2 || v != 30) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: assignment => got %v, %v, want 2, 30<br>", [i, v])));
+		pass = false; PASS = false;
+	}
+
+
+	var got = g.Slice(0, []);
+	var v; for (var i = 0, $r10 = s, $n11 = $r10.len; i < $n11; i++) { v = $r10.at(i);
+		if (i == 0) {
+			s.set([2], 100);
+			s = g.Append(s, [40]);
+		}
+		got = g.Append(got, [v]);
+	}
+	if (got.len != 3 || got.at(2) != 100 || s.len != 4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: changed => got %v, len %v, want [10 20 100], 4<br>", [g.Typed(got, "[]int"), s.len])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function rangeArray() {
+	var pass = true;
+
+	var a = g.MkArray([4], "", ["a", "b", "c", "d"]);
+	var str = "";
+	var v; for (var i = 0; i < a.v.length; i++) { v = a.v[i];
+		if (i % 2 == 0) {
+			str += v;
+		}
+	}
+	if (str != "ac") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: array => got %q, want %q<br>", [str, "ac"])));
+		pass = false; PASS = false;
+	}
+
+
+	var n = g.MkArray([3], 0, [1, 2, 3]);
+	var last = 0;
+	var v; for (var i = 0, $r1 = g.Clone(n.v, [0]); i < $r1.length; i++) { v = $r1[i];
+		if (i == 0) {
+			n.v[2] = 100;
+		}
+		last = v;
+	}
+	if (last != 3 || n.v[2] != 100) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: copy => got %v, %v, want 3, 100<br>", [last, n.v[2]])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function rangeString() {
+	var pass = true;
+
+	var idx = g.Slice(0, []);
+	var runes = g.Slice(0, []);
+	var i, r; for (var $i1 = 0, $r2 = g.RangeString("a\xc3\xa9\xe4\xb8\x96\xf0\x9f\x98\x80"); $i1 < $r2.length; $i1 += 2) { i = $r2[$i1]; r = $r2[$i1 + 1];
+		idx = g.Append(idx, [i]);
+		runes = g.Append(runes, [r]);
+	}
+
+
+	var i, r; for (var $i3 = 0, $r4 = g.RangeString("\xffa"); $i3 < $r4.length; $i3 += 2) { i = $r4[$i3]; r = $r4[$i3 + 1];
+		if (i == 0 && r != 65533) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: invalid => got %v, want 65533<br>", [g.Typed(r, "rune")])));
+			pass = false; PASS = false;
+		}
+	}
+
+	var wantIdx = g.Slice(0, [0, 1, 3, 6]);
+	var wantRunes = g.Slice(0, [97, 233, 19990, 128512]);
+	if (idx.len != 4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: number of runes => got %v, want 4<br>", [idx.len])));
+		pass = false; PASS = false;
+	} else {
+		for (var i = 0; i < 4; i++) {
+			if (!g.Equal(idx.at(i), wantIdx.at(i), 0) || !g.Equal(runes.at(i), wantRunes.at(i), 0)) {
+				document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %d. got %v %v, want %v %v<br>", [i, idx.at(i), g.Typed(runes.at(i), "rune"), wantIdx.at(i), g.Typed(wantRunes.at(i), "rune")])));
+
+				pass = false; PASS = false;
+			}
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function rangeMap() {
+	var pass = true;
+
+	var m = g.MkMap("", [[1, "a"], [2, "b"], [3, "c"]]);
+	var sum = 0;
+	var k; for (var $e1 of m.entries()) { k = $e1[0];
+		sum += k;
+	}
+	if (sum != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: integer keys => got %v, want 6<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	var str = "";
+	var k, v; for (var $e2 of m.entries()) { k = $e2[0]; v = $e2[1];
+		if (k + 1 == 3) {
+			str = v;
+		}
+	}
+	if (str != "b") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: key and value => got %q, want %q<br>", [str, "b"])));
+		pass = false; PASS = false;
+	}
+
+	var b = g.MkMap(0, [[true, 1], [false, 2]]);
+	var k, v; for (var $e3 of b.entries()) { k = $e3[0]; v = $e3[1];
+		if (k && v != 1 || !k && v != 2) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: boolean keys => got %v: %v<br>", [k, v])));
+			pass = false; PASS = false;
+		}
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function rangeInt() {
+	var pass = true;
+
+	var sum = 0;
+	for (var i = 0; i < 5; i++) {
+		sum += i;
+	}
+	if (sum != 10) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range 5 => got %v, want 10<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	var n = 3;
+	var count = 0;
+	for (var $i1 = 0; $i1 < n; $i1++) {
+		count++;
+	}
+	if (count != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range n => got %v, want 3<br>", [count])));
+		pass = false; PASS = false;
+	}
+
+	calls = 0;
+	count = 0;
+	for (var $i2 = 0, $n3 = numbers().len; $i2 < $n3; $i2++) {
+		count++;
+	}
+	if (calls != 1 || count != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: evaluated once => got %v calls, count %v<br>", [calls, count])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Range<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN slice"], true) + "<br>"));
+	rangeSlice();
+	document.write(g.Text(g.Sprint(["=== RUN array"], true) + "<br>"));
+	rangeArray();
+	document.write(g.Text(g.Sprint(["=== RUN string"], true) + "<br>"));
+	rangeString();
+	document.write(g.Text(g.Sprint(["=== RUN map"], true) + "<br>"));
+	rangeMap();
+	document.write(g.Text(g.Sprint(["=== RUN int"], true) + "<br>"));
+	rangeInt();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Range"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
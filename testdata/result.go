@@ -0,0 +1,183 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type point struct {
+	x, y int
+}
+
+func zeros() (i int, f float64, s string, b bool, p point, sl []int, m map[string]int, fn func(), e interface{}, ok bool) {
+	ok = i == 0 && f == 0 && s == "" && !b && p.x == 0 && p.y == 0 &&
+		sl == nil && len(sl) == 0 && m == nil && len(m) == 0 && fn == nil && e == nil
+	return
+}
+
+func divMod(a, b int) (q, r int) {
+	r = a % b
+	q = (a - r) / b
+	return
+}
+
+func split(sum int) (x, y int) {
+	x = sum * 4
+	y = sum - x
+	return y, x // the values are assigned to the results
+}
+
+func shadow(n int) (v int) {
+	v = n
+	if n > 0 {
+		v := n * 10
+		_ = v
+	}
+	for i := 0; i < 2; i++ {
+		v := i
+		_ = v
+	}
+	return
+}
+
+func closure() (total int) {
+	add := func(n int) { total += n }
+	add(2)
+	add(3)
+	return
+}
+
+func appendTo(n int) (s []int) {
+	for i := 0; i < n; i++ {
+		s = append(s, i)
+	}
+	return
+}
+
+func label() (n int, s string) {
+	n, s = 1, "x"
+	return
+}
+
+func anon() (st struct{ A string }) {
+	st.A = "x"
+	return
+}
+
+var calls int
+
+func count() (n int) {
+	calls++
+	n = calls
+	return
+}
+
+func values() {
+	pass := true
+
+	if _, _, _, _, _, _, _, _, _, ok := zeros(); !ok {
+		fmt.Println("\tFAIL: zero values")
+		pass, PASS = false, false
+	}
+
+	if q, r := divMod(7, 2); q != 3 || r != 1 {
+		fmt.Printf("\tFAIL: bare return => got %v, %v, want 3, 1\n", q, r)
+		pass, PASS = false, false
+	}
+	if x, y := split(3); x != -9 || y != 12 {
+		fmt.Printf("\tFAIL: explicit return => got %v, %v, want -9, 12\n", x, y)
+		pass, PASS = false, false
+	}
+
+	if st := anon(); st.A != "x" {
+		fmt.Printf("\tFAIL: anonymous struct => got %q, want \"x\"\n", st.A)
+		pass, PASS = false, false
+	}
+
+	// The zero value is compared like the one of any int.
+	q, _ := divMod(0, 1)
+	if m := map[int]string{0: "zero"}; m[q] != "zero" {
+		fmt.Printf("\tFAIL: map key => got %q, want \"zero\"\n", m[q])
+		pass, PASS = false, false
+	}
+	switch q {
+	case 0:
+	default:
+		fmt.Printf("\tFAIL: switch => got %v, want 0\n", q)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func scope() {
+	pass := true
+
+	if shadow(3) != 3 {
+		fmt.Printf("\tFAIL: shadowed => got %v, want 3\n", shadow(3))
+		pass, PASS = false, false
+	}
+	if closure() != 5 {
+		fmt.Printf("\tFAIL: closure => got %v, want 5\n", closure())
+		pass, PASS = false, false
+	}
+	if s := appendTo(3); len(s) != 3 || s[2] != 2 {
+		fmt.Printf("\tFAIL: slice => got len %v\n", len(s))
+		pass, PASS = false, false
+	}
+
+	// The result "s" of appendTo is not seen out of that function.
+	n, s := label()
+	if s == "" || n != 1 {
+		fmt.Printf("\tFAIL: other function => got %v, %q\n", n, s)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func blank() {
+	pass := true
+	calls = 0
+
+	_ = count()
+	var _ = count()
+	_, _ = count(), count()
+	var _, n, _ = count(), count(), count()
+
+	if calls != 7 || n != 6 {
+		fmt.Printf("\tFAIL: calls => got %v, %v, want 7, 6\n", calls, n)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Named results\n\n")
+
+	fmt.Println("=== RUN values")
+	values()
+	fmt.Println("=== RUN scope")
+	scope()
+	fmt.Println("=== RUN blank")
+	blank()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Named results")
+	}
+}
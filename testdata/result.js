@@ -0,0 +1,184 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function zeros() { var i = 0; var f = 0; var s = ""; var b = false; var p = new point(0, 0); var sl = g.MkSlice(); var m = g.MkMap(0); var fn = undefined; var e = undefined; var ok = false;
+	ok = i == 0 && f == 0 && s == "" && !b && p.x == 0 && p.y == 0 && sl.isNil() && sl.len == 0 && m.isNil() && m.len() == 0 && fn == undefined && e == undefined;
+
+	return [i, f, s, b, g.Clone(p, {x: 0, y: 0}), sl, m, fn, e, ok];
+}
+
+function divMod(a, b) { var q = 0, r = 0;
+	r = a % b;
+	q = (a - r) / b;
+	return [q, r];
+}
+
+function split(sum) { var x = 0, y = 0;
+	x = sum * 4;
+	y = sum - x;
+	return [y, x];
+}
+
+function shadow(n) { var v1 = 0;
+	v1 = n;
+	if (n > 0) {
+		var v = n * 10;
+
+	}
+	for (var i = 0; i < 2; i++) {
+		var v = i;
+
+	}
+	return v1;
+}
+
+function closure() { var total = 0;
+	var add = function(n) { total += n; };
+	add(2);
+	add(3);
+	return total;
+}
+
+function appendTo(n) { var s = g.MkSlice();
+	for (var i = 0; i < n; i++) {
+		s = g.Append(s, [i]);
+	}
+	return s;
+}
+
+function label() { var n = 0; var s = "";
+	n = 1; s = "x";
+	return [n, s];
+}
+
+function anon() { var st = {A: ""};
+	st.A = "x";
+	return st;
+}
+
+var calls = g.Int(0);
+
+function count() { var n = 0;
+	calls++;
+	n = calls;
+	return n;
+}
+
+function values() {
+	var pass = true;
+
+	var ok = zeros()[9]; if (!ok) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: zero values"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var $r1 = divMod(7, 2), q = $r1[0], r = $r1[1]; if (q != 3 || r != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: bare return => got %v, %v, want 3, 1<br>", [q, r])));
+		pass = false; PASS = false;
+	}
+	var $r2 = split(3), x = $r2[0], y = $r2[1]; if (x != -9 || y != 12) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: explicit return => got %v, %v, want -9, 12<br>", [x, y])));
+		pass = false; PASS = false;
+	}
+
+	var st = anon(); if (st.A != "x") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: anonymous struct => got %q, want \"x\"<br>", [st.A])));
+		pass = false; PASS = false;
+	}
+
+
+	var q = divMod(0, 1)[0];
+	var m = g.MkMap("", [[0, "zero"]]); if (m.get(q)[0] != "zero") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: map key => got %q, want \"zero\"<br>", [m.get(q)[0]])));
+		pass = false; PASS = false;
+	}
+	switch (q) {
+	case 0: break;
+	default:
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: switch => got %v, want 0<br>", [q])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function scope() {
+	var pass = true;
+
+	if (shadow(3) != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: shadowed => got %v, want 3<br>", [shadow(3)])));
+		pass = false; PASS = false;
+	}
+	if (closure() != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: closure => got %v, want 5<br>", [closure()])));
+		pass = false; PASS = false;
+	}
+	var s = appendTo(3); if (s.len != 3 || s.at(2) != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice => got len %v<br>", [s.len])));
+		pass = false; PASS = false;
+	}
+
+
+	var $r1 = label(), n = $r1[0], s = $r1[1];
+	if (s == "" || n != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: other function => got %v, %q<br>", [n, s])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function blank() {
+	var pass = true;
+	calls = 0;
+
+	count();
+	count();
+	count(); count();
+	var n = (count(), count()); count();
+
+	if (calls != 7 || n != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: calls => got %v, %v, want 7, 6<br>", [calls, n])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Named results<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN values"], true) + "<br>"));
+	values();
+	document.write(g.Text(g.Sprint(["=== RUN scope"], true) + "<br>"));
+	scope();
+	document.write(g.Text(g.Sprint(["=== RUN blank"], true) + "<br>"));
+	blank();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Named results"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
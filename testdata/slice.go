@@ -388,6 +388,14 @@ func _copy() {
 		pass, PASS = false, false
 	}
 
+	n4 := copy(s[2:], s)
+	if string(s) == "232345" && n4 == 4 {
+		// ok
+	} else {
+		fmt.Printf("\tFAIL: overlap => got %q, n=%v\n", string(s), n4)
+		pass, PASS = false, false
+	}
+
 	n3 := copy(b, "Hello, World!")
 	if string(b) == "Hello" && n3 == 5 {
 		// ok
@@ -396,6 +404,16 @@ func _copy() {
 		pass, PASS = false, false
 	}
 
+	// The result is not used.
+	dst := make([]int, 3)
+	src := []int{1, 2, 3, 4}
+	copy(dst, src)
+	_ = copy(dst[1:], src[3:])
+	if fmt.Sprint(dst) != "[1 4 3]" {
+		fmt.Printf("\tFAIL: statement => got %v\n", dst)
+		pass, PASS = false, false
+	}
+
 	if pass {
 		fmt.Println("\tpass")
 	}
@@ -447,7 +465,7 @@ func _append() {
 
 	// == Delete
 
-	/*del := func(i int, slice []byte) []byte {
+	del := func(i int, slice []byte) []byte {
 		switch i {
 		case 0:
 			slice = slice[1:]
@@ -514,7 +532,7 @@ func _append() {
 		fmt.Printf("\tFAIL: (simple) delete last element => got %q, len=%d\n",
 			string(slice), len(slice))
 		pass, PASS = false, false
-	}*/
+	}
 
 	// == []interface
 
@@ -526,6 +544,167 @@ func _append() {
 	}
 }
 
+func sharing() {
+	pass := true
+
+	// == 1. append writes into the array while the capacity allows it.
+	base := make([]int, 3, 5)
+	s1 := append(base, 1)
+	s2 := append(base, 2) // overwrites the element appended to s1
+
+	if len(s1) == 4 && cap(s1) == 5 && s1[3] == 2 && s2[3] == 2 {
+		// ok
+	} else {
+		fmt.Printf("\tFAIL: 1. in place => got %v %v\n", s1, s2)
+		pass, PASS = false, false
+	}
+
+	s2[0] = 9
+	if base[0] != 9 || s1[0] != 9 {
+		fmt.Printf("\tFAIL: 1. same array => got %v %v\n", base, s1)
+		pass, PASS = false, false
+	}
+
+	// == 2. Beyond the capacity, a new array is used.
+	s3 := append(s1, 3, 4)
+	s3[0] = 7
+
+	if len(s3) == 6 && cap(s3) >= 6 && s1[0] == 9 && s3[0] == 7 {
+		// ok
+	} else {
+		fmt.Printf("\tFAIL: 2. new array => got %v %v, cap=%d\n", s1, s3, cap(s3))
+		pass, PASS = false, false
+	}
+
+	// == 3. The array is shared with the slices got from it.
+	arr := [5]int{1, 2, 3, 4, 5}
+	head := arr[:2]
+	head = append(head, 30)
+
+	if arr[2] != 30 || len(head) != 3 || cap(head) != 5 {
+		fmt.Printf("\tFAIL: 3. array => got %v %v\n", arr, head)
+		pass, PASS = false, false
+	}
+
+	// == 4. The slice of a slice can be extended until its capacity.
+	tail := head[1:2]
+	tail = tail[:cap(tail)]
+
+	if len(tail) != 4 || tail[3] != 5 {
+		fmt.Printf("\tFAIL: 4. reslice => got %v, len=%d\n", tail, len(tail))
+		pass, PASS = false, false
+	}
+
+	// == 5. Append to a nil slice and of a string.
+	var nilSlice []byte
+	nilSlice = append(nilSlice, "go"...)
+	nilSlice = append(nilSlice, '!')
+
+	if string(nilSlice) != "go!" || nilSlice == nil {
+		fmt.Printf("\tFAIL: 5. nil => got %q\n", string(nilSlice))
+		pass, PASS = false, false
+	}
+
+	// == 6. Growth keeps the elements.
+	var many []int
+	for i := 0; i < 100; i++ {
+		many = append(many, i)
+	}
+	sum := 0
+	for _, v := range many {
+		sum += v
+	}
+	if len(many) != 100 || cap(many) < 100 || sum != 4950 {
+		fmt.Printf("\tFAIL: 6. grow => got len=%d, sum=%d\n", len(many), sum)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func length(s []int) int { return len(s) }
+
+func expression() {
+	pass := true
+
+	// == 1. The third index limits the capacity.
+	arr := [5]int{1, 2, 3, 4, 5}
+	limited := arr[1:2:3]
+
+	if len(limited) != 1 || cap(limited) != 2 || limited[0] != 2 {
+		fmt.Printf("\tFAIL: 1. max => got %v, cap=%d\n", limited, cap(limited))
+		pass, PASS = false, false
+	}
+
+	// == 2. Append writes into the array until the capacity, then copies it.
+	limited = append(limited, 30)
+	limited = append(limited, 40)
+	limited[0] = 20
+
+	if fmt.Sprint(arr) != "[1 2 30 4 5]" || fmt.Sprint(limited) != "[20 30 40]" {
+		fmt.Printf("\tFAIL: 2. append => got %v %v\n", arr, limited)
+		pass, PASS = false, false
+	}
+
+	// == 3. Slice of a slice with the three indexes.
+	s := arr[:]
+	var full []int = s[1:3:4]
+	i, j, k := 0, 1, 2
+	if len(full) != 2 || cap(full) != 3 || cap(full[i:j:k]) != 2 || len(full[j:]) != 1 || full[1] != 30 {
+		fmt.Printf("\tFAIL: 3. slice => got %v, cap=%d\n", full, cap(full))
+		pass, PASS = false, false
+	}
+
+	// == 4. Pointer to an array.
+	p := &arr
+	p[1:][0] = 22
+
+	if fmt.Sprint(p[1:3], (*[5]int)(p)[3:]) != "[22 30] [4 5]" || arr[1] != 22 {
+		fmt.Printf("\tFAIL: 4. pointer => got %v\n", p[1:3])
+		pass, PASS = false, false
+	}
+
+	// == 5. The slice of a string is a string, with indexes in bytes.
+	str := "héllo"
+	lo := 1
+	if sub := str[lo:3]; sub != "é" || len(sub) != 2 || str[3:] != "llo" || str[:1] != "h" {
+		fmt.Printf("\tFAIL: 5. string => got %q\n", sub)
+		pass, PASS = false, false
+	}
+
+	// == 6. The slice of a nil slice is nil.
+	var nilSlice []int
+	if nilSlice[0:0] != nil || nilSlice[:] != nil {
+		fmt.Println("\tFAIL: 6. nil")
+		pass, PASS = false, false
+	}
+
+	// == 7. The literals are values of any expression.
+	pairs := [][2]int{{1, 1}, {2, 3}}
+	pairs[0][1] = 9
+	if fmt.Sprint(pairs) != "[[1 9] [2 3]]" || len(pairs) != 2 || len(pairs[1]) != 2 ||
+		length([]int{}) != 0 || length([]int{1}) != 1 {
+		fmt.Printf("\tFAIL: 7. literal => got %v\n", pairs)
+		pass, PASS = false, false
+	}
+
+	// == 8. The elements do not share the zero value.
+	grid := make([][2]int, 2)
+	grid[0][0] = 1
+	grid = append(grid, [2]int{})
+	grid[2][1] = 2
+	if fmt.Sprint(grid) != "[[1 0] [0 0] [0 2]]" {
+		fmt.Printf("\tFAIL: 8. zero => got %v\n", grid)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
 func main() {
 	fmt.Print("\n\n== Slices\n\n")
 
@@ -545,6 +724,10 @@ func main() {
 	_copy()
 	fmt.Println("=== RUN append")
 	_append()
+	fmt.Println("=== RUN sharing")
+	sharing()
+	fmt.Println("=== RUN expression")
+	expression()
 
 	if PASS {
 		fmt.Println("PASS")
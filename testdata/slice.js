@@ -14,51 +14,51 @@ function builtIn() {
 	var pass = true;
 
 	var s1 = g.MkSlice();
-	var s2 = g.MkSlice(0, 0);
+	var s2 = g.Slice(0, []);
 	var s3 = g.MkSlice(0, 0);
 	var s4 = g.MkSlice(0, 0, 10);
 	var s5 = g.Slice(0, [1, 3, 5]);
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("nil s1", s1.isNil(), true),
-		_("nil s2", s2.isNil(), false),
-		_("nil s3", s3.isNil(), false),
-		_("nil s4", s4.isNil(), false),
-		_("nil s5", s5.isNil(), false),
-		_("nil s5", !s5.isNil(), true),
-
-		_("len s1", s1.len == 0, true),
-		_("len s2", s2.len == 0, true),
-		_("len s3", s3.len == 0, true),
-		_("len s4", s4.len == 0, true),
-		_("len s5", s5.len == 3, true),
-
-		_("cap s1", s1.cap == 0, true),
-		_("cap s2", s2.cap == 0, true),
-		_("cap s3", s3.cap == 0, true),
-		_("cap s4", s4.cap == 10, true),
-		_("cap s5", s5.cap == 3, true)
+	}; }; var tests = [
+		$T1("nil s1", s1.isNil(), true),
+		$T1("nil s2", s2.isNil(), false),
+		$T1("nil s3", s3.isNil(), false),
+		$T1("nil s4", s4.isNil(), false),
+		$T1("nil s5", s5.isNil(), false),
+		$T1("nil s5", !s5.isNil(), true),
+
+		$T1("len s1", s1.len == 0, true),
+		$T1("len s2", s2.len == 0, true),
+		$T1("len s3", s3.len == 0, true),
+		$T1("len s4", s4.len == 0, true),
+		$T1("len s5", s5.len == 3, true),
+
+		$T1("cap s1", s1.cap == 0, true),
+		$T1("cap s2", s2.cap == 0, true),
+		$T1("cap s3", s3.cap == 0, true),
+		$T1("cap s4", s4.cap == 10, true),
+		$T1("cap s5", s5.cap == 3, true)
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function shortHand() {
 	var pass = true;
 
-	var array = g.MkArray([10], 0, ['a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j']);
+	var array = g.MkArray([10], 0, [97, 98, 99, 100, 101, 102, 103, 104, 105, 106]);
 	var a_slice = g.MkSlice(), b_slice = g.MkSlice();
 
 
@@ -67,51 +67,51 @@ function shortHand() {
 	if (a_slice.str() == "efgh" && a_slice.len == 4 && a_slice.cap == 6) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [4:8] => got " + a_slice.get() + ", len=" + a_slice.len + ", cap=" + a_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [4:8] => got %v, len=%v, cap=%v<br>", [g.Typed(a_slice, "[]byte"), a_slice.len, a_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	a_slice = g.SliceFrom(array, 6, 7);
 	if (a_slice.str() != "g") {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [6:7] => got " + a_slice.get() + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [6:7] => got %v<br>", [g.Typed(a_slice, "[]byte")])));
+		pass = false; PASS = false;
 	}
 
 	a_slice = g.SliceFrom(array, 0, 3);
 	if (a_slice.str() == "abc" && a_slice.len == 3 && a_slice.cap == 10) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [:3] => got " + a_slice.get() + ", len=" + a_slice.len + ", cap=" + a_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [:3] => got %v, len=%v, cap=%v<br>", [g.Typed(a_slice, "[]byte"), a_slice.len, a_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	a_slice = g.SliceFrom(array, 5);
 	if (a_slice.str() == "fghij" && a_slice.len == 5 && a_slice.cap == 5) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [5:] => got " + a_slice.get() + ", len=" + a_slice.len + ", cap=" + a_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [5:] => got %v, len=%v, cap=%v<br>", [g.Typed(a_slice, "[]byte"), a_slice.len, a_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	a_slice = g.SliceFrom(array, 0);
 	if (a_slice.str() == "abcdefghij" && a_slice.len == 10 && a_slice.cap == 10) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [:] => got " + a_slice.get() + ", len=" + a_slice.len + ", cap=" + a_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [:] => got %v, len=%v, cap=%v<br>", [g.Typed(a_slice, "[]byte"), a_slice.len, a_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	a_slice = g.SliceFrom(array, 3, 7);
 	if (a_slice.str() == "defg" && a_slice.len == 4 && a_slice.cap == 7) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [3:7] => got " + a_slice.get() + ", len=" + a_slice.len + ", cap=" + a_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. [3:7] => got %v, len=%v, cap=%v<br>", [g.Typed(a_slice, "[]byte"), a_slice.len, a_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 
@@ -120,31 +120,31 @@ function shortHand() {
 	if (b_slice.str() == "ef" && b_slice.len == 2 && b_slice.cap == 6) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. [1:3] => got " + b_slice.get() + ", len=" + b_slice.len + ", cap=" + b_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. [1:3] => got %v, len=%v, cap=%v<br>", [g.Typed(b_slice, "[]byte"), b_slice.len, b_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	b_slice = g.SliceFrom(a_slice, 0, 3);
 	if (b_slice.str() == "def" && b_slice.len == 3 && b_slice.cap == 7) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. [:3] => got " + b_slice.get() + ", len=" + b_slice.len + ", cap=" + b_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. [:3] => got %v, len=%v, cap=%v<br>", [g.Typed(b_slice, "[]byte"), b_slice.len, b_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	b_slice = g.SliceFrom(a_slice, 0);
 	if (b_slice.str() == "defg" && b_slice.len == 4 && b_slice.cap == 7) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. [:] => got " + b_slice.get() + ", len=" + b_slice.len + ", cap=" + b_slice.cap + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. [:] => got %v, len=%v, cap=%v<br>", [g.Typed(b_slice, "[]byte"), b_slice.len, b_slice.cap])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -153,10 +153,10 @@ function useFunc() {
 
 
 	var Max = function(slice) {
-		var max = slice.get()[0];
+		var max = slice.at(0);
 		for (var index = 1; index < slice.len; index++) {
-			if (slice.get()[index] > max) {
-				max = slice.get()[index];
+			if (slice.at(index) > max) {
+				max = slice.at(index);
 			}
 		}
 		return max;
@@ -170,99 +170,99 @@ function useFunc() {
 
 	slice = g.SliceFrom(A1, 0);
 	if (Max(slice) != 9) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A1 => got " + Max(slice) + ", want 9<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A1 => got %v, want 9<br>", [Max(slice)])));
+		pass = false; PASS = false;
 	}
 
 	slice = g.SliceFrom(A2, 0);
 	if (Max(slice) != 4) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A2 => got " + Max(slice) + ", want 4<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A2 => got %v, want 4<br>", [Max(slice)])));
+		pass = false; PASS = false;
 	}
 
 	slice = g.SliceFrom(A3, 0);
 	if (Max(slice) != 1) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A3 => got " + Max(slice) + ", want 1<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: A3 => got %v, want 1<br>", [Max(slice)])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function reference() {
 	var pass = true;
 
-	var A = g.MkArray([10], 0, ['a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j']);
+	var A = g.MkArray([10], 0, [97, 98, 99, 100, 101, 102, 103, 104, 105, 106]);
 	var slice1 = g.SliceFrom(A, 3, 7);
 	var slice2 = g.SliceFrom(A, 5);
 	var slice3 = g.SliceFrom(slice1, 0, 2);
 
 
 
-	var _ = function(msg, in_, out) { return {
+	var $T1 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; var tests = [
-		_("A", g.SliceFrom(A, 0).str(), "abcdefghij"),
-		_("slice1", slice1.str(), "defg"),
-		_("slice2", slice2.str(), "fghij"),
-		_("slice3", slice3.str(), "de")
+	}; }; var tests = [
+		$T1("A", g.SliceFrom(A, 0).str(), "abcdefghij"),
+		$T1("slice1", slice1.str(), "defg"),
+		$T1("slice2", slice2.str(), "fghij"),
+		$T1("slice3", slice3.str(), "de")
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 
 
-	A.v[4] = 'E';
+	A.v[4] = 69;
 
-	_ = function(msg, in_, out) { return {
+	$T4 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; tests = [
-		_("A", g.SliceFrom(A, 0).str(), "abcdEfghij"),
-		_("slice1", slice1.str(), "dEfg"),
-		_("slice2", slice2.str(), "fghij"),
-		_("slice3", slice3.str(), "dE")
+	}; }; tests = [
+		$T4("A", g.SliceFrom(A, 0).str(), "abcdEfghij"),
+		$T4("slice1", slice1.str(), "dEfg"),
+		$T4("slice2", slice2.str(), "fghij"),
+		$T4("slice3", slice3.str(), "dE")
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i5 = 0, $n6 = tests.len; $i5 < $n6; $i5++) { t = tests.at($i5);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 
 
-	slice2.set([1], 'G');
+	slice2.set([1], 71);
 
-	_ = function(msg, in_, out) { return {
+	$T7 = function(msg, in_, out) { return {
 		msg: msg,
 		in_: in_,
 		out: out
-	};}; tests = [
-		_("A", g.SliceFrom(A, 0).str(), "abcdEfGhij"),
-		_("slice1", slice1.str(), "dEfG"),
-		_("slice2", slice2.str(), "fGhij"),
-		_("slice3", slice3.str(), "dE")
+	}; }; tests = [
+		$T7("A", g.SliceFrom(A, 0).str(), "abcdEfGhij"),
+		$T7("slice1", slice1.str(), "dEfG"),
+		$T7("slice2", slice2.str(), "fGhij"),
+		$T7("slice3", slice3.str(), "dE")
 	];
 
-	var t; for (var _ in tests) { t = tests[_];
-		if (JSON.stringify(t.in_) != JSON.stringify(t.out)) {
-			document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. " + t.msg + " => got " + t.in_ + ", want " + t.out + "<br>");
-			pass = false, PASS = false;
+	var t; for (var $i8 = 0, $n9 = tests.len; $i8 < $n9; $i8++) { t = tests.at($i8);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
 		}
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -274,37 +274,37 @@ function resize() {
 
 	slice = g.MkSlice(0, 4, 5);
 
-	if (slice.len == 4 && slice.cap == 5 && slice.get()[0] == 0 && slice.get()[1] == 0 && slice.get()[2] == 0 && slice.get()[3] == 0) {
+	if (slice.len == 4 && slice.cap == 5 && slice.at(0) == 0 && slice.at(1) == 0 && slice.at(2) == 0 && slice.at(3) == 0) {
 
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. got " + slice.get() + ", want [0 0 0 0]<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. got %v, want [0 0 0 0]<br>", [g.Typed(slice, "[]byte")])));
+		pass = false; PASS = false;
 	}
 
 
-	slice.set([1], 2), slice.set([3], 3);
+	var tmp1 = slice; slice.set([1], 2); tmp1.set([3], 3);
 
-	if (slice.get()[0] == 0 && slice.get()[1] == 2 && slice.get()[2] == 0 && slice.get()[3] == 3) {
+	if (slice.at(0) == 0 && slice.at(1) == 2 && slice.at(2) == 0 && slice.at(3) == 3) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. got " + slice.get() + ", want [0 2 0 3]<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. got %v, want [0 2 0 3]<br>", [g.Typed(slice, "[]byte")])));
+		pass = false; PASS = false;
 	}
 
 
 	slice = g.MkSlice(0, 2);
 
-	if (slice.len == 2 && slice.cap == 2 && slice.get()[0] == 0 && slice.get()[1] == 0) {
+	if (slice.len == 2 && slice.cap == 2 && slice.at(0) == 0 && slice.at(1) == 0) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. got " + slice.get() + ", want [0 0]<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. got %v, want [0 0]<br>", [g.Typed(slice, "[]byte")])));
+		pass = false; PASS = false;
 	}
 
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
@@ -316,7 +316,7 @@ function grow() {
 		var new_capacity = slice.cap + add;
 		var new_slice = g.MkSlice(0, slice.len, new_capacity);
 		for (var index = 0; index < slice.len; index++) {
-			new_slice.set([index], slice.get()[index]);
+			new_slice.set([index], slice.at(index));
 		}
 		return new_slice;
 	};
@@ -324,23 +324,23 @@ function grow() {
 	var slice = g.Slice(0, [0, 1, 2, 3]);
 
 
-	if (slice.len == 4 && slice.cap == 4 && slice.get()[0] == 0 && slice.get()[1] == 1 && slice.get()[2] == 2 && slice.get()[3] == 3) {
+	if (slice.len == 4 && slice.cap == 4 && slice.at(0) == 0 && slice.at(1) == 1 && slice.at(2) == 2 && slice.at(3) == 3) {
 
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. got " + slice.get() + ", want [0 1 2 3]<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. got %v, want [0 1 2 3]<br>", [g.Typed(slice, "[]int")])));
+		pass = false; PASS = false;
 	}
 
 
 	slice = GrowIntSlice(slice, 3);
 
-	if (slice.len == 4 && slice.cap == 7 && slice.get()[0] == 0 && slice.get()[1] == 1 && slice.get()[2] == 2 && slice.get()[3] == 3) {
+	if (slice.len == 4 && slice.cap == 7 && slice.at(0) == 0 && slice.at(1) == 1 && slice.at(2) == 2 && slice.at(3) == 3) {
 
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. got " + slice.get() + ", want [0 1 2 3]<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. got %v, want [0 1 2 3]<br>", [g.Typed(slice, "[]int")])));
+		pass = false; PASS = false;
 	}
 
 
@@ -348,27 +348,27 @@ function grow() {
 
 
 	slice = g.SliceFrom(slice, 0, slice.len + 2);
-	slice.set([4], 4), slice.set([5], 5);
+	var tmp2 = slice; slice.set([4], 4); tmp2.set([5], 5);
 
-	if (slice.len == 6 && slice.cap == 7 && slice.get()[0] == 0 && slice.get()[1] == 1 && slice.get()[2] == 2 && slice.get()[3] == 3 && slice.get()[4] == 4 && slice.get()[5] == 5) {
+	if (slice.len == 6 && slice.cap == 7 && slice.at(0) == 0 && slice.at(1) == 1 && slice.at(2) == 2 && slice.at(3) == 3 && slice.at(4) == 4 && slice.at(5) == 5) {
 
 
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. got " + slice.get() + ", want [0 1 2 3 4 5]<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. got %v, want [0 1 2 3 4 5]<br>", [g.Typed(slice, "[]int")])));
+		pass = false; PASS = false;
 	}
 
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function _copy() {
 	var pass = true;
 
-	var a = g.MkArray([8], 0, ['0', '1', '2', '3', '4', '5', '6', '7']);
+	var a = g.MkArray([8], 0, [48, 49, 50, 51, 52, 53, 54, 55]);
 	var s = g.MkSlice(0, 6);
 	var b = g.MkSlice(0, 5);
 
@@ -376,181 +376,364 @@ function _copy() {
 	if (s.str() == "012345" && n1 == 6) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. => got " + s.str() + ", n=" + n1 + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. => got %q, n=%v<br>", [s.str(), n1])));
+		pass = false; PASS = false;
 	}
 
 	var n2 = g.Copy(s, g.SliceFrom(s, 2));
 	if (s.str() == "234545" && n2 == 4) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. => got " + s.str() + ", n=" + n2 + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. => got %q, n=%v<br>", [s.str(), n2])));
+		pass = false; PASS = false;
+	}
+
+	var n4 = g.Copy(g.SliceFrom(s, 2), s);
+	if (s.str() == "232345" && n4 == 4) {
+
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: overlap => got %q, n=%v<br>", [s.str(), n4])));
+		pass = false; PASS = false;
 	}
 
 	var n3 = g.Copy(b, "Hello, World!");
 	if (b.str() == "Hello" && n3 == 5) {
 
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. => got " + b.str() + ", n=" + n3 + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. => got %q, n=%v<br>", [b.str(), n3])));
+		pass = false; PASS = false;
+	}
+
+
+	var dst = g.MkSlice(0, 3);
+	var src = g.Slice(0, [1, 2, 3, 4]);
+	g.Copy(dst, src);
+	g.Copy(g.SliceFrom(dst, 1), g.SliceFrom(src, 3));
+	if (g.Sprint([g.Typed(dst, "[]int")], false) != "[1 4 3]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: statement => got %v<br>", [g.Typed(dst, "[]int")])));
+		pass = false; PASS = false;
 	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function _append() {
 	var pass = true;
 
-	var slice = g.Slice(0, ['1', '2', '3']);
+	var slice = g.Slice(0, [49, 50, 51]);
 	if (slice.str() == "123" && slice.len == 3) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. => got " + slice.str() + ", len=" + slice.len + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. => got %q, len=%d<br>", [slice.str(), slice.len])));
+		pass = false; PASS = false;
 	}
 
-	slice = g.Append(slice, '4');
+	slice = g.Append(slice, [52]);
 	if (slice.str() == "1234" && slice.len == 4) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. => got " + slice.str() + ", len=" + slice.len + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. => got %q, len=%d<br>", [slice.str(), slice.len])));
+		pass = false; PASS = false;
 	}
 
-	slice = g.Append(slice, '5', '6');
+	slice = g.Append(slice, [53, 54]);
 	if (slice.str() == "123456" && slice.len == 6) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. => got " + slice.str() + ", len=" + slice.len + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. => got %q, len=%d<br>", [slice.str(), slice.len])));
+		pass = false; PASS = false;
 	}
 
-	slice = g.Append(slice, '7', '8', '9');
+	slice = g.Append(slice, [55, 56, 57]);
 	if (slice.str() == "123456789" && slice.len == 9) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. => got " + slice.str() + ", len=" + slice.len + "<br>");
-		pass = false, PASS = false;
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. => got %q, len=%d<br>", [slice.str(), slice.len])));
+		pass = false; PASS = false;
 	}
 
 
 
-	var a_slice = g.Slice(0, ['1', '2', '3']);
-	var b_slice = g.Slice(0, ['7', '8', '9']);
+	var a_slice = g.Slice(0, [49, 50, 51]);
+	var b_slice = g.Slice(0, [55, 56, 57]);
 
 	a_slice = g.Append(a_slice, b_slice.get());
 	if (a_slice.str() == "123789" && a_slice.len == 6) {
 	} else {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: append a slice => got " + a_slice.str() + ", len=" + a_slice.len + "<br>");
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: append a slice => got %q, len=%d<br>", [a_slice.str(), a_slice.len])));
 
-		pass = false, PASS = false;
+		pass = false; PASS = false;
 	}
 
 
 
+	var del = function(i, slice) {
+		switch (i) {
+		case 0:
+			slice = g.SliceFrom(slice, 1); break;
+		case slice.len - 1:
+			slice = g.SliceFrom(slice, 0, slice.len - 1); break;
+		default:
+			slice = g.Append(g.SliceFrom(slice, 0, i), g.SliceFrom(slice, i + 1).get());
+		}
+		return slice;
+	};
 
+	slice = g.Slice(0, [48, 49, 50, 51, 52, 53, 54, 55, 56, 57]);
 
+	slice = del(5, slice);
+	if (slice.str() == "012346789" && slice.len == 9) {
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: delete 5th element => got %q, len=%d<br>", [slice.str(), slice.len])));
 
+		pass = false; PASS = false;
+	}
 
+	slice = del(0, slice);
+	if (slice.str() == "12346789" && slice.len == 8) {
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: delete first element => got %q, len=%d<br>", [slice.str(), slice.len])));
 
+		pass = false; PASS = false;
+	}
 
+	slice = del(slice.len - 1, slice);
+	if (slice.str() == "1234678" && slice.len == 7) {
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: delete last element => got %q, len=%d<br>", [slice.str(), slice.len])));
 
+		pass = false; PASS = false;
+	}
 
 
 
+	var simpleDel = function(i, slice) {
+		slice = g.Append(g.SliceFrom(slice, 0, i), g.SliceFrom(slice, i + 1).get());
+		return slice;
+	};
 
+	slice = simpleDel(3, slice);
+	if (slice.str() == "123678" && slice.len == 6) {
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (simple) delete 3rd element => got %q, len=%d<br>", [slice.str(), slice.len])));
 
+		pass = false; PASS = false;
+	}
 
+	slice = simpleDel(0, slice);
+	if (slice.str() == "23678" && slice.len == 5) {
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (simple) delete first element => got %q, len=%d<br>", [slice.str(), slice.len])));
 
+		pass = false; PASS = false;
+	}
 
+	slice = simpleDel(slice.len - 1, slice);
+	if (slice.str() == "2367" && slice.len == 4) {
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: (simple) delete last element => got %q, len=%d<br>", [slice.str(), slice.len])));
 
+		pass = false; PASS = false;
+	}
 
 
 
 
 
 
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
 
+function sharing() {
+	var pass = true;
 
 
+	var base = g.MkSlice(0, 3, 5);
+	var s1 = g.Append(base, [1]);
+	var s2 = g.Append(base, [2]);
 
+	if (s1.len == 4 && s1.cap == 5 && s1.at(3) == 2 && s2.at(3) == 2) {
 
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. in place => got %v %v<br>", [g.Typed(s1, "[]int"), g.Typed(s2, "[]int")])));
+		pass = false; PASS = false;
+	}
 
+	s2.set([0], 9);
+	if (base.at(0) != 9 || s1.at(0) != 9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. same array => got %v %v<br>", [g.Typed(base, "[]int"), g.Typed(s1, "[]int")])));
+		pass = false; PASS = false;
+	}
 
 
+	var s3 = g.Append(s1, [3, 4]);
+	s3.set([0], 7);
 
+	if (s3.len == 6 && s3.cap >= 6 && s1.at(0) == 9 && s3.at(0) == 7) {
 
+	} else {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. new array => got %v %v, cap=%d<br>", [g.Typed(s1, "[]int"), g.Typed(s3, "[]int"), s3.cap])));
+		pass = false; PASS = false;
+	}
 
 
+	var arr = g.MkArray([5], 0, [1, 2, 3, 4, 5]);
+	var head = g.SliceFrom(arr, 0, 2);
+	head = g.Append(head, [30]);
 
+	if (arr.v[2] != 30 || head.len != 3 || head.cap != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. array => got %v %v<br>", [g.Typed(arr, "[5]int"), g.Typed(head, "[]int")])));
+		pass = false; PASS = false;
+	}
 
 
+	var tail = g.SliceFrom(head, 1, 2);
+	tail = g.SliceFrom(tail, 0, tail.cap);
 
+	if (tail.len != 4 || tail.at(3) != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. reslice => got %v, len=%d<br>", [g.Typed(tail, "[]int"), tail.len])));
+		pass = false; PASS = false;
+	}
 
 
+	var nilSlice = g.MkSlice();
+	nilSlice = g.Append(nilSlice, "go");
+	nilSlice = g.Append(nilSlice, [33]);
 
+	if (nilSlice.str() != "go!" || nilSlice.isNil()) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 5. nil => got %q<br>", [nilSlice.str()])));
+		pass = false; PASS = false;
+	}
 
 
+	var many = g.MkSlice();
+	for (var i = 0; i < 100; i++) {
+		many = g.Append(many, [i]);
+	}
+	var sum = 0;
+	var v; for (var $i1 = 0, $n2 = many.len; $i1 < $n2; $i1++) { v = many.at($i1);
+		sum += v;
+	}
+	if (many.len != 100 || many.cap < 100 || sum != 4950) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 6. grow => got len=%d, sum=%d<br>", [many.len, sum])));
+		pass = false; PASS = false;
+	}
 
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
 
+function length(s) { return s.len; }
 
+function expression() {
+	var pass = true;
 
 
+	var arr = g.MkArray([5], 0, [1, 2, 3, 4, 5]);
+	var limited = g.SliceFrom(arr, 1, 2, 3);
 
+	if (limited.len != 1 || limited.cap != 2 || limited.at(0) != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 1. max => got %v, cap=%d<br>", [g.Typed(limited, "[]int"), limited.cap])));
+		pass = false; PASS = false;
+	}
 
 
+	limited = g.Append(limited, [30]);
+	limited = g.Append(limited, [40]);
+	limited.set([0], 20);
 
+	if (g.Sprint([g.Typed(arr, "[5]int")], false) != "[1 2 30 4 5]" || g.Sprint([g.Typed(limited, "[]int")], false) != "[20 30 40]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 2. append => got %v %v<br>", [g.Typed(arr, "[5]int"), g.Typed(limited, "[]int")])));
+		pass = false; PASS = false;
+	}
 
 
+	var s = g.SliceFrom(arr, 0);
+	var full = g.SliceFrom(s, 1, 3, 4);
+	var i = 0, j = 1, k = 2;
+	if (full.len != 2 || full.cap != 3 || g.SliceFrom(full, i, j, k).cap != 2 || g.SliceFrom(full, j).len != 1 || full.at(1) != 30) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 3. slice => got %v, cap=%d<br>", [g.Typed(full, "[]int"), full.cap])));
+		pass = false; PASS = false;
+	}
 
 
+	var p = arr;
+	g.SliceFrom(p, 1).set([0], 22);
 
+	if (g.Sprint([g.Typed(g.SliceFrom(p, 1, 3), "[]int"), g.Typed(g.SliceFrom(p, 3), "[]int")], false) != "[22 30] [4 5]" || arr.v[1] != 22) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 4. pointer => got %v<br>", [g.Typed(g.SliceFrom(p, 1, 3), "[]int")])));
+		pass = false; PASS = false;
+	}
 
 
+	var str = "h\xc3\xa9llo";
+	var lo = 1;
+	var sub = g.StrSlice(str, lo, 3); if (sub != "\xc3\xa9" || g.StrLen(sub) != 2 || g.StrSlice(str, 3) != "llo" || g.StrSlice(str, 0, 1) != "h") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 5. string => got %q<br>", [sub])));
+		pass = false; PASS = false;
+	}
 
 
+	var nilSlice = g.MkSlice();
+	if (!g.SliceFrom(nilSlice, 0, 0).isNil() || !g.SliceFrom(nilSlice, 0).isNil()) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 6. nil"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
 
 
+	var pairs = g.Slice(g.MkArray([2], 0), [g.MkArray([2], 0, [1, 1]), g.MkArray([2], 0, [2, 3])]);
+	pairs.at(0).v[1] = 9;
+	if (g.Sprint([g.Typed(pairs, "[][2]int")], false) != "[[1 9] [2 3]]" || pairs.len != 2 || pairs.at(1).len() != 2 || length(g.Slice(0, [])) != 0 || length(g.Slice(0, [1])) != 1) {
 
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 7. literal => got %v<br>", [g.Typed(pairs, "[][2]int")])));
+		pass = false; PASS = false;
+	}
 
 
-
-
-
-
-
-
-
+	var grid = g.MkSlice(g.MkArray([2], 0), 2);
+	grid.at(0).v[0] = 1;
+	grid = g.Append(grid, [g.MkArray([2], 0)]);
+	grid.at(2).v[1] = 2;
+	if (g.Sprint([g.Typed(grid, "[][2]int")], false) != "[[1 0] [0 0] [0 2]]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: 8. zero => got %v<br>", [g.Typed(grid, "[][2]int")])));
+		pass = false; PASS = false;
+	}
 
 	if (pass) {
-		document.write("&nbsp;&nbsp;&nbsp;&nbsp;pass<br>");
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
 	}
 }
 
 function main() {
-	document.write("<br><br>== Slices<br><br>");
+	document.write(g.Text(g.Sprint(["<br><br>== Slices<br><br>"], false)));
 
-	document.write("=== RUN builtIn<br>");
+	document.write(g.Text(g.Sprint(["=== RUN builtIn"], true) + "<br>"));
 	builtIn();
-	document.write("=== RUN shortHand<br>");
+	document.write(g.Text(g.Sprint(["=== RUN shortHand"], true) + "<br>"));
 	shortHand();
-	document.write("=== RUN useFunc<br>");
+	document.write(g.Text(g.Sprint(["=== RUN useFunc"], true) + "<br>"));
 	useFunc();
-	document.write("=== RUN reference<br>");
+	document.write(g.Text(g.Sprint(["=== RUN reference"], true) + "<br>"));
 	reference();
-	document.write("=== RUN resize<br>");
+	document.write(g.Text(g.Sprint(["=== RUN resize"], true) + "<br>"));
 	resize();
-	document.write("=== RUN grow<br>");
+	document.write(g.Text(g.Sprint(["=== RUN grow"], true) + "<br>"));
 	grow();
-	document.write("=== RUN copy<br>");
+	document.write(g.Text(g.Sprint(["=== RUN copy"], true) + "<br>"));
 	_copy();
-	document.write("=== RUN append<br>");
+	document.write(g.Text(g.Sprint(["=== RUN append"], true) + "<br>"));
 	_append();
+	document.write(g.Text(g.Sprint(["=== RUN sharing"], true) + "<br>"));
+	sharing();
+	document.write(g.Text(g.Sprint(["=== RUN expression"], true) + "<br>"));
+	expression();
 
 	if (PASS) {
-		document.write("PASS<br>");
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
 	} else {
-		document.write("FAIL<br>");
-		alert("Fail: Slices");
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Slices"));
 	}
 } main();
 /* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,216 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+func length() {
+	pass := true
+
+	s := "héllo, 世界"
+	if len(s) != 14 {
+		fmt.Printf("\tFAIL: len => got %v, want 14\n", len(s))
+		pass, PASS = false, false
+	}
+	if len("€") != 3 {
+		fmt.Printf("\tFAIL: len of constant => got %v, want 3\n", len("€"))
+		pass, PASS = false, false
+	}
+	if n := len("😀" + s); n != 18 {
+		fmt.Printf("\tFAIL: len of surrogates => got %v, want 18\n", n)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func index() {
+	pass := true
+
+	s := "aé"
+	if s[0] != 'a' || s[1] != 0xC3 || s[2] != 0xA9 {
+		fmt.Printf("\tFAIL: index => got %v %v %v, want 97 195 169\n", s[0], s[1], s[2])
+		pass, PASS = false, false
+	}
+
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		sum += int(s[i])
+	}
+	if sum != 461 {
+		fmt.Printf("\tFAIL: loop => got %v, want 461\n", sum)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func slicing() {
+	pass := true
+
+	s := "héllo, 世界"
+	if s[:3] != "hé" || s[3:6] != "llo" || s[8:] != "世界" || s[8:11] != "世" {
+		fmt.Printf("\tFAIL: slice => got %q %q %q %q\n", s[:3], s[3:6], s[8:], s[8:11])
+		pass, PASS = false, false
+	}
+	if t := s[1:]; len(t) != 13 || t[:2] != "é" {
+		fmt.Printf("\tFAIL: slice of slice => got %q\n", t)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+type raw []byte
+
+type text string
+
+func conversion() {
+	pass := true
+
+	s := "añ😀"
+	b := []byte(s)
+	if len(b) != 7 || b[0] != 'a' || b[1] != 0xC3 || b[3] != 0xF0 {
+		fmt.Printf("\tFAIL: []byte => got %v\n", b)
+		pass, PASS = false, false
+	}
+	if string(b) != s {
+		fmt.Printf("\tFAIL: string([]byte) => got %q, want %q\n", string(b), s)
+		pass, PASS = false, false
+	}
+
+	r := []rune(s)
+	if len(r) != 3 || r[1] != 'ñ' || r[2] != 0x1F600 {
+		fmt.Printf("\tFAIL: []rune => got %v\n", r)
+		pass, PASS = false, false
+	}
+	if string(r) != s {
+		fmt.Printf("\tFAIL: string([]rune) => got %q, want %q\n", string(r), s)
+		pass, PASS = false, false
+	}
+
+	var c rune = '世'
+	if string(c) != "世" || string(r[2]) != "😀" {
+		fmt.Printf("\tFAIL: string(rune) => got %q %q\n", string(c), string(r[2]))
+		pass, PASS = false, false
+	}
+	if x := 0xD800; string(rune(x)) != "�" {
+		fmt.Printf("\tFAIL: string(invalid rune) => got %q\n", string(rune(x)))
+		pass, PASS = false, false
+	}
+
+	// Literals, results of calls and named types.
+	if string([]byte{104, 105}) != "hi" || string([]rune{0x4e16, 'x'}) != "世x" {
+		fmt.Printf("\tFAIL: string(literal) => got %q %q\n",
+			string([]byte{104, 105}), string([]rune{0x4e16, 'x'}))
+		pass, PASS = false, false
+	}
+	nb := []byte("ab")
+	if string(append(nb, 'c', 'd')) != "abcd" || string(append(r, '!')) != s+"!" {
+		fmt.Printf("\tFAIL: string(append) => got %q %q\n",
+			string(append(nb, 'c', 'd')), string(append(r, '!')))
+		pass, PASS = false, false
+	}
+	if t := text(raw("ok")); t != "ok" || string(raw{'n', 'o'}) != "no" {
+		fmt.Printf("\tFAIL: named types => got %q %q\n", t, string(raw{'n', 'o'}))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+// The strings keep the bytes which are not valid in UTF-8.
+func invalid() {
+	pass := true
+
+	s := string([]byte{0xff, 0x41, 0xe4})
+	if len(s) != 3 || s[0] != 0xff || s[2] != 0xe4 {
+		fmt.Printf("\tFAIL: string([]byte) => got %q\n", s)
+		pass, PASS = false, false
+	}
+	if len("\xff\xfe") != 2 || "\xff\xfe"[1] != 0xfe {
+		fmt.Printf("\tFAIL: escapes => got %v\n", []byte("\xff\xfe"))
+		pass, PASS = false, false
+	}
+
+	b := []byte("é")
+	if t := string(b[:1]) + string(b[1:]); t != "é" {
+		fmt.Printf("\tFAIL: join of bytes => got %q, want \"é\"\n", t)
+		pass, PASS = false, false
+	}
+	if r := []rune("a\xffb"); len(r) != 3 || r[1] != 0xFFFD {
+		fmt.Printf("\tFAIL: []rune => got %v\n", r)
+		pass, PASS = false, false
+	}
+	if q := fmt.Sprintf("%q", "a\xffé"); q != `"a\xffé"` {
+		fmt.Printf("\tFAIL: quoted => got %s\n", q)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+// The literals keep the text which was used like marks of layout by the
+// translator.
+func literals() {
+	pass := true
+
+	s := "a<<NL>>b<<SP>>c<<TAB>>d"
+	if len(s) != 23 || s[1:7] != "<<NL>>" {
+		fmt.Printf("\tFAIL: marks => got %q\n", s)
+		pass, PASS = false, false
+	}
+	const iota_ = "<<iota>>"
+	if len(iota_) != 8 {
+		fmt.Printf("\tFAIL: iota mark => got %q\n", iota_)
+		pass, PASS = false, false
+	}
+	if x := `{ }` + " ;"; len(x) != 5 {
+		fmt.Printf("\tFAIL: spaces => got %q\n", x)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Strings\n\n")
+
+	fmt.Println("=== RUN length")
+	length()
+	fmt.Println("=== RUN index")
+	index()
+	fmt.Println("=== RUN slicing")
+	slicing()
+	fmt.Println("=== RUN conversion")
+	conversion()
+	fmt.Println("=== RUN invalid")
+	invalid()
+	fmt.Println("=== RUN literals")
+	literals()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Strings")
+	}
+}
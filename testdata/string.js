@@ -0,0 +1,217 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function length() {
+	var pass = true;
+
+	var s = "h\xc3\xa9llo, \xe4\xb8\x96\xe7\x95\x8c";
+	if (g.StrLen(s) != 14) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: len => got %v, want 14<br>", [g.StrLen(s)])));
+		pass = false; PASS = false;
+	}
+	if (3 != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: len of constant => got %v, want 3<br>", [3])));
+		pass = false; PASS = false;
+	}
+	var n = g.StrLen("\xf0\x9f\x98\x80" + s); if (n != 18) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: len of surrogates => got %v, want 18<br>", [n])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function index() {
+	var pass = true;
+
+	var s = "a\xc3\xa9";
+	if (g.StrIndex(s, 0) != 97 || g.StrIndex(s, 1) != 0xC3 || g.StrIndex(s, 2) != 0xA9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: index => got %v %v %v, want 97 195 169<br>", [g.Typed(g.StrIndex(s, 0), "byte"), g.Typed(g.StrIndex(s, 1), "byte"), g.Typed(g.StrIndex(s, 2), "byte")])));
+		pass = false; PASS = false;
+	}
+
+	var sum = 0;
+	for (var i = 0; i < g.StrLen(s); i++) {
+		sum += g.Int(g.StrIndex(s, i));
+	}
+	if (sum != 461) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: loop => got %v, want 461<br>", [sum])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function slicing() {
+	var pass = true;
+
+	var s = "h\xc3\xa9llo, \xe4\xb8\x96\xe7\x95\x8c";
+	if (g.StrSlice(s, 0, 3) != "h\xc3\xa9" || g.StrSlice(s, 3, 6) != "llo" || g.StrSlice(s, 8) != "\xe4\xb8\x96\xe7\x95\x8c" || g.StrSlice(s, 8, 11) != "\xe4\xb8\x96") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice => got %q %q %q %q<br>", [g.StrSlice(s, 0, 3), g.StrSlice(s, 3, 6), g.StrSlice(s, 8), g.StrSlice(s, 8, 11)])));
+		pass = false; PASS = false;
+	}
+	var t = g.StrSlice(s, 1); if (g.StrLen(t) != 13 || g.StrSlice(t, 0, 2) != "\xc3\xa9") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice of slice => got %q<br>", [t])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function raw(t) { this.t = t; } g.Named(raw);
+
+function text(t) { this.t = t; } g.Named(text, "string");
+
+function conversion() {
+	var pass = true;
+
+	var s = "a\xc3\xb1\xf0\x9f\x98\x80";
+	var b = g.Bytes(s);
+	if (b.len != 7 || b.at(0) != 97 || b.at(1) != 0xC3 || b.at(3) != 0xF0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: []byte => got %v<br>", [g.Typed(b, "[]byte")])));
+		pass = false; PASS = false;
+	}
+	if (!g.Equal(b.str(), s, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string([]byte) => got %q, want %q<br>", [b.str(), s])));
+		pass = false; PASS = false;
+	}
+
+	var r = g.Runes(s);
+	if (r.len != 3 || r.at(1) != 241 || r.at(2) != 0x1F600) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: []rune => got %v<br>", [g.Typed(r, "[]rune")])));
+		pass = false; PASS = false;
+	}
+	if (!g.Equal(r.str(true), s, 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string([]rune) => got %q, want %q<br>", [r.str(true), s])));
+		pass = false; PASS = false;
+	}
+
+	var c = g.Rune(19990);
+	if (g.RuneStr(c) != "\xe4\xb8\x96" || g.RuneStr(r.at(2)) != "\xf0\x9f\x98\x80") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string(rune) => got %q %q<br>", [g.RuneStr(c), g.RuneStr(r.at(2))])));
+		pass = false; PASS = false;
+	}
+	var x = 0xD800; if (g.RuneStr(g.Rune(x)) != "\xef\xbf\xbd") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string(invalid rune) => got %q<br>", [g.RuneStr(g.Rune(x))])));
+		pass = false; PASS = false;
+	}
+
+
+	if (g.Slice(0, [104, 105]).str() != "hi" || g.Slice(0, [0x4e16, 120]).str(true) != "\xe4\xb8\x96x") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string(literal) => got %q %q<br>", [g.Slice(0, [104, 105]).str(), g.Slice(0, [0x4e16, 120]).str(true)])));
+
+		pass = false; PASS = false;
+	}
+	var nb = g.Bytes("ab");
+	if (g.Append(nb, [99, 100]).str() != "abcd" || !g.Equal(g.Append(r, [33]).str(true), s + "!", 0)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string(append) => got %q %q<br>", [g.Append(nb, [99, 100]).str(), g.Append(r, [33]).str(true)])));
+
+		pass = false; PASS = false;
+	}
+	var t = g.Bytes("ok").str(); if (t != "ok" || g.Slice(0, [110, 111]).str() != "no") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: named types => got %q %q<br>", [g.Typed(t, "main.text", "string"), g.Slice(0, [110, 111]).str()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+
+function invalid() {
+	var pass = true;
+
+	var s = g.Slice(0, [0xff, 0x41, 0xe4]).str();
+	if (g.StrLen(s) != 3 || g.StrIndex(s, 0) != 0xff || g.StrIndex(s, 2) != 0xe4) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: string([]byte) => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+	if (2 != 2 || g.StrIndex("\xff\xfe", 1) != 0xfe) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: escapes => got %v<br>", [g.Typed(g.Bytes("\xff\xfe"), "[]byte")])));
+		pass = false; PASS = false;
+	}
+
+	var b = g.Bytes("\xc3\xa9");
+	var t = g.SliceFrom(b, 0, 1).str() + g.SliceFrom(b, 1).str(); if (t != "\xc3\xa9") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: join of bytes => got %q, want \"\xc3\xa9\"<br>", [t])));
+		pass = false; PASS = false;
+	}
+	var r = g.Runes("a\xffb"); if (r.len != 3 || r.at(1) != 0xFFFD) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: []rune => got %v<br>", [g.Typed(r, "[]rune")])));
+		pass = false; PASS = false;
+	}
+	var q = g.Sprintf("%q", ["a\xff\xc3\xa9"]); if (q != "\"a\\xff\xc3\xa9\"") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: quoted => got %s<br>", [q])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+
+
+function literals() {
+	var pass = true;
+
+	var s = "a<<NL>>b<<SP>>c<<TAB>>d";
+	if (g.StrLen(s) != 23 || g.StrSlice(s, 1, 7) != "<<NL>>") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: marks => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+	const iota_ = "<<iota>>";
+	if (8 != 8) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: iota mark => got %q<br>", [iota_])));
+		pass = false; PASS = false;
+	}
+	var x = "{ }" + " ;"; if (g.StrLen(x) != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: spaces => got %q<br>", [x])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Strings<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN length"], true) + "<br>"));
+	length();
+	document.write(g.Text(g.Sprint(["=== RUN index"], true) + "<br>"));
+	index();
+	document.write(g.Text(g.Sprint(["=== RUN slicing"], true) + "<br>"));
+	slicing();
+	document.write(g.Text(g.Sprint(["=== RUN conversion"], true) + "<br>"));
+	conversion();
+	document.write(g.Text(g.Sprint(["=== RUN invalid"], true) + "<br>"));
+	invalid();
+	document.write(g.Text(g.Sprint(["=== RUN literals"], true) + "<br>"));
+	literals();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Strings"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,182 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var PASS = true
+
+func search() {
+	pass := true
+
+	s := "héllo, wörld"
+	tests := []struct {
+		msg string
+		in  bool
+		out bool
+	}{
+		{"Contains", strings.Contains(s, "wö"), true},
+		{"Contains empty", strings.Contains(s, ""), true},
+		{"HasPrefix", strings.HasPrefix(s, "hé"), true},
+		{"HasSuffix", strings.HasSuffix(s, "rld"), true},
+		{"EqualFold", strings.EqualFold("Héllo", "hÉLLO"), true},
+		{"EqualFold distinct", strings.EqualFold("Go", "Goo"), false},
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %v, want %v\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+
+	// The indexes are in bytes.
+	if i := strings.Index(s, "l"); i != 3 || s[i:i+1] != "l" {
+		fmt.Printf("\tFAIL: Index => got %d\n", i)
+		pass, PASS = false, false
+	}
+	if i := strings.Index(s, "wö"); i != 8 || s[i:] != "wörld" {
+		fmt.Printf("\tFAIL: Index after => got %d\n", i)
+		pass, PASS = false, false
+	}
+	if strings.Index(s, "z") != -1 {
+		fmt.Println("\tFAIL: Index not found")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func split() {
+	pass := true
+
+	parts := strings.Split("a,b,,c", ",")
+	if len(parts) != 4 || parts[2] != "" || fmt.Sprintf("%q", parts) != `["a" "b" "" "c"]` {
+		fmt.Printf("\tFAIL: Split => got %q\n", parts)
+		pass, PASS = false, false
+	}
+	if runes := strings.Split("héy", ""); len(runes) != 3 || runes[1] != "é" {
+		fmt.Printf("\tFAIL: Split runes => got %q\n", runes)
+		pass, PASS = false, false
+	}
+
+	parts = append(parts, "d")
+	if s := strings.Join(parts, "-"); s != "a-b--c-d" {
+		fmt.Printf("\tFAIL: Join => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	// The white spaces "\t" and "\n" are not used since they are changed to
+	// HTML in the tests.
+	fields := strings.Fields("  one \v two\rthree  ")
+	for i, f := range fields {
+		fields[i] = strings.ToUpper(f)
+	}
+	if fmt.Sprint(fields) != "[ONE TWO THREE]" || len(strings.Fields(" \f ")) != 0 {
+		fmt.Printf("\tFAIL: Fields => got %q\n", fields)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func transform() {
+	pass := true
+
+	tests := []struct {
+		msg string
+		in  string
+		out string
+	}{
+		{"TrimSpace", strings.TrimSpace(" \r héllo \f"), "héllo"},
+		{"Replace", strings.Replace("oink oink oink", "k", "ky", 2), "oinky oinky oink"},
+		{"Replace all", strings.Replace("oink oink oink", "oink", "moo", -1), "moo moo moo"},
+		{"Replace empty", strings.Replace("hé", "", "-", -1), "-h-é-"},
+		{"ReplaceAll", strings.ReplaceAll("a.b.c", ".", "/"), "a/b/c"},
+		{"ToUpper", strings.ToUpper("héllo"), "HÉLLO"},
+		{"ToLower", strings.ToLower("HÉLLO"), "héllo"},
+		{"Repeat", strings.Repeat("ab", 3), "ababab"},
+	}
+
+	for _, t := range tests {
+		if t.in != t.out {
+			fmt.Printf("\tFAIL: %s => got %q, want %q\n", t.msg, t.in, t.out)
+			pass, PASS = false, false
+		}
+	}
+
+	r := strings.NewReplacer("<", "&lt;", ">", "&gt;")
+	if s := r.Replace("<b>hé</b>"); s != "&lt;b&gt;hé&lt;/b&gt;" {
+		fmt.Printf("\tFAIL: Replacer => got %q\n", s)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func builder() {
+	pass := true
+
+	var b strings.Builder
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(&b, "%d...", 3-i)
+	}
+	b.WriteString("ignición")
+	b.WriteByte('!')
+	b.WriteRune('🚀')
+
+	if s := b.String(); s != "3...2...1...ignición!🚀" || b.Len() != len(s) {
+		fmt.Printf("\tFAIL: Builder => got %q, len=%d\n", s, b.Len())
+		pass, PASS = false, false
+	}
+
+	b.Reset()
+	if n, _ := b.WriteString("é"); n != 2 || b.String() != "é" {
+		fmt.Printf("\tFAIL: Reset => got %q, n=%d\n", b.String(), n)
+		pass, PASS = false, false
+	}
+
+	pb := &strings.Builder{}
+	pb.WriteString("go")
+	if pb.String() != "go" {
+		fmt.Printf("\tFAIL: pointer => got %q\n", pb.String())
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Package strings\n\n")
+
+	fmt.Println("=== RUN search")
+	search()
+	fmt.Println("=== RUN split")
+	split()
+	fmt.Println("=== RUN transform")
+	transform()
+	fmt.Println("=== RUN builder")
+	builder()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Package strings")
+	}
+}
@@ -0,0 +1,183 @@
+
+
+
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function search() {
+	var pass = true;
+
+	var s = "h\xc3\xa9llo, w\xc3\xb6rld";
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("Contains", g.Contains(s, "w\xc3\xb6"), true),
+		$T1("Contains empty", g.Contains(s, ""), true),
+		$T1("HasPrefix", g.HasPrefix(s, "h\xc3\xa9"), true),
+		$T1("HasSuffix", g.HasSuffix(s, "rld"), true),
+		$T1("EqualFold", g.EqualFold("H\xc3\xa9llo", "h\xc3\x89LLO"), true),
+		$T1("EqualFold distinct", g.EqualFold("Go", "Goo"), false)
+	];
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %v, want %v<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+
+
+	var i = g.Index(s, "l"); if (i != 3 || g.StrSlice(s, i, i + 1) != "l") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Index => got %d<br>", [i])));
+		pass = false; PASS = false;
+	}
+	var i = g.Index(s, "w\xc3\xb6"); if (i != 8 || g.StrSlice(s, i) != "w\xc3\xb6rld") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Index after => got %d<br>", [i])));
+		pass = false; PASS = false;
+	}
+	if (g.Index(s, "z") != -1) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Index not found"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function split() {
+	var pass = true;
+
+	var parts = g.Split("a,b,,c", ",");
+	if (parts.len != 4 || parts.at(2) != "" || g.Sprintf("%q", [g.Typed(parts, "[]string")]) != "[\"a\" \"b\" \"\" \"c\"]") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Split => got %q<br>", [g.Typed(parts, "[]string")])));
+		pass = false; PASS = false;
+	}
+	var runes = g.Split("h\xc3\xa9y", ""); if (runes.len != 3 || runes.at(1) != "\xc3\xa9") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Split runes => got %q<br>", [g.Typed(runes, "[]string")])));
+		pass = false; PASS = false;
+	}
+
+	parts = g.Append(parts, ["d"]);
+	var s = g.Join(parts, "-"); if (s != "a-b--c-d") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Join => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+
+
+	var fields = g.Fields("  one \x0b two\x0dthree  ");
+	var f; for (var i = 0, $n1 = fields.len; i < $n1; i++) { f = fields.at(i);
+		fields.set([i], g.ToUpper(f));
+	}
+	if (g.Sprint([g.Typed(fields, "[]string")], false) != "[ONE TWO THREE]" || g.Fields(" \x0c ").len != 0) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Fields => got %q<br>", [g.Typed(fields, "[]string")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function transform() {
+	var pass = true;
+
+	var $T1 = function(msg, in_, out) { return {
+		msg: msg,
+		in_: in_,
+		out: out
+	}; }; var tests = [
+		$T1("TrimSpace", g.TrimSpace(" \x0d h\xc3\xa9llo \x0c"), "h\xc3\xa9llo"),
+		$T1("Replace", g.Replace("oink oink oink", "k", "ky", 2), "oinky oinky oink"),
+		$T1("Replace all", g.Replace("oink oink oink", "oink", "moo", -1), "moo moo moo"),
+		$T1("Replace empty", g.Replace("h\xc3\xa9", "", "-", -1), "-h-\xc3\xa9-"),
+		$T1("ReplaceAll", g.ReplaceAll("a.b.c", ".", "/"), "a/b/c"),
+		$T1("ToUpper", g.ToUpper("h\xc3\xa9llo"), "H\xc3\x89LLO"),
+		$T1("ToLower", g.ToLower("H\xc3\x89LLO"), "h\xc3\xa9llo"),
+		$T1("Repeat", g.Repeat("ab", 3), "ababab")
+	];
+
+	var t; for (var $i2 = 0, $n3 = tests.len; $i2 < $n3; $i2++) { t = tests.at($i2);
+		if (!g.Equal(t.in_, t.out, 0)) {
+			document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: %s => got %q, want %q<br>", [t.msg, t.in_, t.out])));
+			pass = false; PASS = false;
+		}
+	}
+
+	var r = g.NewReplacer(g.SliceArgs(["<", "&lt;", ">", "&gt;"]));
+	var s = r.Replace("<b>h\xc3\xa9</b>"); if (s != "&lt;b&gt;h\xc3\xa9&lt;/b&gt;") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Replacer => got %q<br>", [s])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function builder() {
+	var pass = true;
+
+	var b = new g.Builder("");
+	for (var i = 0; i < 3; i++) {
+		g.Fprint(b, g.Sprintf("%d...", [3 - i]));
+	}
+	b.WriteString("ignici\xc3\xb3n");
+	b.WriteByte(33);
+	b.WriteRune(128640);
+
+	var s = b.String(); if (s != "3...2...1...ignici\xc3\xb3n!\xf0\x9f\x9a\x80" || b.Len() != g.StrLen(s)) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Builder => got %q, len=%d<br>", [s, b.Len()])));
+		pass = false; PASS = false;
+	}
+
+	b.Reset();
+	var n = b.WriteString("\xc3\xa9")[0]; if (n != 2 || b.String() != "\xc3\xa9") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: Reset => got %q, n=%d<br>", [b.String(), n])));
+		pass = false; PASS = false;
+	}
+
+	var pb = new g.Builder("");
+	pb.WriteString("go");
+	if (pb.String() != "go") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: pointer => got %q<br>", [pb.String()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Package strings<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN search"], true) + "<br>"));
+	search();
+	document.write(g.Text(g.Sprint(["=== RUN split"], true) + "<br>"));
+	split();
+	document.write(g.Text(g.Sprint(["=== RUN transform"], true) + "<br>"));
+	transform();
+	document.write(g.Text(g.Sprint(["=== RUN builder"], true) + "<br>"));
+	builder();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Package strings"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,229 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+var calls = ""
+
+func call(s string, v int) int {
+	calls += s
+	return v
+}
+
+type point struct {
+	x, y int
+}
+
+func initTag() {
+	pass := true
+
+	x := 1
+	switch x := call("a", 2); x {
+	case 1:
+		fmt.Println("\tFAIL: init and tag (1)")
+		pass, PASS = false, false
+	case 2:
+		// ok
+	}
+	if x != 1 {
+		fmt.Printf("\tFAIL: scope of init => got %v, want 1\n", x)
+		pass, PASS = false, false
+	}
+
+	switch y := x * 10; {
+	case y > 5:
+		// ok
+	default:
+		fmt.Println("\tFAIL: init without tag")
+		pass, PASS = false, false
+	}
+
+	p := point{1, 0}
+	switch p := (point{2, 0}); p.x {
+	case 2:
+		// ok
+	default:
+		fmt.Printf("\tFAIL: selector of init => got %v, want 2\n", p.x)
+		pass, PASS = false, false
+	}
+	switch p.x {
+	case 1:
+		x := 3
+		p := point{x, 0}
+		_ = p
+	}
+	if x != 1 || p.x != 1 {
+		fmt.Printf("\tFAIL: scope of clause => got %v, %v, want 1, 1\n", x, p.x)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func cases() {
+	pass := true
+
+	calls = ""
+	switch call("t", 3) {
+	case call("a", 1), call("b", 2):
+		fmt.Println("\tFAIL: case list (1, 2)")
+		pass, PASS = false, false
+	case call("c", 3), call("d", 4):
+		// ok
+	case call("e", 5):
+		fmt.Println("\tFAIL: case list (5)")
+		pass, PASS = false, false
+	}
+	if calls != "tabc" {
+		fmt.Printf("\tFAIL: lazy evaluation => got %q, want %q\n", calls, "tabc")
+		pass, PASS = false, false
+	}
+
+	s := ""
+	for i := 0; i < 4; i++ {
+		switch i {
+		default:
+			s += "d"
+		case 1:
+			s += "1"
+			fallthrough
+		case 2:
+			s += "2"
+		}
+	}
+	if s != "d122d" {
+		fmt.Printf("\tFAIL: default above => got %q, want %q\n", s, "d122d")
+		pass, PASS = false, false
+	}
+
+	s = ""
+	switch 5 {
+	case 5:
+		s += "5"
+		fallthrough
+	default:
+		s += "d"
+		fallthrough
+	case 6:
+		s += "6"
+	}
+	if s != "5d6" {
+		fmt.Printf("\tFAIL: fallthrough default => got %q, want %q\n", s, "5d6")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func branch() {
+	pass := true
+
+	n := 0
+	for i := 0; i < 5; i++ {
+		switch {
+		case i%2 == 0:
+			if i == 4 {
+				break
+			}
+			n += 10
+		default:
+			continue
+		}
+		n++
+	}
+	if n != 23 {
+		fmt.Printf("\tFAIL: break and continue => got %v, want 23\n", n)
+		pass, PASS = false, false
+	}
+
+	s := ""
+	switch 1 {
+	case 1:
+		switch 2 {
+		case 1:
+			s += "a"
+		case 2:
+			s += "b"
+		}
+		s += "c"
+	case 2:
+		s += "d"
+	}
+	if s != "bc" {
+		fmt.Printf("\tFAIL: nested => got %q, want %q\n", s, "bc")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func equality() {
+	pass := true
+
+	p := point{1, 2}
+	switch p {
+	case point{2, 1}:
+		fmt.Println("\tFAIL: struct tag (2, 1)")
+		pass, PASS = false, false
+	case point{1, 2}:
+		// ok
+	default:
+		fmt.Println("\tFAIL: struct tag (default)")
+		pass, PASS = false, false
+	}
+
+	a := [2]string{"a", "b"}
+	switch a {
+	case [2]string{"a", "b"}:
+		// ok
+	default:
+		fmt.Println("\tFAIL: array tag")
+		pass, PASS = false, false
+	}
+
+	var v interface{} = "x"
+	switch v {
+	case 1:
+		fmt.Println("\tFAIL: interface tag (1)")
+		pass, PASS = false, false
+	case "x":
+		// ok
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Switch\n\n")
+
+	fmt.Println("=== RUN initTag")
+	initTag()
+	fmt.Println("=== RUN cases")
+	cases()
+	fmt.Println("=== RUN branch")
+	branch()
+	fmt.Println("=== RUN equality")
+	equality()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Switch")
+	}
+}
@@ -0,0 +1,230 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+var calls = "";
+
+function call(s, v) {
+	calls += s;
+	return v;
+}
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function initTag() {
+	var pass = true;
+
+	var x = 1;
+	var x$ = call("a", 2); switch (x$) {
+	case 1:
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: init and tag (1)"], true) + "<br>"));
+		pass = false; PASS = false; break;
+	case 2:
+
+	}
+	if (x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: scope of init => got %v, want 1<br>", [x])));
+		pass = false; PASS = false;
+	}
+
+	var y = x * 10; switch (true) {
+	case y > 5: break;
+
+	default:
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: init without tag"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var p = new point(1, 0);
+	var p$ = (new point(2, 0)); switch (p$.x) {
+	case 2: break;
+
+	default:
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: selector of init => got %v, want 2<br>", [p$.x])));
+		pass = false; PASS = false;
+	}
+	switch (p.x) {
+	case 1:
+		var x$1 = 3;
+		var p$1 = new point(x$1, 0);
+
+	}
+	if (x != 1 || p.x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: scope of clause => got %v, %v, want 1, 1<br>", [x, p.x])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function cases() {
+	var pass = true;
+
+	calls = "";
+	switch (call("t", 3)) {
+	case call("a", 1): case call("b", 2):
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: case list (1, 2)"], true) + "<br>"));
+		pass = false; PASS = false; break;
+	case call("c", 3): case call("d", 4): break;
+
+	case call("e", 5):
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: case list (5)"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+	if (calls != "tabc") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: lazy evaluation => got %q, want %q<br>", [calls, "tabc"])));
+		pass = false; PASS = false;
+	}
+
+	var s = "";
+	for (var i = 0; i < 4; i++) {
+		switch (i) {
+		default:
+			s += "d"; break;
+		case 1:
+			s += "1";
+
+		case 2:
+			s += "2";
+		}
+	}
+	if (s != "d122d") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: default above => got %q, want %q<br>", [s, "d122d"])));
+		pass = false; PASS = false;
+	}
+
+	s = "";
+	switch (5) {
+	case 5:
+		s += "5";
+
+	default:
+		s += "d";
+
+	case 6:
+		s += "6";
+	}
+	if (s != "5d6") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: fallthrough default => got %q, want %q<br>", [s, "5d6"])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function branch() {
+	var pass = true;
+
+	var n = 0;
+	for (var i = 0; i < 5; i++) {
+		switch (true) {
+		case i % 2 == 0:
+			if (i == 4) {
+				break;
+			}
+			n += 10; break;
+		default:
+			continue;
+		}
+		n++;
+	}
+	if (n != 23) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: break and continue => got %v, want 23<br>", [n])));
+		pass = false; PASS = false;
+	}
+
+	var s = "";
+	switch (1) {
+	case 1:
+		switch (2) {
+		case 1:
+			s += "a"; break;
+		case 2:
+			s += "b";
+		}
+		s += "c"; break;
+	case 2:
+		s += "d";
+	}
+	if (s != "bc") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: nested => got %q, want %q<br>", [s, "bc"])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function equality() {
+	var pass = true;
+
+	var p = new point(1, 2);
+	var $s1 = p; switch (true) {
+	case g.Equal($s1, new point(2, 1), {x: 0, y: 0}):
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: struct tag (2, 1)"], true) + "<br>"));
+		pass = false; PASS = false; break;
+	case g.Equal($s1, new point(1, 2), {x: 0, y: 0}): break;
+
+	default:
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: struct tag (default)"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var a = g.MkArray([2], "", ["a", "b"]);
+	var $s2 = a.v; switch (true) {
+	case g.Equal($s2, g.MkArray([2], "", ["a", "b"]), [0]): break;
+
+	default:
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: array tag"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	var v = "x";
+	var $s3 = v; switch (true) {
+	case g.Equal($s3, g.Typed(1, "int"), 1):
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface tag (1)"], true) + "<br>"));
+		pass = false; PASS = false; break;
+	case g.Equal($s3, "x", 1):
+
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Switch<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN initTag"], true) + "<br>"));
+	initTag();
+	document.write(g.Text(g.Sprint(["=== RUN cases"], true) + "<br>"));
+	cases();
+	document.write(g.Text(g.Sprint(["=== RUN branch"], true) + "<br>"));
+	branch();
+	document.write(g.Text(g.Sprint(["=== RUN equality"], true) + "<br>"));
+	equality();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Switch"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,265 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+type point struct {
+	x, y int
+}
+
+type line struct {
+	from, to point
+	tags     [2]string
+}
+
+func (p point) moved(dx int) point {
+	p.x += dx
+	return p
+}
+
+func (p point) sum() int { return p.x + p.y }
+
+func reset(p point) point {
+	p.x = 0
+	return p
+}
+
+func fill(a [3]int) int {
+	a[0] = 9
+	return a[0]
+}
+
+func keep(p point) func() int {
+	return func() int { return p.x }
+}
+
+var origin point
+
+func getOrigin() point { return origin }
+
+func same(p point) point { return p }
+
+func pair(a [2]int) ([2]int, int) { return a, a[0] }
+
+func pair2(a [2]int) int { return a[0] + a[1] }
+
+func assignment() {
+	pass := true
+
+	a := [3]int{1, 2, 3}
+	b := a
+	b[0] = 10
+
+	p := point{1, 2}
+	q := p
+	q.x = 10
+
+	var r point
+	r = p
+	r.y = 20
+
+	l1 := line{p, q, [2]string{"a", "b"}}
+	l2 := l1
+	l2.from.x = 5
+	l2.tags[0] = "z"
+
+	origin = p
+	origin.x = 7
+
+	if a[0] != 1 || b[0] != 10 {
+		fmt.Printf("\tFAIL: array => got %v, %v\n", a, b)
+		pass, PASS = false, false
+	}
+	if p.x != 1 || q.x != 10 || p.y != 2 || r.y != 20 {
+		fmt.Printf("\tFAIL: struct => got %v, %v, %v\n", p, q, r)
+		pass, PASS = false, false
+	}
+	if l1.from.x != 1 || l1.tags[0] != "a" || l2.from.x != 5 || l2.tags[0] != "z" {
+		fmt.Printf("\tFAIL: nested => got %v, %v\n", l1, l2)
+		pass, PASS = false, false
+	}
+	if p.x != 1 || origin.x != 7 {
+		fmt.Printf("\tFAIL: global => got %v, %v\n", p, origin)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func call() {
+	pass := true
+
+	p := point{1, 2}
+	a := [3]int{1, 2, 3}
+
+	if got := reset(p); got.x != 0 || p.x != 1 {
+		fmt.Printf("\tFAIL: parameter => got %v, %v\n", got, p)
+		pass, PASS = false, false
+	}
+	if got := fill(a); got != 9 || a[0] != 1 {
+		fmt.Printf("\tFAIL: array parameter => got %v, %v\n", got, a)
+		pass, PASS = false, false
+	}
+	if got := p.moved(5); got.x != 6 || p.x != 1 {
+		fmt.Printf("\tFAIL: receiver => got %v, %v\n", got, p)
+		pass, PASS = false, false
+	}
+	if got := p.sum(); got != 3 {
+		fmt.Printf("\tFAIL: sum => got %v\n", got)
+		pass, PASS = false, false
+	}
+
+	o := getOrigin()
+	o.x = 50
+	if origin.x == 50 {
+		fmt.Printf("\tFAIL: global result => got %v\n", origin)
+		pass, PASS = false, false
+	}
+	q := same(p)
+	q.x = 50
+	if p.x != 1 {
+		fmt.Printf("\tFAIL: parameter result => got %v\n", p)
+		pass, PASS = false, false
+	}
+	a2 := [2]int{1, 2}
+	b2, _ := pair(a2)
+	b2[0] = 50
+	if a2[0] != 1 {
+		fmt.Printf("\tFAIL: multiple results => got %v\n", a2)
+		pass, PASS = false, false
+	}
+
+	f := keep(p)
+	p.x = 100
+	if got := f(); got != 1 {
+		fmt.Printf("\tFAIL: captured => got %v\n", got)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func container() {
+	pass := true
+
+	p := point{1, 2}
+	s := []point{p}
+	p.x = 5
+
+	m := map[string]point{"p": p}
+	p.x = 6
+
+	ps := [2]point{p, p}
+	ps[0].x = 7
+
+	s = append(s, p)
+	p.y = 8
+
+	if s[0].x != 1 || s[1].y != 2 {
+		fmt.Printf("\tFAIL: slice => got %v\n", s)
+		pass, PASS = false, false
+	}
+	if m["p"].x != 5 {
+		fmt.Printf("\tFAIL: map => got %v\n", m)
+		pass, PASS = false, false
+	}
+	if ps[1].x != 6 || p.x != 6 {
+		fmt.Printf("\tFAIL: array => got %v, %v\n", ps, p)
+		pass, PASS = false, false
+	}
+
+	e := s[0]
+	e.x = 9
+	v := m["p"]
+	v.x = 9
+	if s[0].x != 1 || m["p"].x != 5 {
+		fmt.Printf("\tFAIL: element => got %v, %v\n", s, m)
+		pass, PASS = false, false
+	}
+
+	// The rows of a multi-dimensional array are arrays.
+	grid := [2][2]int{{1, 2}, {3, 4}}
+	row := grid[0]
+	row[0] = 100
+	if grid[0][0] != 1 || row[1] != 2 || len(row) != 2 || pair2(grid[1]) != 7 {
+		fmt.Printf("\tFAIL: row => got %v, %v\n", grid, row)
+		pass, PASS = false, false
+	}
+	sum := 0
+	for _, r := range grid {
+		sum += r[0] * r[1]
+	}
+	if sum != 14 {
+		fmt.Printf("\tFAIL: range of rows => got %v, want 14\n", sum)
+		pass, PASS = false, false
+	}
+	pr := &grid[1]
+	grid[1] = [2]int{7, 8}
+	pr[1] = 9
+	if grid[1][0] != 7 || grid[1][1] != 9 || grid != [2][2]int{{1, 2}, {7, 9}} {
+		fmt.Printf("\tFAIL: row assigned => got %v\n", grid)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func ranged() {
+	pass := true
+
+	ps := []point{{1, 2}, {3, 4}}
+	for _, p := range ps {
+		p.x = 0
+	}
+	if ps[0].x != 1 || ps[1].x != 3 {
+		fmt.Printf("\tFAIL: range => got %v\n", ps)
+		pass, PASS = false, false
+	}
+
+	var fs []func() int
+	for _, p := range ps {
+		fs = append(fs, func() int { return p.x })
+	}
+	ps[0].x = 10
+	if fs[0]() != 1 {
+		fmt.Printf("\tFAIL: captured => got %v\n", fs[0]())
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Value semantics\n\n")
+
+	fmt.Println("=== RUN assignment")
+	assignment()
+	fmt.Println("=== RUN call")
+	call()
+	fmt.Println("=== RUN container")
+	container()
+	fmt.Println("=== RUN ranged")
+	ranged()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Value semantics")
+	}
+}
@@ -0,0 +1,266 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function line(from, to, tags) {
+	this.from = from; this.to = to;
+	this.tags = tags;
+}
+
+point.prototype.moved = function(dx) { var p = g.Clone(this, {x: 0, y: 0});
+	p.x += dx;
+	return p;
+};
+
+point.prototype.sum = function() { return this.x + this.y; };
+
+function reset(p) { p = g.Clone(p, {x: 0, y: 0});
+	p.x = 0;
+	return p;
+}
+
+function fill(a) { a = g.Clone(a, [0]);
+	a.v[0] = 9;
+	return a.v[0];
+}
+
+function keep(p) { p = g.Clone(p, {x: 0, y: 0});
+	return function() { return p.x; };
+}
+
+var origin = new point(0, 0);
+
+function getOrigin() { return g.Clone(origin, {x: 0, y: 0}); }
+
+function same(p) { return g.Clone(p, {x: 0, y: 0}); }
+
+function pair(a) { return [g.Clone(a, [0]), a.v[0]]; }
+
+function pair2(a) { return a.v[0] + a.v[1]; }
+
+function assignment() {
+	var pass = true;
+
+	var a = g.MkArray([3], 0, [1, 2, 3]);
+	var b = g.Clone(a, [0]);
+	b.v[0] = 10;
+
+	var p = new point(1, 2);
+	var q = g.Clone(p, {x: 0, y: 0});
+	q.x = 10;
+
+	var r = new point(0, 0);
+	r = g.Clone(p, {x: 0, y: 0});
+	r.y = 20;
+
+	var l1 = new line(g.Clone(p, {x: 0, y: 0}), g.Clone(q, {x: 0, y: 0}), g.MkArray([2], "", ["a", "b"]));
+	var l2 = g.Clone(l1, {from: {x: 0, y: 0}, to: {x: 0, y: 0}, tags: [0]});
+	l2.from.x = 5;
+	l2.tags.v[0] = "z";
+
+	origin = g.Clone(p, {x: 0, y: 0});
+	origin.x = 7;
+
+	if (a.v[0] != 1 || b.v[0] != 10) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: array => got %v, %v<br>", [g.Typed(a, "[3]int"), g.Typed(b, "[3]int")])));
+		pass = false; PASS = false;
+	}
+	if (p.x != 1 || q.x != 10 || p.y != 2 || r.y != 20) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: struct => got %v, %v, %v<br>", [g.Typed(p, "main.point"), g.Typed(q, "main.point"), g.Typed(r, "main.point")])));
+		pass = false; PASS = false;
+	}
+	if (l1.from.x != 1 || l1.tags.v[0] != "a" || l2.from.x != 5 || l2.tags.v[0] != "z") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: nested => got %v, %v<br>", [g.Typed(l1, "main.line"), g.Typed(l2, "main.line")])));
+		pass = false; PASS = false;
+	}
+	if (p.x != 1 || origin.x != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: global => got %v, %v<br>", [g.Typed(p, "main.point"), g.Typed(origin, "main.point")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function call() {
+	var pass = true;
+
+	var p = new point(1, 2);
+	var a = g.MkArray([3], 0, [1, 2, 3]);
+
+	var got = reset(p); if (got.x != 0 || p.x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: parameter => got %v, %v<br>", [g.Typed(got, "main.point"), g.Typed(p, "main.point")])));
+		pass = false; PASS = false;
+	}
+	var got = fill(a); if (got != 9 || a.v[0] != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: array parameter => got %v, %v<br>", [got, g.Typed(a, "[3]int")])));
+		pass = false; PASS = false;
+	}
+	var got = p.moved(5); if (got.x != 6 || p.x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: receiver => got %v, %v<br>", [g.Typed(got, "main.point"), g.Typed(p, "main.point")])));
+		pass = false; PASS = false;
+	}
+	var got = p.sum(); if (got != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sum => got %v<br>", [got])));
+		pass = false; PASS = false;
+	}
+
+	var o = getOrigin();
+	o.x = 50;
+	if (origin.x == 50) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: global result => got %v<br>", [g.Typed(origin, "main.point")])));
+		pass = false; PASS = false;
+	}
+	var q = same(p);
+	q.x = 50;
+	if (p.x != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: parameter result => got %v<br>", [g.Typed(p, "main.point")])));
+		pass = false; PASS = false;
+	}
+	var a2 = g.MkArray([2], 0, [1, 2]);
+	var b2 = pair(a2)[0];
+	b2.v[0] = 50;
+	if (a2.v[0] != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple results => got %v<br>", [g.Typed(a2, "[2]int")])));
+		pass = false; PASS = false;
+	}
+
+	var f = keep(p);
+	p.x = 100;
+	var got = f(); if (got != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: captured => got %v<br>", [got])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function container() {
+	var pass = true;
+
+	var p = new point(1, 2);
+	var s = g.Slice(new point(0, 0), [g.Clone(p, {x: 0, y: 0})]);
+	p.x = 5;
+
+	var m = g.MkMap(new point(0, 0), [["p", g.Clone(p, {x: 0, y: 0})]]);
+	p.x = 6;
+
+	var ps = g.MkArray([2], new point(0, 0), [g.Clone(p, {x: 0, y: 0}), g.Clone(p, {x: 0, y: 0})]);
+	ps.v[0].x = 7;
+
+	s = g.Append(s, [g.Clone(p, {x: 0, y: 0})]);
+	p.y = 8;
+
+	if (s.at(0).x != 1 || s.at(1).y != 2) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: slice => got %v<br>", [g.Typed(s, "[]main.point")])));
+		pass = false; PASS = false;
+	}
+	if (m.get("p")[0].x != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: map => got %v<br>", [g.Typed(m, "map[string]main.point")])));
+		pass = false; PASS = false;
+	}
+	if (ps.v[1].x != 6 || p.x != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: array => got %v, %v<br>", [g.Typed(ps, "[2]main.point"), g.Typed(p, "main.point")])));
+		pass = false; PASS = false;
+	}
+
+	var e = g.Clone(s.at(0), {x: 0, y: 0});
+	e.x = 9;
+	var v = g.Clone(m.get("p")[0], {x: 0, y: 0});
+	v.x = 9;
+	if (s.at(0).x != 1 || m.get("p")[0].x != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: element => got %v, %v<br>", [g.Typed(s, "[]main.point"), g.Typed(m, "map[string]main.point")])));
+		pass = false; PASS = false;
+	}
+
+
+	var grid = g.MkArray([2,2], 0, [[1, 2], [3, 4]]);
+	var row = g.Clone(g.Row(grid.v[0]), [0]);
+	row.v[0] = 100;
+	if (grid.v[0][0] != 1 || row.v[1] != 2 || row.v.length != 2 || pair2(g.Row(grid.v[1])) != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: row => got %v, %v<br>", [g.Typed(grid, "[2][2]int"), g.Typed(row, "[2]int")])));
+		pass = false; PASS = false;
+	}
+	var sum = 0;
+	var r; for (var $i1 = 0, $r2 = g.Clone(grid.v, [[0]]); $i1 < $r2.length; $i1++) { r = g.Row($r2[$i1]);
+		sum += r.v[0] * r.v[1];
+	}
+	if (sum != 14) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range of rows => got %v, want 14<br>", [sum])));
+		pass = false; PASS = false;
+	}
+	var pr = g.Row(grid.v[1]);
+	g.Store(grid.v[1], g.MkArray([2], 0, [7, 8]), [0]);
+	pr.v[1] = 9;
+	if (grid.v[1][0] != 7 || grid.v[1][1] != 9 || !g.Equal(grid.v, g.MkArray([2,2], 0, [[1, 2], [7, 9]]), [[0]])) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: row assigned => got %v<br>", [g.Typed(grid, "[2][2]int")])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function ranged() {
+	var pass = true;
+
+	var ps = g.Slice(new point(0, 0), [new point(1, 2), new point(3, 4)]);
+	var p; for (var $i1 = 0, $n2 = ps.len; $i1 < $n2; $i1++) { p = g.Clone(ps.at($i1), {x: 0, y: 0});
+		p.x = 0;
+	}
+	if (ps.at(0).x != 1 || ps.at(1).x != 3) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: range => got %v<br>", [g.Typed(ps, "[]main.point")])));
+		pass = false; PASS = false;
+	}
+
+	var fs = g.MkSlice();
+	for (var $i3 = 0, $n4 = ps.len; $i3 < $n4; $i3++) { let p = g.Clone(ps.at($i3), {x: 0, y: 0});
+		fs = g.Append(fs, [function() { return p.x; }]);
+	}
+	ps.at(0).x = 10;
+	if (fs.at(0)() != 1) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: captured => got %v<br>", [fs.at(0)()])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Value semantics<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN assignment"], true) + "<br>"));
+	assignment();
+	document.write(g.Text(g.Sprint(["=== RUN call"], true) + "<br>"));
+	call();
+	document.write(g.Text(g.Sprint(["=== RUN container"], true) + "<br>"));
+	container();
+	document.write(g.Text(g.Sprint(["=== RUN ranged"], true) + "<br>"));
+	ranged();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Value semantics"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
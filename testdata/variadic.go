@@ -0,0 +1,181 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import "fmt"
+
+var PASS = true
+
+func sum(nums ...int) int {
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+func count(prefix string, nums ...int) (int, bool) {
+	return len(nums), nums == nil
+}
+
+func first(nums ...int) int {
+	if len(nums) == 0 {
+		return -1
+	}
+	return nums[0]
+}
+
+func setFirst(v int, nums ...int) {
+	nums[0] = v
+}
+
+func two() (int, int) { return 3, 4 }
+
+func label() (string, int) { return "n", 5 }
+
+func add(a, b int) int { return a + b }
+
+func join(sep string, args ...interface{}) string {
+	s := ""
+	for i, v := range args {
+		if i != 0 {
+			s += sep
+		}
+		s += fmt.Sprint(v)
+	}
+	return s
+}
+
+type point struct {
+	x, y int
+}
+
+func maxX(points ...point) int {
+	m := 0
+	for _, p := range points {
+		if p.x > m {
+			m = p.x
+		}
+	}
+	return m
+}
+
+func values() {
+	pass := true
+
+	if sum() != 0 || sum(1) != 1 || sum(1, 2, 3) != 6 {
+		fmt.Printf("\tFAIL: sum => got %v, %v, %v\n", sum(), sum(1), sum(1, 2, 3))
+		pass, PASS = false, false
+	}
+
+	if n, isNil := count("a"); n != 0 || !isNil {
+		fmt.Printf("\tFAIL: no arguments => got %v, %v\n", n, isNil)
+		pass, PASS = false, false
+	}
+	if n, isNil := count("a", 4, 5); n != 2 || isNil {
+		fmt.Printf("\tFAIL: after a parameter => got %v, %v\n", n, isNil)
+		pass, PASS = false, false
+	}
+
+	if first() != -1 || first(7, 8) != 7 {
+		fmt.Printf("\tFAIL: index => got %v, %v\n", first(), first(7, 8))
+		pass, PASS = false, false
+	}
+
+	if maxX(point{1, 2}, point{5, 0}, point{3, 3}) != 5 {
+		fmt.Println("\tFAIL: struct elements")
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func spread() {
+	pass := true
+
+	s := []int{1, 2, 3, 4}
+	if sum(s...) != 10 {
+		fmt.Printf("\tFAIL: spread => got %v, want 10\n", sum(s...))
+		pass, PASS = false, false
+	}
+	if sum(s[1:3]...) != 5 {
+		fmt.Printf("\tFAIL: spread a slice expression => got %v, want 5\n", sum(s[1:3]...))
+		pass, PASS = false, false
+	}
+
+	setFirst(9, s...)
+	if s[0] != 9 {
+		fmt.Printf("\tFAIL: same backing array => got %v, want 9\n", s[0])
+		pass, PASS = false, false
+	}
+
+	f := sum
+	if f(1, 2) != 3 || f(s...) != 18 {
+		fmt.Printf("\tFAIL: function value => got %v, %v\n", f(1, 2), f(s...))
+		pass, PASS = false, false
+	}
+
+	// The values returned by a call are the arguments.
+	if add(two()) != 7 || sum(two()) != 7 {
+		fmt.Printf("\tFAIL: multiple values => got %v, %v\n", add(two()), sum(two()))
+		pass, PASS = false, false
+	}
+	if n, isNil := count(label()); n != 1 || isNil {
+		fmt.Printf("\tFAIL: multiple values after a parameter => got %v, %v\n", n, isNil)
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func empty() {
+	pass := true
+
+	if join(",", 1, "a", true) != "1,a,true" {
+		fmt.Printf("\tFAIL: interface => got %v\n", join(",", 1, "a", true))
+		pass, PASS = false, false
+	}
+
+	args := []interface{}{"x", 2}
+	if join("-", args...) != "x-2" {
+		fmt.Printf("\tFAIL: interface spread => got %v\n", join("-", args...))
+		pass, PASS = false, false
+	}
+
+	nums := []interface{}{1, 2, "a", 3}
+	if fmt.Sprint(args...) != "x2" || fmt.Sprint(nums...) != "1 2a3" {
+		fmt.Printf("\tFAIL: library spread => got %v, %v\n",
+			fmt.Sprint(args...), fmt.Sprint(nums...))
+		pass, PASS = false, false
+	}
+
+	if pass {
+		fmt.Println("\tpass")
+	}
+}
+
+func main() {
+	fmt.Print("\n\n== Variadic functions\n\n")
+
+	fmt.Println("=== RUN values")
+	values()
+	fmt.Println("=== RUN spread")
+	spread()
+	fmt.Println("=== RUN empty")
+	empty()
+
+	if PASS {
+		fmt.Println("PASS")
+	} else {
+		fmt.Println("FAIL")
+		print("Fail: Variadic functions")
+	}
+}
@@ -0,0 +1,182 @@
+
+
+
+
+
+
+
+
+
+
+var PASS = true;
+
+function sum(nums) {
+	var total = 0;
+	var n; for (var $i1 = 0, $n2 = nums.len; $i1 < $n2; $i1++) { n = nums.at($i1);
+		total += n;
+	}
+	return total;
+}
+
+function count(prefix, nums) {
+	return [nums.len, nums.isNil()];
+}
+
+function first(nums) {
+	if (nums.len == 0) {
+		return -1;
+	}
+	return nums.at(0);
+}
+
+function setFirst(v, nums) {
+	nums.set([0], v);
+}
+
+function two() { return [3, 4]; }
+
+function label() { return ["n", 5]; }
+
+function add(a, b) { return a + b; }
+
+function join(sep, args) {
+	var s = "";
+	var v; for (var i = 0, $n1 = args.len; i < $n1; i++) { v = args.at(i);
+		if (i != 0) {
+			s += sep;
+		}
+		s += g.Sprint([v], false);
+	}
+	return s;
+}
+
+function point(x, y) {
+	this.x = x; this.y = y;
+}
+
+function maxX(points) {
+	var m = 0;
+	var p; for (var $i1 = 0, $n2 = points.len; $i1 < $n2; $i1++) { p = points.at($i1);
+		if (p.x > m) {
+			m = p.x;
+		}
+	}
+	return m;
+}
+
+function values() {
+	var pass = true;
+
+	if (sum(g.SliceArgs([])) != 0 || sum(g.SliceArgs([1])) != 1 || sum(g.SliceArgs([1, 2, 3])) != 6) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: sum => got %v, %v, %v<br>", [sum(g.SliceArgs([])), sum(g.SliceArgs([1])), sum(g.SliceArgs([1, 2, 3]))])));
+		pass = false; PASS = false;
+	}
+
+	var $r1 = count("a", g.SliceArgs([])), n = $r1[0], isNil = $r1[1]; if (n != 0 || !isNil) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: no arguments => got %v, %v<br>", [n, isNil])));
+		pass = false; PASS = false;
+	}
+	var $r2 = count("a", g.SliceArgs([4, 5])), n = $r2[0], isNil = $r2[1]; if (n != 2 || isNil) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: after a parameter => got %v, %v<br>", [n, isNil])));
+		pass = false; PASS = false;
+	}
+
+	if (first(g.SliceArgs([])) != -1 || first(g.SliceArgs([7, 8])) != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: index => got %v, %v<br>", [first(g.SliceArgs([])), first(g.SliceArgs([7, 8]))])));
+		pass = false; PASS = false;
+	}
+
+	if (maxX(g.SliceArgs([new point(1, 2), new point(5, 0), new point(3, 3)])) != 5) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;FAIL: struct elements"], true) + "<br>"));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function spread() {
+	var pass = true;
+
+	var s = g.Slice(0, [1, 2, 3, 4]);
+	if (sum(s) != 10) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: spread => got %v, want 10<br>", [sum(s)])));
+		pass = false; PASS = false;
+	}
+	if (sum(g.SliceFrom(s, 1, 3)) != 5) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: spread a slice expression => got %v, want 5<br>", [sum(g.SliceFrom(s, 1, 3))])));
+		pass = false; PASS = false;
+	}
+
+	setFirst(9, s);
+	if (s.at(0) != 9) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: same backing array => got %v, want 9<br>", [s.at(0)])));
+		pass = false; PASS = false;
+	}
+
+	var f = sum;
+	if (f(g.SliceArgs([1, 2])) != 3 || f(s) != 18) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: function value => got %v, %v<br>", [f(g.SliceArgs([1, 2])), f(s)])));
+		pass = false; PASS = false;
+	}
+
+
+	if (add(...two()) != 7 || sum(...g.TupleArgs(two(), 0)) != 7) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple values => got %v, %v<br>", [add(...two()), sum(...g.TupleArgs(two(), 0))])));
+		pass = false; PASS = false;
+	}
+	var $r1 = count(...g.TupleArgs(label(), 1)), n = $r1[0], isNil = $r1[1]; if (n != 1 || isNil) {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: multiple values after a parameter => got %v, %v<br>", [n, isNil])));
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function empty() {
+	var pass = true;
+
+	if (join(",", g.SliceArgs([g.Typed(1, "int"), "a", true])) != "1,a,true") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface => got %v<br>", [join(",", g.SliceArgs([g.Typed(1, "int"), "a", true]))])));
+		pass = false; PASS = false;
+	}
+
+	var args = g.Slice(undefined, ["x", g.Typed(2, "int")]);
+	if (join("-", args) != "x-2") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: interface spread => got %v<br>", [join("-", args)])));
+		pass = false; PASS = false;
+	}
+
+	var nums = g.Slice(undefined, [g.Typed(1, "int"), g.Typed(2, "int"), "a", g.Typed(3, "int")]);
+	if (g.Sprint(args.get(), false) != "x2" || g.Sprint(nums.get(), false) != "1 2a3") {
+		document.write(g.Text(g.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;FAIL: library spread => got %v, %v<br>", [g.Sprint(args.get(), false), g.Sprint(nums.get(), false)])));
+
+		pass = false; PASS = false;
+	}
+
+	if (pass) {
+		document.write(g.Text(g.Sprint(["&nbsp;&nbsp;&nbsp;&nbsp;pass"], true) + "<br>"));
+	}
+}
+
+function main() {
+	document.write(g.Text(g.Sprint(["<br><br>== Variadic functions<br><br>"], false)));
+
+	document.write(g.Text(g.Sprint(["=== RUN values"], true) + "<br>"));
+	values();
+	document.write(g.Text(g.Sprint(["=== RUN spread"], true) + "<br>"));
+	spread();
+	document.write(g.Text(g.Sprint(["=== RUN empty"], true) + "<br>"));
+	empty();
+
+	if (PASS) {
+		document.write(g.Text(g.Sprint(["PASS"], true) + "<br>"));
+	} else {
+		document.write(g.Text(g.Sprint(["FAIL"], true) + "<br>"));
+		alert(g.Text("Fail: Variadic functions"));
+	}
+} main();
+/* Generated by Go2js (github.com/kless/go2js) */
@@ -0,0 +1,478 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// The packages imported are parsed from their source, so they are cached
+// between translations.
+var typesImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+// checkTypes runs the type checker on the file so the translation can know the
+// type of the expressions, and how the selectors are resolved.
+//
+// The errors are not reported since the translation checks by itself the
+// subset of Go that is supported; the information is used when it is available.
+func (tr *translation) checkTypes(file *ast.File) {
+	tr.info = &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Instances:  make(map[*ast.Ident]types.Instance),
+	}
+
+	// The JS library uses objects which are only defined in JavaScript.
+	if Bootstrap {
+		return
+	}
+
+	conf := types.Config{
+		Importer: typesImporter,
+		Error:    func(err error) {},
+	}
+	tr.pkg, _ = conf.Check(file.Name.Name, tr.fset, []*ast.File{file}, tr.info)
+}
+
+// typeOf returns the type of the expression, or nil if it is unknown.
+func (tr *translation) typeOf(expr ast.Expr) types.Type {
+	if tv, ok := tr.info.Types[expr]; ok {
+		return tv.Type
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		if obj := tr.info.ObjectOf(ident); obj != nil {
+			return obj.Type()
+		}
+	}
+	return nil
+}
+
+// constLit returns the literal of a constant value folded by the type checker,
+// if it is a number or a boolean. The numbers are exact, without the integers
+// of 32 bits used by the bitwise operators of JavaScript.
+func constLit(value constant.Value) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	switch value.Kind() {
+	case constant.Bool, constant.Int:
+		return value.ExactString(), true
+	case constant.Float:
+		f, _ := constant.Float64Val(value)
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// isString reports whether the type of the expression is a string.
+func (tr *translation) isString(expr ast.Expr) bool {
+	return !Bootstrap && isStringType(tr.typeOf(expr))
+}
+
+// isStringType reports whether the type is a string.
+func isStringType(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	t, ok := typ.Underlying().(*types.Basic)
+	return ok && t.Info()&types.IsString != 0
+}
+
+// isRuneType reports whether the type is a rune; a byte is an unsigned integer.
+func isRuneType(typ types.Type) bool {
+	t, ok := typ.Underlying().(*types.Basic)
+	return ok && t.Kind() == types.Int32
+}
+
+// selection returns how the selector is resolved, or nil if it is a qualified
+// identifier or it is unknown.
+func (tr *translation) selection(sel *ast.SelectorExpr) *types.Selection {
+	return tr.info.Selections[sel]
+}
+
+// fieldKind returns the data type of the expression if it is a field of a
+// struct, which are not saved like the variables.
+func (tr *translation) fieldKind(expr ast.Expr) dataType {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || Bootstrap {
+		return otherType
+	}
+	if s := tr.selection(sel); s == nil || s.Kind() != types.FieldVal {
+		return otherType
+	}
+
+	return typeKind(tr.typeOf(sel))
+}
+
+// exprKind returns the data type of the expression if it is a slice or a map,
+// given by the type checker. The variables are not checked since they are
+// saved like slices or maps when they are declared.
+func (tr *translation) exprKind(expr ast.Expr) dataType {
+	if _, ok := expr.(*ast.Ident); ok || Bootstrap {
+		return otherType
+	}
+	return typeKind(tr.typeOf(expr))
+}
+
+// typeKind returns the data type of a slice or a map, given by the type
+// checker; otherType for the other types, or if it is unknown.
+func typeKind(typ types.Type) dataType {
+	if typ == nil {
+		return otherType
+	}
+	switch typ.Underlying().(type) {
+	case *types.Slice:
+		return sliceType
+	case *types.Map:
+		return mapType
+	}
+	return otherType
+}
+
+// namedOf returns the named type declared by the identifier, if any.
+func (tr *translation) namedOf(ident *ast.Ident) *types.Named {
+	if obj, ok := tr.info.Defs[ident].(*types.TypeName); ok {
+		if named, ok := obj.Type().(*types.Named); ok {
+			return named
+		}
+	}
+	return nil
+}
+
+// rename gives an unique name to the object, to be used in all its references,
+// adding "$" to the name since it is not valid in Go identifiers.
+func (tr *translation) rename(obj types.Object, name string) string {
+	newName := name + "$"
+
+	for i := 1; ; i++ {
+		isUsed := false
+		for _, v := range tr.renamed {
+			if v == newName {
+				isUsed = true
+				break
+			}
+		}
+		if !isUsed {
+			break
+		}
+		newName = name + "$" + strconv.Itoa(i)
+	}
+
+	tr.renamed[obj] = newName
+	return newName
+}
+
+// isShadowing checks if the object hides another local variable of the function.
+func isShadowing(obj types.Object) bool {
+	if obj.Parent() == nil {
+		return false
+	}
+	for scope := obj.Parent().Parent(); scope != nil; scope = scope.Parent() {
+		if scope.Parent() == types.Universe || scope.Parent() == nil {
+			break // package scope
+		}
+		if v, ok := scope.Lookup(obj.Name()).(*types.Var); ok && v != obj {
+			return true
+		}
+	}
+	return false
+}
+
+// varName returns the name used in JavaScript for the identifier.
+func (tr *translation) varName(ident *ast.Ident) string {
+	if name, ok := tr.renamed[tr.info.ObjectOf(ident)]; ok {
+		return name
+	}
+	return validIdent(ident.Name)
+}
+
+// loopVar reports if the variable declared by the loop is captured by a
+// function literal or addressed into the node, so it needs a new variable in
+// each iteration.
+func (tr *translation) loopVar(ident *ast.Ident, node ast.Node) (isCaptured, isAddressed bool) {
+	obj := tr.info.Defs[ident]
+	if obj == nil {
+		return
+	}
+	inFuncLit := 0
+
+	var inspect func(ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncLit:
+			inFuncLit++
+			ast.Inspect(t.Body, inspect)
+			inFuncLit--
+			return false
+		case *ast.Ident:
+			if inFuncLit != 0 && tr.info.Uses[t] == obj {
+				isCaptured = true
+			}
+		}
+		return true
+	}
+	ast.Inspect(node, inspect)
+	return isCaptured, tr.isAddressed(obj)
+}
+
+// isFreshVar reports whether the variables declared are into the body of a loop
+// and some one is captured by a function literal, so they are new in each
+// iteration; all of them have to be new, since they are declared with "let".
+// The variables of the clauses of a switch are renamed, since all the clauses
+// are a block in JavaScript.
+func (tr *translation) isFreshVar(names interface{}) bool {
+	var idents []*ast.Ident
+	switch t := names.(type) {
+	case []*ast.Ident:
+		idents = t
+	case []ast.Expr:
+		for _, v := range t {
+			ident, ok := v.(*ast.Ident)
+			if !ok {
+				return false
+			}
+			idents = append(idents, ident)
+		}
+	}
+	if len(idents) == 0 || tr.funcBody == nil || Bootstrap {
+		return false
+	}
+	inLoop, inClause := tr.placeOf(idents[0])
+	if !inLoop {
+		return false
+	}
+
+	isFresh := false
+	for _, ident := range idents {
+		if isBlank(ident) {
+			continue
+		}
+		if tr.info.Defs[ident] == nil { // declared before
+			return false
+		}
+		if isCaptured, _ := tr.loopVar(ident, tr.funcBody); isCaptured {
+			isFresh = true
+		}
+	}
+	if isFresh && inClause {
+		for _, ident := range idents {
+			if obj := tr.info.Defs[ident]; obj != nil {
+				if _, ok := tr.renamed[obj]; !ok {
+					tr.rename(obj, validIdent(obj.Name()))
+				}
+			}
+		}
+	}
+	return isFresh
+}
+
+// placeOf reports whether the identifier is into the body of a loop of the
+// function being translated, out of the function literals, and whether it is
+// directly into a clause of a switch or select.
+func (tr *translation) placeOf(ident *ast.Ident) (inLoop, inClause bool) {
+	pos := ident.Pos()
+
+	ast.Inspect(tr.funcBody, func(n ast.Node) bool {
+		if n == nil || pos < n.Pos() || pos >= n.End() {
+			return false
+		}
+		switch t := n.(type) {
+		case *ast.ForStmt:
+			inLoop = inLoop || pos >= t.Body.Pos()
+		case *ast.RangeStmt:
+			inLoop = inLoop || pos >= t.Body.Pos()
+		case *ast.FuncLit:
+			inLoop = false
+		case *ast.BlockStmt:
+			inClause = false
+		case *ast.CaseClause, *ast.CommClause:
+			inClause = true
+		}
+		return true
+	})
+	return inLoop, inClause
+}
+
+// * * *
+
+// embeddedPath returns the names of the embedded fields which have to be
+// passed through to get a promoted field or method, with a dot after each one.
+func embeddedPath(sel *types.Selection) string {
+	path := ""
+	typ := sel.Recv()
+	index := sel.Index()
+
+	for _, i := range index[:len(index)-1] {
+		st, ok := derefType(typ).Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+		field := st.Field(i)
+
+		path += validProp(field.Name()) + "."
+		typ = field.Type()
+	}
+	return path
+}
+
+// derefType returns the base type of a pointer, else the type.
+func derefType(typ types.Type) types.Type {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return typ
+}
+
+// isPointer reports whether the type is a pointer.
+func isPointer(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Pointer)
+	return ok
+}
+
+// isArrayVar reports whether the identifier is a variable of an array, or of a
+// pointer to it, which is read through its field FIELD_VALUE. Without type
+// information, the name is checked between the variables declared as arrays.
+func (tr *translation) isArrayVar(ident *ast.Ident, name string) bool {
+	if !Bootstrap {
+		if v, ok := tr.info.ObjectOf(ident).(*types.Var); ok {
+			_, isArray := derefType(v.Type()).Underlying().(*types.Array)
+			return isArray
+		}
+	}
+	return tr.isType(arrayType, name)
+}
+
+// isMultiDim reports whether the index expression gets an array of a
+// multi-dimensional array, which is indexed like only one.
+func (tr *translation) isMultiDim(index *ast.IndexExpr) bool {
+	if Bootstrap {
+		return true
+	}
+	x, elem := tr.typeOf(index.X), tr.typeOf(index)
+	if x == nil || elem == nil {
+		return true
+	}
+	_, xIsArray := derefType(x).Underlying().(*types.Array)
+	_, elemIsArray := elem.(*types.Array)
+	return xIsArray && elemIsArray
+}
+
+// isRow reports whether the expression is a row of a multi-dimensional array,
+// which is read like an array.
+func (tr *translation) isRow(expr ast.Expr) bool {
+	index, ok := ast.Unparen(expr).(*ast.IndexExpr)
+	return ok && !Bootstrap && tr.isMultiDim(index)
+}
+
+// isArrayElem reports whether the expression is an array got from an element
+// of a slice or a map, which is an array of the library.
+func (tr *translation) isArrayElem(expr ast.Expr) bool {
+	index, ok := expr.(*ast.IndexExpr)
+	if !ok || Bootstrap {
+		return false
+	}
+	_, isArray := tr.typeOf(index).(*types.Array)
+	return isArray && !tr.isMultiDim(index)
+}
+
+// hasAnonStruct reports whether the elements of the array or slice type are
+// anonymous structs, at any dimension.
+func hasAnonStruct(typ types.Type) bool {
+	for {
+		switch t := typ.(type) {
+		case *types.Slice:
+			typ = t.Elem()
+		case *types.Array:
+			typ = t.Elem()
+		case *types.Pointer:
+			typ = t.Elem()
+		case *types.Struct:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// isStructType reports whether the type is a struct, or a pointer to it.
+func isStructType(typ types.Type) bool {
+	_, ok := derefType(typ).Underlying().(*types.Struct)
+	return ok
+}
+
+// isNamedValue reports whether the type is a named type which is neither a
+// struct nor an interface. Its values keep the representation of the underlying
+// type, so they are stored into an object of the type to have its methods.
+func isNamedValue(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.TypeParams() != nil {
+		return false
+	}
+	switch named.Underlying().(type) {
+	case *types.Struct, *types.Interface:
+		return false
+	}
+	return true
+}
+
+// storesType reports whether the values of the type are stored into an
+// interface with their type, since it can not be known from the value: the
+// named types which are neither structs nor interfaces, and the basic types
+// but bool and string.
+func storesType(typ types.Type) bool {
+	if isNamedValue(typ) {
+		return true
+	}
+	t, ok := typ.(*types.Basic)
+	if !ok || t.Info()&types.IsUntyped != 0 {
+		return false
+	}
+	switch t.Kind() {
+	case types.Bool, types.String, types.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// isObjectPointer reports whether the type is a pointer to a struct or an
+// array, which is the own object pointed; so it is marked like a pointer into
+// an interface, to be compared by identity.
+func isObjectPointer(typ types.Type) bool {
+	ptr, ok := typ.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	switch ptr.Elem().Underlying().(type) {
+	case *types.Struct, *types.Array:
+		return true
+	}
+	return false
+}
+
+// basicName returns the name of the underlying type if it is basic, else an
+// empty string.
+func basicName(typ types.Type) string {
+	if t, ok := typ.Underlying().(*types.Basic); ok {
+		return t.Name()
+	}
+	return ""
+}
+
+// hasMethods reports whether the type has methods with a receiver of value.
+func hasMethods(typ types.Type) bool {
+	return types.NewMethodSet(typ).Len() != 0
+}
@@ -0,0 +1,203 @@
+// Copyright 2011 Jonas mg
+//
+// This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+// If a copy of the MPL was not distributed with this file, You can obtain one at
+// http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// The arrays and structs are objects in JavaScript, so they are copied by the
+// library where Go copies their values: in the assignments, the composite
+// literals, the results and the parameters.
+//
+//	b := a  => var b = g.Clone(a, [0])
+//
+// The copy is skipped when the value is new, like the composite literals and
+// the results of calls; and the parameters, the receivers and the values of a
+// "range" are only copied when they could be changed into the function, since
+// else nobody can see the difference.
+
+// valueDesc returns the descriptor used by the library to compare and to copy
+// the values of the type.
+//
+// The descriptor is 0 for the types compared by value, like the basic types,
+// or by identity, like the pointers; 1 for the interfaces and the type
+// parameters, whose dynamic type is checked at run time; an array with the
+// descriptor of the elements for the arrays; and an object with the descriptor
+// of each field for the structs.
+func (tr *translation) valueDesc(typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Interface, *types.TypeParam:
+		return "1"
+
+	case *types.Array:
+		return "[" + tr.valueDesc(t.Elem()) + "]"
+
+	case *types.Struct:
+		desc := ""
+
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			if field.Name() == "_" {
+				continue
+			}
+			if desc != "" {
+				desc += ", "
+			}
+
+			desc += validProp(field.Name()) + ": " + tr.valueDesc(field.Type())
+		}
+		return "{" + desc + "}"
+	}
+	return "0"
+}
+
+// isValueType reports whether the values of the type are copied: arrays and
+// structs.
+func isValueType(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Array, *types.Struct:
+		return true
+	}
+	return false
+}
+
+// isNewValue reports whether the expression creates a new value, which has not
+// to be copied.
+func (tr *translation) isNewValue(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.CompositeLit, *ast.BasicLit, *ast.FuncLit:
+		return true
+	case *ast.ParenExpr:
+		return tr.isNewValue(t.X)
+	case *ast.CallExpr:
+		// A conversion returns the same value.
+		if tv, ok := tr.info.Types[t.Fun]; ok && tv.IsType() {
+			return tr.isNewValue(t.Args[0])
+		}
+		return true
+	}
+	return false
+}
+
+// copyValue returns the value of the expression, copied if it is an array or a
+// struct which could be seen from another place. The arrays are copied like
+// they are given, either the object or its field FIELD_VALUE.
+func (tr *translation) copyValue(expr ast.Expr, value string) string {
+	if Bootstrap || tr.isNewValue(expr) {
+		return value
+	}
+	typ := tr.typeOf(expr)
+	if t, ok := typ.(*types.Tuple); ok { // v, ok := m[k]
+		typ = t.At(0).Type()
+	}
+	if !isValueType(typ) {
+		return value
+	}
+	return "g.Clone(" + value + ", " + tr.valueDesc(typ) + ")"
+}
+
+// copyResult returns the value of a result, which is not copied when it is a
+// variable of the function that nobody else can see.
+func (tr *translation) copyResult(expr ast.Expr, value string) string {
+	if ident, ok := expr.(*ast.Ident); ok && tr.funcBody != nil {
+		obj := tr.info.Uses[ident]
+
+		if obj != nil && obj.Pos() >= tr.funcPos && obj.Pos() < tr.funcBody.End() {
+			// The parameters are only local when they were copied.
+			isLocal := obj.Pos() >= tr.funcBody.Pos() || tr.isChanged(obj, tr.funcBody)
+
+			if isLocal && !tr.isShared(obj, tr.funcBody) {
+				return value
+			}
+		}
+	}
+	return tr.copyValue(expr, value)
+}
+
+// isChanged reports whether the variable of an array or struct could be
+// changed, or seen changed, into the node: it is assigned, incremented or
+// shared.
+func (tr *translation) isChanged(obj types.Object, node ast.Node) bool {
+	return tr.changes(obj, node, true)
+}
+
+// isShared reports whether the variable of an array or struct could be seen
+// from another place into the node: it is addressed, sliced, used by a method
+// with a pointer receiver or captured by a closure.
+func (tr *translation) isShared(obj types.Object, node ast.Node) bool {
+	return tr.changes(obj, node, false)
+}
+
+func (tr *translation) changes(obj types.Object, node ast.Node, withAssign bool) bool {
+	if obj == nil || !isValueType(obj.Type()) || node == nil {
+		return false
+	}
+
+	// isVar reports whether the root of the expression is the variable.
+	var isVar func(ast.Expr) bool
+	isVar = func(expr ast.Expr) bool {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			return tr.info.Uses[t] == obj
+		case *ast.SelectorExpr:
+			// The fields got through a pointer are not into the value.
+			if _, ok := tr.typeOf(t.X).Underlying().(*types.Pointer); ok {
+				return false
+			}
+			return isVar(t.X)
+		case *ast.IndexExpr:
+			if _, ok := tr.typeOf(t.X).Underlying().(*types.Array); !ok {
+				return false
+			}
+			return isVar(t.X)
+		case *ast.ParenExpr:
+			return isVar(t.X)
+		}
+		return false
+	}
+
+	changed := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if changed {
+			return false
+		}
+		switch t := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range t.Lhs {
+				if withAssign && isVar(lhs) {
+					changed = true
+				}
+			}
+		case *ast.IncDecStmt:
+			changed = withAssign && isVar(t.X)
+		case *ast.UnaryExpr:
+			changed = t.Op == token.AND && isVar(t.X)
+		case *ast.SliceExpr:
+			changed = isVar(t.X)
+		case *ast.SelectorExpr:
+			if sel := tr.selection(t); sel != nil && sel.Kind() == types.MethodVal &&
+				!isValueRecv(sel) && isVar(t.X) {
+				changed = true
+			}
+		case *ast.FuncLit:
+			ast.Inspect(t.Body, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && tr.info.Uses[ident] == obj {
+					changed = true
+				}
+				return !changed
+			})
+		}
+		return !changed
+	})
+	return changed
+}
@@ -10,7 +10,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"strconv"
+	"go/types"
 	"strings"
 )
 
@@ -19,63 +19,50 @@ import (
 // http://golang.org/doc/go_spec.html#Constant_declarations
 // https://developer.mozilla.org/en/JavaScript/Reference/Statements/const
 
-// getConst translates a constant.
-func (tr *translation) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
-	iotaExpr := make([]string, 0) // iota expressions
-	isMultipleLine := false
+// getConst translates a constant. The numbers and booleans are got from the type
+// checker, which folds the expressions. Else, the specs without values repeat the
+// last values, which are translated again with the value of "iota" of the spec.
+func (tr *translation) getConst(spec []ast.Spec, isGlobal bool) {
+	var lastValues []ast.Expr
 	tr.isConst = true
 
-	if len(spec) > 1 {
-		isMultipleLine = true
-		tr.addLine(pos)
-		tr.WriteString("const ")
-	}
-
 	// godoc go/ast ValueSpec
 	//  Doc     *CommentGroup // associated documentation; or nil
 	//  Names   []*Ident      // value names (len(Names) > 0)
 	//  Type    Expr          // value type; or nil
 	//  Values  []Expr        // initial values; or nil
 	//  Comment *CommentGroup // line comments; or nil
-	for i, s := range spec {
+	for _, s := range spec {
 		vSpec := s.(*ast.ValueSpec)
 
 		// Type checking
 		if tr.getExpression(vSpec.Type).hasError {
 			continue
 		}
+		if vSpec.Values != nil {
+			lastValues = vSpec.Values
+		}
 
-		tr.addLine(vSpec.Pos())
+		tr.setLine(vSpec.Pos())
 		isFirst := true
 
 		for i, ident := range vSpec.Names {
 			if ident.Name == "_" {
-				iotaExpr = append(iotaExpr, "")
 				continue
 			}
+			tr.iota = ident.Obj.Data.(int)
 
-			value := strconv.Itoa(ident.Obj.Data.(int)) // possible value of iota
-
-			if vSpec.Values != nil {
-				v := vSpec.Values[i]
-
-				expr := tr.getExpression(v)
-				if expr.hasError {
-					continue
-				}
+			expr := tr.getExpression(lastValues[i])
+			if expr.hasError || tr.hasError {
+				continue
+			}
+			value := expr.String()
 
-				if expr.useIota {
-					exprStr := expr.String()
-					value = strings.Replace(exprStr, IOTA, value, -1)
-					iotaExpr = append(iotaExpr, exprStr)
-				} else {
-					value = expr.String()
-				}
-			} else {
-				if tr.hasError {
-					continue
+			// The value is folded by the type checker, with "iota" of the spec.
+			if c, ok := tr.info.Defs[ident].(*types.Const); ok {
+				if lit, ok := constLit(c.Val()); ok {
+					value = lit
 				}
-				value = strings.Replace(iotaExpr[i], IOTA, value, -1)
 			}
 
 			if isGlobal {
@@ -83,39 +70,22 @@ func (tr *translation) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 			}
 
 			// == Write
-			name := validIdent(ident.Name)
-
 			if isFirst {
 				isFirst = false
-
-				if !isGlobal && isMultipleLine {
-					tr.WriteString(strings.Repeat(TAB, tr.tabLevel))
-				}
-				if isMultipleLine {
-					tr.WriteString(name + SP + "=" + SP + value)
-				} else {
-					tr.WriteString(fmt.Sprintf("const %s=%s", name+SP, SP+value))
-				}
-
+				tr.WriteString("const ")
 			} else {
-				tr.WriteString(fmt.Sprintf(",%s=%s", SP+name+SP, SP+value))
+				tr.WriteString(", ")
 			}
+			tr.WriteString(validIdent(ident.Name) + " = " + value)
 		}
 
 		// It is possible that there is only a blank identifier.
 		if !isFirst {
-			if isMultipleLine {
-				if i == len(spec)-1 {
-					tr.WriteString(";")
-				} else {
-					tr.WriteString(",")
-				}
-			} else {
-				tr.WriteString(";")
-			}
+			tr.WriteString(";")
 		}
 	}
 
+	tr.iota = 0
 	tr.isConst = false
 }
 
@@ -127,12 +97,6 @@ func (tr *translation) getConst(pos token.Pos, spec []ast.Spec, isGlobal bool) {
 
 // getVar translates a variable.
 func (tr *translation) getVar(spec []ast.Spec, isGlobal bool) {
-	isMultipleLine := false
-
-	if len(spec) > 1 {
-		isMultipleLine = true
-	}
-
 	// godoc go/ast ValueSpec
 	for _, s := range spec {
 		vSpec := s.(*ast.ValueSpec)
@@ -140,15 +104,22 @@ func (tr *translation) getVar(spec []ast.Spec, isGlobal bool) {
 		// It is necessary to add the first variable before of checking
 		tr.lastVarName = vSpec.Names[0].Name
 
-		// Type checking
-		if tr.getExpression(vSpec.Type).hasError {
+		// Type checking; the array types are not values of the variable.
+		restore := tr.keepArray()
+		hasError := tr.getExpression(vSpec.Type).hasError
+		restore()
+		if hasError {
 			continue
 		}
 
-		tr.addLine(vSpec.Pos())
+		tr.setLine(vSpec.Pos())
+
+		if tr.deferredVars[vSpec] {
+			tr.writeDeferredVar(vSpec)
+			continue
+		}
 		// Pass token.DEFINE to know that it is a new variable
-		tr.writeVar(vSpec.Names, vSpec.Values, vSpec.Type, token.DEFINE,
-			isGlobal, isMultipleLine)
+		tr.writeVar(vSpec.Names, vSpec.Values, vSpec.Type, token.DEFINE, isGlobal)
 	}
 }
 
@@ -166,49 +137,65 @@ func (tr *translation) getType(spec []ast.Spec, isGlobal bool) {
 	for _, s := range spec {
 		tSpec := s.(*ast.TypeSpec)
 
-		// Type checking
-		if tr.getExpression(tSpec.Type).hasError {
+		// Type checking; the array types are not values of a variable.
+		restore := tr.keepArray()
+		hasError := tr.getExpression(tSpec.Type).hasError
+		restore()
+		if hasError {
 			continue
 		}
 		name := validIdent(tSpec.Name)
 
-		switch typ := tSpec.Type.(type) {
-		// godoc go/ast Ident
-		//  NamePos token.Pos // identifier position
-		//  Name    string    // identifier name
-		//  Obj     *Object   // denoted object; or nil
-		case *ast.StructType:
-			tr.getStruct(typ, name, isGlobal)
-
-		case *ast.ArrayType:
-			tr.addLine(tSpec.Pos())
-
-			if typ.Len != nil { // array
-				tr.WriteString(fmt.Sprintf("function %s(){}%s.alias(g.ArrayType);",
-					name, SP+name))
-			} else { // slice
-				tr.WriteString(fmt.Sprintf("function %s(){}%s.alias(g.SliceType);",
-					name, SP+name))
-			}
-		case *ast.MapType:
-			tr.addLine(tSpec.Pos())
-			tr.WriteString(fmt.Sprintf("function %s(){}%s.alias(g.MapType);",
-				name, SP+name))
-				//"function %s(v,%szero)%s{%sg.mapType.apply(this,%sarguments)%s}%s",
-				//name, SP, SP, SP, SP, SP, SP))
-
-		case *ast.Ident:
-			tr.addLine(tSpec.Pos())
-			tr.WriteString(fmt.Sprintf("function %s(t)%s{%sthis%s=t;%s}",
-				name, SP, SP, FIELD_TYPE, SP))
-			tr.zeroType[tr.funcId][tr.blockId][name] = "FOO"
-//fmt.Println(tSpec.Name.Name)
+		// The values of the types which are neither structs nor interfaces
+		// keep the representation of the underlying type; the constructor
+		// stores them when they need the methods.
+		if named := tr.namedOf(tSpec.Name); named != nil && isNamedValue(named) {
+			tr.setLine(tSpec.Pos())
+			args := name
+			if kind := basicName(named); kind != "" {
+				args += fmt.Sprintf(", %q", kind)
+			}
+			tr.WriteString(fmt.Sprintf("function %s(t) { this%s = t; } g.Named(%s);",
+				name, FIELD_TYPE, args))
+		} else {
+			switch typ := tSpec.Type.(type) {
+			// godoc go/ast Ident
+			//  NamePos token.Pos // identifier position
+			//  Name    string    // identifier name
+			//  Obj     *Object   // denoted object; or nil
+			case *ast.StructType:
+				tr.getStruct(typ, tSpec.TypeParams, name, isGlobal)
+				tr.writePromoted(tSpec.Name)
+
+				if named := tr.namedOf(tSpec.Name); named != nil {
+					if fields := tr.fieldTypes(named); fields != "" {
+						tr.WriteString(fmt.Sprintf("g.FieldTypes(%s, %s);", name, fields))
+					}
+				}
 
-		default:
-			/*tr.addLine(tSpec.Pos())
-			tr.WriteString(fmt.Sprintf("function %s(t)%s{%sthis%s=arguments;%s}",
-				validIdent(tSpec.Name), SP, SP, FIELD_TYPE, SP))*/
-			panic(fmt.Sprintf("unimplemented: %T", typ))
+			// The interfaces are only checked at compiling.
+			case *ast.InterfaceType:
+				continue
+
+			case *ast.ArrayType:
+				tr.setLine(tSpec.Pos())
+
+				if typ.Len != nil { // array
+					tr.WriteString(fmt.Sprintf("function %s() {} %s.alias(g.ArrayType);", name, name))
+				} else { // slice
+					tr.WriteString(fmt.Sprintf("function %s() {} %s.alias(g.SliceType);", name, name))
+				}
+			case *ast.MapType:
+				tr.setLine(tSpec.Pos())
+				tr.WriteString(fmt.Sprintf("function %s() {} %s.alias(g.MapType);", name, name))
+
+			case *ast.Ident:
+				tr.setLine(tSpec.Pos())
+				tr.WriteString(fmt.Sprintf("function %s(t) { this%s = t; }", name, FIELD_TYPE))
+
+			default:
+				panic(fmt.Sprintf("unimplemented: %T", typ))
+			}
 		}
 
 		if tr.hasError {
@@ -224,7 +211,7 @@ func (tr *translation) getType(spec []ast.Spec, isGlobal bool) {
 //
 
 // getStruct translates a custom struct.
-func (tr *translation) getStruct(typ *ast.StructType, name string, isGlobal bool) {
+func (tr *translation) getStruct(typ *ast.StructType, typeParams *ast.FieldList, name string, isGlobal bool) {
 	// godoc go/ast StructType
 	//  Struct     token.Pos  // position of "struct" keyword
 	//  Fields     *FieldList // list of field declarations
@@ -238,13 +225,10 @@ func (tr *translation) getStruct(typ *ast.StructType, name string, isGlobal bool
 		panic("list of fields incomplete ???")
 	}
 
-	var fieldNames, fieldLines, fieldsInit string
-	//!anonField := make([]bool, 0) // anonymous field
-
-	firstPos := tr.getLine(typ.Fields.Opening)
-	posOldField := firstPos
-	posNewField := 0
-	isFirst := true
+	var fieldNames, fieldsInit string
+	var fields []jsStmt // assignments of the fields, in the named structs
+	var anonFields string // fields of the anonymous structs
+	line := tr.getLine(typ.Fields.Opening)
 
 	// godoc go/ast Field
 	//  Doc     *CommentGroup // associated documentation; or nil
@@ -253,136 +237,217 @@ func (tr *translation) getStruct(typ *ast.StructType, name string, isGlobal bool
 	//  Tag     *BasicLit     // field tag; or nil
 	//  Comment *CommentGroup // line comments; or nil
 	for _, field := range typ.Fields.List {
-		isPointer := false
+		names := field.Names
 
-		if _, ok := field.Type.(*ast.FuncType); ok {
-			tr.addError("%s: function type in struct", tr.fset.Position(field.Pos()))
-			continue
-		}
-		if field.Names == nil {
-			tr.addError("%s: anonymous field in struct", tr.fset.Position(field.Pos()))
-			continue
-		}
-		// Type checking
-		if expr := tr.getExpression(field.Type); expr.hasError {
+		// Type checking; the array types are not values of a variable.
+		restore := tr.keepArray()
+		expr := tr.getExpression(field.Type)
+		zero, _ := tr.zeroValue(true, field.Type)
+		restore()
+
+		if expr.hasError {
 			continue
-		} else if expr.isPointer {
-			isPointer = true
 		}
 
-		zero, _ := tr.zeroValue(true, field.Type)
+		// The embedded field is named like its type.
+		if names == nil {
+			names = []*ast.Ident{embeddedName(field.Type)}
+		}
 
-		for _, v := range field.Names {
-			fieldName := validIdent(v.Name)
+		for _, v := range names {
+			fieldName := validProp(v.Name)
 			if fieldName == "_" {
 				continue
 			}
 
-			if !isFirst {
-				fieldNames += "," + SP
-				fieldsInit += "," + SP
+			if fieldNames != "" {
+				fieldNames += ", "
+				fieldsInit += ", "
 			}
 			fieldNames += fieldName
 			fieldsInit += zero
-			//!anonField = append(anonField, false)
-
-			// == Printing of fields
-			posNewField = tr.getLine(v.Pos())
-
-			if posNewField != posOldField {
-				fieldLines += strings.Repeat(NL, posNewField-posOldField)
-				fieldLines += strings.Repeat(TAB, tr.tabLevel+1)
-			} else {
-				fieldLines += SP
-			}
 
 			if name != "" {
-				fieldLines += fmt.Sprintf("this.%s=", fieldName)
-			} else {
-				fieldLines += fmt.Sprintf("%s:%s", fieldName, SP)
-			}
-			if !isPointer {
-				fieldLines += fmt.Sprintf("%s", fieldName)
+				fields = append(fields, &jsCode{tr.getLine(v.Pos()),
+					fmt.Sprintf("this.%s = %s;", fieldName, fieldName)})
 			} else {
-				fieldLines += fmt.Sprintf("{p:%s}", fieldName)
-			}
-			if name != "" {
-				fieldLines += ";"
-			} else {
-				fieldLines += ","
-			}
-
-			posOldField = posNewField
-			// ==
-
-			if isFirst {
-				isFirst = false
+				if anonFields != "" {
+					anonFields += ","
+				}
+				if tr.getLine(v.Pos()) != line {
+					line = tr.getLine(v.Pos())
+					anonFields += lineRef(line)
+				}
+				if anonFields != "" {
+					anonFields += " "
+				}
+				anonFields += fieldName + ": " + fieldName
 			}
 		}
 	}
-	// Remove the last character.
-	if fieldLines != "" {
-		fieldLines = fieldLines[:len(fieldLines)-1]
-	}
-
-	// The right brace
-	posNewField = tr.getLine(typ.Fields.Closing)
+	// The generic types store the descriptors of their type parameters.
+	if typeParams != nil {
+		params := tr.joinTypeParams(typeParams, "$")
+		var paramLines []jsStmt
 
-	if posNewField != posOldField {
-		fieldLines += strings.Repeat(NL, posNewField-posOldField)
-		fieldLines += strings.Repeat(TAB, tr.tabLevel)
-	} else {
-		fieldLines += SP
-	}
-
-	// Empty structs
-	if fieldLines == SP {
-		fieldLines = ""
+		for _, v := range strings.Split(strings.TrimSuffix(params, ", "), ", ") {
+			paramLines = append(paramLines, &jsCode{-1, fmt.Sprintf("this.%s = %s;", v, v)})
+		}
+		if fieldNames == "" {
+			params = strings.TrimSuffix(params, ", ")
+		}
+		fieldNames = params + fieldNames
+		fields = append(paramLines, fields...)
 	}
 
 	// == Write
-	tr.addLine(typ.Pos())
-
 	if name != "" {
-		tr.WriteString(fmt.Sprintf("function %s(%s)%s{%s}",
-			name, fieldNames, SP, fieldLines))
-		//tr.WriteString(fmt.Sprintf("function %s(%s)%s{%sthis._z=%q;%s}",
-		//validIdent(name), fieldNames, SP, SP, fieldsInit, fieldLines))
+		tr.addStmt(&jsFunc{
+			Line:   tr.getLine(typ.Pos()),
+			Head:   "function " + name,
+			Params: strings.Split(fieldNames, ", "),
+			Body: &jsBlock{
+				Line:   tr.getLine(typ.Fields.Opening),
+				List:   fields,
+				Rbrace: tr.getLine(typ.Fields.Closing),
+			},
+		})
 
 		// Store the name of new type with its values initialized
 		tr.zeroType[tr.funcId][tr.blockId][name] = fieldsInit
 	} else {
-		tr.WriteString(fmt.Sprintf("_%s=%sfunction(%s)%s{%sreturn%s{%s};};%s",
-			SP, SP, fieldNames, SP, SP, SP, fieldLines, SP))
+		if tr.getLine(typ.Fields.Closing) != line {
+			anonFields += lineRef(tr.getLine(typ.Fields.Closing))
+		}
+		tr.anonType = tr.newTmp("T")
+		tr.WriteString(fmt.Sprintf("%s = function(%s) { return {%s}; }; ", tr.anonType,
+			fieldNames, anonFields))
 
 		if _, ok := tr.structSlices[tr.funcId][tr.blockId][tr.lastVarName]; !ok {
 			tr.structSlices[tr.funcId][tr.blockId][tr.lastVarName] = void
 			tr.insertVar = true
 		}
 	}
+}
+
+// structLit returns the composite literal of a struct, got from the type given
+// by the type checker. It is used in generic types, in the literals where the
+// type is elided, and in the anonymous structs.
+func (tr *translation) structLit(lit *ast.CompositeLit) string {
+	typ := derefType(tr.typeOf(lit)) // elided &T
+	if typ == nil {
+		panic(fmt.Sprintf("'CompositeLit' unimplemented: %T", lit.Type))
+	}
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		panic(fmt.Sprintf("'CompositeLit' unimplemented: %s", typ))
+	}
+	named, isNamed := typ.(*types.Named)
+	if !isNamed && len(lit.Elts) == 0 {
+		return tr.typeZero(st)
+	}
+	values, fields := "", ""
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		value := ""
+
+		switch {
+		case len(lit.Elts) == 0:
+			value = tr.typeZero(field.Type())
+
+		case isKeyValue(lit.Elts[0]): // the fields not specified have the zero value
+			value = tr.typeZero(field.Type())
+
+			for _, elt := range lit.Elts {
+				kv := elt.(*ast.KeyValueExpr)
+				if kv.Key.(*ast.Ident).Name == field.Name() {
+					value = tr.copyValue(kv.Value, tr.getExpression(kv.Value).String())
+				}
+			}
+		default:
+			elt := lit.Elts[i]
+			value = tr.copyValue(elt, tr.getExpression(elt).String())
+		}
+		if field.Name() == BLANK {
+			continue
+		}
+
+		if values != "" {
+			values += ", "
+			fields += ", "
+		}
+		values += value
+		fields += validProp(field.Name()) + ": " + value
+	}
+
+	// The anonymous structs are objects.
+	if !isNamed {
+		return "{" + fields + "}"
+	}
+	if named.TypeArgs() != nil {
+		values = strings.TrimSuffix(tr.typeArgs(named.TypeArgs())+values, ", ")
+	}
+	return fmt.Sprintf("new %s(%s)", validIdent(named.Obj().Name()), values)
+}
+
+// isKeyValue reports whether the element of a composite literal has a key.
+func isKeyValue(elt ast.Expr) bool {
+	_, ok := elt.(*ast.KeyValueExpr)
+	return ok
+}
+
+// writePromoted writes the methods promoted from the embedded fields of a
+// struct, so they can be called from values stored in interfaces.
+func (tr *translation) writePromoted(typeName *ast.Ident) {
+	named := tr.namedOf(typeName)
+	if named == nil {
+		return
+	}
+	name := validIdent(typeName)
+	mset := types.NewMethodSet(types.NewPointer(named))
 
-	tr.line += posNewField - firstPos // update the global position
+	for i := 0; i < mset.Len(); i++ {
+		sel := mset.At(i)
+		if len(sel.Index()) == 1 { // declared in the own type
+			continue
+		}
+		method := validProp(sel.Obj().Name())
+		field := "this." + embeddedPath(sel)
+		field = field[:len(field)-1]
+
+		if tr.Len() != 0 {
+			tr.WriteString(" ")
+		}
+		tr.WriteString(fmt.Sprintf("%s.prototype.%s = function() { return %s.%s.apply(%s, arguments); };",
+			name, method, field, method, field))
+	}
 }
 
 // == Utility
 //
 
 // writeVar translates variables for both declarations and assignments.
-func (tr *translation) writeVar(names interface{}, values []ast.Expr, type_ interface{}, operator token.Token, isGlobal, isMultipleLine bool) {
+func (tr *translation) writeVar(names interface{}, values []ast.Expr, type_ interface{}, operator token.Token, isGlobal bool) {
 	var sign string
 	var signIsAssign, signIsDefine, isBitClear bool
 
 	tr.isVar = true
 	defer func() { tr.isVar = false }()
 
-	if !isGlobal && isMultipleLine {
-		tr.WriteString(strings.Repeat(TAB, tr.tabLevel))
+	if allBlank(names) {
+		tr.writeBlank(values)
+		return
 	}
 
 	// == Operator
 	switch operator {
 	case token.DEFINE:
-		tr.WriteString("var ")
+		if tr.isLet || !isGlobal && tr.isFreshVar(names) {
+			tr.WriteString("let ")
+		} else {
+			tr.WriteString("var ")
+		}
 		sign = "="
 		signIsDefine = true
 	case token.ASSIGN:
@@ -413,32 +478,52 @@ func (tr *translation) writeVar(names interface{}, values []ast.Expr, type_ inte
 	var _names []string
 	var idxValidNames []int // index of variables which are not in blank
 	var name_expr []*expression
+	var isBoxed []bool // the variable declared is stored in an object
 
 	switch t := names.(type) {
 	case []*ast.Ident:
 		_names = make([]string, len(t))
 		name_expr = make([]*expression, len(t))
+		isBoxed = make([]bool, len(t))
 
 		for i, v := range t {
-			expr := tr.getExpression(v)
+			expr := tr.newExpression(nil)
+			expr.isLHS = true
+			expr.translate(v)
 
-			_names[i] = validIdent(expr.String())
+			_names[i] = expr.String()
 			name_expr[i] = expr
+			isBoxed[i] = signIsDefine && tr.isBoxed(tr.info.Defs[v])
 		}
 	case []ast.Expr: // like avobe
 		_names = make([]string, len(t))
 		name_expr = make([]*expression, len(t))
+		isBoxed = make([]bool, len(t))
 
 		for i, v := range t {
-			expr := tr.getExpression(v)
+			expr := tr.newExpression(nil)
+			expr.isLHS = true
+			expr.translate(v)
 
 			_names[i] = expr.String()
 			name_expr[i] = expr
+			if ident, ok := v.(*ast.Ident); ok {
+				isBoxed[i] = signIsDefine && tr.isBoxed(tr.info.Defs[ident])
+			}
 		}
 	default:
 		panic("unreachable")
 	}
 
+	// boxIf returns the value stored in an object when the variable is
+	// addressed.
+	boxIf := func(i int, value string) string {
+		if isBoxed[i] {
+			return box(value)
+		}
+		return value
+	}
+
 	// Check if there is any variable to use; and it is exported
 	for i, v := range _names {
 		if v != BLANK {
@@ -453,29 +538,68 @@ func (tr *translation) writeVar(names interface{}, values []ast.Expr, type_ inte
 		return
 	}
 
+	// The structs and arrays which could be pointed are changed in place.
+	if lhs, ok := names.([]ast.Expr); ok && len(lhs) == 1 && signIsAssign && tr.isInPlace(lhs[0]) {
+		typ := tr.typeOf(lhs[0])
+		dst, value := _names[0], tr.getValue(values[0])
+		if _, ok = typ.Underlying().(*types.Array); ok {
+			dst, value = stripField(dst), stripField(value)
+		}
+		tr.WriteString(fmt.Sprintf("g.Store(%s, %s, %s);", dst, value, tr.valueDesc(typ)))
+		return
+	}
+
 	if values != nil {
 		// == Function
-		if call, ok := values[0].(*ast.CallExpr); ok {
+		if call, ok := values[0].(*ast.CallExpr); ok && len(values) == 1 {
 
+			switch f := call.Fun.(type) {
 			// Function literal
-			if _, ok := call.Fun.(*ast.SelectorExpr); ok {
-				goto _noFunc
-			}
+			case *ast.SelectorExpr:
+				// The methods and the functions of packages which return
+				// multiple values are like functions.
+				_, isTuple := tr.typeOf(call).(*types.Tuple)
+				if sel := tr.selection(f); sel != nil && sel.Kind() != types.MethodVal || !isTuple {
+					goto _noFunc
+				}
 
 			// Declaration of slice/array
-			fun := call.Fun.(*ast.Ident).Name
-			if fun == "make" || fun == "new" {
+			case *ast.Ident:
+				if f.Name == "make" || f.Name == "new" {
+					goto _noFunc
+				}
+
+			// Conversion
+			case *ast.ArrayType:
 				goto _noFunc
 			}
 
 			// == Assign variable to the output of a function
-			fun = tr.getExpression(call).String()
+			fun := tr.getExpression(call).String()
+
+			if signIsDefine {
+				results, _ := tr.typeOf(call).(*types.Tuple)
+				for _, i := range idxValidNames {
+					// The new variable shadows any other one with the same name.
+					tr.vars[tr.funcId][tr.blockId][_names[i]] = void
+
+					if results != nil {
+						tr.declType(_names[i], results.At(i).Type())
+					} else {
+						tr.declType(_names[i], tr.typeOf(call))
+					}
+				}
+			}
 
 			if len(_names) == 1 {
+				if name_expr[0].addSet {
+					tr.WriteString(_names[0] + setElem(name_expr[0], sign, fun) + ";")
+					return
+				}
 				if tr.resultUseFunc[0] {
 					_names[0] = stripField(_names[0])
 				}
-				tr.WriteString(_names[0] + SP + sign + SP + fun + ";")
+				tr.WriteString(_names[0] + " " + sign + " " + boxIf(0, fun) + ";")
 				return
 			}
 			if len(idxValidNames) == 1 {
@@ -483,18 +607,26 @@ func (tr *translation) writeVar(names interface{}, values []ast.Expr, type_ inte
 				if tr.resultUseFunc[i] {
 					_names[i] = stripField(_names[i])
 				}
-				tr.WriteString(fmt.Sprintf("%s%s%s[%d];", _names[i], SP+sign+SP, fun, i))
+				tr.WriteString(fmt.Sprintf("%s %s %s;", _names[i], sign,
+					boxIf(i, fmt.Sprintf("%s[%d]", fun, i))))
 				return
 			}
 
 			// multiple variables
-			str := fmt.Sprintf("_%s", SP+sign+SP+fun)
+			tmp := tr.newTmp("r")
+			str := fmt.Sprintf("%s = %s", tmp, fun)
+			if !signIsDefine {
+				str = fmt.Sprintf("var %s;", str)
+			}
 
-			for _, i := range idxValidNames {
+			for j, i := range idxValidNames {
 				if tr.resultUseFunc[i] {
 					_names[i] = stripField(_names[i])
 				}
-				str += fmt.Sprintf(",%s%s_[%d]", SP+_names[i], SP+sign+SP, i)
+				if j != 0 || signIsDefine {
+					str += ","
+				}
+				str += fmt.Sprintf(" %s %s %s", _names[i], sign, boxIf(i, fmt.Sprintf("%s[%d]", tmp, i)))
 			}
 
 			tr.WriteString(str + ";")
@@ -505,7 +637,6 @@ func (tr *translation) writeVar(names interface{}, values []ast.Expr, type_ inte
 _noFunc:
 	expr := tr.newExpression(nil)
 	typeIs := otherType
-	isFuncLit := false
 	isZeroValue := false
 	isFirst := true
 	value := ""
@@ -516,6 +647,23 @@ _noFunc:
 		isZeroValue = true
 	}
 
+	// The values of the blank identifier which have calls are evaluated
+	// together with the next value, or after of the declaration if they are
+	// the last ones.
+	blanks := make(map[int]string) // index of name: values evaluated before
+	blankLast := ""
+	if len(values) > 1 && len(values) == len(_names) {
+		for i, v := range values {
+			if _names[i] != BLANK {
+				if blankLast != "" {
+					blanks[i], blankLast = blankLast, ""
+				}
+			} else if call := tr.blankValue(v); call != "" {
+				blankLast += call + ", "
+			}
+		}
+	}
+
 	for iValidNames, idxName := range idxValidNames {
 		name := _names[idxName]
 		nameExpr := ""
@@ -527,18 +675,25 @@ _noFunc:
 			nameExpr += name
 			isFirst = false
 		} else {
-			nameExpr += "," + SP + name
+			nameExpr += ", " + name
 		}
 
-		if !signIsDefine && len(name_expr[idxName].index) == 0 {
-			nameExpr += tagPointer(false, 'P', tr.funcId, tr.blockId, name)
-		}
 
 		// == Value
 		if isZeroValue {
-			if typeIs == sliceType {
+			// The named types are saved like their underlying type.
+			if ident, ok := type_.(*ast.Ident); ok {
+				tr.declType(name, tr.typeOf(ident))
+			}
+			if typeIs == sliceType && !Bootstrap {
 				tr.slices[tr.funcId][tr.blockId][name] = void
 			}
+			if typeIs == arrayType {
+				tr.arrays[tr.funcId][tr.blockId][name] = void
+			}
+			if typeIs == mapType {
+				tr.maps[tr.funcId][tr.blockId][name] = void
+			}
 		} else {
 			var valueOfValidName ast.Expr
 
@@ -549,55 +704,61 @@ _noFunc:
 				valueOfValidName = values[idxName]
 			}
 
-			// If the expression is an anonymous function, then, at translating,
-			// it is written in the main buffer.
 			expr = tr.newExpression(name)
 			expr.isValue = true
 
-			if _, ok := valueOfValidName.(*ast.FuncLit); !ok {
-				expr.translate(valueOfValidName)
-				exprStr := expr.String()
+			expr.translate(valueOfValidName)
+			exprStr := expr.String()
 
-				if isBitClear {
-					exprStr = "~(" + exprStr + ")"
-				}
-				value = exprStr
+			if isBitClear {
+				exprStr = "~(" + exprStr + ")"
+			}
+			value = exprStr
 
-				_, typeIs = tr.zeroValue(false, type_)
+			_, typeIs = tr.zeroValue(false, type_)
 
-				if expr.isVarAddress {
-					tr.addr[tr.funcId][tr.blockId][name] = true
-					if !signIsDefine {
-						nameExpr += ADDR
+			// == Map: v, ok := m[k]
+			isMapIndex := tr.isType(mapType, expr.mapName)
+			if index, ok := ast.Unparen(valueOfValidName).(*ast.IndexExpr); ok && tr.isMap(index.X) {
+				isMapIndex = true
+			}
+			if len(values) == 1 && isMapIndex {
+				value = value[:len(value)-3] // remove '[0]'
+
+				if len(idxValidNames) == 1 {
+					i := idxValidNames[0]
+					elem := fmt.Sprintf("%s[%d]", value, i)
+					if i == 0 {
+						elem = tr.copyValue(valueOfValidName, elem)
 					}
-				} /*else {
-					tr.addr[tr.funcId][tr.blockId][name] = false
-				}*/
-
-				// == Map: v, ok := m[k]
-				if len(values) == 1 && tr.isType(mapType, expr.mapName) {
-					value = value[:len(value)-3] // remove '[0]'
-
-					if len(idxValidNames) == 1 {
-						tr.WriteString(fmt.Sprintf("%s%s%s[%d];",
-							_names[idxValidNames[0]],
-							SP+sign+SP,
-							value, idxValidNames[0]))
-					} else {
-						tr.WriteString(fmt.Sprintf("_%s,%s_[%d],%s_[%d];",
-							SP+sign+SP+value,
-							SP+_names[0]+SP+sign+SP, 0,
-							SP+_names[1]+SP+sign+SP, 1))
+					tr.WriteString(fmt.Sprintf("%s %s %s;", _names[i], sign, boxIf(i, elem)))
+				} else {
+					tmp := tr.newTmp("r")
+					format := "%s = %s, %s %s %s, %s %s %s;"
+					if !signIsDefine {
+						format = "var %s = %s; %s %s %s, %s %s %s;"
 					}
-
-					return
+					tr.WriteString(fmt.Sprintf(format, tmp, value,
+						_names[0], sign, boxIf(0, tr.copyValue(valueOfValidName, tmp+"[0]")),
+						_names[1], sign, boxIf(1, tmp+"[1]")))
 				}
-				// ==
-			} else {
-				isFuncLit = true
 
-				tr.WriteString(nameExpr)
-				expr.translate(valueOfValidName)
+				return
+			}
+			// ==
+			if copied := tr.copyValue(valueOfValidName, stripField(value)); copied != stripField(value) {
+				value = copied
+			}
+
+			// The values got from a call or a literal use the type given by the
+			// checker, or the type written in the literal at bootstrapping.
+			switch valueOfValidName.(type) {
+			case *ast.CallExpr, *ast.CompositeLit:
+				if Bootstrap {
+					tr.declLit(name, valueOfValidName)
+				} else if signIsDefine {
+					tr.declType(name, tr.typeOf(valueOfValidName))
+				}
 			}
 
 			// Check if new variables assigned to another ones are slices or maps.
@@ -612,92 +773,156 @@ _noFunc:
 		}
 
 		if signIsDefine {
-			typeIsPointer := false
-			if typeIs == pointerType {
-				typeIsPointer = true
-			}
-
-			tr.vars[tr.funcId][tr.blockId][name] = typeIsPointer
-
-			// The value could be a pointer so this new variable has to be it.
-			if tr.vars[tr.funcId][tr.blockId][value] {
-				tr.vars[tr.funcId][tr.blockId][name] = true
-			}
+			tr.vars[tr.funcId][tr.blockId][name] = void
+		}
 
-			// Could be addressed ahead
-			if value != "" && !expr.isPointer && !expr.isVarAddress && !typeIsPointer {
-				value = tagPointer(isZeroValue, 'L', tr.funcId, tr.blockId, name) +
-					value +
-					tagPointer(isZeroValue, 'R', tr.funcId, tr.blockId, name)
-			}
+		// Insert "var" to variable of anonymous struct.
+		if tr.insertVar && tr.isType(structType, name) {
+			tr.WriteString("var ")
+			tr.insertVar = false
 		}
+		tr.WriteString(nameExpr)
 
-		if !isFuncLit {
-			// Insert "var" to variable of anonymous struct.
-			if tr.insertVar && tr.isType(structType, name) {
-				tr.WriteString("var ")
-				tr.insertVar = false
-			}
-			tr.WriteString(nameExpr)
+		/*switch expr.kind {
+		case sliceKind:
+		}*/
 
-			/*switch expr.kind {
-			case sliceKind:
-			}*/
+		rhs := "" // value written after of the sign
 
-			if name_expr[idxName].addSet {
-				tr.WriteString(SP + value + ")")
+		if name_expr[idxName].addSet {
+			tr.WriteString(setElem(name_expr[idxName], sign, value))
 
-			} else if expr.kind == sliceKind || expr.isSliceExpr {
-				if signIsDefine || signIsAssign {
-					tr.slices[tr.funcId][tr.blockId][nameExpr] = void
+		} else if expr.kind == sliceKind || expr.isSliceExpr {
+			if signIsDefine || signIsAssign {
+				tr.slices[tr.funcId][tr.blockId][nameExpr] = void
 
-					if value == "" {
-						tr.WriteString(fmt.Sprintf("%sg.MkSlice(0,%s0)", SP+sign+SP, SP))
+				if value == "" {
+					rhs = "g.MkSlice(0, 0)"
+				} else {
+					if expr.isSliceExpr {
+						rhs = fmt.Sprintf("g.SliceFrom(%s)", value)
 					} else {
-						if expr.isSliceExpr {
-							tr.WriteString(fmt.Sprintf("%sg.SliceFrom(%s)", SP+sign+SP, value))
-						} else {
-							tr.WriteString(fmt.Sprintf("%sg.Slice(%s)", SP+sign+SP, value))
-						}
+						rhs = fmt.Sprintf("g.Slice(%s)", value)
 					}
 				}
-			} else if expr.isMake {
-				tr.WriteString(fmt.Sprintf("%sg.MkSlice(%s)", SP+sign+SP, value))
-				tr.slices[tr.funcId][tr.blockId][nameExpr] = void
+			}
+		} else if expr.isMake {
+			rhs = fmt.Sprintf("g.MkSlice(%s)", value)
+			tr.slices[tr.funcId][tr.blockId][nameExpr] = void
 
-			} else {
-				if value != "" {
-					// Get the numeric function
-					if iValidNames == 0 {
-						if ident, ok := type_.(*ast.Ident); ok {
-							switch ident.Name {
-							case "uint", "uint8", "uint16", "uint32",
-								"int", "int8", "int16", "int32",
-								"float32", "float64",
-								"byte", "rune":
-								numericFunc = "g." + strings.Title(ident.Name)
-							}
+		} else {
+			if value != "" {
+				// Get the numeric function. The named results have the zero
+				// value like a primitive, so they can be compared.
+				if iValidNames == 0 && !Bootstrap && !tr.isResult(names, idxName) {
+					if ident, ok := type_.(*ast.Ident); ok {
+						switch ident.Name {
+						case "uint", "uint8", "uint16", "uint32",
+							"int", "int8", "int16", "int32",
+							"float32", "float64",
+							"byte", "rune":
+							numericFunc = "g." + strings.Title(ident.Name)
 						}
 					}
-					if numericFunc != "" {
-						tr.WriteString(fmt.Sprintf("%s%s(%s)",
-							SP+sign+SP, numericFunc, value))
-					} else {
-						tr.WriteString(SP + sign + SP + value)
-					}
 				}
-
-				if tr.isArray {
-					tr.WriteString(")")
-					tr.isArray = false
+				if numericFunc != "" {
+					rhs = fmt.Sprintf("%s(%s)", numericFunc, value)
+				} else {
+					rhs = value
 				}
 			}
+
+			if tr.isArray {
+				rhs += ")"
+				tr.isArray = false
+			}
+		}
+
+		if rhs != "" {
+			if blanks[idxName] != "" {
+				rhs = "(" + blanks[idxName] + rhs + ")"
+			}
+			tr.WriteString(" " + sign + " " + boxIf(idxName, rhs))
 		}
 	}
 
 	if !isFirst {
 		tr.WriteString(";")
 	}
+	if blankLast != "" {
+		tr.WriteString(" " + strings.TrimSuffix(blankLast, ", ") + ";")
+	}
+}
+
+// isResult reports whether the name at the index is a named result of the
+// function, declared like a variable.
+func (tr *translation) isResult(names interface{}, i int) bool {
+	idents, ok := names.([]*ast.Ident)
+	return ok && tr.results[tr.info.Defs[idents[i]]]
+}
+
+// allBlank reports whether all the names are the blank identifier.
+func allBlank(names interface{}) bool {
+	switch t := names.(type) {
+	case []*ast.Ident:
+		for _, v := range t {
+			if !isBlank(v) {
+				return false
+			}
+		}
+	case []ast.Expr:
+		for _, v := range t {
+			if !isBlank(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// writeBlank writes the values assigned to the blank identifier which have
+// calls, since they are only evaluated.
+func (tr *translation) writeBlank(values []ast.Expr) {
+	for _, v := range values {
+		if call := tr.blankValue(v); call != "" {
+			tr.WriteString(call + ";")
+		}
+	}
+}
+
+// blankValue returns the value assigned to the blank identifier, or nothing
+// if it has not calls.
+func (tr *translation) blankValue(value ast.Expr) string {
+	hasCall := false
+	ast.Inspect(value, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if tv, ok := tr.info.Types[t.Fun]; !ok || !tv.IsType() {
+				hasCall = true
+			}
+		}
+		return !hasCall
+	})
+
+	if !hasCall {
+		return ""
+	}
+	return tr.getExpression(value).String()
+}
+
+// embeddedName returns the identifier used like name of an embedded field.
+func embeddedName(typ ast.Expr) *ast.Ident {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr: // type from a package
+		return t.Sel
+	}
+	panic(fmt.Sprintf("embeddedName(): unexpected type: %T", typ))
 }
 
 // getTypeFields returns the fields of a custom type.
@@ -706,15 +931,24 @@ func (tr *translation) getTypeFields(fields []string) (args, allFields string) {
 		if i == 0 {
 			args = f
 		} else {
-			args += "," + SP + f
-			allFields += SP
+			args += ", " + f
+			allFields += " "
 		}
 
-		allFields += fmt.Sprintf("this.%s=%s;", f, f)
+		allFields += fmt.Sprintf("this.%s = %s;", f, f)
 	}
 	return
 }
 
+// setElem returns the end of the call to the function "set" of a slice or map,
+// with the value of the assignment whose operator is "sign".
+func setElem(expr *expression, sign, value string) string {
+	if sign != "=" { // compound assignment
+		value = fmt.Sprintf("%s %s (%s)", expr.elem, strings.TrimSuffix(sign, "="), value)
+	}
+	return " " + value + ")"
+}
+
 // == Zero value
 //
 
@@ -729,6 +963,58 @@ const (
 	structType
 )
 
+// declType saves the new variable like a slice, map or array when it is of
+// that type, given by the type checker.
+func (tr *translation) declType(name string, typ types.Type) {
+	if typ == nil || Bootstrap {
+		return
+	}
+	// A variable declared before with the same name in this block is replaced.
+	delete(tr.slices[tr.funcId][tr.blockId], name)
+	delete(tr.maps[tr.funcId][tr.blockId], name)
+	delete(tr.arrays[tr.funcId][tr.blockId], name)
+
+	switch typ.Underlying().(type) {
+	case *types.Slice:
+		tr.slices[tr.funcId][tr.blockId][name] = void
+	case *types.Map:
+		tr.maps[tr.funcId][tr.blockId][name] = void
+	case *types.Array:
+		tr.arrays[tr.funcId][tr.blockId][name] = void
+	default:
+		return
+	}
+	if _, ok := tr.vars[tr.funcId][tr.blockId][name]; !ok {
+		tr.vars[tr.funcId][tr.blockId][name] = void
+	}
+}
+
+// declLit saves the kind of a variable whose value is a literal or a call to
+// "make", from the type written in the expression.
+func (tr *translation) declLit(name string, value ast.Expr) {
+	var typ ast.Expr
+
+	switch t := value.(type) {
+	case *ast.CompositeLit:
+		typ = t.Type
+	case *ast.CallExpr:
+		if ident, ok := t.Fun.(*ast.Ident); ok && ident.Name == "make" && len(t.Args) != 0 {
+			typ = t.Args[0]
+		}
+	}
+
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		if t.Len == nil {
+			tr.slices[tr.funcId][tr.blockId][name] = void
+		} else {
+			tr.arrays[tr.funcId][tr.blockId][name] = void
+		}
+	case *ast.MapType:
+		tr.maps[tr.funcId][tr.blockId][name] = void
+	}
+}
+
 // zeroValue returns the zero value of the value type if "init", and a boolean
 // indicating if it is a pointer.
 func (tr *translation) zeroValue(init bool, typ interface{}) (value string, dt dataType) {
@@ -740,6 +1026,9 @@ func (tr *translation) zeroValue(init bool, typ interface{}) (value string, dt d
 
 	case *ast.ArrayType:
 		if t.Len != nil { // array
+			if typ := tr.typeOf(t); typ != nil && !Bootstrap {
+				return tr.typeZero(typ), arrayType
+			}
 			return tr.getExpression(t).String(), arrayType
 		}
 
@@ -750,29 +1039,40 @@ func (tr *translation) zeroValue(init bool, typ interface{}) (value string, dt d
 		}
 		return "[]", sliceType
 
-	case *ast.InterfaceType: // nil
+	case *ast.InterfaceType, *ast.FuncType: // nil
 		return "undefined", otherType
 
 	case *ast.MapType:
-		tr.maps[tr.funcId][tr.blockId][tr.lastVarName] = void
-		return fmt.Sprintf("g.Map(%s)", tr.zeroOfMap(t)), mapType
+		return fmt.Sprintf("g.MkMap(%s)", tr.zeroOfMap(t)), mapType
 
-	case *ast.StructType:
+	case *ast.StructType: // anonymous
+		if t := tr.typeOf(t); t != nil && !Bootstrap {
+			return tr.typeZero(t), structType
+		}
 		return "", structType
 
 	case *ast.Ident:
+		if name, ok := tr.isTypeParam(t); ok {
+			return name + ".zero()", otherType
+		}
 		ident = t
-	case *ast.StarExpr:
-		tr.initIsPointer = true
-		return tr.zeroValue(init, t.X)
+	case *ast.StarExpr: // nil
+		if !init {
+			return "", pointerType
+		}
+		return "undefined", pointerType
+
+	case *ast.IndexExpr, *ast.IndexListExpr: // generic type
+		return tr.typeZero(tr.typeOf(typ.(ast.Expr))), otherType
+	case *ast.SelectorExpr: // type of other package
+		if t := tr.typeOf(typ.(ast.Expr)); t != nil {
+			return tr.typeZero(t), otherType
+		}
 	default:
 		panic(fmt.Sprintf("zeroValue(): unexpected type: %T", typ))
 	}
 
 	if !init {
-		if tr.initIsPointer {
-			return "", pointerType
-		}
 		return
 	}
 
@@ -790,26 +1090,33 @@ func (tr *translation) zeroValue(init bool, typ interface{}) (value string, dt d
 	case "complex64", "complex128":
 		value = "(0+0i)"
 	default:
-		value = ident.Name
-		value = fmt.Sprintf("new %s(%s)", value, tr.zeroOfType(value))
 		isType = false
+
+		// The named types which are not structs have the zero value of their
+		// underlying type.
+		// The structs are built from the type given by the checker.
+		if named, ok := tr.typeOf(ident).(*types.Named); ok {
+			if _, ok = named.Underlying().(*types.Struct); !ok || !Bootstrap {
+				value = tr.typeZero(named)
+				break
+			}
+		}
+		value = validIdent(ident.Name)
+		value = fmt.Sprintf("new %s(%s)", value, tr.zeroOfType(value))
 	}
 
 	if !Bootstrap && isType {
 		//value = fmt.Sprintf("g.%s(%s)", strings.Title(ident.Name), value)
 	}
-	if tr.initIsPointer {
-		value = "{p:undefined}"
-		dt = pointerType
-		tr.initIsPointer = false
-	}
 	return
 }
 
-// zeroOfMap returns the zero value of a map.
+// zeroOfMap returns the zero value of the values of a map.
 func (tr *translation) zeroOfMap(m *ast.MapType) string {
-	if mapT, ok := m.Value.(*ast.MapType); ok { // nested map
-		return tr.zeroOfMap(mapT)
+	if !Bootstrap {
+		if t, ok := tr.typeOf(m).(*types.Map); ok {
+			return tr.typeZero(t.Elem())
+		}
 	}
 	v, _ := tr.zeroValue(true, m.Value)
 	return v
@@ -817,23 +1124,32 @@ func (tr *translation) zeroOfMap(m *ast.MapType) string {
 
 // zeroOfType returns the zero value of a custom type.
 func (tr *translation) zeroOfType(name string) string {
+	if zero, ok := tr.findZeroType(name); ok {
+		return zero
+	}
+	//fmt.Printf("Function %d, block %d, name %s\n", tr.funcId, tr.blockId, name)
+	panic("zeroOfType: type not found: " + name)
+}
+
+// findZeroType returns the zero value of a custom type, and a boolean
+// indicating if the type was found.
+func (tr *translation) findZeroType(name string) (string, bool) {
 	// In the actual function
 	if tr.funcId != 0 {
 		for block := tr.blockId; block >= 0; block-- {
-			if _, ok := tr.zeroType[tr.funcId][block][name]; ok {
-				return tr.zeroType[tr.funcId][block][name]
+			if zero, ok := tr.zeroType[tr.funcId][block][name]; ok {
+				return zero, true
 			}
 		}
 	}
 
 	// Finally, search in the global variables (funcId = 0).
 	for block := tr.blockId; block >= 0; block-- { // block until 0
-		if _, ok := tr.zeroType[0][block][name]; ok {
-			return tr.zeroType[0][block][name]
+		if zero, ok := tr.zeroType[0][block][name]; ok {
+			return zero, true
 		}
 	}
-	//fmt.Printf("Function %d, block %d, name %s\n", tr.funcId, tr.blockId, name)
-	panic("zeroOfType: type not found: " + name)
+	return "", false
 }
 
 // == Checking
@@ -844,9 +1160,19 @@ func (tr *translation) isType(t dataType, name string) bool {
 	if name == "" {
 		return false
 	}
-	name = strings.SplitN(name, "<<", 2)[0] // could have a tag
+	// The slices whose address is taken are boxed.
+	if t == sliceType && !Bootstrap {
+		name = strings.TrimSuffix(name, FIELD_POINTER)
+	}
 
-	for funcId := tr.funcId; funcId >= 0; funcId-- {
+	// Search in the actual function, and then in the global variables
+	// (funcId = 0); the scopes of other functions are not visible.
+	funcIds := []int{tr.funcId}
+	if tr.funcId != 0 {
+		funcIds = append(funcIds, 0)
+	}
+
+	for _, funcId := range funcIds {
 		for blockId := tr.blockId; blockId >= 0; blockId-- {
 			// Avoid translation to Go types in functions parameters during bootstrap.
 			if Bootstrap && blockId == 0 {
@@ -855,23 +1181,17 @@ func (tr *translation) isType(t dataType, name string) bool {
 			if _, ok := tr.vars[funcId][blockId][name]; ok { // variable found
 				switch t {
 				case arrayType:
-					if _, ok = tr.arrays[funcId][blockId][name]; ok {
-						return true
-					}
+					_, ok = tr.arrays[funcId][blockId][name]
 				case mapType:
-					if _, ok = tr.maps[funcId][blockId][name]; ok {
-						return true
-					}
+					_, ok = tr.maps[funcId][blockId][name]
 				case sliceType:
-					if _, ok = tr.slices[funcId][blockId][name]; ok {
-						return true
-					}
+					_, ok = tr.slices[funcId][blockId][name]
 				case structType:
-					if _, ok = tr.structSlices[funcId][blockId][name]; ok {
-						return true
-					}
+					_, ok = tr.structSlices[funcId][blockId][name]
+				default:
+					ok = false
 				}
-				return false
+				return ok
 			}
 		}
 	}